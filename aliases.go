@@ -0,0 +1,49 @@
+package ryegen
+
+import "github.com/refaktor/ryegen/binder/binderio"
+
+// writeAliasRegistration writes the statements registering the deprecated
+// aliases in Aliases with Builtins and BuiltinNames to cb, for the init
+// function building them (see "rename-aliases" in config.toml). Aliases
+// never replace builtins of the same name.
+func writeAliasRegistration(cb *binderio.CodeBuilder) {
+	cb.Linef(`for old, name := range Aliases {`)
+	cb.Indent++
+	cb.Linef(`if _, ok := Builtins[old]; ok {`)
+	cb.Indent++
+	cb.Linef(`continue`)
+	cb.Indent--
+	cb.Linef(`}`)
+	cb.Linef(`Builtins[old] = deprecatedAlias(old, name, Builtins[name])`)
+	cb.Linef(`BuiltinNames = append(BuiltinNames, old)`)
+	cb.Indent--
+	cb.Linef(`}`)
+}
+
+// writeDeprecatedAlias writes deprecatedAlias to cb, which wraps builtins
+// under their old names. The generated code imports env, fmt, os and
+// sync.
+func writeDeprecatedAlias(cb *binderio.CodeBuilder) {
+	cb.Linef(`var deprecatedAliasWarned sync.Map`)
+	cb.Linef(``)
+	cb.Linef(`// deprecatedAlias returns a copy of target registered under the old`)
+	cb.Linef(`// name of a renamed builtin, warning once when it's first called.`)
+	cb.Linef(`func deprecatedAlias(old, name string, target *env.Builtin) *env.Builtin {`)
+	cb.Indent++
+	cb.Linef(`alias := *target`)
+	cb.Linef(`alias.Doc = "Deprecated: renamed to " + name + ". " + target.Doc`)
+	cb.Linef(`alias.Fn = func(ps *env.ProgramState, arg0, arg1, arg2, arg3, arg4 env.Object) env.Object {`)
+	cb.Indent++
+	cb.Linef(`if _, warned := deprecatedAliasWarned.LoadOrStore(old, struct{}{}); !warned {`)
+	cb.Indent++
+	cb.Linef(`fmt.Fprintf(os.Stderr, "warning: %%v is deprecated, use %%v instead\n", old, name)`)
+	cb.Indent--
+	cb.Linef(`}`)
+	cb.Linef(`return target.Fn(ps, arg0, arg1, arg2, arg3, arg4)`)
+	cb.Indent--
+	cb.Linef(`}`)
+	cb.Linef(`return &alias`)
+	cb.Indent--
+	cb.Linef(`}`)
+	cb.Linef(``)
+}
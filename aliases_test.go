@@ -0,0 +1,145 @@
+package ryegen
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/refaktor/ryegen/binder/binderio"
+)
+
+const aliasesTestSrc = `package check
+
+import (
+	"io"
+	"os"
+	"testing"
+
+	"github.com/refaktor/rye/env"
+)
+
+func TestDeprecatedAlias(t *testing.T) {
+	if got := BuiltinNames; len(got) != 2 || got[0] != "hi" || got[1] != "greet-hello" {
+		t.Fatalf("unexpected names %v", got)
+	}
+	alias := Builtins["greet-hello"]
+	if alias.Doc != "Deprecated: renamed to hi. Greets." {
+		t.Errorf("unexpected doc %q", alias.Doc)
+	}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stderr := os.Stderr
+	os.Stderr = w
+	ps := &env.ProgramState{}
+	for range 2 {
+		if res := alias.Fn(ps, nil, nil, nil, nil, nil); res.(env.String).Value != "hello" {
+			t.Errorf("unexpected result %v", res)
+		}
+	}
+	os.Stderr = stderr
+	w.Close()
+	out, _ := io.ReadAll(r)
+	if string(out) != "warning: greet-hello is deprecated, use hi instead\n" {
+		t.Errorf("expected a single warning, got %q", out)
+	}
+}
+`
+
+func TestDeprecatedAlias(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go test")
+	}
+
+	mod := t.TempDir()
+	write := func(name string, data []byte) {
+		t.Helper()
+		path := filepath.Join(mod, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0777); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, data, 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	stub, err := os.ReadFile("binder/bindertest/testdata/ryestub/env/env.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cb binderio.CodeBuilder
+	cb.Linef(`package check`)
+	cb.Linef(``)
+	cb.Linef(`import (`)
+	cb.Linef(`	"fmt"`)
+	cb.Linef(`	"os"`)
+	cb.Linef(`	"sync"`)
+	cb.Linef(``)
+	cb.Linef(`	"github.com/refaktor/rye/env"`)
+	cb.Linef(`)`)
+	cb.Linef(``)
+	cb.Linef(`var Builtins = map[string]*env.Builtin{`)
+	cb.Linef(`	"hi": {Doc: "Greets.", Fn: func(ps *env.ProgramState, arg0, arg1, arg2, arg3, arg4 env.Object) env.Object {`)
+	cb.Linef(`		return env.String{Value: "hello"}`)
+	cb.Linef(`	}},`)
+	cb.Linef(`}`)
+	cb.Linef(``)
+	cb.Linef(`var BuiltinNames = []string{"hi"}`)
+	cb.Linef(``)
+	// Aliases never replace builtins.
+	cb.Linef(`var Aliases = map[string]string{"greet-hello": "hi", "hi": "greet-hello"}`)
+	cb.Linef(``)
+	cb.Linef(`func init() {`)
+	cb.Indent++
+	writeAliasRegistration(&cb)
+	cb.Indent--
+	cb.Linef(`}`)
+	cb.Linef(``)
+	writeDeprecatedAlias(&cb)
+	write("go.mod", []byte("module example.com/check\n\ngo 1.22\n\nrequire github.com/refaktor/rye v0.0.0\n\nreplace github.com/refaktor/rye => ./rye\n"))
+	write("rye/go.mod", []byte("module github.com/refaktor/rye\n\ngo 1.22\n"))
+	write("rye/env/env.go", stub)
+	write("check.go", []byte(cb.String()))
+	write("check_test.go", []byte(aliasesTestSrc))
+
+	cmd := exec.Command("go", "test", ".")
+	cmd.Dir = mod
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOPROXY=off", "GOWORK=off")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("go test: %v\n%s", err, out)
+	}
+}
+
+func TestRenameAliasesOption(t *testing.T) {
+	for _, enabled := range []bool{false, true} {
+		dir := t.TempDir()
+		writeSrcRepos(t, dir, strings.Replace(greetSrc, "// Hello greets name.\n", "// Hello greets name.\n//\n//ryegen:rename hi\n", 1))
+		config := "out-dir = \"out\"\npackage = \"example.com/greet\"\nversion = \"v1.0.0\"\nproxy = \"off\"\nsource-directives = true\n"
+		if enabled {
+			config += "rename-aliases = true\n"
+		}
+		if err := os.WriteFile(filepath.Join(dir, "config.toml"), []byte(config), 0666); err != nil {
+			t.Fatal(err)
+		}
+
+		var sink MemorySink
+		res, err := TryRunWithOptions(RunOptions{Dir: dir, Sink: &sink})
+		if !assert.NoError(t, err) {
+			return
+		}
+		files := sink.Files()
+		code := string(files[filepath.ToSlash(res.OutFile)])
+		data := string(files[filepath.ToSlash(filepath.Join(filepath.Dir(res.OutFile), "generated_data.go"))])
+		assert.Contains(t, code, `m["hi"] = `)
+		for _, s := range []string{"func deprecatedAlias(", "range Aliases", "\t\"os\"\n"} {
+			assert.Equal(t, enabled, strings.Contains(code, s), "%v (enabled: %v)", s, enabled)
+		}
+		assert.Equal(t, enabled, strings.Contains(data, `"greet-hello": "hi",`), "enabled: %v", enabled)
+		assert.Equal(t, enabled, strings.Contains(data, "var Aliases"), "enabled: %v", enabled)
+	}
+}
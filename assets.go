@@ -0,0 +1,250 @@
+package ryegen
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+
+	"github.com/refaktor/ryegen/binder/binderio"
+)
+
+// File holding the generated Assets file system and environment setup,
+// and the directory of the embedded assets, relative to the output
+// directory.
+const (
+	assetsFileName = "assets.go"
+	assetsDirName  = "assets"
+)
+
+// readAssets reads the files of "assets" in config.toml (relative to dir,
+// "" for the working directory), keyed by slash-separated path relative
+// to dir. Directories are read recursively, leaving out the files and
+// directories in exclude, such as the output and cache directories
+// (e.g. for assets = ["."]).
+func readAssets(dir string, paths, exclude []string) (map[string][]byte, error) {
+	excluded := make(map[string]struct{}, len(exclude))
+	for _, path := range exclude {
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return nil, fmt.Errorf("read assets: %w", err)
+		}
+		excluded[abs] = struct{}{}
+	}
+
+	res := make(map[string][]byte)
+	for _, root := range paths {
+		if dir != "" && !filepath.IsAbs(root) {
+			root = filepath.Join(dir, root)
+		}
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if abs, err := filepath.Abs(path); err == nil {
+				if _, ok := excluded[abs]; ok {
+					if d.IsDir() {
+						return filepath.SkipDir
+					}
+					return nil
+				}
+			}
+			if d.IsDir() {
+				return nil
+			}
+			b, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			if dir != "" {
+				if rel, err := filepath.Rel(dir, path); err == nil {
+					path = rel
+				}
+			}
+			res[filepath.ToSlash(filepath.Clean(path))] = b
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("read assets: %w", err)
+		}
+	}
+	return res, nil
+}
+
+// writeAssetsFiles writes the assets below outDir and the code embedding
+// them and setting up env into outDir, or removes the code if there are
+// neither assets nor env (see [OutputSink]).
+//
+// Embedded assets are extracted to a directory named after a hash of
+// their content, so each version is only extracted once per user.
+// Assets removed from config.toml aren't removed from outDir.
+func writeAssetsFiles(sink OutputSink, outDir, pkgName, dontBuildFlag string, assets map[string][]byte, env map[string]string) error {
+	path := filepath.Join(outDir, assetsFileName)
+	if len(assets) == 0 && len(env) == 0 {
+		return removeOutput(sink, path)
+	}
+
+	names := slices.Sorted(maps.Keys(assets))
+	hash := sha256.New()
+	for _, name := range names {
+		if err := sink.WriteFile(filepath.Join(outDir, assetsDirName, filepath.FromSlash(name)), assets[name]); err != nil {
+			return err
+		}
+		fmt.Fprintf(hash, "%v\x00%v\x00", name, len(assets[name]))
+		hash.Write(assets[name])
+	}
+
+	var cb binderio.CodeBuilder
+	cb.Linef(`// Code generated by ryegen. DO NOT EDIT.`)
+	cb.Linef(``)
+	if dontBuildFlag != "" {
+		cb.Linef(`//go:build !%v`, dontBuildFlag)
+		cb.Linef(``)
+	}
+	cb.Linef(`package %v`, pkgName)
+	cb.Linef(``)
+	cb.Linef(`import (`)
+	cb.Indent++
+	if len(assets) > 0 {
+		cb.Linef(`"embed"`)
+		cb.Linef(`"io/fs"`)
+	}
+	cb.Linef(`"os"`)
+	if len(assets) > 0 {
+		cb.Linef(`"path/filepath"`)
+		cb.Linef(`"sync"`)
+	}
+	cb.Indent--
+	cb.Linef(`)`)
+	cb.Linef(``)
+
+	if len(assets) > 0 {
+		cb.Linef(`// Assets holds the files of "assets" in config.toml, below %q.`, assetsDirName)
+		cb.Linef(`//`)
+		cb.Linef(`//go:embed all:%v`, assetsDirName)
+		cb.Linef(`var Assets embed.FS`)
+		cb.Linef(``)
+		cb.Linef(`// extractAssets writes Assets to a directory in the user's cache`)
+		cb.Linef(`// directory (unless a previous run did), returning its path.`)
+		cb.Linef(`var extractAssets = sync.OnceValues(func() (string, error) {`)
+		cb.Indent++
+		cb.Linef(`base, err := os.UserCacheDir()`)
+		cb.Linef(`if err != nil {`)
+		cb.Indent++
+		cb.Linef(`base = os.TempDir()`)
+		cb.Indent--
+		cb.Linef(`}`)
+		cb.Linef(`dir := filepath.Join(base, "ryegen", %v)`, strconv.Quote(pkgName+"-"+hex.EncodeToString(hash.Sum(nil))[:16]))
+		cb.Linef(`if _, err := os.Stat(dir); err == nil {`)
+		cb.Indent++
+		cb.Linef(`return dir, nil`)
+		cb.Indent--
+		cb.Linef(`}`)
+		cb.Linef(`if err := os.MkdirAll(filepath.Dir(dir), 0o777); err != nil {`)
+		cb.Indent++
+		cb.Linef(`return "", err`)
+		cb.Indent--
+		cb.Linef(`}`)
+		cb.Linef(`// Extract into a temporary directory first, so other processes`)
+		cb.Linef(`// never see a partially extracted one.`)
+		cb.Linef(`tmp, err := os.MkdirTemp(filepath.Dir(dir), ".extract-")`)
+		cb.Linef(`if err != nil {`)
+		cb.Indent++
+		cb.Linef(`return "", err`)
+		cb.Indent--
+		cb.Linef(`}`)
+		cb.Linef(`defer os.RemoveAll(tmp)`)
+		cb.Linef(`sub, err := fs.Sub(Assets, %q)`, assetsDirName)
+		cb.Linef(`if err != nil {`)
+		cb.Indent++
+		cb.Linef(`return "", err`)
+		cb.Indent--
+		cb.Linef(`}`)
+		cb.Linef(`if err := os.CopyFS(tmp, sub); err != nil {`)
+		cb.Indent++
+		cb.Linef(`return "", err`)
+		cb.Indent--
+		cb.Linef(`}`)
+		cb.Linef(`if err := os.Rename(tmp, dir); err != nil {`)
+		cb.Indent++
+		cb.Linef(`if _, statErr := os.Stat(dir); statErr == nil {`)
+		cb.Indent++
+		cb.Linef(`// Extracted by another process in the meantime.`)
+		cb.Linef(`return dir, nil`)
+		cb.Indent--
+		cb.Linef(`}`)
+		cb.Linef(`return "", err`)
+		cb.Indent--
+		cb.Linef(`}`)
+		cb.Linef(`return dir, nil`)
+		cb.Indent--
+		cb.Linef(`})`)
+		cb.Linef(``)
+	}
+
+	cb.Linef(`// setupEnv sets the variables of "env" in config.toml which aren't set`)
+	cb.Linef(`// yet. Called by LoadPrelude.`)
+	cb.Linef(`func setupEnv() error {`)
+	cb.Indent++
+	cb.Linef(`for _, kv := range [][2]string{`)
+	cb.Indent++
+	for name, val := range sortedMapAll(env) {
+		cb.Linef(`{%v, %v},`, strconv.Quote(name), strconv.Quote(val))
+	}
+	cb.Indent--
+	cb.Linef(`} {`)
+	cb.Indent++
+	cb.Linef(`if _, ok := os.LookupEnv(kv[0]); ok {`)
+	cb.Indent++
+	cb.Linef(`continue`)
+	cb.Indent--
+	cb.Linef(`}`)
+	cb.Linef(`var expandErr error`)
+	cb.Linef(`val := os.Expand(kv[1], func(name string) string {`)
+	cb.Indent++
+	if len(assets) > 0 {
+		cb.Linef(`if name == "ASSETS" {`)
+		cb.Indent++
+		cb.Linef(`dir, err := extractAssets()`)
+		cb.Linef(`if err != nil {`)
+		cb.Indent++
+		cb.Linef(`expandErr = err`)
+		cb.Indent--
+		cb.Linef(`}`)
+		cb.Linef(`return dir`)
+		cb.Indent--
+		cb.Linef(`}`)
+	}
+	cb.Linef(`return os.Getenv(name)`)
+	cb.Indent--
+	cb.Linef(`})`)
+	cb.Linef(`if expandErr != nil {`)
+	cb.Indent++
+	cb.Linef(`return expandErr`)
+	cb.Indent--
+	cb.Linef(`}`)
+	cb.Linef(`if err := os.Setenv(kv[0], val); err != nil {`)
+	cb.Indent++
+	cb.Linef(`return err`)
+	cb.Indent--
+	cb.Linef(`}`)
+	cb.Indent--
+	cb.Linef(`}`)
+	cb.Linef(`return nil`)
+	cb.Indent--
+	cb.Linef(`}`)
+
+	fmtErr, err := saveCode(sink, &cb, path)
+	if err != nil {
+		return err
+	}
+	if fmtErr != nil {
+		return fmt.Errorf("save %v: fmt: %w", path, fmtErr)
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package ryegen
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// writeFiles writes files (by slash-separated path relative to dir) to
+// dir.
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0777); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestReadAssets(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"config.toml":                  "",
+		"fonts/a.ttf":                  "a",
+		"fonts/sub/b.ttf":              "b",
+		"out/pkg/assets/fonts/a.ttf":   "old",
+		"out/pkg/generated.go":         "",
+		"out/other/keep.txt":           "keep",
+		"_srcrepos/example.com/m/m.go": "",
+		".ryegen-cache/packages.gob":   "",
+	})
+	exclude := []string{
+		filepath.Join(dir, "out", "pkg"),
+		filepath.Join(dir, "_srcrepos"),
+		filepath.Join(dir, ".ryegen-cache"),
+	}
+
+	assets, err := readAssets(dir, []string{"."}, exclude)
+	assert.NoError(err)
+	assert.Equal(map[string][]byte{
+		"config.toml":        []byte(""),
+		"fonts/a.ttf":        []byte("a"),
+		"fonts/sub/b.ttf":    []byte("b"),
+		"out/other/keep.txt": []byte("keep"),
+	}, assets)
+
+	assets, err = readAssets(dir, []string{"fonts/sub", "config.toml"}, exclude)
+	assert.NoError(err)
+	assert.Equal(map[string][]byte{
+		"config.toml":     []byte(""),
+		"fonts/sub/b.ttf": []byte("b"),
+	}, assets)
+
+	_, err = readAssets(dir, []string{"missing"}, exclude)
+	assert.ErrorContains(err, "read assets")
+}
+
+func TestWriteAssetsFiles(t *testing.T) {
+	assert := assert.New(t)
+
+	var sink MemorySink
+	assert.NoError(writeAssetsFiles(&sink, "out", "pkg", "", map[string][]byte{"fonts/a.ttf": []byte("a")}, map[string]string{"FONT": "$ASSETS/fonts/a.ttf"}))
+	files := sink.Files()
+	assert.Equal([]byte("a"), files["out/assets/fonts/a.ttf"])
+	code := string(files["out/assets.go"])
+	assert.Contains(code, "//go:embed all:assets\n")
+	assert.Contains(code, `{"FONT", "$ASSETS/fonts/a.ttf"},`)
+
+	// Only environment variables: nothing is embedded.
+	sink = MemorySink{}
+	assert.NoError(writeAssetsFiles(&sink, "out", "pkg", "", nil, map[string]string{"A": "1"}))
+	code = string(sink.Files()["out/assets.go"])
+	assert.NotContains(code, "embed")
+	assert.NotContains(code, "ASSETS")
+
+	// Neither: the file is removed.
+	assert.NoError(writeAssetsFiles(&sink, "out", "pkg", "", nil, nil))
+	assert.Empty(sink.Files())
+}
+
+const assetsTestSrc = `package pkg
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSetupEnv(t *testing.T) {
+	os.Setenv("KEEP", "set")
+	if err := setupEnv(); err != nil {
+		t.Fatal(err)
+	}
+	if got := os.Getenv("KEEP"); got != "set" {
+		t.Fatalf("KEEP overwritten with %q", got)
+	}
+	if got := os.Getenv("HOME_COPY"); got != os.Getenv("HOME") {
+		t.Fatalf("expected HOME, got %q", got)
+	}
+	font := os.Getenv("FONT")
+	data, err := os.ReadFile(font)
+	if err != nil || string(data) != "a" {
+		t.Fatalf("read %v: %q, %v", font, data, err)
+	}
+	cache, _ := os.UserCacheDir()
+	if rel, err := filepath.Rel(cache, font); err != nil || rel != filepath.Join("ryegen", filepath.Base(filepath.Dir(filepath.Dir(font))), "fonts", "a.ttf") {
+		t.Fatalf("expected %v in the cache directory %v", font, cache)
+	}
+
+	// Extracted once.
+	dir, err := extractAssets()
+	if err != nil || dir != filepath.Dir(filepath.Dir(font)) {
+		t.Fatalf("expected %v, got %v, %v", filepath.Dir(filepath.Dir(font)), dir, err)
+	}
+	ents, _ := os.ReadDir(filepath.Dir(dir))
+	if len(ents) != 1 {
+		t.Fatalf("expected only the extracted directory, got %v", ents)
+	}
+}
+`
+
+func TestAssetsExtraction(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go test")
+	}
+
+	mod := t.TempDir()
+	writeFiles(t, mod, map[string]string{
+		"go.mod":      "module example.com/pkg\n\ngo 1.23\n",
+		"pkg_test.go": assetsTestSrc,
+	})
+	if err := writeAssetsFiles(FileSink{}, mod, "pkg", "", map[string][]byte{"fonts/a.ttf": []byte("a")}, map[string]string{
+		"FONT":      "$ASSETS/fonts/a.ttf",
+		"KEEP":      "overwritten",
+		"HOME_COPY": "$HOME",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Keep the build cache, which is in the user's cache directory by
+	// default.
+	goCache, err := exec.Command("go", "env", "GOCACHE").Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmd := exec.Command("go", "test", ".")
+	cmd.Dir = mod
+	cmd.Env = append(os.Environ(), "XDG_CACHE_HOME="+t.TempDir(), "GOCACHE="+strings.TrimSpace(string(goCache)), "GOFLAGS=-mod=mod", "GOPROXY=off", "GOWORK=off")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("go test: %v\n%s", err, out)
+	}
+}
@@ -0,0 +1,159 @@
+package binder
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"strings"
+
+	"github.com/refaktor/ryegen/binder/binderio"
+	"github.com/refaktor/ryegen/ir"
+)
+
+// BinaryHelperNames are the Go-style names of the helper builtins
+// generated by [GenerateBinaryHelper] if the "binary" preset is enabled.
+var BinaryHelperNames = func() []string {
+	var res []string
+	for _, bits := range []int{16, 32, 64} {
+		for _, order := range []string{"LE", "BE"} {
+			res = append(res, fmt.Sprintf("ReadUint%v%v", bits, order))
+			res = append(res, fmt.Sprintf("EncodeUint%v%v", bits, order))
+		}
+	}
+	return res
+}()
+
+// GenerateBinaryHelper generates one of the builtins in
+// [BinaryHelperNames], which read and encode fixed-size unsigned integers
+// directly from and to Rye strings holding binary data, instead of going
+// through []byte block conversions. Data is accepted as a string or a
+// native []byte.
+func GenerateBinaryHelper(deps *Dependencies, ctx *Context, name string) (*BindingFunc, error) {
+	const modulePath = "encoding/binary"
+
+	binMod, ok := ctx.ModNames[modulePath]
+	if !ok {
+		return nil, errors.New("unknown module path " + modulePath)
+	}
+
+	var read bool
+	var rest string
+	if s, ok := strings.CutPrefix(name, "ReadUint"); ok {
+		read, rest = true, s
+	} else if s, ok := strings.CutPrefix(name, "EncodeUint"); ok {
+		rest = s
+	} else {
+		return nil, errors.New("unknown binary helper " + name)
+	}
+	var bits int
+	var order, orderName string
+	if _, err := fmt.Sscanf(rest, "%d%s", &bits, &order); err != nil {
+		return nil, errors.New("unknown binary helper " + name)
+	}
+	switch order {
+	case "LE":
+		orderName = "LittleEndian"
+	case "BE":
+		orderName = "BigEndian"
+	default:
+		return nil, errors.New("unknown binary helper " + name)
+	}
+	if bits != 16 && bits != 32 && bits != 64 {
+		return nil, errors.New("unknown binary helper " + name)
+	}
+	uintTyp, err := ir.NewIdent(ctx.IR.ConstValues, ctx.ModNames, nil, &ast.Ident{Name: fmt.Sprintf("uint%v", bits)})
+	if err != nil {
+		return nil, err
+	}
+	byteOrder := fmt.Sprintf(`%v.%v`, binMod, orderName)
+	orderDesc := map[string]string{"LE": "little", "BE": "big"}[order]
+
+	res := &BindingFunc{}
+	res.Category = "Binary helpers"
+	res.Name = name
+	res.File = &ir.File{
+		ModuleName: binMod,
+		ModulePath: modulePath,
+	}
+
+	var cb binderio.CodeBuilder
+
+	if read {
+		res.Doc = fmt.Sprintf("Read a %v-bit unsigned %v-endian integer from binary data at a byte offset", bits, orderDesc)
+		res.Signature = Signature{Args: []SignatureArg{{"data", "string or native([]byte)"}, {"offset", "integer"}}, Results: []string{"integer"}}
+		res.DocComment = res.Signature.DocComment()
+		res.Argsn = 2
+		cb.Linef(`var data []byte`)
+		cb.Linef(`switch v := arg0.(type) {`)
+		cb.Linef(`case env.String:`)
+		cb.Indent++
+		cb.Linef(`data = []byte(v.Value)`)
+		cb.Indent--
+		cb.Linef(`case env.Native:`)
+		cb.Indent++
+		cb.Linef(`b, ok := v.Value.([]byte)`)
+		cb.Linef(`if !ok {`)
+		cb.Indent++
+		cb.Append(makeMakeRetArgErr(0)(`"expected native of type []byte, but got "+objectDebugString(ps.Idx, v)`))
+		cb.Indent--
+		cb.Linef(`}`)
+		cb.Linef(`data = b`)
+		cb.Indent--
+		cb.Linef(`default:`)
+		cb.Indent++
+		cb.Append(makeMakeRetArgErr(0)(`"expected string or native, but got "+objectDebugString(ps.Idx, v)`))
+		cb.Indent--
+		cb.Linef(`}`)
+		cb.Linef(`offObj, ok := arg1.(env.Integer)`)
+		cb.Linef(`if !ok {`)
+		cb.Indent++
+		cb.Append(makeMakeRetArgErr(1)(`"expected integer, but got "+objectDebugString(ps.Idx, arg1)`))
+		cb.Indent--
+		cb.Linef(`}`)
+		cb.Linef(`if offObj.Value < 0 || offObj.Value > int64(len(data))-%v {`, bits/8)
+		cb.Indent++
+		cb.Append(makeMakeRetArgErr(1)(fmt.Sprintf(`"offset "+strconv.FormatInt(offObj.Value, 10)+" out of range for %v bytes in data of length "+strconv.Itoa(len(data))`, bits/8)))
+		deps.Imports["strconv"] = struct{}{}
+		cb.Indent--
+		cb.Linef(`}`)
+		cb.Linef(`res := %v.Uint%v(data[offObj.Value:])`, byteOrder, bits)
+		cb.Linef(`var resObj env.Object`)
+		if _, found := ConvGoToRye(
+			deps,
+			ctx,
+			&cb,
+			uintTyp,
+			`resObj`,
+			`res`,
+			-1,
+			nil,
+		); !found {
+			return nil, errors.New("unhandled type conversion (go to rye): " + uintTyp.Name)
+		}
+		cb.Linef(`return resObj`)
+	} else {
+		res.Doc = fmt.Sprintf("Encode an integer as %v-bit unsigned %v-endian binary data", bits, orderDesc)
+		res.Signature = Signature{Args: []SignatureArg{{"value", "integer"}}, Results: []string{"string"}}
+		res.DocComment = res.Signature.DocComment()
+		res.Argsn = 1
+		cb.Linef(`var val %v`, uintTyp.Name)
+		if _, found := ConvRyeToGo(
+			deps,
+			ctx,
+			&cb,
+			uintTyp,
+			`val`,
+			`arg0`,
+			0,
+			makeMakeRetArgErr(0),
+		); !found {
+			return nil, errors.New("unhandled type conversion (rye to go): " + uintTyp.Name)
+		}
+		cb.Linef(`return *env.NewString(string(%v.AppendUint%v(nil, val)))`, byteOrder, bits)
+	}
+	deps.Imports[modulePath] = struct{}{}
+
+	res.Body = cb.String()
+
+	return res, nil
+}
@@ -4,10 +4,20 @@ import (
 	"errors"
 	"fmt"
 	"go/ast"
+	"go/doc"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"hash/fnv"
+	"maps"
+	"slices"
+	"strconv"
 	"strings"
+	"unicode"
 
 	"github.com/iancoleman/strcase"
 	"github.com/refaktor/ryegen/binder/binderio"
+	"github.com/refaktor/ryegen/config"
 	"github.com/refaktor/ryegen/ir"
 )
 
@@ -52,10 +62,32 @@ func (id BindingFuncID) UniqueName(ctx *Context) string {
 // renameCandidate (optional) has top priority
 func (id BindingFuncID) RyeifiedNameCandidates(ctx *Context, noPrefix, cutNew bool, renameCandidate string) (candidates []string) {
 	prefix := id.modPrefix(ctx)
+	if custom, ok := ctx.Config.CustomPrefix(id.File.ModulePath); ok && id.Recv == "" {
+		// e.g. "fy-" for fyne.NewApp => fy-app, capitalized like the
+		// package name, so fyne.New => fy-fy
+		custom = strings.TrimSuffix(custom, "-")
+		if custom != "" {
+			prefix = strings.ToUpper(custom[:1]) + custom[1:]
+		}
+	}
+	short := ctx.Config.Naming == config.NamingShort && id.Recv == ""
+	// Unique module name prefix, if prefix is the package name instead,
+	// which other packages may share.
+	var uniquePrefix string
+	if short {
+		cutNew = true
+		if _, ok := ctx.Config.CustomPrefix(id.File.ModulePath); !ok && id.File.ModuleName != "" {
+			// e.g. types-... rather than b-types-... for example.com/b/types
+			pkgPrefix := strings.ToUpper(id.File.ModuleName[:1]) + id.File.ModuleName[1:]
+			if pkgPrefix != prefix {
+				prefix, uniquePrefix = pkgPrefix, prefix
+			}
+		}
+	}
 
 	addCandidate := func(s string) {
 		if id.Recv != "" {
-			candidates = append(candidates, id.Recv+"//"+strcase.ToKebab(s))
+			candidates = append(candidates, ctx.kind(id.Recv)+"//"+strcase.ToKebab(s))
 		} else {
 			candidates = append(candidates, strcase.ToKebab(s))
 		}
@@ -66,6 +98,16 @@ func (id BindingFuncID) RyeifiedNameCandidates(ctx *Context, noPrefix, cutNew bo
 		addCandidate(renameCandidate)
 	}
 
+	if short {
+		// e.g. http.NewHTTPClient => client
+		if name, ok := id.unstutteredName(ctx); ok {
+			addCandidate(name)
+		}
+		noPrefix = true
+	}
+
+	// Name following the prefix in the last candidate.
+	var prefixed string
 	newWasCut := false
 	if cutNew {
 		if after, found := strings.CutPrefix(id.Name, "New"); found {
@@ -75,14 +117,14 @@ func (id BindingFuncID) RyeifiedNameCandidates(ctx *Context, noPrefix, cutNew bo
 					addCandidate(prefix)
 				}
 				// e.g. app.New => app-app
-				addCandidate(prefix + prefix)
+				prefixed = prefix
 			} else {
 				if noPrefix {
 					// e.g. lib.NewApp => app
 					addCandidate(after)
 				}
 				// e.g. lib.NewApp => lib-app
-				addCandidate(prefix + after)
+				prefixed = after
 			}
 			newWasCut = true
 		}
@@ -93,73 +135,505 @@ func (id BindingFuncID) RyeifiedNameCandidates(ctx *Context, noPrefix, cutNew bo
 			addCandidate(id.Name)
 		}
 		// e.g. lib.NewApp => lib-new-app
-		addCandidate(prefix + id.Name)
+		prefixed = id.Name
+	}
+	addCandidate(prefix + prefixed)
+	if uniquePrefix != "" {
+		// e.g. example.com/b/types.NewApp => b-types-app, in case
+		// types-app is taken by another types package
+		addCandidate(uniquePrefix + prefixed)
 	}
 
 	return candidates
 }
 
+// unstutteredName returns the name (with "New" cut) without the package
+// name at the start, e.g. http.NewHTTPClient => Client. Only returns ok
+// if the package has no other declaration with the resulting name.
+func (id BindingFuncID) unstutteredName(ctx *Context) (string, bool) {
+	pkgName := id.File.ModuleName
+	name := strings.TrimPrefix(id.Name, "New")
+	if pkgName == "" || len(name) <= len(pkgName) || !strings.EqualFold(name[:len(pkgName)], pkgName) {
+		return "", false
+	}
+	rest := name[len(pkgName):]
+	if !unicode.IsUpper(rune(rest[0])) {
+		return "", false
+	}
+	modName := ctx.ModNames[id.File.ModulePath]
+	for _, goName := range []string{rest, "New" + rest} {
+		key := modName + "." + goName
+		if _, ok := ctx.IR.Funcs[key]; ok {
+			return "", false
+		}
+		if _, ok := ctx.IR.Structs[key]; ok {
+			return "", false
+		}
+		if _, ok := ctx.IR.Values[key]; ok {
+			return "", false
+		}
+	}
+	return rest, true
+}
+
+// ShortKinds maps the native kinds of the package-level named types in
+// ctx (e.g. "Go(*widget.Button)") to short kinds made of the package
+// and type name (e.g. "widget-button"), for receiver-naming = "short".
+// Structs are mapped by their pointer kind, other types by their value
+// kind.
+//
+// Kinds whose short forms collide (e.g. http.HTTPClient and
+// http.HttpClient) are left out and returned in conflicts, sorted.
+func ShortKinds(ctx *Context) (kinds map[string]string, conflicts []string) {
+	longKinds := make(map[string][]string) // short to long kinds
+	add := func(name string, ptr bool) {
+		mod, typ, ok := strings.Cut(name, ".")
+		if !ok || !token.IsExported(typ) || strings.ContainsAny(typ, "[.") {
+			return
+		}
+		long := "Go(" + name + ")"
+		if ptr {
+			long = "Go(*" + name + ")"
+		}
+		short := strcase.ToKebab(mod) + "-" + strcase.ToKebab(typ)
+		longKinds[short] = append(longKinds[short], long)
+	}
+	for name := range ctx.IR.Structs {
+		add(name, true)
+	}
+	for name := range ctx.IR.Interfaces {
+		add(name, false)
+	}
+	for name := range ctx.IR.Typedefs {
+		add(name, false)
+	}
+
+	kinds = make(map[string]string)
+	for short, longs := range longKinds {
+		if len(longs) > 1 {
+			slices.Sort(longs)
+			conflicts = append(conflicts, fmt.Sprintf("%v (%v)", short, strings.Join(longs, ", ")))
+			continue
+		}
+		kinds[longs[0]] = short
+	}
+	slices.Sort(conflicts)
+	return kinds, conflicts
+}
+
 type BindingFunc struct {
 	BindingFuncID
 	Doc        string
 	DocComment string
-	Argsn      int
-	Body       string
+	// Rye signature, also described in DocComment.
+	Signature Signature
+	// Go declaration bound by the builtin (e.g. "os.Open",
+	// "(*os.File).Read" or "os.ProcAttr.Dir"), empty for helpers.
+	GoSymbol string
+	Argsn    int
+	Body     string
+	// Binding hints from "//ryegen:" comments in the bound package's source.
+	Directives []ir.Directive
+	// Number of generated conversions by converter (see [Dependencies.ConvUsage]).
+	ConvUsage map[ConvID]int
+	// Dependencies between the converters used (see [Dependencies.ConvDeps]).
+	ConvDeps map[ConvEdge]int
+	// Go API function calling the builtin (see [GenerateGoAPI]), empty
+	// if disabled or unsupported.
+	GoAPI string
 }
 
-func GenerateBinding(deps *Dependencies, ctx *Context, fn *ir.Func) (*BindingFunc, error) {
-	res := &BindingFunc{}
+// typeExcludeDirectives returns the "exclude" directives of a type, which
+// also apply to its members (methods, getters, setters).
+func typeExcludeDirectives(ctx *Context, typ ir.Ident) []ir.Directive {
+	if se, ok := typ.Expr.(*ast.StarExpr); ok {
+		var err error
+		typ, err = ir.NewIdent(ctx.IR.ConstValues, ctx.ModNames, typ.File, se.X)
+		if err != nil {
+			return nil
+		}
+	}
+	var res []ir.Directive
+	for _, d := range ctx.IR.Directives[typ.Name] {
+		if d.Name == "exclude" {
+			res = append(res, d)
+		}
+	}
+	return res
+}
 
-	var docComment strings.Builder
-	docComment.WriteString(fn.DocComment)
-	if fn.DocComment != "" {
-		docComment.WriteString("\n")
+// BindArgsKey returns the key of fn in [config.Config.BindArgs]:
+// "<package path>.<Func>" or "<package path>.<Type>.<Method>".
+func BindArgsKey(fn *ir.Func) string {
+	var key string
+	if id, ok := fn.Name.Expr.(*ast.Ident); ok {
+		key = id.Name
 	}
-	if fn.Recv != nil || len(fn.Params) > 0 {
-		docComment.WriteString("Args:\n")
-		if fn.Recv != nil {
-			typName, err := GetRyeTypeDesc(ctx, fn.Recv.File, fn.Recv.Expr)
-			if err != nil {
-				return nil, err
+	if fn.Recv != nil {
+		expr := fn.Recv.Expr
+		if se, ok := expr.(*ast.StarExpr); ok {
+			expr = se.X
+		}
+		if id, ok := expr.(*ast.Ident); ok {
+			key = id.Name + "." + key
+		}
+	}
+	return fn.File.ModulePath + "." + key
+}
+
+// ErrUnsafePointers is the root cause of errors for bindings excluded
+// because they pass pointers as unsafe.Pointer or uintptr between Rye
+// and Go, unless allowed in [config.Config.AllowUnsafe].
+var ErrUnsafePointers = errors.New(`passes unsafe pointers (see "allow-unsafe" in config.toml)`)
+
+// Substrings of names of uintptr values likely holding pointers rather
+// than e.g. file descriptors or handles.
+var pointerParamNameParts = []string{"ptr", "pointer", "addr"}
+
+// holdsUnsafePointer reports whether v holds an unsafe.Pointer (also
+// nested, e.g. in a callback) or a uintptr which, going by its name, is
+// a pointer.
+func holdsUnsafePointer(v ir.NamedIdent) bool {
+	if id, ok := v.Type.Expr.(*ast.Ident); ok && id.Name == "uintptr" && !v.Unnamed {
+		name := strings.ToLower(v.Name.Name)
+		if slices.ContainsFunc(pointerParamNameParts, func(part string) bool { return strings.Contains(name, part) }) {
+			return true
+		}
+	}
+	found := false
+	ast.Inspect(v.Type.Expr, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return !found
+		}
+		if x, ok := sel.X.(*ast.Ident); ok && sel.Sel.Name == "Pointer" {
+			if imp, ok := v.Type.File.ImportsByName[x.Name]; ok && imp.ModulePath == "unsafe" {
+				found = true
 			}
-			fmt.Fprintf(&docComment, " * recv - %v\n", typName)
 		}
-		for _, param := range fn.Params {
-			typName, err := GetRyeTypeDesc(ctx, param.Type.File, param.Type.Expr)
+		return false
+	})
+	return found
+}
+
+// unsafeAllowed reports whether the binding with the key (e.g.
+// [BindArgsKey]) may pass unsafe pointers.
+func unsafeAllowed(ctx *Context, key string) bool {
+	return ctx.Config.UnsafeAck && slices.Contains(ctx.Config.AllowUnsafe, key)
+}
+
+// checkUnsafeFunc returns an error wrapping [ErrUnsafePointers] if a
+// parameter of fn which isn't bound in boundArgs or a result of fn holds
+// an unsafe pointer (see [holdsUnsafePointer]) and fn isn't allowed to
+// pass them.
+func checkUnsafeFunc(ctx *Context, fn *ir.Func, boundArgs map[string]string) error {
+	if unsafeAllowed(ctx, BindArgsKey(fn)) {
+		return nil
+	}
+	for _, param := range fn.Params {
+		if _, ok := boundArgs[param.Name.Name]; ok {
+			continue
+		}
+		if holdsUnsafePointer(param) {
+			return fmt.Errorf("parameter %v: %w", param.Name.Name, ErrUnsafePointers)
+		}
+	}
+	for i, result := range fn.Results {
+		if holdsUnsafePointer(result) {
+			return fmt.Errorf("result %v: %w", i+1, ErrUnsafePointers)
+		}
+	}
+	return nil
+}
+
+// rewriteGoExpr parses a Go expression from the config and qualifies it
+// for the generated code: package qualifiers, which refer to file's
+// package or its imports, are rewritten to the generated code's
+// qualifiers, and bare identifiers declared in file's package (e.g.
+// ModeFast or Event in make(chan Event)) are qualified with its package.
+func rewriteGoExpr(deps *Dependencies, ctx *Context, file *ir.File, exprStr string) (string, error) {
+	expr, err := parser.ParseExpr(exprStr)
+	if err != nil {
+		return "", err
+	}
+	qualifier := func(modulePath string) (string, error) {
+		name := ctx.ModNames[modulePath]
+		if name == "" {
+			return "", fmt.Errorf("package %v isn't bound", modulePath)
+		}
+		deps.Imports[modulePath] = struct{}{}
+		return name, nil
+	}
+
+	// Identifiers which don't refer to package level declarations:
+	// selected names, struct literal keys and local declarations.
+	notRefs := make(map[*ast.Ident]struct{})
+	locals := make(map[string]struct{})
+	ast.Inspect(expr, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.SelectorExpr:
+			notRefs[n.Sel] = struct{}{}
+		case *ast.CompositeLit:
+			if _, isMap := n.Type.(*ast.MapType); !isMap {
+				for _, elt := range n.Elts {
+					if kv, ok := elt.(*ast.KeyValueExpr); ok {
+						if key, ok := kv.Key.(*ast.Ident); ok {
+							notRefs[key] = struct{}{}
+						}
+					}
+				}
+			}
+		case *ast.Field:
+			for _, name := range n.Names {
+				locals[name.Name] = struct{}{}
+			}
+		case *ast.AssignStmt:
+			if n.Tok == token.DEFINE {
+				for _, lhs := range n.Lhs {
+					if id, ok := lhs.(*ast.Ident); ok {
+						locals[id.Name] = struct{}{}
+					}
+				}
+			}
+		}
+		return true
+	})
+
+	var rewriteErr error
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if rewriteErr != nil {
+			return false
+		}
+		switch n := n.(type) {
+		case *ast.SelectorExpr:
+			x, ok := n.X.(*ast.Ident)
+			if !ok {
+				return true
+			}
+			if _, ok := locals[x.Name]; ok {
+				return true
+			}
+			var modulePath string
+			if imp, ok := file.ImportsByName[x.Name]; ok {
+				modulePath = imp.ModulePath
+			} else if x.Name == file.ModuleName {
+				modulePath = file.ModulePath
+			} else if packageLevelDecl(ctx, file, x.Name) {
+				// E.g. a field of a package level variable.
+				return true
+			} else {
+				rewriteErr = fmt.Errorf("unknown package %v", x.Name)
+				return false
+			}
+			x.Name, rewriteErr = qualifier(modulePath)
+			return false
+		case *ast.Ident:
+			if _, ok := notRefs[n]; ok {
+				return true
+			}
+			if _, ok := locals[n.Name]; ok {
+				return true
+			}
+			if !packageLevelDecl(ctx, file, n.Name) {
+				// Predeclared (e.g. make, nil) or unknown, which the
+				// compiler reports.
+				return true
+			}
+			if !n.IsExported() {
+				rewriteErr = fmt.Errorf("%v is unexported", n.Name)
+				return false
+			}
+			qual, err := qualifier(file.ModulePath)
 			if err != nil {
-				return nil, err
+				rewriteErr = err
+				return false
 			}
-			fmt.Fprintf(&docComment, " * %v - %v\n", strcase.ToKebab(param.Name.Name), typName)
+			// Printed as is, so this makes it a qualified identifier.
+			n.Name = qual + "." + n.Name
+		}
+		return true
+	})
+	if rewriteErr != nil {
+		return "", rewriteErr
+	}
+	var b strings.Builder
+	if err := format.Node(&b, token.NewFileSet(), expr); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// packageLevelDecl reports whether name is declared at the package level
+// of file's package.
+func packageLevelDecl(ctx *Context, file *ir.File, name string) bool {
+	key := ctx.ModNames[file.ModulePath] + "." + name
+	if _, ok := ctx.IR.Funcs[key]; ok {
+		return true
+	}
+	if _, ok := ctx.IR.Interfaces[key]; ok {
+		return true
+	}
+	if _, ok := ctx.IR.Structs[key]; ok {
+		return true
+	}
+	if _, ok := ctx.IR.Typedefs[key]; ok {
+		return true
+	}
+	if _, ok := ctx.IR.Aliases[key]; ok {
+		return true
+	}
+	if _, ok := ctx.IR.GenericAliases[key]; ok {
+		return true
+	}
+	_, ok := ctx.IR.Values[key]
+	return ok
+}
+
+// boundArgExprs returns the Go expressions to pass for parameters of fn
+// bound in [config.Config.BindArgs], by parameter name, qualified for
+// the generated code (see [rewriteGoExpr]).
+func boundArgExprs(deps *Dependencies, ctx *Context, fn *ir.Func) (map[string]string, error) {
+	bound := ctx.Config.BindArgs[BindArgsKey(fn)]
+	if len(bound) == 0 {
+		return nil, nil
+	}
+	res := make(map[string]string, len(bound))
+	for name, exprStr := range bound {
+		if !slices.ContainsFunc(fn.Params, func(p ir.NamedIdent) bool { return p.Name.Name == name }) {
+			return nil, fmt.Errorf("bind-args: %v has no parameter %v", BindArgsKey(fn), name)
+		}
+		expr, err := rewriteGoExpr(deps, ctx, fn.File, exprStr)
+		if err != nil {
+			return nil, fmt.Errorf("bind-args: %v: %v: %w", BindArgsKey(fn), name, err)
+		}
+		res[name] = expr
+	}
+	return res, nil
+}
+
+// StructDefaultsKey returns the key of a struct in
+// [config.Config.StructDefaults]: "<package path>.<Struct>".
+func StructDefaultsKey(structName ir.Ident) string {
+	var name string
+	if id, ok := structName.Expr.(*ast.Ident); ok {
+		name = id.Name
+	}
+	return structName.File.ModulePath + "." + name
+}
+
+// InternalAllowed reports whether the methods of types in the internal
+// package modulePath may be bound (see [config.Config.AllowInternal]).
+func InternalAllowed(ctx *Context, modulePath string) bool {
+	return slices.Contains(ctx.Config.AllowInternal, modulePath)
+}
+
+func GenerateBinding(deps *Dependencies, ctx *Context, fn *ir.Func) (*BindingFunc, error) {
+	res := &BindingFunc{}
+
+	if fn.Recv != nil && ir.IdentIsInternal(ctx.ModNames, *fn.Recv) && !InternalAllowed(ctx, fn.File.ModulePath) {
+		return nil, errors.New("method of internal type " + fn.Recv.Name)
+	}
+
+	boundArgs, err := boundArgExprs(deps, ctx, fn)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkUnsafeFunc(ctx, fn, boundArgs); err != nil {
+		return nil, err
+	}
+
+	var sig Signature
+	if fn.Recv != nil {
+		typName, err := GetRyeTypeDesc(ctx, fn.Recv.File, fn.Recv.Expr)
+		if err != nil {
+			return nil, err
 		}
+		sig.Args = append(sig.Args, SignatureArg{"recv", typName})
 	}
+	for _, param := range fn.Params {
+		if _, ok := boundArgs[param.Name.Name]; ok {
+			continue
+		}
+		typName, err := GetRyeTypeDesc(ctx, param.Type.File, param.Type.Expr)
+		if err != nil {
+			return nil, err
+		}
+		sig.Args = append(sig.Args, SignatureArg{strcase.ToKebab(param.Name.Name), typName})
+	}
+	// Doc comment lines of the results, which differ from the signature
+	// for multiple and comma-ok results.
+	var resultDocs []string
 	{
 		results := fn.Results
 		canErr := false
-		if len(results) > 0 {
-			if results[len(results)-1].Type.Name == "error" {
-				results = results[:len(results)-1]
-				canErr = true
-			}
-			docComment.WriteString("Result:\n")
-			if len(results) == 1 {
-				typName, err := GetRyeTypeDesc(ctx, results[0].Type.File, results[0].Type.Expr)
-				if err != nil {
-					return nil, err
-				}
-				fmt.Fprintf(&docComment, " * %v\n", typName)
-			} else if len(results) > 1 {
-				docComment.WriteString("[\n")
-				for _, param := range results {
-					typName, err := GetRyeTypeDesc(ctx, param.Type.File, param.Type.Expr)
-					if err != nil {
-						return nil, err
-					}
-					fmt.Fprintf(&docComment, "    %v\n", typName)
-				}
-				docComment.WriteString("]\n")
+		if len(results) > 0 && results[len(results)-1].Type.Name == "error" {
+			results = results[:len(results)-1]
+			canErr = true
+		}
+		typNames := make([]string, len(results))
+		for i, param := range results {
+			var err error
+			typNames[i], err = GetRyeTypeDesc(ctx, param.Type.File, param.Type.Expr)
+			if err != nil {
+				return nil, err
 			}
-			if canErr {
-				docComment.WriteString(" * error\n")
+		}
+		if IsCommaOkResults(ctx, results) {
+			sig.Results = append(sig.Results, typNames[0])
+			if ctx.Config.CommaOk == config.CommaOkFailure {
+				resultDocs = append(resultDocs, fmt.Sprintf(" * %v (failure if not ok)", typNames[0]))
+			} else {
+				resultDocs = append(resultDocs, fmt.Sprintf(" * %v (void if not ok)", typNames[0]))
+			}
+		} else if len(results) == 1 {
+			sig.Results = append(sig.Results, typNames[0])
+			resultDocs = append(resultDocs, " * "+typNames[0])
+		} else if keys, ok := DictResultKeys(ctx, results); ok {
+			items := make([]string, len(results))
+			resultDocs = append(resultDocs, "{")
+			for i := range results {
+				items[i] = keys[i] + ":" + typNames[i]
+				resultDocs = append(resultDocs, fmt.Sprintf("    %v: %v", keys[i], typNames[i]))
+			}
+			resultDocs = append(resultDocs, "}")
+			sig.Results = append(sig.Results, "{"+strings.Join(items, " ")+"}")
+		} else if len(results) > 1 {
+			resultDocs = append(resultDocs, "[")
+			for _, typName := range typNames {
+				resultDocs = append(resultDocs, "    "+typName)
+			}
+			resultDocs = append(resultDocs, "]")
+			sig.Results = append(sig.Results, "["+strings.Join(typNames, " ")+"]")
+		}
+		if canErr {
+			sig.Results = append(sig.Results, "error")
+			resultDocs = append(resultDocs, " * error")
+		}
+	}
+	res.Signature = sig
+
+	var docComment strings.Builder
+	docComment.WriteString(fn.DocComment)
+	if fn.DocComment != "" {
+		docComment.WriteString("\n")
+	}
+	docComment.WriteString(Signature{Args: sig.Args}.DocComment())
+	if len(resultDocs) > 0 {
+		docComment.WriteString("Result:\n")
+		for _, line := range resultDocs {
+			docComment.WriteString(line + "\n")
+		}
+	}
+
+	{
+		exampleKey := fn.Name.Name
+		if fn.Recv != nil {
+			exampleKey = strings.TrimPrefix(fn.Recv.Name, "*") + "." + fn.Name.Name
+		}
+		for _, code := range ctx.IR.Examples[exampleKey] {
+			docComment.WriteString("Example (Go):\n")
+			for _, line := range strings.Split(code, "\n") {
+				fmt.Fprintf(&docComment, "    %v\n", line)
 			}
 		}
 	}
@@ -181,9 +655,14 @@ func GenerateBinding(deps *Dependencies, ctx *Context, fn *ir.Func) (*BindingFun
 	}
 	res.File = fn.File
 
+	res.Directives = slices.Clone(ctx.IR.Directives[ir.FuncGoIdent(fn)])
+	if fn.Recv != nil {
+		res.Directives = append(res.Directives, typeExcludeDirectives(ctx, *fn.Recv)...)
+	}
+
 	if fn.Recv != nil {
 		typ := *fn.Recv
-		if _, ok := ctx.IR.Structs[typ.Name]; ok {
+		if BoxesAsPointer(ctx, typ) {
 			var err error
 			typ, err = ir.NewIdent(ctx.IR.ConstValues, ctx.ModNames, typ.File, &ast.StarExpr{X: typ.Expr})
 			if err != nil {
@@ -196,7 +675,8 @@ func GenerateBinding(deps *Dependencies, ctx *Context, fn *ir.Func) (*BindingFun
 	var cb binderio.CodeBuilder
 
 	res.Doc = ir.FuncGoIdent(fn)
-	res.Argsn = len(fn.Params)
+	res.GoSymbol = ir.FuncGoIdent(fn)
+	res.Argsn = len(fn.Params) - len(boundArgs)
 	if fn.Recv != nil {
 		res.Argsn++
 	}
@@ -210,9 +690,106 @@ func GenerateBinding(deps *Dependencies, ctx *Context, fn *ir.Func) (*BindingFun
 		fn.Recv,
 		fn.Params,
 		fn.Results,
+		boundArgs,
+	); err != nil {
+		return nil, err
+	}
+	if !ir.ModulePathIsInternal(ctx.ModNames, fn.File.ModulePath) {
+		deps.Imports[fn.File.ModulePath] = struct{}{}
+	}
+
+	res.Body = cb.String()
+
+	if ctx.Config.GoAPI && len(boundArgs) == 0 {
+		if goAPI, err := GenerateGoAPI(deps, ctx, fn); err == nil {
+			res.GoAPI = goAPI
+		}
+	}
+
+	return res, nil
+}
+
+// GenerateMethodValue generates a builtin that takes a receiver and returns
+// a builtin calling the method on that receiver (Go method value).
+func GenerateMethodValue(deps *Dependencies, ctx *Context, fn *ir.Func) (*BindingFunc, error) {
+	if fn.Recv == nil {
+		return nil, errors.New("expected method")
+	}
+	if ir.IdentIsInternal(ctx.ModNames, *fn.Recv) {
+		return nil, errors.New("cannot create method value of internal type " + fn.Recv.Name)
+	}
+	if err := checkUnsafeFunc(ctx, fn, nil); err != nil {
+		return nil, err
+	}
+
+	res := &BindingFunc{}
+	res.Category = "Method values"
+
+	{
+		id, ok := fn.Name.Expr.(*ast.Ident)
+		if !ok {
+			panic("expected func name to be *ast.Ident")
+		}
+		res.Name = id.Name + "Fn"
+	}
+	res.File = fn.File
+
+	recv := *fn.Recv
+	if BoxesAsPointer(ctx, recv) {
+		var err error
+		recv, err = ir.NewIdent(ctx.IR.ConstValues, ctx.ModNames, recv.File, &ast.StarExpr{X: recv.Expr})
+		if err != nil {
+			panic(err)
+		}
+	}
+	res.Recv = recv.RyeName()
+
+	{
+		typName, err := GetRyeTypeDesc(ctx, fn.Recv.File, fn.Recv.Expr)
+		if err != nil {
+			return nil, err
+		}
+		res.Signature = Signature{Args: []SignatureArg{{"recv", typName}}, Results: []string{"builtin"}}
+		res.DocComment = res.Signature.DocComment()
+	}
+	res.Doc = fmt.Sprintf("Get %v method value", ir.FuncGoIdent(fn))
+	res.GoSymbol = ir.FuncGoIdent(fn)
+	res.Argsn = 1
+
+	var cb binderio.CodeBuilder
+
+	cb.Linef(`var self %v`, fn.Recv.Name)
+	deps.MarkUsed(*fn.Recv)
+	if _, found := ConvRyeToGo(
+		deps,
+		ctx,
+		&cb,
+		*fn.Recv,
+		`self`,
+		`arg0`,
+		0,
+		makeMakeRetArgErr(0),
+	); !found {
+		return nil, errors.New("unhandled type conversion (rye to go): " + fn.Recv.Name)
+	}
+	cb.Linef(`methodValue := self.%v`, fn.Name.Name)
+	cb.Linef(`return *env.NewBuiltin(func(ps *env.ProgramState, arg0, arg1, arg2, arg3, arg4 env.Object) env.Object {`)
+	cb.Indent++
+	if err := ConvGoToRyeCodeFuncBody(
+		deps,
+		ctx,
+		&cb,
+		`methodValue`,
+		makeMakeRetArgErr(1),
+		nil,
+		fn.Params,
+		fn.Results,
+		nil,
 	); err != nil {
 		return nil, err
 	}
+	cb.Indent--
+	cb.Linef(`}, %v, false, false, "%v")`, len(fn.Params), ir.FuncGoIdent(fn))
 	deps.Imports[fn.File.ModulePath] = struct{}{}
 
 	res.Body = cb.String()
@@ -220,7 +797,135 @@ func GenerateBinding(deps *Dependencies, ctx *Context, fn *ir.Func) (*BindingFun
 	return res, nil
 }
 
+// GenerateChannelWatch generates a builtin for a struct field of receivable
+// channel type which calls a Rye function for every value received from the
+// channel in a separate goroutine. If the callback fails, the struct's Close
+// method is called (if it has one) and watching stops. A nil self is a
+// failure.
+func GenerateChannelWatch(deps *Dependencies, ctx *Context, field ir.NamedIdent, struc *ir.Struct) (*BindingFunc, error) {
+	chTyp, ok := field.Type.Expr.(*ast.ChanType)
+	if !ok || chTyp.Dir == ast.SEND {
+		return nil, errors.New("expected receivable channel field")
+	}
+	elemTyp, err := ir.NewIdent(ctx.IR.ConstValues, ctx.ModNames, field.Type.File, chTyp.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &BindingFunc{}
+	res.Category = "Channel watchers"
+
+	{
+		typName, err := GetRyeTypeDesc(ctx, elemTyp.File, elemTyp.Expr)
+		if err != nil {
+			return nil, err
+		}
+		res.Signature = Signature{Args: []SignatureArg{{"fn", fmt.Sprintf("function(%v)", typName)}}, Results: []string{"self"}}
+		res.DocComment = res.Signature.DocComment()
+	}
+
+	res.Directives = append(res.Directives, ctx.IR.Directives[struc.Name.Name+"."+field.Name.Name]...)
+	for i, d := range res.Directives {
+		if d.Name == "rename" && len(d.Args) == 1 {
+			// Would otherwise collide with the getter
+			res.Directives[i].Args = []string{d.Args[0] + "Watch"}
+		}
+	}
+	res.Directives = append(res.Directives, typeExcludeDirectives(ctx, struc.Name)...)
+
+	structName, err := ir.NewIdent(ctx.IR.ConstValues, ctx.ModNames, struc.Name.File, &ast.StarExpr{X: struc.Name.Expr})
+	if err != nil {
+		return nil, err
+	}
+
+	res.Recv = structName.RyeName()
+	res.Name = field.Name.Name + "Watch"
+	res.File = structName.File
+	res.Doc = fmt.Sprintf("Call a function for every value received from %v %v", structName.Name, field.Name.Name)
+	res.GoSymbol = structName.Name + "." + field.Name.Name
+	res.Argsn = 2
+
+	var cb binderio.CodeBuilder
+
+	cb.Linef(`var self %v`, structName.Name)
+	deps.MarkUsed(structName)
+	if _, found := ConvRyeToGo(
+		deps,
+		ctx,
+		&cb,
+		structName,
+		`self`,
+		`arg0`,
+		0,
+		makeMakeRetArgErr(0),
+	); !found {
+		return nil, errors.New("unhandled type conversion (rye to go): " + structName.Name)
+	}
+	cb.Linef(`if self == nil {`)
+	cb.Indent++
+	cb.Append(makeMakeRetArgErr(0)(`"expected non-nil native"`))
+	cb.Indent--
+	cb.Linef(`}`)
+	cb.Linef(`fn, ok := arg1.(env.Function)`)
+	cb.Linef(`if !ok {`)
+	cb.Indent++
+	cb.Append(makeMakeRetArgErr(1)(`"expected function, but got "+objectDebugString(ps.Idx, arg1)`))
+	cb.Indent--
+	cb.Linef(`}`)
+	cb.Linef(`if fn.Argsn != 1 {`)
+	cb.Indent++
+	cb.Append(makeMakeRetArgErr(1)(`"expected 1 function argument, but got "+strconv.Itoa(fn.Argsn)`))
+	deps.Imports["strconv"] = struct{}{}
+	cb.Indent--
+	cb.Linef(`}`)
+	cb.Linef(`ch := self.%v`, field.Name.Name)
+	cb.Linef(`psCopy := *ps`)
+	cb.Linef(`go func() {`)
+	cb.Indent++
+	cb.Linef(`ps := &psCopy`)
+	cb.Linef(`for v := range ch {`)
+	cb.Indent++
+	cb.Linef(`var vObj env.Object`)
+	deps.MarkUsed(elemTyp)
+	if _, found := ConvGoToRye(
+		deps,
+		ctx,
+		&cb,
+		elemTyp,
+		`vObj`,
+		`v`,
+		1,
+		nil,
+	); !found {
+		return nil, errors.New("unhandled type conversion (go to rye): " + elemTyp.Name)
+	}
+	cb.Linef(`evaldo.CallFunctionArgsN(fn, ps, ps.Ctx, vObj)`)
+	cb.Linef(`if ps.ErrorFlag || ps.FailureFlag {`)
+	cb.Indent++
+	cb.Linef(`fmt.Printf("\033[31mError: \033[1m%%v\033[m\n", "((RYEGEN:FUNCNAME)): callback: "+objectDebugString(ps.Idx, ps.Res))`)
+	deps.Imports["fmt"] = struct{}{}
+	if closeFn, ok := struc.Methods["Close"]; ok && len(closeFn.Params) == 0 {
+		cb.Linef(`self.Close()`)
+	}
+	cb.Linef(`return`)
+	cb.Indent--
+	cb.Linef(`}`)
+	cb.Indent--
+	cb.Linef(`}`)
+	cb.Indent--
+	cb.Linef(`}()`)
+	cb.Linef(`return arg0`)
+
+	res.Body = cb.String()
+
+	return res, nil
+}
+
 func GenerateGetterOrSetter(deps *Dependencies, ctx *Context, field ir.NamedIdent, structName ir.Ident, setter bool) (*BindingFunc, error) {
+	if holdsUnsafePointer(field) && !unsafeAllowed(ctx, StructDefaultsKey(structName)+"."+field.Name.Name) {
+		return nil, fmt.Errorf("field %v: %w", field.Name.Name, ErrUnsafePointers)
+	}
+
 	res := &BindingFunc{}
 	if setter {
 		res.Category = "Setters"
@@ -228,22 +933,28 @@ func GenerateGetterOrSetter(deps *Dependencies, ctx *Context, field ir.NamedIden
 		res.Category = "Getters"
 	}
 
-	var docComment strings.Builder
-	if setter {
-		docComment.WriteString("Args:\n")
-		typName, err := GetRyeTypeDesc(ctx, field.Type.File, field.Type.Expr)
-		if err != nil {
-			return nil, err
-		}
-		fmt.Fprintf(&docComment, " * %v - %v\n", strcase.ToKebab(field.Name.Name), typName)
-	}
-	docComment.WriteString("Result:\n")
 	typName, err := GetRyeTypeDesc(ctx, field.Type.File, field.Type.Expr)
 	if err != nil {
 		return nil, err
 	}
-	fmt.Fprintf(&docComment, " * %v\n", typName)
-	res.DocComment = docComment.String()
+	if setter {
+		res.Signature.Args = []SignatureArg{{strcase.ToKebab(field.Name.Name), typName}}
+	}
+	res.Signature.Results = []string{typName}
+	res.DocComment = res.Signature.DocComment()
+
+	for _, d := range ctx.IR.Directives[structName.Name+"."+field.Name.Name] {
+		if d.Name == "rename" && len(d.Args) == 1 {
+			// Getter and setter would otherwise end up with the same name
+			if setter {
+				d.Args = []string{d.Args[0] + "!"}
+			} else {
+				d.Args = []string{d.Args[0] + "?"}
+			}
+		}
+		res.Directives = append(res.Directives, d)
+	}
+	res.Directives = append(res.Directives, typeExcludeDirectives(ctx, structName)...)
 
 	{
 		var err error
@@ -261,6 +972,8 @@ func GenerateGetterOrSetter(deps *Dependencies, ctx *Context, field ir.NamedIden
 	}
 	res.File = structName.File
 
+	res.GoSymbol = structName.Name + "." + field.Name.Name
+
 	var cb binderio.CodeBuilder
 
 	if setter {
@@ -285,19 +998,41 @@ func GenerateGetterOrSetter(deps *Dependencies, ctx *Context, field ir.NamedIden
 	); !found {
 		return nil, errors.New("unhandled type conversion (go to rye): " + structName.Name)
 	}
+	cb.Linef(`if self == nil {`)
+	cb.Indent++
+	cb.Append(makeMakeRetArgErr(0)(`"expected non-nil native"`))
+	cb.Indent--
+	cb.Linef(`}`)
 
-	typIsNonPtrStruct := false
+	typIsBoxed := false
 	ptrTyp := field.Type
-	if _, ok := ctx.IR.Structs[ptrTyp.Name]; ok {
+	if BoxesAsPointer(ctx, ptrTyp) {
 		var err error
 		ptrTyp, err = ir.NewIdent(ctx.IR.ConstValues, ctx.ModNames, ptrTyp.File, &ast.StarExpr{X: ptrTyp.Expr})
 		if err != nil {
 			panic(err)
 		}
-		typIsNonPtrStruct = true
+		typIsBoxed = true
 	}
 
-	if setter {
+	if _, isOneof := oneofCases(ctx, ptrTyp); setter && isOneof {
+		// The oneof interface type is unexported, so convert directly
+		// into the field instead of declaring a variable.
+		if _, found := ConvRyeToGo(
+			deps,
+			ctx,
+			&cb,
+			ptrTyp,
+			`self.`+field.Name.Name,
+			`arg1`,
+			1,
+			makeMakeRetArgErr(1),
+		); !found {
+			return nil, errors.New("unhandled type conversion (go to rye): " + structName.Name)
+		}
+
+		cb.Linef(`return arg0`)
+	} else if setter {
 		cb.Linef(`var newVal %v`, ptrTyp.Name)
 		deps.MarkUsed(ptrTyp)
 		if _, found := ConvRyeToGo(
@@ -314,7 +1049,7 @@ func GenerateGetterOrSetter(deps *Dependencies, ctx *Context, field ir.NamedIden
 		}
 
 		deref := ""
-		if typIsNonPtrStruct {
+		if typIsBoxed {
 			deref = "*"
 		}
 		cb.Linef(`self.%v = %vnewVal`, field.Name.Name, deref)
@@ -322,73 +1057,225 @@ func GenerateGetterOrSetter(deps *Dependencies, ctx *Context, field ir.NamedIden
 		cb.Linef(`return arg0`)
 	} else {
 		addr := ""
-		if typIsNonPtrStruct {
+		if typIsBoxed {
 			addr = "&"
 		}
-		cb.Linef(`var resObj env.Object`)
-		if _, found := ConvGoToRye(
-			deps,
-			ctx,
-			&cb,
-			ptrTyp,
-			`resObj`,
-			addr+`self.`+field.Name.Name,
-			-1,
-			nil,
-		); !found {
-			return nil, errors.New("unhandled type conversion (go to rye): " + field.Type.Name)
+		in := `self.` + field.Name.Name
+		if typIsBoxed && getterReturnsCopy(ctx, field.Type) {
+			// Don't keep the parent alive through the result.
+			cb.Linef(`fieldCopy := self.%v`, field.Name.Name)
+			in = `fieldCopy`
+		}
+		cb.Linef(`var resObj env.Object`)
+		if _, found := ConvGoToRye(
+			deps,
+			ctx,
+			&cb,
+			ptrTyp,
+			`resObj`,
+			addr+in,
+			-1,
+			nil,
+		); !found {
+			return nil, errors.New("unhandled type conversion (go to rye): " + field.Type.Name)
+		}
+		cb.Linef(`return resObj`)
+	}
+	res.Body = cb.String()
+
+	return res, nil
+}
+
+func GenerateValue(deps *Dependencies, ctx *Context, value ir.NamedIdent) (*BindingFunc, error) {
+	res := &BindingFunc{}
+
+	res.Category = "Global vars/consts"
+
+	{
+		id, ok := value.Name.Expr.(*ast.Ident)
+		if !ok {
+			panic("expected var/const name to be *ast.Ident")
+		}
+		res.Name = id.Name
+	}
+
+	typName, err := GetRyeTypeDesc(ctx, value.Type.File, value.Type.Expr)
+	if err != nil {
+		return nil, err
+	}
+	res.Signature = Signature{Results: []string{typName}}
+	res.DocComment = res.Signature.DocComment()
+
+	res.File = value.Name.File
+	res.Directives = slices.Clone(ctx.IR.Directives[value.Name.Name])
+	res.Doc = fmt.Sprintf("Get %v value", value.Name.Name)
+	res.GoSymbol = value.Name.Name
+	res.Argsn = 0
+
+	deps.MarkUsed(value.Name)
+
+	var cb binderio.CodeBuilder
+
+	in := value.Name.Name
+	if IsSynchronizedValue(ctx, value) {
+		cb.Linef(`%v.RLock()`, GlobalsMutexName)
+		cb.Linef(`val := %v`, value.Name.Name)
+		cb.Linef(`%v.RUnlock()`, GlobalsMutexName)
+		in = `val`
+	}
+	cb.Linef(`var resObj env.Object`)
+	if _, found := ConvGoToRye(
+		deps,
+		ctx,
+		&cb,
+		value.Type,
+		`resObj`,
+		in,
+		-1,
+		nil,
+	); !found {
+		return nil, errors.New("unhandled type conversion (go to rye): " + value.Type.Name)
+	}
+	cb.Linef(`return resObj`)
+	res.Body = cb.String()
+
+	return res, nil
+}
+
+// IsTypedConst reports whether value is a constant of a named type
+// which is converted to its underlying type (e.g. an integer), so
+// [GenerateTypedConst] can be used to get it as a native of its type.
+func IsTypedConst(ctx *Context, value ir.NamedIdent) bool {
+	if _, isConst := ctx.IR.ConstValues[value.Name.Name]; !isConst {
+		return false
+	}
+	if _, ok := ctx.IR.Typedefs[value.Type.Name]; !ok {
+		return false
+	}
+	if value.Type.File == nil || ir.IdentIsInternal(ctx.ModNames, value.Type) {
+		return false
+	}
+	return nativeGoToRyeShouldGetUnderlyingType(ctx, value.Type)
+}
+
+// GenerateTypedConst generates a getter for a constant of a named type
+// (see [IsTypedConst]) returning it as a native of its type, in
+// addition to the one generated by [GenerateValue]. This way, type
+// identity is preserved when the constant is passed back to Go, e.g.
+// as an interface value.
+func GenerateTypedConst(deps *Dependencies, ctx *Context, value ir.NamedIdent) (*BindingFunc, error) {
+	if !IsTypedConst(ctx, value) {
+		return nil, errors.New("expected constant of named type")
+	}
+
+	res := &BindingFunc{}
+	res.Category = "Global vars/consts"
+
+	{
+		id, ok := value.Name.Expr.(*ast.Ident)
+		if !ok {
+			panic("expected var/const name to be *ast.Ident")
+		}
+		res.Name = id.Name + "Native"
+	}
+
+	res.Signature = Signature{Results: []string{KindName(ctx, value.Type)}}
+	res.DocComment = res.Signature.DocComment()
+	res.File = value.Name.File
+	for _, d := range ctx.IR.Directives[value.Name.Name] {
+		if d.Name == "rename" && len(d.Args) == 1 {
+			// Would otherwise end up with the same name as the
+			// regular getter
+			d.Args = []string{d.Args[0] + "-native"}
 		}
-		cb.Linef(`return resObj`)
+		res.Directives = append(res.Directives, d)
 	}
+	res.Doc = fmt.Sprintf("Get %v value as native of type %v", value.Name.Name, value.Type.Name)
+	res.GoSymbol = value.Name.Name
+	res.Argsn = 0
+
+	deps.MarkUsed(value.Name)
+	deps.MarkUsed(value.Type)
+
+	var cb binderio.CodeBuilder
+	cb.Linef(`return *env.NewNative(ps.Idx, %v(%v), "%v")`, value.Type.Name, value.Name.Name, KindName(ctx, value.Type))
 	res.Body = cb.String()
 
 	return res, nil
 }
 
-func GenerateValue(deps *Dependencies, ctx *Context, value ir.NamedIdent) (*BindingFunc, error) {
+// GlobalsMutexName is the name of the sync.RWMutex in the generated code
+// guarding accesses to globals listed in [config.Config.Synchronize].
+const GlobalsMutexName = "globalsMu"
+
+// IsSynchronizedValue reports whether value is a variable listed in
+// [config.Config.Synchronize].
+func IsSynchronizedValue(ctx *Context, value ir.NamedIdent) bool {
+	if _, isConst := ctx.IR.ConstValues[value.Name.Name]; isConst {
+		return false
+	}
+	id, ok := value.Name.Expr.(*ast.Ident)
+	if !ok {
+		return false
+	}
+	return slices.Contains(ctx.Config.Synchronize, value.Name.File.ModulePath+"."+id.Name)
+}
+
+// GenerateValueSetter generates a setter for a global variable listed in
+// [config.Config.Synchronize], which assigns it while holding
+// [GlobalsMutexName].
+func GenerateValueSetter(deps *Dependencies, ctx *Context, value ir.NamedIdent) (*BindingFunc, error) {
+	if !IsSynchronizedValue(ctx, value) {
+		return nil, errors.New("expected synchronized global variable")
+	}
+
 	res := &BindingFunc{}
 
-	res.Category = "Global vars/consts"
+	res.Category = "Global var setters"
 
 	{
 		id, ok := value.Name.Expr.(*ast.Ident)
 		if !ok {
-			panic("expected var/const name to be *ast.Ident")
+			panic("expected var name to be *ast.Ident")
 		}
-		res.Name = id.Name
+		res.Name = "Set" + id.Name
 	}
 
-	var docComment strings.Builder
-	docComment.WriteString("Result:\n")
 	typName, err := GetRyeTypeDesc(ctx, value.Type.File, value.Type.Expr)
 	if err != nil {
 		return nil, err
 	}
-	fmt.Fprintf(&docComment, " * %v\n", typName)
-	res.DocComment = docComment.String()
+	res.Signature = Signature{Args: []SignatureArg{{"value", typName}}, Results: []string{"value"}}
+	res.DocComment = res.Signature.DocComment()
 
 	res.File = value.Name.File
-	res.Doc = fmt.Sprintf("Get %v value", value.Name.Name)
-	res.Argsn = 0
+	res.Directives = slices.Clone(ctx.IR.Directives[value.Name.Name])
+	res.Doc = fmt.Sprintf("Set %v value (synchronized)", value.Name.Name)
+	res.GoSymbol = value.Name.Name
+	res.Argsn = 1
 
 	deps.MarkUsed(value.Name)
 
 	var cb binderio.CodeBuilder
 
-	cb.Linef(`var resObj env.Object`)
-	if _, found := ConvGoToRye(
+	cb.Linef(`var val %v`, value.Type.Name)
+	deps.MarkUsed(value.Type)
+	if _, found := ConvRyeToGo(
 		deps,
 		ctx,
 		&cb,
 		value.Type,
-		`resObj`,
-		value.Name.Name,
-		-1,
-		nil,
+		`val`,
+		`arg0`,
+		0,
+		makeMakeRetArgErr(0),
 	); !found {
-		return nil, errors.New("unhandled type conversion (go to rye): " + value.Type.Name)
+		return nil, errors.New("unhandled type conversion (rye to go): " + value.Type.Name)
 	}
-	cb.Linef(`return resObj`)
+	cb.Linef(`%v.Lock()`, GlobalsMutexName)
+	cb.Linef(`%v = val`, value.Name.Name)
+	cb.Linef(`%v.Unlock()`, GlobalsMutexName)
+	cb.Linef(`return arg0`)
 	res.Body = cb.String()
 
 	return res, nil
@@ -405,7 +1292,9 @@ func GenerateNewStruct(deps *Dependencies, ctx *Context, structName ir.Ident) (*
 		res.Name = "New" + id.Name
 	}
 	res.File = structName.File
+	res.Directives = slices.Clone(ctx.IR.Directives[structName.Name])
 	res.Doc = fmt.Sprintf("Create a new %v struct", structName.Name)
+	res.GoSymbol = structName.Name
 	res.Argsn = 0
 
 	deps.MarkUsed(structName)
@@ -416,7 +1305,40 @@ func GenerateNewStruct(deps *Dependencies, ctx *Context, structName ir.Ident) (*
 	}
 
 	var cb binderio.CodeBuilder
-	cb.Linef(`res := &%v{}`, structName.Name)
+	if defaults := ctx.Config.StructDefaults[StructDefaultsKey(structName)]; len(defaults) > 0 {
+		struc, ok := ctx.IR.Structs[structName.Name]
+		if !ok {
+			return nil, errors.New("unknown struct " + structName.Name)
+		}
+		for name := range defaults {
+			if !slices.ContainsFunc(struc.Fields, func(f ir.NamedIdent) bool { return f.Name.Name == name }) {
+				return nil, fmt.Errorf("struct-defaults: %v has no field %v", StructDefaultsKey(structName), name)
+			}
+			if !token.IsExported(name) {
+				return nil, fmt.Errorf("struct-defaults: %v: field %v is unexported", StructDefaultsKey(structName), name)
+			}
+		}
+		var fieldNames []string
+		cb.Linef(`res := &%v{`, structName.Name)
+		cb.Indent++
+		for _, f := range struc.Fields {
+			exprStr, ok := defaults[f.Name.Name]
+			if !ok {
+				continue
+			}
+			expr, err := rewriteGoExpr(deps, ctx, structName.File, exprStr)
+			if err != nil {
+				return nil, fmt.Errorf("struct-defaults: %v: %v: %w", StructDefaultsKey(structName), f.Name.Name, err)
+			}
+			cb.Linef(`%v: %v,`, f.Name.Name, expr)
+			fieldNames = append(fieldNames, f.Name.Name)
+		}
+		cb.Indent--
+		cb.Linef(`}`)
+		res.Doc += " with defaults for " + strings.Join(fieldNames, ", ")
+	} else {
+		cb.Linef(`res := &%v{}`, structName.Name)
+	}
 	cb.Linef(`var resObj env.Object`)
 	if _, found := ConvGoToRye(
 		deps,
@@ -436,10 +1358,443 @@ func GenerateNewStruct(deps *Dependencies, ctx *Context, structName ir.Ident) (*
 	return res, nil
 }
 
+// GenerateKindOf generates a builtin for an interface, returning a word
+// naming the concrete type of a native implementing it (e.g.
+// "Go(*ast.Ident)" for an ast.Node), or the interface's name if the
+// concrete type isn't bound.
+func GenerateKindOf(deps *Dependencies, ctx *Context, iface *ir.Interface) (*BindingFunc, error) {
+	if ir.IdentIsInternal(ctx.ModNames, iface.Name) {
+		return nil, errors.New("cannot get kind of internal interface " + iface.Name.Name)
+	}
+
+	res := &BindingFunc{}
+	res.Category = "Type assertions"
+	{
+		id, ok := iface.Name.Expr.(*ast.Ident)
+		if !ok {
+			panic("expected interface name to be *ast.Ident")
+		}
+		res.Name = id.Name + "KindOf"
+	}
+	res.File = iface.Name.File
+	res.Directives = typeExcludeDirectives(ctx, iface.Name)
+	{
+		typName, err := GetRyeTypeDesc(ctx, iface.Name.File, iface.Name.Expr)
+		if err != nil {
+			return nil, err
+		}
+		res.Signature = Signature{Args: []SignatureArg{{"value", typName}}, Results: []string{"word"}}
+		res.DocComment = res.Signature.DocComment()
+	}
+	res.Doc = fmt.Sprintf("Get the concrete type of a %v", iface.Name.Name)
+	res.GoSymbol = iface.Name.Name
+	res.Argsn = 1
+
+	var cb binderio.CodeBuilder
+
+	cb.Linef(`var self %v`, iface.Name.Name)
+	deps.MarkUsed(iface.Name)
+	if _, found := ConvRyeToGo(
+		deps,
+		ctx,
+		&cb,
+		iface.Name,
+		`self`,
+		`arg0`,
+		0,
+		makeMakeRetArgErr(0),
+	); !found {
+		return nil, errors.New("unhandled type conversion (rye to go): " + iface.Name.Name)
+	}
+	cb.Linef(`if self == nil {`)
+	cb.Indent++
+	cb.Append(makeMakeRetArgErr(0)(`"expected non-nil value"`))
+	cb.Indent--
+	cb.Linef(`}`)
+	cb.Linef(`return ifaceToNative(ps.Idx, self, "%v").Kind`, KindName(ctx, iface.Name))
+
+	res.Body = cb.String()
+
+	return res, nil
+}
+
+// GenerateDowncast generates a builtin converting a native holding a
+// struct (e.g. obtained through an interface) to a native of the struct
+// pointer type, failing if the native holds a different type.
+func GenerateDowncast(deps *Dependencies, ctx *Context, structName ir.Ident) (*BindingFunc, error) {
+	if ir.IdentIsInternal(ctx.ModNames, structName) {
+		return nil, errors.New("cannot downcast to internal type " + structName.Name)
+	}
+
+	res := &BindingFunc{}
+	res.Category = "Type assertions"
+	{
+		id, ok := structName.Expr.(*ast.Ident)
+		if !ok {
+			panic("expected struct name to be *ast.Ident")
+		}
+		res.Name = "As" + id.Name
+	}
+	res.File = structName.File
+	res.Directives = typeExcludeDirectives(ctx, structName)
+
+	structPtr, err := ir.NewIdent(ctx.IR.ConstValues, ctx.ModNames, structName.File, &ast.StarExpr{X: structName.Expr})
+	if err != nil {
+		return nil, err
+	}
+
+	res.Signature = Signature{Args: []SignatureArg{{"value", "native"}}, Results: []string{fmt.Sprintf("native(%v)", structPtr.Name)}}
+	res.DocComment = res.Signature.DocComment()
+	res.Doc = fmt.Sprintf("Convert a native to %v, failing if it holds a different type", structPtr.Name)
+	res.GoSymbol = structName.Name
+	res.Argsn = 1
+
+	var cb binderio.CodeBuilder
+
+	cb.Linef(`nat, ok := arg0.(env.Native)`)
+	cb.Linef(`if !ok {`)
+	cb.Indent++
+	cb.Append(makeMakeRetArgErr(0)(`"expected native, but got "+objectDebugString(ps.Idx, arg0)`))
+	cb.Indent--
+	cb.Linef(`}`)
+	cb.Linef(`var res %v`, structPtr.Name)
+	deps.MarkUsed(structPtr)
+	cb.Linef(`switch v := nat.Value.(type) {`)
+	cb.Linef(`case %v:`, structPtr.Name)
+	cb.Indent++
+	cb.Linef(`res = v`)
+	cb.Indent--
+	cb.Linef(`case %v:`, structName.Name)
+	cb.Indent++
+	cb.Linef(`res = &v`)
+	cb.Indent--
+	cb.Linef(`default:`)
+	cb.Indent++
+	cb.Append(makeMakeRetArgErr(0)(fmt.Sprintf(`"expected native of type %v, but got "+objectDebugString(ps.Idx, arg0)`, structPtr.Name)))
+	cb.Indent--
+	cb.Linef(`}`)
+	cb.Linef(`var resObj env.Object`)
+	if _, found := ConvGoToRye(
+		deps,
+		ctx,
+		&cb,
+		structPtr,
+		`resObj`,
+		`res`,
+		-1,
+		nil,
+	); !found {
+		return nil, errors.New("unhandled type conversion (go to rye): " + structPtr.Name)
+	}
+	cb.Linef(`return resObj`)
+
+	res.Body = cb.String()
+
+	return res, nil
+}
+
+// GenerateUpcast generates a builtin converting any native implementing
+// iface to a native tagged with the interface type (e.g. "as-reader"), so
+// the interface's methods can be called on it and it can be passed to
+// APIs expecting the interface.
+func GenerateUpcast(deps *Dependencies, ctx *Context, iface *ir.Interface) (*BindingFunc, error) {
+	if ir.IdentIsInternal(ctx.ModNames, iface.Name) {
+		return nil, errors.New("cannot upcast to internal interface " + iface.Name.Name)
+	}
+
+	res := &BindingFunc{}
+	res.Category = "Type assertions"
+	{
+		id, ok := iface.Name.Expr.(*ast.Ident)
+		if !ok {
+			panic("expected interface name to be *ast.Ident")
+		}
+		res.Name = "As" + id.Name
+	}
+	res.File = iface.Name.File
+	res.Directives = typeExcludeDirectives(ctx, iface.Name)
+
+	res.Signature = Signature{Args: []SignatureArg{{"value", "native"}}, Results: []string{fmt.Sprintf("native(%v)", iface.Name.Name)}}
+	res.DocComment = res.Signature.DocComment()
+	res.Doc = fmt.Sprintf("Convert a native to %v, failing if it doesn't implement it", iface.Name.Name)
+	res.GoSymbol = iface.Name.Name
+	res.Argsn = 1
+
+	var cb binderio.CodeBuilder
+
+	cb.Linef(`nat, ok := arg0.(env.Native)`)
+	cb.Linef(`if !ok {`)
+	cb.Indent++
+	cb.Append(makeMakeRetArgErr(0)(`"expected native, but got "+objectDebugString(ps.Idx, arg0)`))
+	cb.Indent--
+	cb.Linef(`}`)
+	cb.Linef(`res, ok := nat.Value.(%v)`, iface.Name.Name)
+	deps.MarkUsed(iface.Name)
+	cb.Linef(`if !ok {`)
+	cb.Indent++
+	cb.Append(makeMakeRetArgErr(0)(fmt.Sprintf(`"expected native implementing %v, but got "+objectDebugString(ps.Idx, arg0)`, iface.Name.Name)))
+	cb.Indent--
+	cb.Linef(`}`)
+	cb.Linef(`return *env.NewNative(ps.Idx, res, "%v")`, KindName(ctx, iface.Name))
+
+	res.Body = cb.String()
+
+	return res, nil
+}
+
+// HelpListingPlaceholder is replaced by the quoted help text (see [HelpText])
+// in the body of bindings generated by [GenerateHelp], once all final
+// binding names are known.
+const HelpListingPlaceholder = `((RYEGEN:HELPLISTING))`
+
+// GenerateHelp generates a help builtin for a Go package, returning
+// the package synopsis and a listing of its builtins.
+func GenerateHelp(ctx *Context, modulePath string) (*BindingFunc, error) {
+	modName, ok := ctx.ModNames[modulePath]
+	if !ok {
+		return nil, errors.New("unknown module path " + modulePath)
+	}
+
+	res := &BindingFunc{}
+	res.Category = "Help"
+	res.Name = "Help"
+	res.File = &ir.File{
+		ModuleName: modName,
+		ModulePath: modulePath,
+	}
+	res.Doc = fmt.Sprintf("Get help for package %v", modulePath)
+	res.Signature = Signature{Results: []string{"string"}}
+	res.DocComment = res.Signature.DocComment()
+	res.Argsn = 0
+
+	var cb binderio.CodeBuilder
+	cb.Linef(`return *env.NewString(%v)`, HelpListingPlaceholder)
+	res.Body = cb.String()
+
+	return res, nil
+}
+
+// ListPlaceholder is replaced by the Go expression returned by
+// [ListItems] in the body of bindings generated by [GenerateList], once
+// all final binding names are known.
+const ListPlaceholder = `((RYEGEN:LISTITEMS))`
+
+// GenerateList generates a list builtin for a Go package, returning a
+// sorted block of its builtins with their signatures (see
+// [Signature.String]).
+func GenerateList(ctx *Context, modulePath string) (*BindingFunc, error) {
+	modName, ok := ctx.ModNames[modulePath]
+	if !ok {
+		return nil, errors.New("unknown module path " + modulePath)
+	}
+
+	res := &BindingFunc{}
+	res.Category = "Help"
+	res.Name = "List"
+	res.File = &ir.File{
+		ModuleName: modName,
+		ModulePath: modulePath,
+	}
+	res.Doc = fmt.Sprintf("List builtins of package %v with their signatures", modulePath)
+	res.Signature = Signature{Results: []string{"block of strings"}}
+	res.DocComment = res.Signature.DocComment()
+	res.Argsn = 0
+
+	var cb binderio.CodeBuilder
+	cb.Linef(`return *env.NewBlock(*env.NewTSeries(%v))`, ListPlaceholder)
+	res.Body = cb.String()
+
+	return res, nil
+}
+
+// Signature is the Rye signature of a builtin, listed in the "Args:" and
+// "Result:" sections of its [BindingFunc.DocComment] and by list
+// builtins (see [GenerateList]).
+type Signature struct {
+	Args []SignatureArg
+	// Rye type descriptions (see [GetRyeTypeDesc]). Multiple results
+	// returned in a block or dict are a single entry (e.g. "[a b]").
+	Results []string
+}
+
+// SignatureArg is an argument of a [Signature].
+type SignatureArg struct {
+	Name string // kebab-case
+	Type string // Rye type description (see [GetRyeTypeDesc])
+}
+
+// DocComment returns the "Args:" and "Result:" doc comment sections of
+// s.
+func (s Signature) DocComment() string {
+	var b strings.Builder
+	if len(s.Args) > 0 {
+		b.WriteString("Args:\n")
+		for _, arg := range s.Args {
+			fmt.Fprintf(&b, " * %v - %v\n", arg.Name, arg.Type)
+		}
+	}
+	if len(s.Results) > 0 {
+		b.WriteString("Result:\n")
+		for _, res := range s.Results {
+			fmt.Fprintf(&b, " * %v\n", res)
+		}
+	}
+	return b.String()
+}
+
+// String returns s on one line (e.g. "path:string flag:integer ->
+// native(*os.File) error").
+func (s Signature) String() string {
+	var b strings.Builder
+	for i, arg := range s.Args {
+		if i != 0 {
+			b.WriteString(" ")
+		}
+		fmt.Fprintf(&b, "%v:%v", arg.Name, arg.Type)
+	}
+	if len(s.Results) > 0 {
+		if len(s.Args) > 0 {
+			b.WriteString(" ")
+		}
+		b.WriteString("-> " + strings.Join(s.Results, " "))
+	}
+	return b.String()
+}
+
+// ListItems returns the Go expression replacing [ListPlaceholder],
+// given the sorted entries of the list.
+func ListItems(entries []string) string {
+	var b strings.Builder
+	b.WriteString("[]env.Object{")
+	for i, entry := range entries {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprintf(&b, "*env.NewString(%v)", strconv.Quote(entry))
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+// HelpCategoryOrder is the order builtin categories (see
+// [BindingFuncID.Category]) are listed in by help builtins. Other
+// categories follow alphabetically.
+var HelpCategoryOrder = []string{
+	"Functions",
+	"Struct initializers",
+	"Methods",
+	"Method values",
+	"Getters",
+	"Setters",
+	"Global vars/consts",
+	"Global var setters",
+}
+
+// SortHelpCategories sorts categories by [HelpCategoryOrder].
+func SortHelpCategories(categories []string) {
+	slices.SortFunc(categories, func(a, b string) int {
+		ia, ib := slices.Index(HelpCategoryOrder, a), slices.Index(HelpCategoryOrder, b)
+		switch {
+		case ia != -1 && ib != -1:
+			return ia - ib
+		case ia != -1:
+			return -1
+		case ib != -1:
+			return 1
+		default:
+			return strings.Compare(a, b)
+		}
+	})
+}
+
+// HelpText returns the text of a help builtin generated by [GenerateHelp].
+//
+// names maps each category to the sorted final Rye names of the
+// package's builtins in it.
+func HelpText(ctx *Context, modulePath string, names map[string][]string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Package %v\n", modulePath)
+	if synopsis := new(doc.Package).Synopsis(ctx.IR.PackageDocs[modulePath]); synopsis != "" {
+		fmt.Fprintf(&b, "\n%v\n", synopsis)
+	}
+	categories := slices.Collect(maps.Keys(names))
+	SortHelpCategories(categories)
+	for _, category := range categories {
+		fmt.Fprintf(&b, "\n%v:\n", category)
+		for _, name := range names[category] {
+			fmt.Fprintf(&b, "  %v\n", name)
+		}
+	}
+	return b.String()
+}
+
+// StableTypeName returns a Go identifier for a named type to be used in
+// the names of generated helpers, which is the type name (e.g. "Node"
+// for go/ast.Node). If interfaces of several packages share the name,
+// it's followed by the FNV-1a hash of "<package path>.<type name>"
+// (e.g. "Node_007fe9ee"). Unlike the qualified name, it doesn't depend
+// on the (unique) import name of the package, so generated names stay
+// the same between ryegen versions.
+func StableTypeName(ctx *Context, typ ir.Ident) (string, error) {
+	id, ok := typ.Expr.(*ast.Ident)
+	if !ok || typ.File == nil {
+		return "", fmt.Errorf("expected named type, got %v", typ.Name)
+	}
+	ctx.ifacePaths.once.Do(func() {
+		ctx.ifacePaths.paths = make(map[string]map[string]struct{})
+		for _, iface := range ctx.IR.Interfaces {
+			id, ok := iface.Name.Expr.(*ast.Ident)
+			if !ok || iface.Name.File == nil {
+				continue
+			}
+			if ctx.ifacePaths.paths[id.Name] == nil {
+				ctx.ifacePaths.paths[id.Name] = make(map[string]struct{})
+			}
+			ctx.ifacePaths.paths[id.Name][iface.Name.File.ModulePath] = struct{}{}
+		}
+	})
+	paths := ctx.ifacePaths.paths[id.Name]
+	if _, ok := paths[typ.File.ModulePath]; ok && len(paths) == 1 {
+		return id.Name, nil
+	}
+	h := fnv.New32a()
+	h.Write([]byte(typ.File.ModulePath + "." + id.Name))
+	return fmt.Sprintf("%v_%08x", id.Name, h.Sum32()), nil
+}
+
+// IsCodegenInternal reports whether a struct field or method named name
+// is an implementation detail of generated code (protobuf's XXX_* fields
+// and methods, SWIG's pointer accessors), which isn't bound if
+// "codegen-friendly" is set.
+func IsCodegenInternal(ctx *Context, name string) bool {
+	if !ctx.Config.CodegenFriendly {
+		return false
+	}
+	return strings.HasPrefix(name, "XXX_") || name == "Swigcptr" || strings.HasPrefix(name, "SwigIs")
+}
+
+// IsPartialInterface reports whether contexts converted to iface may
+// leave out methods, which are then stubbed (see
+// [config.Config.PartialInterfaces]).
+func IsPartialInterface(ctx *Context, iface *ir.Interface) bool {
+	id, ok := iface.Name.Expr.(*ast.Ident)
+	if !ok || iface.Name.File == nil {
+		return false
+	}
+	return slices.Contains(ctx.Config.PartialInterfaces, "*") ||
+		slices.Contains(ctx.Config.PartialInterfaces, iface.Name.File.ModulePath+"."+id.Name)
+}
+
 func GenerateGenericInterfaceImpl(deps *Dependencies, ctx *Context, iface *ir.Interface) (string, error) {
 	var cb binderio.CodeBuilder
 
-	name := "iface_" + strings.ReplaceAll(iface.Name.Name, ".", "_")
+	partial := IsPartialInterface(ctx, iface)
+
+	typeName, err := StableTypeName(ctx, iface.Name)
+	if err != nil {
+		return "", err
+	}
+	name := "iface_" + typeName
 	cb.Linef(`type %v struct {`, name)
 	cb.Indent++
 	cb.Linef(`self env.RyeCtx`)
@@ -502,7 +1857,7 @@ func GenerateGenericInterfaceImpl(deps *Dependencies, ctx *Context, iface *ir.In
 	}
 	cb.Linef(``)
 
-	cb.Linef(`func ctxTo_%v(ps *env.ProgramState, v env.RyeCtx) (%v, error) {`, strings.ReplaceAll(iface.Name.Name, ".", "_"), iface.Name.Name)
+	cb.Linef(`func ctxTo_%v(ps *env.ProgramState, v env.RyeCtx) (%v, error) {`, typeName, iface.Name.Name)
 	cb.Indent++
 	deps.MarkUsed(iface.Name)
 	cb.Linef(`words := v.GetWords(*ps.Idx).Series.S`)
@@ -525,20 +1880,58 @@ func GenerateGenericInterfaceImpl(deps *Dependencies, ctx *Context, iface *ir.In
 	cb.Linef(`wordToObj[name] = obj`)
 	cb.Indent--
 	cb.Linef(`}`)
-	implTyp := "iface_" + strings.ReplaceAll(iface.Name.Name, ".", "_")
-	cb.Linef(`impl := &%v{`, implTyp)
+	cb.Linef(`impl := &%v{`, name)
 	cb.Indent++
 	cb.Linef(`self: v,`)
 	cb.Indent--
 	cb.Linef(`}`)
+	if partial {
+		cb.Linef(`var missing []string`)
+	}
 	for i, fn := range iface.Funcs {
 		cb.Linef(`ctxObj%v, ok := wordToObj["%v"]`, i, strcase.ToKebab(fn.Name.Name))
 		cb.Linef(`if !ok {`)
 		cb.Indent++
-		cb.Linef(`return nil, errors.New("context to %v: expected context to have function %v")`, iface.Name.Name, fn.Name.Name)
 		deps.Imports["errors"] = struct{}{}
-		cb.Indent--
-		cb.Linef(`}`)
+		if partial {
+			// Stub returning zero values, or an error naming all
+			// missing methods if the method can return one.
+			cb.Linef(`missing = append(missing, "%v")`, fn.Name.Name)
+			var stubTyp strings.Builder
+			stubTyp.WriteString("func(self env.RyeCtx")
+			for i, param := range fn.Params {
+				fmt.Fprintf(&stubTyp, ", arg%v %v", i, param.Type.ParamName())
+			}
+			stubTyp.WriteString(")")
+			if len(fn.Results) > 0 {
+				stubTyp.WriteString(" (")
+				for i, result := range fn.Results {
+					if i != 0 {
+						stubTyp.WriteString(", ")
+					}
+					fmt.Fprintf(&stubTyp, "res%v %v", i, result.Type.Name)
+				}
+				stubTyp.WriteString(")")
+			}
+			cb.Linef(`impl.fn_%v = %v {`, fn.Name.Name, stubTyp.String())
+			cb.Indent++
+			if n := len(fn.Results); n > 0 && fn.Results[n-1].Type.Name == "error" {
+				cb.Linef(`res%v = errors.New("context to %v: method %v not implemented by context (missing: "+strings.Join(missing, ", ")+")")`, n-1, iface.Name.Name, fn.Name.Name)
+				deps.Imports["strings"] = struct{}{}
+			}
+			if len(fn.Results) > 0 {
+				cb.Linef(`return`)
+			}
+			cb.Indent--
+			cb.Linef(`}`)
+			cb.Indent--
+			cb.Linef(`} else {`)
+			cb.Indent++
+		} else {
+			cb.Linef(`return nil, errors.New("context to %v: expected context to have function %v")`, iface.Name.Name, fn.Name.Name)
+			cb.Indent--
+			cb.Linef(`}`)
+		}
 		if !ConvRyeToGoCodeFunc(
 			deps,
 			ctx,
@@ -557,6 +1950,10 @@ func GenerateGenericInterfaceImpl(deps *Dependencies, ctx *Context, iface *ir.In
 		) {
 			return "", errors.New("unhandled function conversion (rye to go): " + fn.Name.Name)
 		}
+		if partial {
+			cb.Indent--
+			cb.Linef(`}`)
+		}
 	}
 	cb.Linef(`return impl, nil`)
 	cb.Indent--
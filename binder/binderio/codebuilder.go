@@ -58,6 +58,40 @@ func (w *CodeBuilder) FmtString() (string, error) {
 	return string(code), nil
 }
 
+// ChunkedMapVar writes a package-level map variable called name of type typ,
+// which is initialized by a sequence of functions each executing at most
+// chunkSize of the given entries. Each entry is code assigning to the map
+// "m" (e.g. `m["key"] = value`).
+//
+// Very large map literals or function bodies can exceed compiler limits,
+// which chunking avoids.
+func (w *CodeBuilder) ChunkedMapVar(name, typ string, chunkSize int, entries []string) {
+	makeFn := "make" + strings.ToUpper(name[:1]) + name[1:]
+	numChunks := (len(entries) + chunkSize - 1) / chunkSize
+
+	w.Linef(`var %v = %v()`, name, makeFn)
+	w.Linef(``)
+	w.Linef(`func %v() %v {`, makeFn, typ)
+	w.Indent++
+	w.Linef(`m := make(%v, %v)`, typ, len(entries))
+	for i := 0; i < numChunks; i++ {
+		w.Linef(`%vChunk%v(m)`, makeFn, i)
+	}
+	w.Linef(`return m`)
+	w.Indent--
+	w.Linef(`}`)
+	for i := 0; i < numChunks; i++ {
+		w.Linef(``)
+		w.Linef(`func %vChunk%v(m %v) {`, makeFn, i, typ)
+		w.Indent++
+		for _, entry := range entries[i*chunkSize : min((i+1)*chunkSize, len(entries))] {
+			w.Append(entry)
+		}
+		w.Indent--
+		w.Linef(`}`)
+	}
+}
+
 func (w *CodeBuilder) Reset() {
 	w.Indent = 0
 	w.b.Reset()
@@ -1,7 +1,9 @@
 package bindertest_test
 
 import (
+	"errors"
 	"fmt"
+	"go/ast"
 	"os"
 	"strings"
 	"testing"
@@ -16,6 +18,20 @@ import (
 
 func testGen(t *testing.T, src string, genOut ...func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string) {
 	t.Helper()
+	testGenWithConfig(t, &config.Config{}, src, genOut...)
+}
+
+func testGenWithConfig(t *testing.T, cfg *config.Config, src string, genOut ...func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string) {
+	t.Helper()
+
+	irData, modNames := irtest.ParseSingleFile(t, src)
+	testGenIR(t, cfg, src, irData, modNames, genOut...)
+}
+
+// testGenIR is like [testGenWithConfig], but generates code from the
+// parsed src, e.g. to parse it with dependencies.
+func testGenIR(t *testing.T, cfg *config.Config, src string, irData *ir.IR, modNames ir.UniqueModuleNames, genOut ...func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string) {
+	t.Helper()
 
 	if !strings.HasSuffix(src, ".go") {
 		panic("expected .go file as src")
@@ -24,8 +40,7 @@ func testGen(t *testing.T, src string, genOut ...func(irData *ir.IR, deps *binde
 
 	assert := assert.New(t)
 
-	irData, modNames := irtest.ParseSingleFile(t, src)
-	ctx := binder.NewContext(&config.Config{}, irData, modNames)
+	ctx := binder.NewContext(cfg, irData, modNames)
 
 	deps := binder.NewDependencies()
 
@@ -112,26 +127,989 @@ func TestVararg(t *testing.T) {
 		},
 	)
 
-	{
-		filename := "testdata/doccomments.go"
-		irData, modNames := irtest.ParseSingleFile(t, filename)
-		ctx := binder.NewContext(&config.Config{}, irData, modNames)
+	testGen(t, "testdata/numeric.go",
+		func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string {
+			bf, err := binder.GenerateBinding(deps, ctx, irData.Funcs["testmodule.SetLevels"])
+			if err != nil {
+				t.Fatal(err)
+			}
+			return bf.Body
+		},
+	)
 
-		deps := binder.NewDependencies()
+	testGen(t, "testdata/anonstructs.go",
+		func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string {
+			bf, err := binder.GenerateBinding(deps, ctx, irData.Funcs["testmodule.Place"])
+			if err != nil {
+				t.Fatal(err)
+			}
+			return bf.DocComment + "\n" + bf.Body
+		},
+	)
 
-		bf, err := binder.GenerateBinding(deps, ctx, irData.Funcs["testmodule.FuncWithDoc"])
-		if err != nil {
-			t.Fatal(err)
-		}
+	testGen(t, "testdata/examples.go",
+		func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string {
+			irData.Examples["testmodule.Counter.Add"] = []string{"var c Counter\nc.Add(1)\nfmt.Println(c)\n// Output:\n// {1}"}
+			bf, err := binder.GenerateBinding(deps, ctx, irData.Funcs["(*testmodule.Counter).Add"])
+			if err != nil {
+				t.Fatal(err)
+			}
+			return bf.DocComment
+		},
+	)
 
-		assert.Equal(bf.DocComment, `This text should be in the generated doc string.
+	testGen(t, "testdata/methodvalues.go",
+		func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string {
+			bf, err := binder.GenerateMethodValue(deps, ctx, irData.Funcs["(*testmodule.Button).SetText"])
+			if err != nil {
+				t.Fatal(err)
+			}
+			return bf.Body
+		},
+	)
 
-Args:
- * x - block(len=4)[integer]
- * y - dict[integer, string]
-Result:
- * string
- * error
-`)
-	}
+	testGen(t, "testdata/chanwatch.go",
+		func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string {
+			struc := irData.Structs["testmodule.Watcher"]
+			bf, err := binder.GenerateChannelWatch(deps, ctx, struc.Fields[0], struc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return bf.Body
+		},
+	)
+
+	testGenWithConfig(t, &config.Config{CommaOk: config.CommaOkFailure}, "testdata/commaok.go",
+		func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string {
+			bf, err := binder.GenerateBinding(deps, ctx, irData.Funcs["testmodule.Lookup"])
+			if err != nil {
+				t.Fatal(err)
+			}
+			return bf.Body
+		},
+	)
+
+	// Error-only results: nil is void, non-nil a failure wrapping the
+	// error, also for returned funcs.
+	testGen(t, "testdata/erroronly.go",
+		func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string {
+			var out strings.Builder
+			for i, name := range []string{"testmodule.Remove", "(*testmodule.File).Close", "testmodule.Closer"} {
+				if i != 0 {
+					out.WriteString("\n")
+				}
+				bf, err := binder.GenerateBinding(deps, ctx, irData.Funcs[name])
+				if err != nil {
+					t.Fatal(err)
+				}
+				out.WriteString(bf.Body)
+			}
+			return out.String()
+		},
+	)
+
+	// Embedded interfaces are fields named by their type, holding
+	// natives. Their methods are promoted.
+	testGen(t, "testdata/embeddedifaces.go",
+		func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string {
+			struc := irData.Structs["testmodule.Source"]
+			var out strings.Builder
+			for _, setter := range []bool{false, true} {
+				bf, err := binder.GenerateGetterOrSetter(deps, ctx, struc.Fields[0], struc.Name, setter)
+				if err != nil {
+					t.Fatal(err)
+				}
+				out.WriteString(bf.Body + "\n")
+			}
+			for _, fn := range []*ir.Func{struc.Methods["Read"], irData.Funcs["testmodule.Open"]} {
+				bf, err := binder.GenerateBinding(deps, ctx, fn)
+				if err != nil {
+					t.Fatal(err)
+				}
+				out.WriteString(bf.DocComment + "\n" + bf.Body + "\n")
+			}
+			return out.String()
+		},
+	)
+
+	testGenWithConfig(t, &config.Config{MultiResults: config.MultiResultsDict}, "testdata/multiresults.go",
+		func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string {
+			var out strings.Builder
+			for _, name := range []string{"SplitHostPort", "MinMax"} {
+				bf, err := binder.GenerateBinding(deps, ctx, irData.Funcs["testmodule."+name])
+				if err != nil {
+					t.Fatal(err)
+				}
+				out.WriteString(bf.DocComment)
+				out.WriteString(bf.Signature.String() + "\n")
+				out.WriteString(bf.Body)
+			}
+			return out.String()
+		},
+	)
+
+	testGenWithConfig(t, &config.Config{
+		Coercions: map[string]map[string]bool{
+			"*":           {config.CoercionIntegerToDecimal: true},
+			"test.module": {config.CoercionStringToBytes: true},
+		},
+	}, "testdata/coercions.go",
+		func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string {
+			bf, err := binder.GenerateBinding(deps, ctx, irData.Funcs["testmodule.Write"])
+			if err != nil {
+				t.Fatal(err)
+			}
+			return bf.Body
+		},
+	)
+
+	testGenWithConfig(t, &config.Config{
+		Synchronize: []string{"test.module/tm.Counter"},
+	}, "testdata/synchronize.go",
+		func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string {
+			bf, err := binder.GenerateValue(deps, ctx, irData.Values["testmodule.Counter"])
+			if err != nil {
+				t.Fatal(err)
+			}
+			return bf.Body
+		},
+		func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string {
+			bf, err := binder.GenerateValueSetter(deps, ctx, irData.Values["testmodule.Counter"])
+			if err != nil {
+				t.Fatal(err)
+			}
+			return bf.Body
+		},
+		func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string {
+			if _, err := binder.GenerateValueSetter(deps, ctx, irData.Values["testmodule.Name"]); err == nil {
+				t.Fatal("expected error generating setter for non-synchronized global")
+			}
+			bf, err := binder.GenerateValue(deps, ctx, irData.Values["testmodule.Name"])
+			if err != nil {
+				t.Fatal(err)
+			}
+			return bf.Body
+		},
+	)
+
+	testGen(t, "testdata/typeassertions.go",
+		func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string {
+			bf, err := binder.GenerateKindOf(deps, ctx, irData.Interfaces["testmodule.Node"])
+			if err != nil {
+				t.Fatal(err)
+			}
+			return bf.Body
+		},
+		func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string {
+			bf, err := binder.GenerateDowncast(deps, ctx, irData.Structs["testmodule.Ident"].Name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return bf.Body
+		},
+		func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string {
+			bf, err := binder.GenerateUpcast(deps, ctx, irData.Interfaces["testmodule.Node"])
+			if err != nil {
+				t.Fatal(err)
+			}
+			return bf.Body
+		},
+	)
+
+	testGenWithConfig(t, &config.Config{GoAPI: true}, "testdata/goapi.go",
+		func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string {
+			bf, err := binder.GenerateBinding(deps, ctx, irData.Funcs["(*testmodule.Counter).Add"])
+			if err != nil {
+				t.Fatal(err)
+			}
+			return bf.GoAPI
+		},
+		func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string {
+			bf, err := binder.GenerateBinding(deps, ctx, irData.Funcs["testmodule.Reset"])
+			if err != nil {
+				t.Fatal(err)
+			}
+			return bf.GoAPI
+		},
+	)
+
+	testGenWithConfig(t, &config.Config{
+		Stringables: map[string]config.Stringable{"test.module/tm.Addr": {ParseFunc: "ParseAddr"}},
+	}, "testdata/stringable.go",
+		func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string {
+			bf, err := binder.GenerateBinding(deps, ctx, irData.Funcs["testmodule.Lookup"])
+			if err != nil {
+				t.Fatal(err)
+			}
+			return bf.Body
+		},
+		func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string {
+			bf, err := binder.GenerateBinding(deps, ctx, irData.Funcs["testmodule.Ping"])
+			if err != nil {
+				t.Fatal(err)
+			}
+			return bf.Body
+		},
+	)
+
+	testGenWithConfig(t, &config.Config{
+		PartialInterfaces: []string{"test.module/tm.ResponseWriter"},
+	}, "testdata/partialiface.go",
+		func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string {
+			ifaceImpl, err := binder.GenerateGenericInterfaceImpl(deps, ctx, irData.Interfaces["testmodule.ResponseWriter"])
+			if err != nil {
+				t.Fatal(err)
+			}
+			return ifaceImpl
+		},
+	)
+
+	testGen(t, "testdata/binaryhelpers.go",
+		func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string {
+			ctx.ModNames["encoding/binary"] = "binary"
+			bf, err := binder.GenerateBinaryHelper(deps, ctx, "ReadUint32LE")
+			if err != nil {
+				t.Fatal(err)
+			}
+			return bf.Body
+		},
+		func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string {
+			bf, err := binder.GenerateBinaryHelper(deps, ctx, "EncodeUint64BE")
+			if err != nil {
+				t.Fatal(err)
+			}
+			return bf.Body
+		},
+	)
+
+	testGen(t, "testdata/reflect.go",
+		func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string {
+			bf, err := binder.GenerateBinding(deps, ctx, irData.Funcs["testmodule.Encode"])
+			if err != nil {
+				t.Fatal(err)
+			}
+			return bf.Body
+		},
+		func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string {
+			bf, err := binder.GenerateBinding(deps, ctx, irData.Funcs["testmodule.TypeByName"])
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Positive(deps.ConvUsage[binder.ConvID{GoToRye: true, Name: "reflect"}])
+			return bf.Body
+		},
+		func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string {
+			bf, err := binder.GenerateReflectHelper(deps, ctx, "Interface")
+			if err != nil {
+				t.Fatal(err)
+			}
+			return bf.Body
+		},
+	)
+
+	testGen(t, "testdata/containerhelpers.go",
+		func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string {
+			typ := irData.Funcs["testmodule.NewHeader"].Results[0].Type
+			assert.Equal([]string{"Set!", "Add", "Del", "Len"}, binder.ContainerHelperNames(ctx, typ))
+			bf, err := binder.GenerateContainerHelper(deps, ctx, typ, "Add")
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal("Go(testmodule.Header)//add", bf.UniqueName(ctx))
+			return bf.Body
+		},
+		func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string {
+			typ := irData.Funcs["testmodule.SumValues"].Params[0].Type
+			assert.Equal([]string{"Get", "Set!", "Add", "Len"}, binder.ContainerHelperNames(ctx, typ))
+			bf, err := binder.GenerateContainerHelper(deps, ctx, typ, "Add")
+			if err != nil {
+				t.Fatal(err)
+			}
+			return bf.Body
+		},
+	)
+
+	testGen(t, "testdata/typedconsts.go",
+		func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string {
+			assert.False(binder.IsTypedConst(ctx, irData.Values["testmodule.ModeFast"]))
+			assert.False(binder.IsTypedConst(ctx, irData.Values["testmodule.Plain"]))
+			bf, err := binder.GenerateTypedConst(deps, ctx, irData.Values["testmodule.TextAlignCenter"])
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal("testmodule-text-align-center-native", bf.UniqueName(ctx))
+			return bf.DocComment + "\n" + bf.Body
+		},
+	)
+
+	testGen(t, "testdata/boxing.go",
+		func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string {
+			assert.True(binder.BoxesAsPointer(ctx, irData.Funcs["testmodule.NewList"].Results[0].Type))
+			assert.False(binder.BoxesAsPointer(ctx, irData.Funcs["testmodule.Freezing"].Results[0].Type))
+			bf, err := binder.GenerateBinding(deps, ctx, irData.Funcs["testmodule.NewList"])
+			if err != nil {
+				t.Fatal(err)
+			}
+			return bf.Body
+		},
+		func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string {
+			bf, err := binder.GenerateBinding(deps, ctx, irData.Funcs["testmodule.List.Len"])
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(bf.Recv, "Go(*testmodule.List)")
+			return bf.Body
+		},
+		func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string {
+			bf, err := binder.GenerateBinding(deps, ctx, irData.Funcs["testmodule.Freezing"])
+			if err != nil {
+				t.Fatal(err)
+			}
+			return bf.Body
+		},
+	)
+
+	testGenWithConfig(t, &config.Config{
+		BindArgs: map[string]map[string]string{
+			// Bare identifiers refer to the function's package.
+			"test.module/tm.Fetch": {"mode": "ModeFast", "retries": "3"},
+		},
+	}, "testdata/bindargs.go",
+		func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string {
+			bf, err := binder.GenerateBinding(deps, ctx, irData.Funcs["testmodule.Fetch"])
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(1, bf.Argsn)
+			assertCompiles(t, "testdata/bindargs.go", deps, bf.Body)
+
+			// Qualified with the package name as well.
+			qualCtx := binder.NewContext(&config.Config{
+				BindArgs: map[string]map[string]string{
+					"test.module/tm.Fetch": {"mode": "testfile.ModeFast", "retries": "3"},
+				},
+			}, irData, ctx.ModNames)
+			qualBf, err := binder.GenerateBinding(binder.NewDependencies(), qualCtx, irData.Funcs["testmodule.Fetch"])
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(bf.Body, qualBf.Body)
+			return bf.Body
+		},
+	)
+
+	testGenWithConfig(t, &config.Config{
+		Bitmasks: []string{"test.module/tm.Perm"},
+	}, "testdata/bitmask.go",
+		func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string {
+			bf, err := binder.GenerateBinding(deps, ctx, irData.Funcs["testmodule.Chmod"])
+			if err != nil {
+				t.Fatal(err)
+			}
+			return bf.DocComment + bf.Body
+		},
+	)
+
+	testGenWithConfig(t, &config.Config{
+		StructDefaults: map[string]map[string]string{
+			"test.module/tm.Registry": {"Events": "make(chan Event, 8)", "Items": "make(map[string]int)"},
+		},
+	}, "testdata/structdefaults.go",
+		func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string {
+			bf, err := binder.GenerateNewStruct(deps, ctx, irData.Structs["testmodule.Registry"].Name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal("Create a new testmodule.Registry struct with defaults for Items, Events", bf.Doc)
+			assertCompiles(t, "testdata/structdefaults.go", deps, bf.Body)
+
+			for _, defaults := range []map[string]string{{"Missing": "1"}, {"closed": "true"}} {
+				ctx := binder.NewContext(&config.Config{
+					StructDefaults: map[string]map[string]string{"test.module/tm.Registry": defaults},
+				}, irData, ctx.ModNames)
+				_, err := binder.GenerateNewStruct(binder.NewDependencies(), ctx, irData.Structs["testmodule.Registry"].Name)
+				assert.Error(err)
+			}
+			return bf.Body
+		},
+	)
+
+	testGenWithConfig(t, &config.Config{
+		DisableConverters: []string{config.DisableChan, config.DisableFunc, config.DisableInterfaceAdapters},
+	}, "testdata/disableconv.go",
+		func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string {
+			bf, err := binder.GenerateBinding(deps, ctx, irData.Funcs["testmodule.Register"])
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Empty(deps.GenericInterfaceImpls)
+			return bf.Body
+		},
+	)
+
+	testGenWithConfig(t, &config.Config{
+		CodegenFriendly: true,
+	}, "testdata/codegen.go",
+		func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string {
+			assert.True(binder.IsCodegenInternal(ctx, "XXX_unrecognized"))
+			assert.False(binder.IsCodegenInternal(ctx, "Name"))
+			struc := irData.Structs["testmodule.Msg"]
+			var field ir.NamedIdent
+			for _, f := range struc.Fields {
+				if f.Name.Name == "Value" {
+					field = f
+				}
+			}
+			bf, err := binder.GenerateGetterOrSetter(deps, ctx, field, struc.Name, true)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return bf.DocComment + bf.Body
+		},
+		func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string {
+			bf, err := binder.GenerateBinding(deps, ctx, irData.Funcs["(*testmodule.Msg).GetValue"])
+			if err != nil {
+				t.Fatal(err)
+			}
+			return bf.Body
+		},
+	)
+
+	testGenWithConfig(t, &config.Config{
+		DictStructs: []string{"*"},
+	}, "testdata/dictstructs.go",
+		func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string {
+			bf, err := binder.GenerateBinding(deps, ctx, irData.Funcs["testmodule.Servers"])
+			if err != nil {
+				t.Fatal(err)
+			}
+			return bf.Body
+		},
+		func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string {
+			bf, err := binder.GenerateBinding(deps, ctx, irData.Funcs["testmodule.SetServers"])
+			if err != nil {
+				t.Fatal(err)
+			}
+			return bf.Body
+		},
+	)
+
+	testGen(t, "testdata/enums.go",
+		func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string {
+			bf, err := binder.GenerateBinding(deps, ctx, irData.Funcs["testmodule.Next"])
+			if err != nil {
+				t.Fatal(err)
+			}
+			return bf.DocComment + "\n" + bf.Body
+		},
+		func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string {
+			bf, err := binder.GenerateBinding(deps, ctx, irData.Funcs["testmodule.SetLevel"])
+			if err != nil {
+				t.Fatal(err)
+			}
+			return bf.DocComment + "\n" + bf.Body
+		},
+	)
+
+	testGenWithConfig(t, &config.Config{
+		DictStructs: []string{"*"},
+	}, "testdata/smallstructs.go",
+		func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string {
+			bf, err := binder.GenerateBinding(deps, ctx, irData.Funcs["testmodule.Layout"])
+			if err != nil {
+				t.Fatal(err)
+			}
+			return bf.Body
+		},
+		func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string {
+			bf, err := binder.GenerateBinding(deps, ctx, irData.Funcs["testmodule.SetAnchors"])
+			if err != nil {
+				t.Fatal(err)
+			}
+			return bf.Body
+		},
+		func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string {
+			bf, err := binder.GenerateBinding(deps, ctx, irData.Funcs["testmodule.Center"])
+			if err != nil {
+				t.Fatal(err)
+			}
+			return bf.Body
+		},
+	)
+
+	testGenWithConfig(t, &config.Config{
+		MaxGetterNesting: 1,
+	}, "testdata/getternesting.go",
+		func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string {
+			var res []string
+			struc := irData.Structs["testmodule.Tree"]
+			for _, f := range struc.Fields {
+				bf, err := binder.GenerateGetterOrSetter(deps, ctx, f, struc.Name, false)
+				if err != nil {
+					t.Fatal(err)
+				}
+				res = append(res, bf.Body)
+			}
+			struc = irData.Structs["testmodule.Meta"]
+			bf, err := binder.GenerateGetterOrSetter(deps, ctx, struc.Fields[0], struc.Name, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			res = append(res, bf.Body)
+			return strings.Join(res, "\n//================================//\n\n")
+		},
+	)
+
+	testGen(t, "testdata/ctxcallback.go",
+		func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string {
+			bf, err := binder.GenerateBinding(deps, ctx, irData.Funcs["(*testmodule.Group).Go"])
+			if err != nil {
+				t.Fatal(err)
+			}
+			return bf.Body
+		},
+		func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string {
+			bf, err := binder.GenerateBinding(deps, ctx, irData.Funcs["testmodule.Retry"])
+			if err != nil {
+				t.Fatal(err)
+			}
+			return bf.Body
+		},
+	)
+
+	testGen(t, "testdata/identity.go",
+		func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string {
+			var b strings.Builder
+			for _, name := range []string{"Register", "Track", "Keep", "Collect"} {
+				bf, err := binder.GenerateBinding(deps, ctx, irData.Funcs["testmodule."+name])
+				if err != nil {
+					t.Fatal(err)
+				}
+				b.WriteString(bf.Body)
+			}
+			return b.String()
+		},
+	)
+
+	testGen(t, "testdata/kindspecs.go",
+		func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string {
+			spec, err := binder.GenerateKindSpec(ctx, irData.Structs["testmodule.Point"])
+			if err != nil {
+				t.Fatal(err)
+			}
+			return spec
+		},
+	)
+
+	{
+		irData, modNames := irtest.ParseSingleFile(t, "testdata/kindspecs.go")
+		ctx := binder.NewContext(&config.Config{}, irData, modNames)
+
+		word, err := binder.KindSpecWord(ctx, irData.Structs["testmodule.Point"])
+		assert.NoError(err)
+		assert.Equal("testmodule-point-kind", word)
+
+		_, err = binder.GenerateKindSpec(ctx, irData.Structs["testmodule.Node"])
+		assert.ErrorContains(err, "no fields with validatable types")
+
+		generic := &ir.Struct{Name: ir.Ident{
+			Expr: &ast.IndexExpr{X: &ast.Ident{Name: "Pair"}, Index: &ast.Ident{Name: "int"}},
+			Name: "testmodule.Pair[int]",
+		}}
+		_, err = binder.KindSpecWord(ctx, generic)
+		assert.ErrorContains(err, "expected named struct, got testmodule.Pair[int]")
+		_, err = binder.GenerateKindSpec(ctx, generic)
+		assert.Error(err)
+	}
+
+	testGen(t, "testdata/ifaceslices.go",
+		func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string {
+			bf, err := binder.GenerateBinding(deps, ctx, irData.Funcs["testmodule.TotalArea"])
+			if err != nil {
+				t.Fatal(err)
+			}
+			return bf.Body
+		},
+	)
+
+	{
+		irData, modNames := irtest.ParseSingleFile(t, "testdata/unsafeparams.go")
+		ctx := binder.NewContext(&config.Config{}, irData, modNames)
+		deps := binder.NewDependencies()
+
+		for name, excluded := range map[string]bool{"Deref": true, "Peek": true, "Visit": true, "NewFile": false, "Addr": true, "Fd": false} {
+			_, err := binder.GenerateBinding(deps, ctx, irData.Funcs["testmodule."+name])
+			assert.Equal(excluded, errors.Is(err, binder.ErrUnsafePointers), name)
+		}
+		struc := irData.Structs["testmodule.Buffer"]
+		for _, field := range struc.Fields {
+			for _, setter := range []bool{false, true} {
+				_, err := binder.GenerateGetterOrSetter(deps, ctx, field, struc.Name, setter)
+				assert.Equal(field.Name.Name == "Data", errors.Is(err, binder.ErrUnsafePointers), field.Name.Name)
+			}
+		}
+
+		ctx = binder.NewContext(&config.Config{
+			AllowUnsafe: []string{"test.module/tm.Deref", "test.module/tm.Buffer.Data"},
+			UnsafeAck:   true,
+		}, irData, modNames)
+		_, err := binder.GenerateBinding(deps, ctx, irData.Funcs["testmodule.Deref"])
+		assert.NoError(err)
+		_, err = binder.GenerateBinding(deps, ctx, irData.Funcs["testmodule.Addr"])
+		assert.ErrorIs(err, binder.ErrUnsafePointers)
+		_, err = binder.GenerateGetterOrSetter(deps, ctx, struc.Fields[0], struc.Name, false)
+		assert.NoError(err)
+	}
+
+	{
+		const pkg = "test.module/tm/internal/impl"
+		irData, modNames := irtest.ParseSingleFileInModule(t, "testdata/allowinternal.go", pkg)
+		fn := irData.Funcs["(*testmodule.Impl).Greet"]
+
+		_, err := binder.GenerateBinding(binder.NewDependencies(), binder.NewContext(&config.Config{}, irData, modNames), fn)
+		assert.Error(err)
+
+		ctx := binder.NewContext(&config.Config{AllowInternal: []string{pkg}}, irData, modNames)
+		deps := binder.NewDependencies()
+		bf, err := binder.GenerateBinding(deps, ctx, fn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal("Go(*testmodule.Impl)", bf.Recv)
+		assert.Contains(bf.Body, `reflect.ValueOf(arg0Val).MethodByName("Greet")`)
+		assert.NotContains(deps.Imports, pkg)
+	}
+
+	{
+		irData, modNames := irtest.ParseSingleFile(t, "testdata/naming.go")
+		ctx := binder.NewContext(&config.Config{}, irData, modNames)
+		assert.Equal(`Package test.module/tm
+
+Functions:
+  open
+  serve
+
+Methods:
+  Go(*testmodule.TestmoduleClient)//close
+
+Binary helpers:
+  binary-read-uint-16-le
+`, binder.HelpText(ctx, "test.module/tm", map[string][]string{
+			"Binary helpers": {"binary-read-uint-16-le"},
+			"Methods":        {"Go(*testmodule.TestmoduleClient)//close"},
+			"Functions":      {"open", "serve"},
+		}))
+	}
+
+	{
+		irData, modNames := irtest.ParseSingleFile(t, "testdata/naming.go")
+		ctx := binder.NewContext(&config.Config{}, irData, modNames)
+		deps := binder.NewDependencies()
+		bf, err := binder.GenerateBinding(deps, ctx, irData.Funcs["testmodule.NewTestmoduleClient"])
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal("-> Go(*testmodule.TestmoduleClient)", bf.Signature.String())
+		sig := binder.Signature{
+			Args:    []binder.SignatureArg{{Name: "recv", Type: "native(time.Time)"}, {Name: "d", Type: "integer"}},
+			Results: []string{"[a b]", "error"},
+		}
+		assert.Equal("recv:native(time.Time) d:integer -> [a b] error", sig.String())
+		assert.Equal("Args:\n * recv - native(time.Time)\n * d - integer\nResult:\n * [a b]\n * error\n", sig.DocComment())
+		assert.Equal("", binder.Signature{}.String())
+		assert.Equal("", binder.Signature{}.DocComment())
+
+		list, err := binder.GenerateList(ctx, "test.module/tm")
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal("List", list.Name)
+		assert.Contains(list.Body, binder.ListPlaceholder)
+		assert.Equal(`[]env.Object{*env.NewString("open"), *env.NewString("serve -> string")}`, binder.ListItems([]string{"open", "serve -> string"}))
+	}
+
+	{
+		irData, modNames := irtest.ParseSingleFile(t, "testdata/naming.go")
+		ctx := binder.NewContext(&config.Config{Naming: config.NamingShort}, irData, modNames)
+		deps := binder.NewDependencies()
+
+		names := make(map[string][]string)
+		// The package is named testfile, its unique module name is
+		// testmodule.
+		for _, name := range []string{"NewTestfileClient", "NewTestmoduleClient", "TestfileServe", "Open"} {
+			bf, err := binder.GenerateBinding(deps, ctx, irData.Funcs["testmodule."+name])
+			if err != nil {
+				t.Fatal(err)
+			}
+			names[name] = bf.RyeifiedNameCandidates(ctx, false, false, "")
+		}
+		assert.Equal(map[string][]string{
+			"NewTestfileClient":   {"client", "testfile-client", "testfile-testfile-client", "testmodule-testfile-client"},
+			"NewTestmoduleClient": {"testmodule-client", "testfile-testmodule-client", "testmodule-testmodule-client"},
+			"TestfileServe":       {"testfile-serve", "testfile-testfile-serve", "testmodule-testfile-serve"},
+			"Open":                {"open", "testfile-open", "testmodule-open"},
+		}, names)
+	}
+
+	{
+		irData, modNames := irtest.ParseSingleFile(t, "testdata/naming.go")
+		ctx := binder.NewContext(&config.Config{
+			CustomPrefixes: [][2]string{{"tm-", "test.module/tm"}},
+		}, irData, modNames)
+		deps := binder.NewDependencies()
+
+		names := make(map[string][]string)
+		for _, name := range []string{"NewTestmoduleClient", "Open"} {
+			bf, err := binder.GenerateBinding(deps, ctx, irData.Funcs["testmodule."+name])
+			if err != nil {
+				t.Fatal(err)
+			}
+			names[name] = bf.RyeifiedNameCandidates(ctx, false, true, "")
+		}
+		assert.Equal(map[string][]string{
+			"NewTestmoduleClient": {"tm-testmodule-client"},
+			"Open":                {"tm-open"},
+		}, names)
+	}
+
+	{
+		irData, modNames := irtest.ParseSingleFile(t, "testdata/shortkinds.go")
+		ctx := binder.NewContext(&config.Config{}, irData, modNames)
+
+		kinds, conflicts := binder.ShortKinds(ctx)
+		assert.Equal(map[string]string{
+			"Go(*testmodule.Button)": "testmodule-button",
+			"Go(testmodule.Widget)":  "testmodule-widget",
+			"Go(testmodule.Celsius)": "testmodule-celsius",
+		}, kinds)
+		assert.Equal([]string{
+			"testmodule-http-client (Go(*testmodule.HTTPClient), Go(*testmodule.HttpClient))",
+		}, conflicts)
+
+		// Short kinds are used in generated code and method names, but
+		// not in Go doc text or binding list names.
+		ctx.Kinds = kinds
+		deps := binder.NewDependencies()
+		bf, err := binder.GenerateBinding(deps, ctx, irData.Funcs["testmodule.NewButton"])
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Contains(bf.Body, `"testmodule-button"`)
+		assert.NotContains(bf.Body, `Go(*testmodule.Button)`)
+		assert.Equal("w:testmodule-widget -> testmodule-button", bf.Signature.String())
+		assert.Contains(bf.DocComment, "NewButton shows w on a Go(*testmodule.Button).")
+		bf, err = binder.GenerateBinding(deps, ctx, irData.Funcs["(*testmodule.Button).SetText"])
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal("Go(*testmodule.Button)//set-text", bf.UniqueName(ctx))
+		assert.Equal([]string{"testmodule-button//set-text"}, bf.RyeifiedNameCandidates(ctx, false, false, ""))
+	}
+
+	testGen(t, "testdata/genericaliases.go",
+		func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string {
+			var b strings.Builder
+			for _, name := range []string{"Handle", "Move"} {
+				bf, err := binder.GenerateBinding(deps, ctx, irData.Funcs["testmodule."+name])
+				if err != nil {
+					t.Fatal(err)
+				}
+				b.WriteString(bf.Body)
+			}
+			return b.String()
+		},
+	)
+
+	testGen(t, "testdata/timeconv.go",
+		func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string {
+			var b strings.Builder
+			for _, name := range []string{"After", "Deadline"} {
+				bf, err := binder.GenerateBinding(deps, ctx, irData.Funcs["testmodule."+name])
+				if err != nil {
+					t.Fatal(err)
+				}
+				b.WriteString(bf.DocComment)
+				b.WriteString(bf.Body)
+			}
+			event := irData.Structs["testmodule.Event"]
+			for _, setter := range []bool{false, true} {
+				bf, err := binder.GenerateGetterOrSetter(deps, ctx, event.Fields[1], event.Name, setter)
+				if err != nil {
+					t.Fatal(err)
+				}
+				b.WriteString(bf.Body)
+			}
+			return b.String()
+		},
+	)
+
+	testGenWithConfig(t, &config.Config{
+		TraceTag: "ryegen_trace",
+	}, "testdata/convtrace.go",
+		func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string {
+			bf, err := binder.GenerateBinding(deps, ctx, irData.Funcs["testmodule.Move"])
+			if err != nil {
+				t.Fatal(err)
+			}
+			return bf.Body
+		},
+	)
+
+	{
+		src, err := os.ReadFile("testdata/frozenconv.conv")
+		if err != nil {
+			t.Fatal(err)
+		}
+		frozen, err := binder.ParseFrozenConvs("frozenconv.conv", string(src))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var deps *binder.Dependencies
+		var body string
+		testGen(t, "testdata/frozenconv.go",
+			func(irData *ir.IR, d *binder.Dependencies, ctx *binder.Context) string {
+				ctx.FrozenConvs = frozen
+				bf, err := binder.GenerateBinding(d, ctx, irData.Funcs["testmodule.IsWarm"])
+				if err != nil {
+					t.Fatal(err)
+				}
+				deps, body = d, bf.Body
+				return bf.Body
+			},
+		)
+		assert.Equal(map[string]int{"rye-to-go/native testmodule.Celsius": 1}, deps.FrozenConvUsage)
+		// Imports of the replaced code are dropped, and those of the
+		// frozen code added.
+		assert.NotContains(deps.Imports, "strconv")
+		assert.Contains(deps.Imports, "math")
+		assertCompiles(t, "testdata/frozenconv.go", deps, body)
+
+		_, err = binder.ParseFrozenConvs("a.conv", "//ryegen:conv rye-to-go/native x.Y\n$import math\n//ryegen:endconv\n")
+		assert.EqualError(err, "a.conv:2: expected quoted package path after $import")
+		_, err = binder.ParseFrozenConvs("a.conv", "//ryegen:conv rye-to-go/native x.Y\nfoo()\n")
+		assert.EqualError(err, "a.conv: unterminated frozen conversion rye-to-go/native x.Y")
+		_, err = binder.ParseFrozenConvs("a.conv", "//ryegen:endconv\n")
+		assert.EqualError(err, "a.conv:1: //ryegen:endconv without //ryegen:conv")
+	}
+
+	{
+		filename := "testdata/doccomments.go"
+		irData, modNames := irtest.ParseSingleFile(t, filename)
+		ctx := binder.NewContext(&config.Config{}, irData, modNames)
+
+		deps := binder.NewDependencies()
+
+		bf, err := binder.GenerateBinding(deps, ctx, irData.Funcs["testmodule.FuncWithDoc"])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(bf.DocComment, `This text should be in the generated doc string.
+
+Args:
+ * x - block(len=4)[integer]
+ * y - dict[integer, string]
+Result:
+ * string
+ * error
+`)
+	}
+}
+
+func TestStableTypeName(t *testing.T) {
+	assert := assert.New(t)
+
+	typ := func(modulePath, moduleName, name string) ir.Ident {
+		return ir.Ident{
+			Expr: &ast.Ident{Name: name},
+			Name: moduleName + "." + name,
+			File: &ir.File{ModulePath: modulePath, ModuleName: moduleName},
+		}
+	}
+	newCtx := func(ifaces ...ir.Ident) *binder.Context {
+		irData := &ir.IR{Interfaces: make(map[string]*ir.Interface)}
+		for _, iface := range ifaces {
+			irData.Interfaces[iface.Name] = &ir.Interface{Name: iface}
+		}
+		return binder.NewContext(&config.Config{}, irData, nil)
+	}
+	name := func(ctx *binder.Context, typ ir.Ident) string {
+		t.Helper()
+		res, err := binder.StableTypeName(ctx, typ)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res
+	}
+
+	// Naming contract: only depends on the package path and type name.
+	// Changing this breaks references to generated helpers.
+	ctx := newCtx(typ("go/ast", "ast", "Node"), typ("go/ast", "ast", "Expr"))
+	assert.Equal("Node", name(ctx, typ("go/ast", "ast", "Node")))
+	assert.Equal("Node", name(ctx, typ("go/ast", "ast2", "Node")))
+
+	// Names shared by interfaces of several packages get a hash.
+	ctx = newCtx(typ("go/ast", "ast", "Node"), typ("example.com/ast", "ast2", "Node"), typ("go/ast", "ast", "Expr"))
+	assert.Equal("Node_007fe9ee", name(ctx, typ("go/ast", "ast", "Node")))
+	assert.Equal("Node_007fe9ee", name(ctx, typ("go/ast", "ast3", "Node")))
+	assert.NotEqual(
+		name(ctx, typ("go/ast", "ast", "Node")),
+		name(ctx, typ("example.com/ast", "ast2", "Node")),
+	)
+	assert.Equal("Expr", name(ctx, typ("go/ast", "ast", "Expr")))
+
+	_, err := binder.StableTypeName(ctx, ir.Ident{Expr: &ast.StarExpr{X: &ast.Ident{Name: "Node"}}, Name: "*ast.Node"})
+	assert.ErrorContains(err, "expected named type, got *ast.Node")
+}
+
+func TestGenerateHelp(t *testing.T) {
+	assert := assert.New(t)
+
+	irData, modNames := irtest.ParseSingleFile(t, "testdata/help.go")
+	ctx := binder.NewContext(&config.Config{}, irData, modNames)
+
+	bf, err := binder.GenerateHelp(ctx, "test.module/tm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal("Help", bf.Name)
+	assert.Equal("Help", bf.Category)
+	assert.Equal("testmodule", bf.File.ModuleName)
+	assert.Equal("test.module/tm", bf.File.ModulePath)
+	assert.Equal("Get help for package test.module/tm", bf.Doc)
+	assert.Equal("Result:\n * string\n", bf.DocComment)
+	assert.Equal(0, bf.Argsn)
+	assert.Equal("return *env.NewString("+binder.HelpListingPlaceholder+")\n", bf.Body)
+
+	assert.Equal(`Package test.module/tm
+
+Package testfile greets people.
+
+Functions:
+  hello
+`, binder.HelpText(ctx, "test.module/tm", map[string][]string{"Functions": {"hello"}}))
+
+	_, err = binder.GenerateHelp(ctx, "example.com/unknown")
+	assert.EqualError(err, "unknown module path example.com/unknown")
+}
+
+func TestTimeHelpers(t *testing.T) {
+	src := "testdata/timehelpers.go"
+	irData, modNames := irtest.ParseSingleFileInModule(t, src, "time")
+	var genOut []func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string
+	for _, name := range binder.TimeHelperNames {
+		genOut = append(genOut, func(irData *ir.IR, deps *binder.Dependencies, ctx *binder.Context) string {
+			bf, err := binder.GenerateTimeHelper(deps, ctx, name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return bf.DocComment + bf.Body
+		})
+	}
+	testGenIR(t, &config.Config{}, src, irData, modNames, genOut...)
+
+	ctx := binder.NewContext(&config.Config{}, irData, modNames)
+	_, err := binder.GenerateTimeHelper(binder.NewDependencies(), ctx, "Later")
+	assert.EqualError(t, err, "unknown time helper Later")
 }
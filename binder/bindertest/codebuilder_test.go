@@ -0,0 +1,50 @@
+package bindertest_test
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/refaktor/ryegen/binder/binderio"
+)
+
+// Huge map literals/functions can exceed compiler limits, so make sure
+// a chunked map with lots of entries still builds.
+func TestChunkedMapVarBuilds(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping build of 100k synthetic builtins in short mode")
+	}
+
+	const numEntries = 100_000
+
+	entries := make([]string, numEntries)
+	for i := range entries {
+		entries[i] = fmt.Sprintf("m[\"builtin-%v\"] = &builtin{\n\tArgsn: %v,\n\tDoc: \"builtin %v\",\n}\n", i, i%5, i)
+	}
+
+	var cb binderio.CodeBuilder
+	cb.Linef(`package chunked`)
+	cb.Linef(``)
+	cb.Linef(`type builtin struct {`)
+	cb.Linef(`	Argsn int`)
+	cb.Linef(`	Doc string`)
+	cb.Linef(`}`)
+	cb.Linef(``)
+	cb.ChunkedMapVar("builtinsGenerated", "map[string]*builtin", 500, entries)
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module chunked\n\ngo 1.21\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "generated.go"), []byte(cb.String()), 0666); err != nil {
+		t.Fatal(err)
+	}
+	cmd := exec.Command("go", "build", ".")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=", "GOTOOLCHAIN=local")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("go build: %v\n%s", err, out)
+	}
+}
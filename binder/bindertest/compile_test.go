@@ -0,0 +1,217 @@
+package bindertest_test
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/refaktor/ryegen/binder"
+	"github.com/refaktor/ryegen/binder/binderio"
+	"github.com/refaktor/ryegen/config"
+	"github.com/refaktor/ryegen/ir/irtest"
+)
+
+// compilePrelude stubs the helpers the generated code defines in its
+// prelude (see main.go).
+const compilePrelude = `
+func objectDebugString(idx *env.Idxs, v any) string { return fmt.Sprint(v) }
+
+func boolToInt64(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func errorToRye(idx *env.Idxs, err error) *env.Error {
+	res := env.NewError(err.Error())
+	res.Values = map[string]env.Object{"native": *env.NewNative(idx, err, "Go(error)")}
+	return res
+}
+
+type convCtx struct{}
+
+func newConvCtx(ps *env.ProgramState) convCtx { return convCtx{} }
+
+func (cc convCtx) numericChecks() string { return "strict" }
+
+func nativeError(e *env.Error) (error, bool) {
+	nat, ok := e.Values["native"].(env.Native)
+	if !ok {
+		return nil, false
+	}
+	err, ok := nat.Value.(error)
+	return err, ok
+}
+`
+
+// writeCompileModule writes a module to a temporary directory, whose
+// package "check" holds the builtin bodies as functions named builtin0,
+// builtin1, ... along with code, using the imports in deps. The fixture
+// src is the package "test.module/tm" (see [irtest.ParseSingleFile]) and
+// Rye's env and evaldo packages are stubbed by testdata/ryestub. Returns
+// the module directory.
+func writeCompileModule(t *testing.T, src string, deps *binder.Dependencies, code string, bodies ...string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	write := func(name, content string) {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0777); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	read := func(name string) string {
+		t.Helper()
+		data, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+
+	write("go.mod", `module example.com/check
+
+go 1.22
+
+require (
+	github.com/refaktor/rye v0.0.0
+	test.module/tm v0.0.0
+)
+
+replace (
+	github.com/refaktor/rye => ./rye
+	test.module/tm => ./tm
+)
+`)
+	write("rye/go.mod", "module github.com/refaktor/rye\n\ngo 1.22\n")
+	write("rye/env/env.go", read("testdata/ryestub/env/env.go"))
+	write("rye/evaldo/evaldo.go", read("testdata/ryestub/evaldo/evaldo.go"))
+	write("tm/go.mod", "module test.module/tm\n\ngo 1.22\n")
+	write("tm/tm.go", read(src))
+
+	var b strings.Builder
+	b.WriteString("package check\n\nimport (\n")
+	imports := maps.Clone(deps.Imports)
+	imports["fmt"] = struct{}{}
+	imports["github.com/refaktor/rye/env"] = struct{}{}
+	for _, path := range slices.Sorted(maps.Keys(imports)) {
+		if path == "test.module/tm" {
+			fmt.Fprintf(&b, "\ttestmodule %q\n", path)
+		} else {
+			fmt.Fprintf(&b, "\t%q\n", path)
+		}
+	}
+	b.WriteString(")\n")
+	b.WriteString(compilePrelude)
+	b.WriteString(code)
+	for i, body := range bodies {
+		fmt.Fprintf(&b, "\nfunc builtin%v(ps *env.ProgramState, arg0, arg1, arg2, arg3, arg4 env.Object) env.Object {\n%v}\n", i, body)
+	}
+	write("check/check.go", b.String())
+	return dir
+}
+
+// goCmd runs the go command in dir, failing the test with its output if
+// it fails.
+func goCmd(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("go", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOPROXY=off", "GOWORK=off")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("go %v: %v\n%s", strings.Join(args, " "), err, out)
+	}
+}
+
+// assertCompiles compiles the builtin bodies generated from the fixture
+// src with deps (see [writeCompileModule]).
+func assertCompiles(t *testing.T, src string, deps *binder.Dependencies, bodies ...string) {
+	t.Helper()
+	goCmd(t, writeCompileModule(t, src, deps, "", bodies...), "vet", "./check")
+}
+
+// runRoundTrips generates the conversions of the param types of the
+// fixture function fn in src to Rye and back, as the functions
+// toRye0(ps, v) env.Object and fromRye0(ps, obj) (T, error), toRye1, ...
+// in the package "check" (see [writeCompileModule]), and runs the test
+// file test in it, passing args to go test.
+func runRoundTrips(t *testing.T, src, fn, test string, args ...string) {
+	t.Helper()
+
+	irData, modNames := irtest.ParseSingleFile(t, src)
+	ctx := binder.NewContext(&config.Config{}, irData, modNames)
+	deps := binder.NewDependencies()
+	f, ok := irData.Funcs["testmodule."+fn]
+	if !ok {
+		t.Fatalf("unknown fixture function %v", fn)
+	}
+
+	var cb binderio.CodeBuilder
+	makeRetConvErr := func(inner string) string {
+		return fmt.Sprintf("return out, errors.New(%v)\n", inner)
+	}
+	deps.Imports["errors"] = struct{}{}
+	for i, param := range f.Params {
+		deps.MarkUsed(param.Type)
+		cb.Linef(``)
+		cb.Linef(`func toRye%v(ps *env.ProgramState, in %v) env.Object {`, i, param.Type.Name)
+		cb.Indent++
+		cb.Linef(`var out env.Object`)
+		if _, found := binder.ConvGoToRye(deps, ctx, &cb, param.Type, `out`, `in`, i, nil); !found {
+			t.Fatalf("no conversion of %v to Rye", param.Type.Name)
+		}
+		cb.Linef(`return out`)
+		cb.Indent--
+		cb.Linef(`}`)
+		cb.Linef(``)
+		cb.Linef(`func fromRye%v(ps *env.ProgramState, in env.Object) (out %v, err error) {`, i, param.Type.Name)
+		cb.Indent++
+		if _, found := binder.ConvRyeToGo(deps, ctx, &cb, param.Type, `out`, `in`, i, makeRetConvErr); !found {
+			t.Fatalf("no conversion of %v from Rye", param.Type.Name)
+		}
+		cb.Linef(`return out, nil`)
+		cb.Indent--
+		cb.Linef(`}`)
+	}
+
+	testSrc, err := os.ReadFile(filepath.Join("testdata", test))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := writeCompileModule(t, src, deps, cb.String())
+	if err := os.WriteFile(filepath.Join(dir, "check", "check_test.go"), testSrc, 0666); err != nil {
+		t.Fatal(err)
+	}
+	goCmd(t, dir, append([]string{"test", "./check"}, args...)...)
+}
+
+func TestRoundTrips(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds and runs generated code")
+	}
+	for _, c := range []struct {
+		fn, test string
+		args     []string
+	}{
+		{"Times", "roundtrip_times_test.go", nil},
+		{"Errors", "roundtrip_errors_test.go", nil},
+		{"BigInts", "roundtrip_bigints_test.go", nil},
+		{"Pointers", "roundtrip_pointers_test.go", nil},
+		// Runs the seed corpus, then fuzzes a bounded number of inputs.
+		{"Fuzz", "roundtrip_fuzz_test.go", []string{"-fuzz=FuzzRoundTrip", "-fuzztime=2000x"}},
+	} {
+		t.Run(c.fn, func(t *testing.T) {
+			runRoundTrips(t, "testdata/roundtrip.go", c.fn, c.test, c.args...)
+		})
+	}
+}
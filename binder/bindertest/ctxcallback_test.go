@@ -0,0 +1,85 @@
+package bindertest_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/refaktor/ryegen/binder"
+	"github.com/refaktor/ryegen/config"
+	"github.com/refaktor/ryegen/ir/irtest"
+)
+
+// checkParallelTest calls the binding of testmodule.Parallel with a
+// callback that fails, returns a Rye error or succeeds, depending on
+// its argument.
+const checkParallelTest = `package check
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/refaktor/rye/env"
+)
+
+var errGo = errors.New("go error")
+
+func TestParallel(t *testing.T) {
+	ps := &env.ProgramState{Idx: &env.Idxs{}}
+	fn := env.Function{
+		Argsn: 2,
+		Call: func(ps *env.ProgramState, args []env.Object) {
+			i := args[1].(env.Integer).Value
+			switch i % 3 {
+			case 0:
+				ps.Res = *env.NewInteger(i)
+			case 1:
+				ps.Res = errorToRye(ps.Idx, errGo)
+				ps.FailureFlag = true
+			case 2:
+				ps.Res = env.NewError("rye error")
+			}
+		},
+	}
+	res, ok := builtin0(ps, *env.NewInteger(60), fn, nil, nil, nil).(*env.Error)
+	if !ok {
+		t.Fatalf("expected error, got %#v", res)
+	}
+	err, ok := nativeError(res)
+	if !ok {
+		t.Fatalf("expected native error in %#v", res)
+	}
+	if !errors.Is(err, errGo) {
+		t.Errorf("expected %v to wrap %v", err, errGo)
+	}
+	if n := strings.Count(err.Error(), "rye error"); n != 20 {
+		t.Errorf("expected 20 Rye errors, got %v in %v", n, err)
+	}
+	if ps.Res != nil {
+		t.Errorf("expected callbacks to keep the caller's result, got %#v", ps.Res)
+	}
+}
+`
+
+func TestContextCallbackParallel(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds with the race detector")
+	}
+
+	const src = "testdata/ctxcallback.go"
+	irData, modNames := irtest.ParseSingleFile(t, src)
+	ctx := binder.NewContext(&config.Config{}, irData, modNames)
+	deps := binder.NewDependencies()
+	bf, err := binder.GenerateBinding(deps, ctx, irData.Funcs["testmodule.Parallel"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	deps.Imports["github.com/refaktor/rye/evaldo"] = struct{}{}
+
+	dir := writeCompileModule(t, src, deps, "", bf.Body)
+	if err := os.WriteFile(filepath.Join(dir, "check", "check_test.go"), []byte(checkParallelTest), 0666); err != nil {
+		t.Fatal(err)
+	}
+	goCmd(t, dir, "test", "-race", "./check")
+}
@@ -0,0 +1,48 @@
+package bindertest_test
+
+import (
+	"testing"
+
+	"github.com/refaktor/ryegen/binder"
+	"github.com/refaktor/ryegen/ir"
+)
+
+func TestMergeDependencies(t *testing.T) {
+	structConv := binder.ConvID{GoToRye: false, Name: "struct"}
+	reader := &ir.Interface{Name: ir.Ident{Name: "io.Reader"}}
+	writer := &ir.Interface{Name: ir.Ident{Name: "io.Writer"}}
+
+	a := binder.NewDependencies()
+	a.Imports["io"] = struct{}{}
+	a.GenericInterfaceImpls["ifaceImpl_io_Reader"] = reader
+	a.ConvUsage[structConv] = 2
+
+	b := binder.NewDependencies()
+	b.Imports["os"] = struct{}{}
+	b.GenericInterfaceImpls["ifaceImpl_io_Reader"] = reader
+	b.ConvUsage[structConv] = 1
+	b.FrozenConvUsage["rye-to-go/struct testmodule.Point"] = 1
+
+	if err := a.Merge(b); err != nil {
+		t.Fatal(err)
+	}
+	if len(a.Imports) != 2 {
+		t.Errorf("expected 2 imports, but got %v", len(a.Imports))
+	}
+	if n := a.ConvUsage[structConv]; n != 3 {
+		t.Errorf("expected struct converter usage 3, but got %v", n)
+	}
+	if n := a.FrozenConvUsage["rye-to-go/struct testmodule.Point"]; n != 1 {
+		t.Errorf("expected frozen conversion usage 1, but got %v", n)
+	}
+
+	c := binder.NewDependencies()
+	c.Imports["bufio"] = struct{}{}
+	c.GenericInterfaceImpls["ifaceImpl_io_Reader"] = writer
+	if err := a.Merge(c); err == nil {
+		t.Fatal("expected conflicting generic interface implementations to fail")
+	}
+	if _, ok := a.Imports["bufio"]; ok {
+		t.Error("expected failed merge not to modify dependencies")
+	}
+}
@@ -0,0 +1,7 @@
+package testfile
+
+type Impl struct {
+	Name string
+}
+
+func (i *Impl) Greet(greeting string) (string, error) { return greeting + " " + i.Name, nil }
@@ -0,0 +1,9 @@
+package testfile
+
+func Place(p struct {
+	X, Y int
+	Meta struct{ Name string }
+}) struct{ Ok bool } {
+	_ = p
+	return struct{ Ok bool }{true}
+}
@@ -0,0 +1,244 @@
+Args:
+ * p - dict{x: integer, y: integer, meta: dict{name: string}}
+Result:
+ * dict{ok: bool}
+
+var arg0Val struct{X, Y int; Meta struct{Name string}}
+//ryegen:conv rye-to-go/struct struct{X, Y int; Meta struct{Name string}}
+switch v := arg0.(type) {
+case env.Dict:
+	for dictK, dictV := range v.Data {
+		switch dictK {
+		case "X", "x":
+			//ryegen:conv rye-to-go/builtin int
+			if vc, ok := dictV.(env.Integer); ok {
+				arg0Val.X = int(vc.Value)
+			} else {
+				ps.FailureFlag = true
+				return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"field x: "+"expected integer, but got "+objectDebugString(ps.Idx, dictV))
+			}
+			//ryegen:endconv
+		case "Y", "y":
+			//ryegen:conv rye-to-go/builtin int
+			if vc, ok := dictV.(env.Integer); ok {
+				arg0Val.Y = int(vc.Value)
+			} else {
+				ps.FailureFlag = true
+				return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"field y: "+"expected integer, but got "+objectDebugString(ps.Idx, dictV))
+			}
+			//ryegen:endconv
+		case "Meta", "meta":
+			//ryegen:conv rye-to-go/smallstruct struct{Name string}
+			switch v := dictV.(type) {
+			case env.Dict:
+				nFound := 0
+				{
+					dictV, ok := v.Data["Name"]
+					if !ok {
+						dictV, ok = v.Data["name"]
+					}
+					if ok {
+						nFound++
+						//ryegen:conv rye-to-go/builtin string
+						if vc, ok := dictV.(env.String); ok {
+							arg0Val.Meta.Name = string(vc.Value)
+						} else {
+							ps.FailureFlag = true
+							return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"field meta: "+"field name: "+"expected string, but got "+objectDebugString(ps.Idx, dictV))
+						}
+						//ryegen:endconv
+					}
+				}
+				if nFound != len(v.Data) {
+					for dictK := range v.Data {
+						switch dictK {
+						case "Name", "name":
+						default:
+							ps.FailureFlag = true
+							return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"field meta: "+"unknown struct field "+dictK)
+						}
+					}
+				}
+			case env.Block:
+				if len(v.Series.S) % 2 != 0 {
+					ps.FailureFlag = true
+					return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"field meta: "+"expected block to have length of multiple of 2, but got block with length "+strconv.Itoa(len(v.Series.S)))
+				}
+				for i := 0; i < len(v.Series.S); i += 2 {
+					var fieldName string
+					switch k := v.Series.S[i].(type) {
+					case env.String:
+						fieldName = k.Value
+					case env.Word:
+						fieldName = ps.Idx.GetWord(k.Index)
+					default:
+						ps.FailureFlag = true
+						return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"field meta: "+"expected struct field name to be string or word, but got "+objectDebugString(ps.Idx, k))
+					}
+					switch fieldName {
+					case "Name", "name":
+						//ryegen:conv rye-to-go/builtin string
+						if vc, ok := v.Series.S[i+1].(env.String); ok {
+							arg0Val.Meta.Name = string(vc.Value)
+						} else {
+							ps.FailureFlag = true
+							return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"field meta: "+"field name: "+"expected string, but got "+objectDebugString(ps.Idx, v.Series.S[i+1]))
+						}
+						//ryegen:endconv
+					default:
+						ps.FailureFlag = true
+						return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"field meta: "+"unknown struct field "+fieldName)
+					}
+				}
+			case env.Native:
+				if vc, ok := v.Value.(struct{Name string}); ok {
+					arg0Val.Meta = vc
+				} else {
+					ps.FailureFlag = true
+					return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"field meta: "+"expected native of type struct{Name string}, but got "+objectDebugString(ps.Idx, v))
+				}
+			default:
+				ps.FailureFlag = true
+				return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"field meta: "+"expected dict, block or native, but got "+objectDebugString(ps.Idx, v))
+			}
+			//ryegen:endconv
+		default:
+			ps.FailureFlag = true
+			return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"unknown struct field "+dictK)
+		}
+	}
+case env.Block:
+	if len(v.Series.S) % 2 != 0 {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected block to have length of multiple of 2, but got block with length "+strconv.Itoa(len(v.Series.S)))
+	}
+	for i := 0; i < len(v.Series.S); i += 2 {
+		var fieldName string
+		switch k := v.Series.S[i].(type) {
+		case env.String:
+			fieldName = k.Value
+		case env.Word:
+			fieldName = ps.Idx.GetWord(k.Index)
+		default:
+			ps.FailureFlag = true
+			return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected struct field name to be string or word, but got "+objectDebugString(ps.Idx, k))
+		}
+		switch fieldName {
+		case "X", "x":
+			//ryegen:conv rye-to-go/builtin int
+			if vc, ok := v.Series.S[i+1].(env.Integer); ok {
+				arg0Val.X = int(vc.Value)
+			} else {
+				ps.FailureFlag = true
+				return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"field x: "+"expected integer, but got "+objectDebugString(ps.Idx, v.Series.S[i+1]))
+			}
+			//ryegen:endconv
+		case "Y", "y":
+			//ryegen:conv rye-to-go/builtin int
+			if vc, ok := v.Series.S[i+1].(env.Integer); ok {
+				arg0Val.Y = int(vc.Value)
+			} else {
+				ps.FailureFlag = true
+				return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"field y: "+"expected integer, but got "+objectDebugString(ps.Idx, v.Series.S[i+1]))
+			}
+			//ryegen:endconv
+		case "Meta", "meta":
+			//ryegen:conv rye-to-go/smallstruct struct{Name string}
+			switch v := v.Series.S[i+1].(type) {
+			case env.Dict:
+				nFound := 0
+				{
+					dictV, ok := v.Data["Name"]
+					if !ok {
+						dictV, ok = v.Data["name"]
+					}
+					if ok {
+						nFound++
+						//ryegen:conv rye-to-go/builtin string
+						if vc, ok := dictV.(env.String); ok {
+							arg0Val.Meta.Name = string(vc.Value)
+						} else {
+							ps.FailureFlag = true
+							return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"field meta: "+"field name: "+"expected string, but got "+objectDebugString(ps.Idx, dictV))
+						}
+						//ryegen:endconv
+					}
+				}
+				if nFound != len(v.Data) {
+					for dictK := range v.Data {
+						switch dictK {
+						case "Name", "name":
+						default:
+							ps.FailureFlag = true
+							return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"field meta: "+"unknown struct field "+dictK)
+						}
+					}
+				}
+			case env.Block:
+				if len(v.Series.S) % 2 != 0 {
+					ps.FailureFlag = true
+					return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"field meta: "+"expected block to have length of multiple of 2, but got block with length "+strconv.Itoa(len(v.Series.S)))
+				}
+				for i := 0; i < len(v.Series.S); i += 2 {
+					var fieldName string
+					switch k := v.Series.S[i].(type) {
+					case env.String:
+						fieldName = k.Value
+					case env.Word:
+						fieldName = ps.Idx.GetWord(k.Index)
+					default:
+						ps.FailureFlag = true
+						return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"field meta: "+"expected struct field name to be string or word, but got "+objectDebugString(ps.Idx, k))
+					}
+					switch fieldName {
+					case "Name", "name":
+						//ryegen:conv rye-to-go/builtin string
+						if vc, ok := v.Series.S[i+1].(env.String); ok {
+							arg0Val.Meta.Name = string(vc.Value)
+						} else {
+							ps.FailureFlag = true
+							return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"field meta: "+"field name: "+"expected string, but got "+objectDebugString(ps.Idx, v.Series.S[i+1]))
+						}
+						//ryegen:endconv
+					default:
+						ps.FailureFlag = true
+						return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"field meta: "+"unknown struct field "+fieldName)
+					}
+				}
+			case env.Native:
+				if vc, ok := v.Value.(struct{Name string}); ok {
+					arg0Val.Meta = vc
+				} else {
+					ps.FailureFlag = true
+					return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"field meta: "+"expected native of type struct{Name string}, but got "+objectDebugString(ps.Idx, v))
+				}
+			default:
+				ps.FailureFlag = true
+				return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"field meta: "+"expected dict, block or native, but got "+objectDebugString(ps.Idx, v))
+			}
+			//ryegen:endconv
+		default:
+			ps.FailureFlag = true
+			return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"unknown struct field "+fieldName)
+		}
+	}
+case env.Native:
+	if vc, ok := v.Value.(struct{X, Y int; Meta struct{Name string}}); ok {
+		arg0Val = vc
+	} else {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native of type struct{X, Y int; Meta struct{Name string}}, but got "+objectDebugString(ps.Idx, v))
+	}
+default:
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected dict, block or native, but got "+objectDebugString(ps.Idx, v))
+}
+//ryegen:endconv
+res0 := testmodule.Place(arg0Val)
+var res0Obj env.Object
+//ryegen:conv go-to-rye/smallstruct struct{Ok bool}
+res0Obj = *env.NewDict(map[string]any{
+	"ok": *env.NewInteger(boolToInt64(res0.Ok)),
+})
+//ryegen:endconv
+return res0Obj
@@ -1,16 +1,21 @@
 var arg0Val []string
+//ryegen:conv rye-to-go/array []string
 switch v := arg0.(type) {
 case env.Block:
 	arg0Val = make([]string, len(v.Series.S))
 	for i, it := range v.Series.S {
 		iv := &arg0Val[i]
+		//ryegen:conv rye-to-go/builtin string
 		if vc, ok := it.(env.String); ok {
 			(*iv) = string(vc.Value)
 		} else {
 			ps.FailureFlag = true
-			return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"block item: "+"expected string, but got "+objectDebugString(ps.Idx, it))
+			return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"block item "+strconv.Itoa(i)+": "+"expected string, but got "+objectDebugString(ps.Idx, it))
 		}
+		//ryegen:endconv
 	}
+case env.Void:
+	arg0Val = nil
 case env.Integer:
 	if v.Value != 0 {
 		ps.FailureFlag = true
@@ -21,40 +26,50 @@ default:
 	ps.FailureFlag = true
 	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected block or nil, but got "+objectDebugString(ps.Idx, v))
 }
+//ryegen:endconv
 testmodule.ProcessSlice(arg0Val)
 return nil
 
 //================================//
 
 var arg0Val [][]string
+//ryegen:conv rye-to-go/array [][]string
 switch v := arg0.(type) {
 case env.Block:
 	arg0Val = make([][]string, len(v.Series.S))
 	for i, it := range v.Series.S {
 		iv := &arg0Val[i]
+		//ryegen:conv rye-to-go/array []string
 		switch v := it.(type) {
 		case env.Block:
 			(*iv) = make([]string, len(v.Series.S))
-			for i, it := range v.Series.S {
-				iv := &(*iv)[i]
+			for i1, it := range v.Series.S {
+				iv := &(*iv)[i1]
+				//ryegen:conv rye-to-go/builtin string
 				if vc, ok := it.(env.String); ok {
 					(*iv) = string(vc.Value)
 				} else {
 					ps.FailureFlag = true
-					return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"block item: "+"block item: "+"expected string, but got "+objectDebugString(ps.Idx, it))
+					return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"block item "+strconv.Itoa(i)+": "+"block item "+strconv.Itoa(i1)+": "+"expected string, but got "+objectDebugString(ps.Idx, it))
 				}
+				//ryegen:endconv
 			}
+		case env.Void:
+			(*iv) = nil
 		case env.Integer:
 			if v.Value != 0 {
 				ps.FailureFlag = true
-				return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"block item: "+"expected integer to be 0 or nil, but got "+strconv.FormatInt(v.Value, 10))
+				return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"block item "+strconv.Itoa(i)+": "+"expected integer to be 0 or nil, but got "+strconv.FormatInt(v.Value, 10))
 			}
 			(*iv) = nil
 		default:
 			ps.FailureFlag = true
-			return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"block item: "+"expected block or nil, but got "+objectDebugString(ps.Idx, v))
+			return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"block item "+strconv.Itoa(i)+": "+"expected block or nil, but got "+objectDebugString(ps.Idx, v))
 		}
+		//ryegen:endconv
 	}
+case env.Void:
+	arg0Val = nil
 case env.Integer:
 	if v.Value != 0 {
 		ps.FailureFlag = true
@@ -65,5 +80,6 @@ default:
 	ps.FailureFlag = true
 	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected block or nil, but got "+objectDebugString(ps.Idx, v))
 }
+//ryegen:endconv
 testmodule.ProcessSliceSlice(arg0Val)
 return nil
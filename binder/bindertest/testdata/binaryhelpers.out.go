@@ -0,0 +1,57 @@
+var data []byte
+switch v := arg0.(type) {
+case env.String:
+	data = []byte(v.Value)
+case env.Native:
+	b, ok := v.Value.([]byte)
+	if !ok {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native of type []byte, but got "+objectDebugString(ps.Idx, v))
+	}
+	data = b
+default:
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected string or native, but got "+objectDebugString(ps.Idx, v))
+}
+offObj, ok := arg1.(env.Integer)
+if !ok {
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"expected integer, but got "+objectDebugString(ps.Idx, arg1))
+}
+if offObj.Value < 0 || offObj.Value > int64(len(data))-4 {
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"offset "+strconv.FormatInt(offObj.Value, 10)+" out of range for 4 bytes in data of length "+strconv.Itoa(len(data)))
+}
+res := binary.LittleEndian.Uint32(data[offObj.Value:])
+var resObj env.Object
+//ryegen:conv go-to-rye/builtin uint32
+resObj = *env.NewInteger(int64(res))
+//ryegen:endconv
+return resObj
+
+//================================//
+
+var val uint64
+//ryegen:conv rye-to-go/builtin uint64
+if vc, ok := arg0.(env.Integer); ok {
+	switch newConvCtx(ps).numericChecks() {
+	case "strict":
+		if vc.Value < 0 {
+			ps.FailureFlag = true
+			return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"value "+strconv.FormatInt(vc.Value, 10)+" out of range for uint64")
+		}
+	}
+	val = uint64(vc.Value)
+} else if vc, ok := arg0.(env.String); ok {
+	u, err := strconv.ParseUint(vc.Value, 10, 64)
+	if err != nil {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+err.Error())
+	}
+	val = uint64(u)
+} else {
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected integer or string, but got "+objectDebugString(ps.Idx, arg0))
+}
+//ryegen:endconv
+return *env.NewString(string(binary.BigEndian.AppendUint64(nil, val)))
@@ -0,0 +1,7 @@
+package testfile
+
+type Mode int
+
+const ModeFast Mode = 1
+
+func Fetch(mode Mode, url string, retries int) error { return nil }
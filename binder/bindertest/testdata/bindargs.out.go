@@ -0,0 +1,17 @@
+var arg0Val testmodule.Mode = testmodule.ModeFast
+var arg1Val string
+//ryegen:conv rye-to-go/builtin string
+if vc, ok := arg0.(env.String); ok {
+	arg1Val = string(vc.Value)
+} else {
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected string, but got "+objectDebugString(ps.Idx, arg0))
+}
+//ryegen:endconv
+var arg2Val int = 3
+resErr := testmodule.Fetch(arg0Val, arg1Val, arg2Val)
+if resErr != nil {
+	ps.FailureFlag = true
+	return errorToRye(ps.Idx, resErr)
+}
+return env.Void{}
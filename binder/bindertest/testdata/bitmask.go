@@ -0,0 +1,16 @@
+package testfile
+
+type Perm uint32
+
+const (
+	PermRead Perm = 1 << iota
+	PermWrite
+	PermExec
+	PermReadWrite = PermRead | PermWrite
+)
+
+const PermNone Perm = 0
+
+func Chmod(name string, perm Perm) (Perm, error) {
+	return perm, nil
+}
@@ -0,0 +1,110 @@
+Args:
+ * name - string
+ * perm - block[word or integer]
+Result:
+ * block[word or integer]
+ * error
+var arg0Val string
+//ryegen:conv rye-to-go/builtin string
+if vc, ok := arg0.(env.String); ok {
+	arg0Val = string(vc.Value)
+} else {
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected string, but got "+objectDebugString(ps.Idx, arg0))
+}
+//ryegen:endconv
+var arg1Val testmodule.Perm
+//ryegen:conv rye-to-go/bitmask testmodule.Perm
+if v, ok := arg1.(env.Block); ok {
+	var bits testmodule.Perm
+	for _, it := range v.Series.S {
+		switch it := it.(type) {
+		case env.Word:
+			switch ps.Idx.GetWord(it.Index) {
+			case "read":
+				bits |= testmodule.PermRead
+			case "write":
+				bits |= testmodule.PermWrite
+			case "exec":
+				bits |= testmodule.PermExec
+			default:
+				ps.FailureFlag = true
+				return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"unknown flag "+ps.Idx.GetWord(it.Index)+" (expected read, write, exec)")
+			}
+		case env.Integer:
+			bits |= testmodule.Perm(it.Value)
+		default:
+			ps.FailureFlag = true
+			return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"expected block of words or integers, but got item "+objectDebugString(ps.Idx, it))
+		}
+	}
+	arg1Val = bits
+} else {
+	//ryegen:conv rye-to-go/typedef testmodule.Perm
+	{
+		nat, natOk := arg1.(env.Native)
+		var natValOk bool
+		var natVal testmodule.Perm
+		if natOk {
+			natVal, natValOk = nat.Value.(testmodule.Perm)
+		}
+		if natValOk {
+			arg1Val = natVal
+		} else {
+			var u uint32
+			//ryegen:conv rye-to-go/builtin uint32
+			if vc, ok := arg1.(env.Integer); ok {
+				switch newConvCtx(ps).numericChecks() {
+				case "strict":
+					if vc.Value < 0 || vc.Value > math.MaxUint32 {
+						ps.FailureFlag = true
+						return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"value "+strconv.FormatInt(vc.Value, 10)+" out of range for uint32")
+					}
+				case "wrap":
+					if vc.Value < math.MinInt32 || vc.Value > math.MaxUint32 {
+						ps.FailureFlag = true
+						return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"value "+strconv.FormatInt(vc.Value, 10)+" out of range for uint32")
+					}
+				}
+				u = uint32(vc.Value)
+			} else {
+				ps.FailureFlag = true
+				return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"expected integer, but got "+objectDebugString(ps.Idx, arg1))
+			}
+			//ryegen:endconv
+			arg1Val = testmodule.Perm(u)
+		}
+	}
+	//ryegen:endconv
+}
+//ryegen:endconv
+res0, resErr := testmodule.Chmod(arg0Val, arg1Val)
+if resErr != nil {
+	ps.FailureFlag = true
+	return errorToRye(ps.Idx, resErr)
+}
+var res0Obj env.Object
+//ryegen:conv go-to-rye/bitmask testmodule.Perm
+{
+	rest := res0
+	var items []env.Object
+	if rest&testmodule.PermRead != 0 {
+		items = append(items, *env.NewWord(ps.Idx.IndexWord("read")))
+		rest &^= testmodule.PermRead
+	}
+	if rest&testmodule.PermWrite != 0 {
+		items = append(items, *env.NewWord(ps.Idx.IndexWord("write")))
+		rest &^= testmodule.PermWrite
+	}
+	if rest&testmodule.PermExec != 0 {
+		items = append(items, *env.NewWord(ps.Idx.IndexWord("exec")))
+		rest &^= testmodule.PermExec
+	}
+	if rest != 0 {
+		// Bits without a flag name (e.g. permission bits)
+		items = append(items, *env.NewInteger(int64(rest)))
+	}
+	res0Obj = *env.NewBlock(*env.NewTSeries(items))
+}
+//ryegen:endconv
+return res0Obj
@@ -0,0 +1,15 @@
+package testfile
+
+type List []int
+
+func NewList() List { return nil }
+
+func (l List) Len() int { return len(l) }
+
+func (l *List) Push(x int) {}
+
+type Celsius float64
+
+func (c Celsius) String() string { return "" }
+
+func Freezing() Celsius { return 0 }
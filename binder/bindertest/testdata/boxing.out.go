@@ -0,0 +1,72 @@
+res0 := testmodule.NewList()
+var res0Obj env.Object
+//ryegen:conv go-to-rye/native testmodule.List
+res0Obj = *env.NewNative(ps.Idx, &res0, "Go(*testmodule.List)")
+//ryegen:endconv
+return res0Obj
+
+//================================//
+
+var arg0Val testmodule.List
+//ryegen:conv rye-to-go/typedef testmodule.List
+{
+	nat, natOk := arg0.(env.Native)
+	var natValOk bool
+	var natVal testmodule.List
+	if natOk {
+		natVal, natValOk = nat.Value.(testmodule.List)
+		if natPtr, ok := nat.Value.(*testmodule.List); ok {
+			natVal, natValOk = *natPtr, true
+		}
+	}
+	if natValOk {
+		arg0Val = natVal
+	} else {
+		var u []int
+		//ryegen:conv rye-to-go/array []int
+		switch v := arg0.(type) {
+		case env.Block:
+			u = make([]int, len(v.Series.S))
+			for i, it := range v.Series.S {
+				iv := &u[i]
+				//ryegen:conv rye-to-go/builtin int
+				if vc, ok := it.(env.Integer); ok {
+					(*iv) = int(vc.Value)
+				} else {
+					ps.FailureFlag = true
+					return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"block item "+strconv.Itoa(i)+": "+"expected integer, but got "+objectDebugString(ps.Idx, it))
+				}
+				//ryegen:endconv
+			}
+		case env.Void:
+			u = nil
+		case env.Integer:
+			if v.Value != 0 {
+				ps.FailureFlag = true
+				return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected integer to be 0 or nil, but got "+strconv.FormatInt(v.Value, 10))
+			}
+			u = nil
+		default:
+			ps.FailureFlag = true
+			return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected block or nil, but got "+objectDebugString(ps.Idx, v))
+		}
+		//ryegen:endconv
+		arg0Val = testmodule.List(u)
+	}
+}
+//ryegen:endconv
+res0 := arg0Val.Len()
+var res0Obj env.Object
+//ryegen:conv go-to-rye/builtin int
+res0Obj = *env.NewInteger(int64(res0))
+//ryegen:endconv
+return res0Obj
+
+//================================//
+
+res0 := testmodule.Freezing()
+var res0Obj env.Object
+//ryegen:conv go-to-rye/native testmodule.Celsius
+res0Obj = *env.NewNative(ps.Idx, res0, "Go(testmodule.Celsius)")
+//ryegen:endconv
+return res0Obj
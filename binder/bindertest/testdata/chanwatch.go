@@ -0,0 +1,10 @@
+package testfile
+
+type Event struct{}
+
+type Watcher struct {
+	Events chan Event
+	Errors <-chan error
+}
+
+func (w *Watcher) Close() error { return nil }
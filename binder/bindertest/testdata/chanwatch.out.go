@@ -0,0 +1,54 @@
+var self *testmodule.Watcher
+//ryegen:conv rye-to-go/native *testmodule.Watcher
+switch v := arg0.(type) {
+case env.Native:
+	if vc, ok := v.Value.(*testmodule.Watcher); ok {
+		self = vc
+	} else {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native of type *testmodule.Watcher, but got "+objectDebugString(ps.Idx, v))
+	}
+case env.Void:
+	self = nil
+case env.Integer:
+	if v.Value != 0 {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected integer to be 0 or nil, but got "+strconv.FormatInt(v.Value, 10))
+	}
+	self = nil
+default:
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native, but got "+objectDebugString(ps.Idx, v))
+}
+//ryegen:endconv
+if self == nil {
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected non-nil native")
+}
+fn, ok := arg1.(env.Function)
+if !ok {
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"expected function, but got "+objectDebugString(ps.Idx, arg1))
+}
+if fn.Argsn != 1 {
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"expected 1 function argument, but got "+strconv.Itoa(fn.Argsn))
+}
+ch := self.Events
+psCopy := *ps
+go func() {
+	ps := &psCopy
+	for v := range ch {
+		var vObj env.Object
+		//ryegen:conv go-to-rye/native testmodule.Event
+		vObj = *env.NewNative(ps.Idx, &v, "Go(*testmodule.Event)")
+		//ryegen:endconv
+		evaldo.CallFunctionArgsN(fn, ps, ps.Ctx, vObj)
+		if ps.ErrorFlag || ps.FailureFlag {
+			fmt.Printf("\033[31mError: \033[1m%v\033[m\n", "((RYEGEN:FUNCNAME)): callback: "+objectDebugString(ps.Idx, ps.Res))
+			self.Close()
+			return
+		}
+	}
+}()
+return arg0
@@ -0,0 +1,45 @@
+package testfile
+
+// Mimics code generated by protoc-gen-go.
+
+type Msg struct {
+	state            int
+	Name             string
+	Count            int32
+	XXX_unrecognized []byte
+	// Types that are assignable to Value:
+	//
+	//	*Msg_Text
+	//	*Msg_Number
+	Value isMsg_Value
+}
+
+func (x *Msg) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (m *Msg) GetValue() isMsg_Value {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+type isMsg_Value interface {
+	isMsg_Value()
+}
+
+type Msg_Text struct {
+	Text string
+}
+
+type Msg_Number struct {
+	Number int64
+}
+
+func (*Msg_Text) isMsg_Value() {}
+
+func (*Msg_Number) isMsg_Value() {}
@@ -0,0 +1,139 @@
+Args:
+ * value - dict with one of number, text
+Result:
+ * dict with one of number, text
+var self *testmodule.Msg
+//ryegen:conv rye-to-go/native *testmodule.Msg
+switch v := arg0.(type) {
+case env.Native:
+	if vc, ok := v.Value.(*testmodule.Msg); ok {
+		self = vc
+	} else {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native of type *testmodule.Msg, but got "+objectDebugString(ps.Idx, v))
+	}
+case env.Void:
+	self = nil
+case env.Integer:
+	if v.Value != 0 {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected integer to be 0 or nil, but got "+strconv.FormatInt(v.Value, 10))
+	}
+	self = nil
+default:
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native, but got "+objectDebugString(ps.Idx, v))
+}
+//ryegen:endconv
+if self == nil {
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected non-nil native")
+}
+//ryegen:conv rye-to-go/oneof isMsg_Value
+switch v := arg1.(type) {
+case env.Dict:
+	if len(v.Data) > 1 {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"expected dict with at most one key, but got "+strconv.Itoa(len(v.Data)))
+	}
+	self.Value = nil
+	for dictK, dictV := range v.Data {
+		switch dictK {
+		case "Number", "number":
+			var val int64
+			//ryegen:conv rye-to-go/builtin int64
+			if vc, ok := dictV.(env.Integer); ok {
+				val = int64(vc.Value)
+			} else {
+				ps.FailureFlag = true
+				return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"field number: "+"expected integer, but got "+objectDebugString(ps.Idx, dictV))
+			}
+			//ryegen:endconv
+			self.Value = &testmodule.Msg_Number{Number: val}
+		case "Text", "text":
+			var val string
+			//ryegen:conv rye-to-go/builtin string
+			if vc, ok := dictV.(env.String); ok {
+				val = string(vc.Value)
+			} else {
+				ps.FailureFlag = true
+				return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"field text: "+"expected string, but got "+objectDebugString(ps.Idx, dictV))
+			}
+			//ryegen:endconv
+			self.Value = &testmodule.Msg_Text{Text: val}
+		default:
+			ps.FailureFlag = true
+			return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"unknown oneof field "+dictK)
+		}
+	}
+case env.Native:
+	switch vc := v.Value.(type) {
+	case *testmodule.Msg_Number:
+		self.Value = vc
+	case *testmodule.Msg_Text:
+		self.Value = vc
+	default:
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"expected native of a oneof wrapper type, but got "+objectDebugString(ps.Idx, v))
+	}
+case env.Void:
+	self.Value = nil
+case env.Integer:
+	if v.Value != 0 {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"expected integer to be 0 or nil, but got "+strconv.FormatInt(v.Value, 10))
+	}
+	self.Value = nil
+default:
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"expected dict, native or nil, but got "+objectDebugString(ps.Idx, v))
+}
+//ryegen:endconv
+return arg0
+
+//================================//
+
+var arg0Val *testmodule.Msg
+//ryegen:conv rye-to-go/native *testmodule.Msg
+switch v := arg0.(type) {
+case env.Native:
+	if vc, ok := v.Value.(*testmodule.Msg); ok {
+		arg0Val = vc
+	} else {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native of type *testmodule.Msg, but got "+objectDebugString(ps.Idx, v))
+	}
+case env.Void:
+	arg0Val = nil
+case env.Integer:
+	if v.Value != 0 {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected integer to be 0 or nil, but got "+strconv.FormatInt(v.Value, 10))
+	}
+	arg0Val = nil
+default:
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native, but got "+objectDebugString(ps.Idx, v))
+}
+//ryegen:endconv
+res0 := arg0Val.GetValue()
+var res0Obj env.Object
+//ryegen:conv go-to-rye/oneof isMsg_Value
+switch v := res0.(type) {
+case *testmodule.Msg_Number:
+	var dVal env.Object
+	//ryegen:conv go-to-rye/builtin int64
+	dVal = *env.NewInteger(int64(v.Number))
+	//ryegen:endconv
+	res0Obj = *env.NewDict(map[string]any{"number": dVal})
+case *testmodule.Msg_Text:
+	var dVal env.Object
+	//ryegen:conv go-to-rye/builtin string
+	dVal = *env.NewString(v.Text)
+	//ryegen:endconv
+	res0Obj = *env.NewDict(map[string]any{"text": dVal})
+default:
+	res0Obj = env.Void{}
+}
+//ryegen:endconv
+return res0Obj
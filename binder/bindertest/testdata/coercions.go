@@ -0,0 +1,3 @@
+package testfile
+
+func Write(data []byte, scale float64) {}
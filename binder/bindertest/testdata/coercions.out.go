@@ -0,0 +1,56 @@
+var arg0Val []byte
+//ryegen:conv rye-to-go/array []byte
+switch v := arg0.(type) {
+case env.String:
+	arg0Val = []byte(v.Value)
+case env.Block:
+	arg0Val = make([]byte, len(v.Series.S))
+	for i, it := range v.Series.S {
+		iv := &arg0Val[i]
+		//ryegen:conv rye-to-go/builtin byte
+		if vc, ok := it.(env.Integer); ok {
+			switch newConvCtx(ps).numericChecks() {
+			case "strict":
+				if vc.Value < 0 || vc.Value > math.MaxUint8 {
+					ps.FailureFlag = true
+					return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"block item "+strconv.Itoa(i)+": "+"value "+strconv.FormatInt(vc.Value, 10)+" out of range for byte")
+				}
+			case "wrap":
+				if vc.Value < math.MinInt8 || vc.Value > math.MaxUint8 {
+					ps.FailureFlag = true
+					return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"block item "+strconv.Itoa(i)+": "+"value "+strconv.FormatInt(vc.Value, 10)+" out of range for byte")
+				}
+			}
+			(*iv) = byte(vc.Value)
+		} else {
+			ps.FailureFlag = true
+			return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"block item "+strconv.Itoa(i)+": "+"expected integer, but got "+objectDebugString(ps.Idx, it))
+		}
+		//ryegen:endconv
+	}
+case env.Void:
+	arg0Val = nil
+case env.Integer:
+	if v.Value != 0 {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected integer to be 0 or nil, but got "+strconv.FormatInt(v.Value, 10))
+	}
+	arg0Val = nil
+default:
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected block, string or nil, but got "+objectDebugString(ps.Idx, v))
+}
+//ryegen:endconv
+var arg1Val float64
+//ryegen:conv rye-to-go/builtin float64
+if vc, ok := arg1.(env.Decimal); ok {
+	arg1Val = float64(vc.Value)
+} else if vc, ok := arg1.(env.Integer); ok {
+	arg1Val = float64(vc.Value)
+} else {
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"expected decimal or integer, but got "+objectDebugString(ps.Idx, arg1))
+}
+//ryegen:endconv
+testmodule.Write(arg0Val, arg1Val)
+return nil
@@ -0,0 +1,5 @@
+package testfile
+
+func Lookup(key string) (string, bool) {
+	return key, true
+}
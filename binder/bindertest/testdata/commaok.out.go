@@ -0,0 +1,19 @@
+var arg0Val string
+//ryegen:conv rye-to-go/builtin string
+if vc, ok := arg0.(env.String); ok {
+	arg0Val = string(vc.Value)
+} else {
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected string, but got "+objectDebugString(ps.Idx, arg0))
+}
+//ryegen:endconv
+res0, res1 := testmodule.Lookup(arg0Val)
+var res0Obj env.Object
+//ryegen:conv go-to-rye/builtin string
+res0Obj = *env.NewString(res0)
+//ryegen:endconv
+if !res1 {
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): not ok")
+}
+return res0Obj
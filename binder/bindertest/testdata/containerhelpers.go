@@ -0,0 +1,13 @@
+package testfile
+
+type Header map[string][]string
+
+func (h Header) Get(key string) string { return "" }
+
+type Values []int
+
+func (v *Values) Reset() {}
+
+func NewHeader() Header { return nil }
+
+func SumValues(v Values) int { return 0 }
@@ -0,0 +1,179 @@
+var self testmodule.Header
+//ryegen:conv rye-to-go/typedef testmodule.Header
+{
+	nat, natOk := arg0.(env.Native)
+	var natValOk bool
+	var natVal testmodule.Header
+	if natOk {
+		natVal, natValOk = nat.Value.(testmodule.Header)
+	}
+	if natValOk {
+		self = natVal
+	} else {
+		var u map[string][]string
+		//ryegen:conv rye-to-go/map map[string][]string
+		switch v := arg0.(type) {
+		case env.Block:
+			if len(v.Series.S) % 2 != 0 {
+				ps.FailureFlag = true
+				return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected block to have length of multiple of 2, but got block with length "+strconv.Itoa(len(v.Series.S)))
+			}
+			u = make(map[string][]string, len(v.Series.S)/2)
+			for i := 0; i < len(v.Series.S); i += 2 {
+				var mapK string
+				//ryegen:conv rye-to-go/builtin string
+				if vc, ok := v.Series.S[i+0].(env.String); ok {
+					mapK = string(vc.Value)
+				} else {
+					ps.FailureFlag = true
+					return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map key: "+"expected string, but got "+objectDebugString(ps.Idx, v.Series.S[i+0]))
+				}
+				//ryegen:endconv
+				var mapV []string
+				//ryegen:conv rye-to-go/array []string
+				switch v := v.Series.S[i+1].(type) {
+				case env.Block:
+					mapV = make([]string, len(v.Series.S))
+					for i, it := range v.Series.S {
+						iv := &mapV[i]
+						//ryegen:conv rye-to-go/builtin string
+						if vc, ok := it.(env.String); ok {
+							(*iv) = string(vc.Value)
+						} else {
+							ps.FailureFlag = true
+							return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map value: "+"block item "+strconv.Itoa(i)+": "+"expected string, but got "+objectDebugString(ps.Idx, it))
+						}
+						//ryegen:endconv
+					}
+				case env.Void:
+					mapV = nil
+				case env.Integer:
+					if v.Value != 0 {
+						ps.FailureFlag = true
+						return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map value: "+"expected integer to be 0 or nil, but got "+strconv.FormatInt(v.Value, 10))
+					}
+					mapV = nil
+				default:
+					ps.FailureFlag = true
+					return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map value: "+"expected block or nil, but got "+objectDebugString(ps.Idx, v))
+				}
+				//ryegen:endconv
+				u[mapK] = mapV
+			}
+		case env.Dict:
+			u = make(map[string][]string, len(v.Data))
+			for dictK, dictV := range v.Data {
+				mapK := dictK
+				var mapV []string
+				//ryegen:conv rye-to-go/array []string
+				switch v := dictV.(type) {
+				case env.Block:
+					mapV = make([]string, len(v.Series.S))
+					for i, it := range v.Series.S {
+						iv := &mapV[i]
+						//ryegen:conv rye-to-go/builtin string
+						if vc, ok := it.(env.String); ok {
+							(*iv) = string(vc.Value)
+						} else {
+							ps.FailureFlag = true
+							return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map value: "+"block item "+strconv.Itoa(i)+": "+"expected string, but got "+objectDebugString(ps.Idx, it))
+						}
+						//ryegen:endconv
+					}
+				case env.Void:
+					mapV = nil
+				case env.Integer:
+					if v.Value != 0 {
+						ps.FailureFlag = true
+						return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map value: "+"expected integer to be 0 or nil, but got "+strconv.FormatInt(v.Value, 10))
+					}
+					mapV = nil
+				default:
+					ps.FailureFlag = true
+					return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map value: "+"expected block or nil, but got "+objectDebugString(ps.Idx, v))
+				}
+				//ryegen:endconv
+				u[mapK] = mapV
+			}
+		case env.Void:
+			u = nil
+		case env.Integer:
+			if v.Value != 0 {
+				ps.FailureFlag = true
+				return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected integer to be 0 or nil, but got "+strconv.FormatInt(v.Value, 10))
+			}
+			u = nil
+		default:
+			ps.FailureFlag = true
+			return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected block, dict or nil, but got "+objectDebugString(ps.Idx, v))
+		}
+		//ryegen:endconv
+		self = testmodule.Header(u)
+	}
+}
+//ryegen:endconv
+if self == nil {
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"assignment to nil map")
+}
+var key string
+//ryegen:conv rye-to-go/builtin string
+if vc, ok := arg1.(env.String); ok {
+	key = string(vc.Value)
+} else {
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"expected string, but got "+objectDebugString(ps.Idx, arg1))
+}
+//ryegen:endconv
+var value string
+//ryegen:conv rye-to-go/builtin string
+if vc, ok := arg2.(env.String); ok {
+	value = string(vc.Value)
+} else {
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 3: "+"expected string, but got "+objectDebugString(ps.Idx, arg2))
+}
+//ryegen:endconv
+self[key] = append(self[key], value)
+return arg0
+
+//================================//
+
+var self *testmodule.Values
+//ryegen:conv rye-to-go/native *testmodule.Values
+switch v := arg0.(type) {
+case env.Native:
+	if vc, ok := v.Value.(*testmodule.Values); ok {
+		self = vc
+	} else {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native of type *testmodule.Values, but got "+objectDebugString(ps.Idx, v))
+	}
+case env.Void:
+	self = nil
+case env.Integer:
+	if v.Value != 0 {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected integer to be 0 or nil, but got "+strconv.FormatInt(v.Value, 10))
+	}
+	self = nil
+default:
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native, but got "+objectDebugString(ps.Idx, v))
+}
+//ryegen:endconv
+if self == nil {
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected non-nil native")
+}
+var value int
+//ryegen:conv rye-to-go/builtin int
+if vc, ok := arg1.(env.Integer); ok {
+	value = int(vc.Value)
+} else {
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"expected integer, but got "+objectDebugString(ps.Idx, arg1))
+}
+//ryegen:endconv
+*self = append(*self, value)
+return arg0
@@ -0,0 +1,7 @@
+package testfile
+
+type Point struct {
+	X, Y int
+}
+
+func Move(p Point, dx int) Point { return p }
@@ -0,0 +1,42 @@
+var arg0Val testmodule.Point
+{
+	convTrace := traceConvBegin(convTrace)
+	//ryegen:conv rye-to-go/native testmodule.Point
+	switch v := arg0.(type) {
+	case env.Native:
+		if vc, ok := v.Value.(*testmodule.Point); ok {
+			arg0Val = *vc
+		} else {
+			ps.FailureFlag = true
+			return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native of type *testmodule.Point, but got "+objectDebugString(ps.Idx, v))
+		}
+	default:
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native, but got "+objectDebugString(ps.Idx, v))
+	}
+	//ryegen:endconv
+	traceConvEnd(convTrace, "rye-to-go/native testmodule.Point")
+}
+var arg1Val int
+{
+	convTrace := traceConvBegin(convTrace)
+	//ryegen:conv rye-to-go/builtin int
+	if vc, ok := arg1.(env.Integer); ok {
+		arg1Val = int(vc.Value)
+	} else {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"expected integer, but got "+objectDebugString(ps.Idx, arg1))
+	}
+	//ryegen:endconv
+	traceConvEnd(convTrace, "rye-to-go/builtin int")
+}
+res0 := testmodule.Move(arg0Val, arg1Val)
+var res0Obj env.Object
+{
+	convTrace := traceConvBegin(convTrace)
+	//ryegen:conv go-to-rye/native testmodule.Point
+	res0Obj = *env.NewNative(ps.Idx, &res0, "Go(*testmodule.Point)")
+	//ryegen:endconv
+	traceConvEnd(convTrace, "go-to-rye/native testmodule.Point")
+}
+return res0Obj
@@ -0,0 +1,30 @@
+package testfile
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+type Group struct{}
+
+func (g *Group) Go(f func(ctx context.Context) error) {}
+
+func Retry(ctx context.Context, attempts int, fn func(ctx context.Context, attempt int) error) error {
+	return nil
+}
+
+// Parallel calls fn n times concurrently and joins the errors.
+func Parallel(n int, fn func(ctx context.Context, i int) error) error {
+	errs := make([]error, n)
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = fn(context.Background(), i)
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
@@ -0,0 +1,147 @@
+var arg0Val *testmodule.Group
+//ryegen:conv rye-to-go/native *testmodule.Group
+switch v := arg0.(type) {
+case env.Native:
+	if vc, ok := v.Value.(*testmodule.Group); ok {
+		arg0Val = vc
+	} else {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native of type *testmodule.Group, but got "+objectDebugString(ps.Idx, v))
+	}
+case env.Void:
+	arg0Val = nil
+case env.Integer:
+	if v.Value != 0 {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected integer to be 0 or nil, but got "+strconv.FormatInt(v.Value, 10))
+	}
+	arg0Val = nil
+default:
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native, but got "+objectDebugString(ps.Idx, v))
+}
+//ryegen:endconv
+var arg1Val func(context.Context) (error)
+//ryegen:conv rye-to-go/func func(context.Context) (error)
+switch fn := arg1.(type) {
+case env.Function:
+	if fn.Argsn != 1 {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"expected 1 function arguments, but got "+strconv.Itoa(fn.Argsn))
+	}
+	fnPs := *ps
+	arg1Val = func(farg0 context.Context) (error) {
+		psCopy := fnPs
+		ps := &psCopy
+		var farg0Val env.Object
+		//ryegen:conv go-to-rye/native context.Context
+		farg0Val = *env.NewNative(ps.Idx, farg0, "Go(context.Context)")
+		//ryegen:endconv
+		actualFn := fn
+		_ = actualFn
+		evaldo.CallFunctionArgsN(fn, ps, ps.Ctx, farg0Val)
+		if e, ok := ps.Res.(*env.Error); ok {
+			if err, ok := nativeError(e); ok {
+				return err
+			}
+			return errors.New(e.Print(*ps.Idx))
+		}
+		if ps.FailureFlag || ps.ErrorFlag {
+			return errors.New(ps.Res.Inspect(*ps.Idx))
+		}
+		return nil
+	}
+case env.Void:
+	arg1Val = nil
+case env.Integer:
+	if fn.Value != 0 {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"expected integer to be 0 or nil, but got "+strconv.FormatInt(fn.Value, 10))
+	}
+	arg1Val = nil
+default:
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"expected function or nil, but got "+objectDebugString(ps.Idx, fn))
+}
+//ryegen:endconv
+arg0Val.Go(arg1Val)
+return arg0
+
+//================================//
+
+var arg0Val context.Context
+//ryegen:conv rye-to-go/native context.Context
+switch v := arg0.(type) {
+case env.Native:
+	if vc, ok := v.Value.(context.Context); ok {
+		arg0Val = vc
+	} else {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native of type context.Context, but got "+objectDebugString(ps.Idx, v))
+	}
+default:
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native, but got "+objectDebugString(ps.Idx, v))
+}
+//ryegen:endconv
+var arg1Val int
+//ryegen:conv rye-to-go/builtin int
+if vc, ok := arg1.(env.Integer); ok {
+	arg1Val = int(vc.Value)
+} else {
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"expected integer, but got "+objectDebugString(ps.Idx, arg1))
+}
+//ryegen:endconv
+var arg2Val func(context.Context, int) (error)
+//ryegen:conv rye-to-go/func func(context.Context, int) (error)
+switch fn := arg2.(type) {
+case env.Function:
+	if fn.Argsn != 2 {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 3: "+"expected 2 function arguments, but got "+strconv.Itoa(fn.Argsn))
+	}
+	fnPs := *ps
+	arg2Val = func(farg0 context.Context, farg1 int) (error) {
+		psCopy := fnPs
+		ps := &psCopy
+		var farg0Val, farg1Val env.Object
+		//ryegen:conv go-to-rye/native context.Context
+		farg0Val = *env.NewNative(ps.Idx, farg0, "Go(context.Context)")
+		//ryegen:endconv
+		//ryegen:conv go-to-rye/builtin int
+		farg1Val = *env.NewInteger(int64(farg1))
+		//ryegen:endconv
+		actualFn := fn
+		_ = actualFn
+		evaldo.CallFunctionArgsN(fn, ps, ps.Ctx, farg0Val, farg1Val)
+		if e, ok := ps.Res.(*env.Error); ok {
+			if err, ok := nativeError(e); ok {
+				return err
+			}
+			return errors.New(e.Print(*ps.Idx))
+		}
+		if ps.FailureFlag || ps.ErrorFlag {
+			return errors.New(ps.Res.Inspect(*ps.Idx))
+		}
+		return nil
+	}
+case env.Void:
+	arg2Val = nil
+case env.Integer:
+	if fn.Value != 0 {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 3: "+"expected integer to be 0 or nil, but got "+strconv.FormatInt(fn.Value, 10))
+	}
+	arg2Val = nil
+default:
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 3: "+"expected function or nil, but got "+objectDebugString(ps.Idx, fn))
+}
+//ryegen:endconv
+resErr := testmodule.Retry(arg0Val, arg1Val, arg2Val)
+if resErr != nil {
+	ps.FailureFlag = true
+	return errorToRye(ps.Idx, resErr)
+}
+return env.Void{}
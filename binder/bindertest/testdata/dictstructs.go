@@ -0,0 +1,12 @@
+package testfile
+
+type Server struct {
+	Host  string
+	Port  int
+	Tags  []string
+	token string
+}
+
+func Servers() map[string]Server { return nil }
+
+func SetServers(servers map[string]Server) {}
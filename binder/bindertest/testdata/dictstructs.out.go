@@ -0,0 +1,375 @@
+res0 := testmodule.Servers()
+var res0Obj env.Object
+//ryegen:conv go-to-rye/map map[string]testmodule.Server
+{
+	data := make(map[string]any, len(res0))
+	for mKey, mVal := range res0 {
+		var dVal env.Object
+		//ryegen:conv go-to-rye/struct testmodule.Server
+		{
+			data := make(map[string]any, 3)
+			{
+				var dVal env.Object
+				//ryegen:conv go-to-rye/builtin string
+				dVal = *env.NewString(mVal.Host)
+				//ryegen:endconv
+				data["host"] = dVal
+			}
+			{
+				var dVal env.Object
+				//ryegen:conv go-to-rye/builtin int
+				dVal = *env.NewInteger(int64(mVal.Port))
+				//ryegen:endconv
+				data["port"] = dVal
+			}
+			{
+				var dVal env.Object
+				//ryegen:conv go-to-rye/array []string
+				{
+					items := make([]env.Object, len(mVal.Tags))
+					for i, it := range mVal.Tags {
+						//ryegen:conv go-to-rye/builtin string
+						items[i] = *env.NewString(it)
+						//ryegen:endconv
+					}
+					dVal = *env.NewBlock(*env.NewTSeries(items))
+				}
+				//ryegen:endconv
+				data["tags"] = dVal
+			}
+			dVal = *env.NewDict(data)
+		}
+		//ryegen:endconv
+		data[mKey] = dVal
+	}
+	res0Obj = *env.NewDict(data)
+}
+//ryegen:endconv
+return res0Obj
+
+//================================//
+
+var arg0Val map[string]testmodule.Server
+//ryegen:conv rye-to-go/map map[string]testmodule.Server
+switch v := arg0.(type) {
+case env.Block:
+	if len(v.Series.S) % 2 != 0 {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected block to have length of multiple of 2, but got block with length "+strconv.Itoa(len(v.Series.S)))
+	}
+	arg0Val = make(map[string]testmodule.Server, len(v.Series.S)/2)
+	for i := 0; i < len(v.Series.S); i += 2 {
+		var mapK string
+		//ryegen:conv rye-to-go/builtin string
+		if vc, ok := v.Series.S[i+0].(env.String); ok {
+			mapK = string(vc.Value)
+		} else {
+			ps.FailureFlag = true
+			return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map key: "+"expected string, but got "+objectDebugString(ps.Idx, v.Series.S[i+0]))
+		}
+		//ryegen:endconv
+		var mapV testmodule.Server
+		//ryegen:conv rye-to-go/struct testmodule.Server
+		switch v := v.Series.S[i+1].(type) {
+		case env.Dict:
+			for dictK, dictV := range v.Data {
+				switch dictK {
+				case "Host", "host":
+					//ryegen:conv rye-to-go/builtin string
+					if vc, ok := dictV.(env.String); ok {
+						mapV.Host = string(vc.Value)
+					} else {
+						ps.FailureFlag = true
+						return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map value: "+"field host: "+"expected string, but got "+objectDebugString(ps.Idx, dictV))
+					}
+					//ryegen:endconv
+				case "Port", "port":
+					//ryegen:conv rye-to-go/builtin int
+					if vc, ok := dictV.(env.Integer); ok {
+						mapV.Port = int(vc.Value)
+					} else {
+						ps.FailureFlag = true
+						return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map value: "+"field port: "+"expected integer, but got "+objectDebugString(ps.Idx, dictV))
+					}
+					//ryegen:endconv
+				case "Tags", "tags":
+					//ryegen:conv rye-to-go/array []string
+					switch v := dictV.(type) {
+					case env.Block:
+						mapV.Tags = make([]string, len(v.Series.S))
+						for i, it := range v.Series.S {
+							iv := &mapV.Tags[i]
+							//ryegen:conv rye-to-go/builtin string
+							if vc, ok := it.(env.String); ok {
+								(*iv) = string(vc.Value)
+							} else {
+								ps.FailureFlag = true
+								return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map value: "+"field tags: "+"block item "+strconv.Itoa(i)+": "+"expected string, but got "+objectDebugString(ps.Idx, it))
+							}
+							//ryegen:endconv
+						}
+					case env.Void:
+						mapV.Tags = nil
+					case env.Integer:
+						if v.Value != 0 {
+							ps.FailureFlag = true
+							return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map value: "+"field tags: "+"expected integer to be 0 or nil, but got "+strconv.FormatInt(v.Value, 10))
+						}
+						mapV.Tags = nil
+					default:
+						ps.FailureFlag = true
+						return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map value: "+"field tags: "+"expected block or nil, but got "+objectDebugString(ps.Idx, v))
+					}
+					//ryegen:endconv
+				default:
+					ps.FailureFlag = true
+					return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map value: "+"unknown struct field "+dictK)
+				}
+			}
+		case env.Block:
+			if len(v.Series.S) % 2 != 0 {
+				ps.FailureFlag = true
+				return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map value: "+"expected block to have length of multiple of 2, but got block with length "+strconv.Itoa(len(v.Series.S)))
+			}
+			for i := 0; i < len(v.Series.S); i += 2 {
+				var fieldName string
+				switch k := v.Series.S[i].(type) {
+				case env.String:
+					fieldName = k.Value
+				case env.Word:
+					fieldName = ps.Idx.GetWord(k.Index)
+				default:
+					ps.FailureFlag = true
+					return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map value: "+"expected struct field name to be string or word, but got "+objectDebugString(ps.Idx, k))
+				}
+				switch fieldName {
+				case "Host", "host":
+					//ryegen:conv rye-to-go/builtin string
+					if vc, ok := v.Series.S[i+1].(env.String); ok {
+						mapV.Host = string(vc.Value)
+					} else {
+						ps.FailureFlag = true
+						return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map value: "+"field host: "+"expected string, but got "+objectDebugString(ps.Idx, v.Series.S[i+1]))
+					}
+					//ryegen:endconv
+				case "Port", "port":
+					//ryegen:conv rye-to-go/builtin int
+					if vc, ok := v.Series.S[i+1].(env.Integer); ok {
+						mapV.Port = int(vc.Value)
+					} else {
+						ps.FailureFlag = true
+						return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map value: "+"field port: "+"expected integer, but got "+objectDebugString(ps.Idx, v.Series.S[i+1]))
+					}
+					//ryegen:endconv
+				case "Tags", "tags":
+					//ryegen:conv rye-to-go/array []string
+					switch v := v.Series.S[i+1].(type) {
+					case env.Block:
+						mapV.Tags = make([]string, len(v.Series.S))
+						for i, it := range v.Series.S {
+							iv := &mapV.Tags[i]
+							//ryegen:conv rye-to-go/builtin string
+							if vc, ok := it.(env.String); ok {
+								(*iv) = string(vc.Value)
+							} else {
+								ps.FailureFlag = true
+								return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map value: "+"field tags: "+"block item "+strconv.Itoa(i)+": "+"expected string, but got "+objectDebugString(ps.Idx, it))
+							}
+							//ryegen:endconv
+						}
+					case env.Void:
+						mapV.Tags = nil
+					case env.Integer:
+						if v.Value != 0 {
+							ps.FailureFlag = true
+							return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map value: "+"field tags: "+"expected integer to be 0 or nil, but got "+strconv.FormatInt(v.Value, 10))
+						}
+						mapV.Tags = nil
+					default:
+						ps.FailureFlag = true
+						return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map value: "+"field tags: "+"expected block or nil, but got "+objectDebugString(ps.Idx, v))
+					}
+					//ryegen:endconv
+				default:
+					ps.FailureFlag = true
+					return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map value: "+"unknown struct field "+fieldName)
+				}
+			}
+		case env.Native:
+			if vc, ok := v.Value.(testmodule.Server); ok {
+				mapV = vc
+			} else if vc, ok := v.Value.(*testmodule.Server); ok {
+				mapV = *vc
+			} else {
+				ps.FailureFlag = true
+				return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map value: "+"expected native of type testmodule.Server, but got "+objectDebugString(ps.Idx, v))
+			}
+		default:
+			ps.FailureFlag = true
+			return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map value: "+"expected dict, block or native, but got "+objectDebugString(ps.Idx, v))
+		}
+		//ryegen:endconv
+		arg0Val[mapK] = mapV
+	}
+case env.Dict:
+	arg0Val = make(map[string]testmodule.Server, len(v.Data))
+	for dictK, dictV := range v.Data {
+		mapK := dictK
+		var mapV testmodule.Server
+		//ryegen:conv rye-to-go/struct testmodule.Server
+		switch v := dictV.(type) {
+		case env.Dict:
+			for dictK, dictV := range v.Data {
+				switch dictK {
+				case "Host", "host":
+					//ryegen:conv rye-to-go/builtin string
+					if vc, ok := dictV.(env.String); ok {
+						mapV.Host = string(vc.Value)
+					} else {
+						ps.FailureFlag = true
+						return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map value: "+"field host: "+"expected string, but got "+objectDebugString(ps.Idx, dictV))
+					}
+					//ryegen:endconv
+				case "Port", "port":
+					//ryegen:conv rye-to-go/builtin int
+					if vc, ok := dictV.(env.Integer); ok {
+						mapV.Port = int(vc.Value)
+					} else {
+						ps.FailureFlag = true
+						return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map value: "+"field port: "+"expected integer, but got "+objectDebugString(ps.Idx, dictV))
+					}
+					//ryegen:endconv
+				case "Tags", "tags":
+					//ryegen:conv rye-to-go/array []string
+					switch v := dictV.(type) {
+					case env.Block:
+						mapV.Tags = make([]string, len(v.Series.S))
+						for i, it := range v.Series.S {
+							iv := &mapV.Tags[i]
+							//ryegen:conv rye-to-go/builtin string
+							if vc, ok := it.(env.String); ok {
+								(*iv) = string(vc.Value)
+							} else {
+								ps.FailureFlag = true
+								return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map value: "+"field tags: "+"block item "+strconv.Itoa(i)+": "+"expected string, but got "+objectDebugString(ps.Idx, it))
+							}
+							//ryegen:endconv
+						}
+					case env.Void:
+						mapV.Tags = nil
+					case env.Integer:
+						if v.Value != 0 {
+							ps.FailureFlag = true
+							return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map value: "+"field tags: "+"expected integer to be 0 or nil, but got "+strconv.FormatInt(v.Value, 10))
+						}
+						mapV.Tags = nil
+					default:
+						ps.FailureFlag = true
+						return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map value: "+"field tags: "+"expected block or nil, but got "+objectDebugString(ps.Idx, v))
+					}
+					//ryegen:endconv
+				default:
+					ps.FailureFlag = true
+					return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map value: "+"unknown struct field "+dictK)
+				}
+			}
+		case env.Block:
+			if len(v.Series.S) % 2 != 0 {
+				ps.FailureFlag = true
+				return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map value: "+"expected block to have length of multiple of 2, but got block with length "+strconv.Itoa(len(v.Series.S)))
+			}
+			for i := 0; i < len(v.Series.S); i += 2 {
+				var fieldName string
+				switch k := v.Series.S[i].(type) {
+				case env.String:
+					fieldName = k.Value
+				case env.Word:
+					fieldName = ps.Idx.GetWord(k.Index)
+				default:
+					ps.FailureFlag = true
+					return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map value: "+"expected struct field name to be string or word, but got "+objectDebugString(ps.Idx, k))
+				}
+				switch fieldName {
+				case "Host", "host":
+					//ryegen:conv rye-to-go/builtin string
+					if vc, ok := v.Series.S[i+1].(env.String); ok {
+						mapV.Host = string(vc.Value)
+					} else {
+						ps.FailureFlag = true
+						return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map value: "+"field host: "+"expected string, but got "+objectDebugString(ps.Idx, v.Series.S[i+1]))
+					}
+					//ryegen:endconv
+				case "Port", "port":
+					//ryegen:conv rye-to-go/builtin int
+					if vc, ok := v.Series.S[i+1].(env.Integer); ok {
+						mapV.Port = int(vc.Value)
+					} else {
+						ps.FailureFlag = true
+						return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map value: "+"field port: "+"expected integer, but got "+objectDebugString(ps.Idx, v.Series.S[i+1]))
+					}
+					//ryegen:endconv
+				case "Tags", "tags":
+					//ryegen:conv rye-to-go/array []string
+					switch v := v.Series.S[i+1].(type) {
+					case env.Block:
+						mapV.Tags = make([]string, len(v.Series.S))
+						for i, it := range v.Series.S {
+							iv := &mapV.Tags[i]
+							//ryegen:conv rye-to-go/builtin string
+							if vc, ok := it.(env.String); ok {
+								(*iv) = string(vc.Value)
+							} else {
+								ps.FailureFlag = true
+								return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map value: "+"field tags: "+"block item "+strconv.Itoa(i)+": "+"expected string, but got "+objectDebugString(ps.Idx, it))
+							}
+							//ryegen:endconv
+						}
+					case env.Void:
+						mapV.Tags = nil
+					case env.Integer:
+						if v.Value != 0 {
+							ps.FailureFlag = true
+							return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map value: "+"field tags: "+"expected integer to be 0 or nil, but got "+strconv.FormatInt(v.Value, 10))
+						}
+						mapV.Tags = nil
+					default:
+						ps.FailureFlag = true
+						return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map value: "+"field tags: "+"expected block or nil, but got "+objectDebugString(ps.Idx, v))
+					}
+					//ryegen:endconv
+				default:
+					ps.FailureFlag = true
+					return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map value: "+"unknown struct field "+fieldName)
+				}
+			}
+		case env.Native:
+			if vc, ok := v.Value.(testmodule.Server); ok {
+				mapV = vc
+			} else if vc, ok := v.Value.(*testmodule.Server); ok {
+				mapV = *vc
+			} else {
+				ps.FailureFlag = true
+				return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map value: "+"expected native of type testmodule.Server, but got "+objectDebugString(ps.Idx, v))
+			}
+		default:
+			ps.FailureFlag = true
+			return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map value: "+"expected dict, block or native, but got "+objectDebugString(ps.Idx, v))
+		}
+		//ryegen:endconv
+		arg0Val[mapK] = mapV
+	}
+case env.Void:
+	arg0Val = nil
+case env.Integer:
+	if v.Value != 0 {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected integer to be 0 or nil, but got "+strconv.FormatInt(v.Value, 10))
+	}
+	arg0Val = nil
+default:
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected block, dict or nil, but got "+objectDebugString(ps.Idx, v))
+}
+//ryegen:endconv
+testmodule.SetServers(arg0Val)
+return nil
@@ -0,0 +1,7 @@
+package testfile
+
+type Handler interface {
+	Handle(x int)
+}
+
+func Register(h Handler, cb func(int), ch chan int) {}
@@ -0,0 +1,55 @@
+var arg0Val testmodule.Handler
+//ryegen:conv rye-to-go/native testmodule.Handler
+switch v := arg0.(type) {
+case env.Native:
+	if vc, ok := v.Value.(testmodule.Handler); ok {
+		arg0Val = vc
+	} else {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native implementing testmodule.Handler, but got "+objectDebugString(ps.Idx, v))
+	}
+case env.Void:
+	arg0Val = nil
+case env.Integer:
+	if v.Value != 0 {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected integer to be 0 or nil, but got "+strconv.FormatInt(v.Value, 10))
+	}
+	arg0Val = nil
+default:
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native implementing testmodule.Handler, but got "+objectDebugString(ps.Idx, v))
+}
+//ryegen:endconv
+var arg1Val func(int)
+//ryegen:conv rye-to-go/native func(int)
+switch v := arg1.(type) {
+case env.Native:
+	if vc, ok := v.Value.(func(int)); ok {
+		arg1Val = vc
+	} else {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"expected native of type func(int), but got "+objectDebugString(ps.Idx, v))
+	}
+default:
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"expected native, but got "+objectDebugString(ps.Idx, v))
+}
+//ryegen:endconv
+var arg2Val chan int
+//ryegen:conv rye-to-go/native chan int
+switch v := arg2.(type) {
+case env.Native:
+	if vc, ok := v.Value.(chan int); ok {
+		arg2Val = vc
+	} else {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 3: "+"expected native of type chan int, but got "+objectDebugString(ps.Idx, v))
+	}
+default:
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 3: "+"expected native, but got "+objectDebugString(ps.Idx, v))
+}
+//ryegen:endconv
+testmodule.Register(arg0Val, arg1Val, arg2Val)
+return nil
@@ -0,0 +1,17 @@
+package testfile
+
+type Reader interface {
+	Read(p []byte) (n int, err error)
+}
+
+type Source struct {
+	Reader
+	Name string
+}
+
+func Open(src struct {
+	Reader
+	Name string
+}) struct{ Reader } {
+	return struct{ Reader }{src.Reader}
+}
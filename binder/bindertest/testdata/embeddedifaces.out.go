@@ -0,0 +1,312 @@
+var self *testmodule.Source
+//ryegen:conv rye-to-go/native *testmodule.Source
+switch v := arg0.(type) {
+case env.Native:
+	if vc, ok := v.Value.(*testmodule.Source); ok {
+		self = vc
+	} else {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native of type *testmodule.Source, but got "+objectDebugString(ps.Idx, v))
+	}
+case env.Void:
+	self = nil
+case env.Integer:
+	if v.Value != 0 {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected integer to be 0 or nil, but got "+strconv.FormatInt(v.Value, 10))
+	}
+	self = nil
+default:
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native, but got "+objectDebugString(ps.Idx, v))
+}
+//ryegen:endconv
+if self == nil {
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected non-nil native")
+}
+var resObj env.Object
+//ryegen:conv go-to-rye/native testmodule.Reader
+resObj = ifaceToNative(ps.Idx, self.Reader, "Go(testmodule.Reader)")
+//ryegen:endconv
+return resObj
+
+var self *testmodule.Source
+//ryegen:conv rye-to-go/native *testmodule.Source
+switch v := arg0.(type) {
+case env.Native:
+	if vc, ok := v.Value.(*testmodule.Source); ok {
+		self = vc
+	} else {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native of type *testmodule.Source, but got "+objectDebugString(ps.Idx, v))
+	}
+case env.Void:
+	self = nil
+case env.Integer:
+	if v.Value != 0 {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected integer to be 0 or nil, but got "+strconv.FormatInt(v.Value, 10))
+	}
+	self = nil
+default:
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native, but got "+objectDebugString(ps.Idx, v))
+}
+//ryegen:endconv
+if self == nil {
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected non-nil native")
+}
+var newVal testmodule.Reader
+//ryegen:conv rye-to-go/native testmodule.Reader
+switch v := arg1.(type) {
+case env.RyeCtx:
+	var err error
+	newVal, err = ctxTo_Reader(ps, v)
+	if err != nil {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+err.Error())
+	}
+case env.Native:
+	if vc, ok := v.Value.(testmodule.Reader); ok {
+		newVal = vc
+	} else {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"expected native implementing testmodule.Reader, but got "+objectDebugString(ps.Idx, v))
+	}
+case env.Void:
+	newVal = nil
+case env.Integer:
+	if v.Value != 0 {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"expected integer to be 0 or nil, but got "+strconv.FormatInt(v.Value, 10))
+	}
+	newVal = nil
+default:
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"expected native or context implementing testmodule.Reader, but got "+objectDebugString(ps.Idx, v))
+}
+//ryegen:endconv
+self.Reader = newVal
+return arg0
+
+Args:
+ * recv - Go(testmodule.Source)
+ * p - block[integer]
+Result:
+ * integer
+ * error
+
+var arg0Val testmodule.Source
+//ryegen:conv rye-to-go/native testmodule.Source
+switch v := arg0.(type) {
+case env.Native:
+	if vc, ok := v.Value.(*testmodule.Source); ok {
+		arg0Val = *vc
+	} else {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native of type *testmodule.Source, but got "+objectDebugString(ps.Idx, v))
+	}
+default:
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native, but got "+objectDebugString(ps.Idx, v))
+}
+//ryegen:endconv
+var arg1Val []byte
+//ryegen:conv rye-to-go/array []byte
+switch v := arg1.(type) {
+case env.Block:
+	arg1Val = make([]byte, len(v.Series.S))
+	for i, it := range v.Series.S {
+		iv := &arg1Val[i]
+		//ryegen:conv rye-to-go/builtin byte
+		if vc, ok := it.(env.Integer); ok {
+			switch newConvCtx(ps).numericChecks() {
+			case "strict":
+				if vc.Value < 0 || vc.Value > math.MaxUint8 {
+					ps.FailureFlag = true
+					return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"block item "+strconv.Itoa(i)+": "+"value "+strconv.FormatInt(vc.Value, 10)+" out of range for byte")
+				}
+			case "wrap":
+				if vc.Value < math.MinInt8 || vc.Value > math.MaxUint8 {
+					ps.FailureFlag = true
+					return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"block item "+strconv.Itoa(i)+": "+"value "+strconv.FormatInt(vc.Value, 10)+" out of range for byte")
+				}
+			}
+			(*iv) = byte(vc.Value)
+		} else {
+			ps.FailureFlag = true
+			return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"block item "+strconv.Itoa(i)+": "+"expected integer, but got "+objectDebugString(ps.Idx, it))
+		}
+		//ryegen:endconv
+	}
+case env.Void:
+	arg1Val = nil
+case env.Integer:
+	if v.Value != 0 {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"expected integer to be 0 or nil, but got "+strconv.FormatInt(v.Value, 10))
+	}
+	arg1Val = nil
+default:
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"expected block or nil, but got "+objectDebugString(ps.Idx, v))
+}
+//ryegen:endconv
+res0, resErr := arg0Val.Read(arg1Val)
+if resErr != nil {
+	ps.FailureFlag = true
+	return errorToRye(ps.Idx, resErr)
+}
+var res0Obj env.Object
+//ryegen:conv go-to-rye/builtin int
+res0Obj = *env.NewInteger(int64(res0))
+//ryegen:endconv
+return res0Obj
+
+Args:
+ * src - dict{reader: Go(testmodule.Reader), name: string}
+Result:
+ * dict{reader: Go(testmodule.Reader)}
+
+var arg0Val struct{testmodule.Reader; Name string}
+//ryegen:conv rye-to-go/struct struct{testmodule.Reader; Name string}
+switch v := arg0.(type) {
+case env.Dict:
+	for dictK, dictV := range v.Data {
+		switch dictK {
+		case "Reader", "reader":
+			//ryegen:conv rye-to-go/native testmodule.Reader
+			switch v := dictV.(type) {
+			case env.RyeCtx:
+				var err error
+				arg0Val.Reader, err = ctxTo_Reader(ps, v)
+				if err != nil {
+					ps.FailureFlag = true
+					return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"field reader: "+err.Error())
+				}
+			case env.Native:
+				if vc, ok := v.Value.(testmodule.Reader); ok {
+					arg0Val.Reader = vc
+				} else {
+					ps.FailureFlag = true
+					return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"field reader: "+"expected native implementing testmodule.Reader, but got "+objectDebugString(ps.Idx, v))
+				}
+			case env.Void:
+				arg0Val.Reader = nil
+			case env.Integer:
+				if v.Value != 0 {
+					ps.FailureFlag = true
+					return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"field reader: "+"expected integer to be 0 or nil, but got "+strconv.FormatInt(v.Value, 10))
+				}
+				arg0Val.Reader = nil
+			default:
+				ps.FailureFlag = true
+				return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"field reader: "+"expected native or context implementing testmodule.Reader, but got "+objectDebugString(ps.Idx, v))
+			}
+			//ryegen:endconv
+		case "Name", "name":
+			//ryegen:conv rye-to-go/builtin string
+			if vc, ok := dictV.(env.String); ok {
+				arg0Val.Name = string(vc.Value)
+			} else {
+				ps.FailureFlag = true
+				return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"field name: "+"expected string, but got "+objectDebugString(ps.Idx, dictV))
+			}
+			//ryegen:endconv
+		default:
+			ps.FailureFlag = true
+			return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"unknown struct field "+dictK)
+		}
+	}
+case env.Block:
+	if len(v.Series.S) % 2 != 0 {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected block to have length of multiple of 2, but got block with length "+strconv.Itoa(len(v.Series.S)))
+	}
+	for i := 0; i < len(v.Series.S); i += 2 {
+		var fieldName string
+		switch k := v.Series.S[i].(type) {
+		case env.String:
+			fieldName = k.Value
+		case env.Word:
+			fieldName = ps.Idx.GetWord(k.Index)
+		default:
+			ps.FailureFlag = true
+			return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected struct field name to be string or word, but got "+objectDebugString(ps.Idx, k))
+		}
+		switch fieldName {
+		case "Reader", "reader":
+			//ryegen:conv rye-to-go/native testmodule.Reader
+			switch v := v.Series.S[i+1].(type) {
+			case env.RyeCtx:
+				var err error
+				arg0Val.Reader, err = ctxTo_Reader(ps, v)
+				if err != nil {
+					ps.FailureFlag = true
+					return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"field reader: "+err.Error())
+				}
+			case env.Native:
+				if vc, ok := v.Value.(testmodule.Reader); ok {
+					arg0Val.Reader = vc
+				} else {
+					ps.FailureFlag = true
+					return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"field reader: "+"expected native implementing testmodule.Reader, but got "+objectDebugString(ps.Idx, v))
+				}
+			case env.Void:
+				arg0Val.Reader = nil
+			case env.Integer:
+				if v.Value != 0 {
+					ps.FailureFlag = true
+					return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"field reader: "+"expected integer to be 0 or nil, but got "+strconv.FormatInt(v.Value, 10))
+				}
+				arg0Val.Reader = nil
+			default:
+				ps.FailureFlag = true
+				return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"field reader: "+"expected native or context implementing testmodule.Reader, but got "+objectDebugString(ps.Idx, v))
+			}
+			//ryegen:endconv
+		case "Name", "name":
+			//ryegen:conv rye-to-go/builtin string
+			if vc, ok := v.Series.S[i+1].(env.String); ok {
+				arg0Val.Name = string(vc.Value)
+			} else {
+				ps.FailureFlag = true
+				return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"field name: "+"expected string, but got "+objectDebugString(ps.Idx, v.Series.S[i+1]))
+			}
+			//ryegen:endconv
+		default:
+			ps.FailureFlag = true
+			return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"unknown struct field "+fieldName)
+		}
+	}
+case env.Native:
+	if vc, ok := v.Value.(struct{testmodule.Reader; Name string}); ok {
+		arg0Val = vc
+	} else {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native of type struct{testmodule.Reader; Name string}, but got "+objectDebugString(ps.Idx, v))
+	}
+default:
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected dict, block or native, but got "+objectDebugString(ps.Idx, v))
+}
+//ryegen:endconv
+res0 := testmodule.Open(arg0Val)
+var res0Obj env.Object
+//ryegen:conv go-to-rye/struct struct{testmodule.Reader}
+{
+	data := make(map[string]any, 1)
+	{
+		var dVal env.Object
+		//ryegen:conv go-to-rye/native testmodule.Reader
+		dVal = ifaceToNative(ps.Idx, res0.Reader, "Go(testmodule.Reader)")
+		//ryegen:endconv
+		data["reader"] = dVal
+	}
+	res0Obj = *env.NewDict(data)
+}
+//ryegen:endconv
+return res0Obj
+
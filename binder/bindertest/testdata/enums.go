@@ -0,0 +1,32 @@
+package testfile
+
+type Weekday int
+
+const (
+	Sunday Weekday = iota
+	Monday
+	Tuesday
+)
+
+func (d Weekday) String() string { return [...]string{"Sunday", "Monday", "Tuesday"}[d] }
+
+type Level uint8
+
+const (
+	LevelDebug Level = iota + 1
+	LevelInfo
+	LevelWarn
+
+	LevelDefault = LevelInfo
+)
+
+func (l Level) String() string { return "" }
+
+// Count has no String method, so it's converted as an integer.
+type Count int
+
+const CountNone Count = 0
+
+func Next(d Weekday) Weekday { return d + 1 }
+
+func SetLevel(l Level, n Count) Level { return l }
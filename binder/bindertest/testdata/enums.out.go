@@ -0,0 +1,217 @@
+Args:
+ * d - word, string or integer
+Result:
+ * word, string or integer
+
+var arg0Val testmodule.Weekday
+//ryegen:conv rye-to-go/enum testmodule.Weekday
+switch v := arg0.(type) {
+case env.Word:
+	switch ps.Idx.GetWord(v.Index) {
+	case "sunday":
+		arg0Val = testmodule.Sunday
+	case "monday":
+		arg0Val = testmodule.Monday
+	case "tuesday":
+		arg0Val = testmodule.Tuesday
+	default:
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"unknown value "+ps.Idx.GetWord(v.Index)+" (expected sunday, monday, tuesday)")
+	}
+case env.String:
+	switch v.Value {
+	case "sunday":
+		arg0Val = testmodule.Sunday
+	case "monday":
+		arg0Val = testmodule.Monday
+	case "tuesday":
+		arg0Val = testmodule.Tuesday
+	default:
+		// Also accept the result of the String method.
+		found := false
+		for _, c := range []testmodule.Weekday{
+			testmodule.Sunday,
+			testmodule.Monday,
+			testmodule.Tuesday,
+		} {
+			if c.String() == v.Value {
+				arg0Val, found = c, true
+				break
+			}
+		}
+		if !found {
+			ps.FailureFlag = true
+			return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"unknown value "+strconv.Quote(v.Value)+" (expected sunday, monday, tuesday)")
+		}
+	}
+default:
+	//ryegen:conv rye-to-go/typedef testmodule.Weekday
+	{
+		nat, natOk := arg0.(env.Native)
+		var natValOk bool
+		var natVal testmodule.Weekday
+		if natOk {
+			natVal, natValOk = nat.Value.(testmodule.Weekday)
+		}
+		if natValOk {
+			arg0Val = natVal
+		} else {
+			var u int
+			//ryegen:conv rye-to-go/builtin int
+			if vc, ok := arg0.(env.Integer); ok {
+				u = int(vc.Value)
+			} else {
+				ps.FailureFlag = true
+				return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected integer, but got "+objectDebugString(ps.Idx, arg0))
+			}
+			//ryegen:endconv
+			arg0Val = testmodule.Weekday(u)
+		}
+	}
+	//ryegen:endconv
+}
+//ryegen:endconv
+res0 := testmodule.Next(arg0Val)
+var res0Obj env.Object
+//ryegen:conv go-to-rye/enum testmodule.Weekday
+switch res0 {
+case testmodule.Sunday:
+	res0Obj = *env.NewWord(ps.Idx.IndexWord("sunday"))
+case testmodule.Monday:
+	res0Obj = *env.NewWord(ps.Idx.IndexWord("monday"))
+case testmodule.Tuesday:
+	res0Obj = *env.NewWord(ps.Idx.IndexWord("tuesday"))
+default:
+	res0Obj = *env.NewInteger(int64(res0))
+}
+//ryegen:endconv
+return res0Obj
+
+//================================//
+
+Args:
+ * l - word, string or integer
+ * n - integer
+Result:
+ * word, string or integer
+
+var arg0Val testmodule.Level
+//ryegen:conv rye-to-go/enum testmodule.Level
+switch v := arg0.(type) {
+case env.Word:
+	switch ps.Idx.GetWord(v.Index) {
+	case "debug":
+		arg0Val = testmodule.LevelDebug
+	case "info":
+		arg0Val = testmodule.LevelInfo
+	case "warn":
+		arg0Val = testmodule.LevelWarn
+	default:
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"unknown value "+ps.Idx.GetWord(v.Index)+" (expected debug, info, warn)")
+	}
+case env.String:
+	switch v.Value {
+	case "debug":
+		arg0Val = testmodule.LevelDebug
+	case "info":
+		arg0Val = testmodule.LevelInfo
+	case "warn":
+		arg0Val = testmodule.LevelWarn
+	default:
+		// Also accept the result of the String method.
+		found := false
+		for _, c := range []testmodule.Level{
+			testmodule.LevelDebug,
+			testmodule.LevelInfo,
+			testmodule.LevelWarn,
+		} {
+			if c.String() == v.Value {
+				arg0Val, found = c, true
+				break
+			}
+		}
+		if !found {
+			ps.FailureFlag = true
+			return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"unknown value "+strconv.Quote(v.Value)+" (expected debug, info, warn)")
+		}
+	}
+default:
+	//ryegen:conv rye-to-go/typedef testmodule.Level
+	{
+		nat, natOk := arg0.(env.Native)
+		var natValOk bool
+		var natVal testmodule.Level
+		if natOk {
+			natVal, natValOk = nat.Value.(testmodule.Level)
+		}
+		if natValOk {
+			arg0Val = natVal
+		} else {
+			var u uint8
+			//ryegen:conv rye-to-go/builtin uint8
+			if vc, ok := arg0.(env.Integer); ok {
+				switch newConvCtx(ps).numericChecks() {
+				case "strict":
+					if vc.Value < 0 || vc.Value > math.MaxUint8 {
+						ps.FailureFlag = true
+						return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"value "+strconv.FormatInt(vc.Value, 10)+" out of range for uint8")
+					}
+				case "wrap":
+					if vc.Value < math.MinInt8 || vc.Value > math.MaxUint8 {
+						ps.FailureFlag = true
+						return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"value "+strconv.FormatInt(vc.Value, 10)+" out of range for uint8")
+					}
+				}
+				u = uint8(vc.Value)
+			} else {
+				ps.FailureFlag = true
+				return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected integer, but got "+objectDebugString(ps.Idx, arg0))
+			}
+			//ryegen:endconv
+			arg0Val = testmodule.Level(u)
+		}
+	}
+	//ryegen:endconv
+}
+//ryegen:endconv
+var arg1Val testmodule.Count
+//ryegen:conv rye-to-go/typedef testmodule.Count
+{
+	nat, natOk := arg1.(env.Native)
+	var natValOk bool
+	var natVal testmodule.Count
+	if natOk {
+		natVal, natValOk = nat.Value.(testmodule.Count)
+	}
+	if natValOk {
+		arg1Val = natVal
+	} else {
+		var u int
+		//ryegen:conv rye-to-go/builtin int
+		if vc, ok := arg1.(env.Integer); ok {
+			u = int(vc.Value)
+		} else {
+			ps.FailureFlag = true
+			return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"expected integer, but got "+objectDebugString(ps.Idx, arg1))
+		}
+		//ryegen:endconv
+		arg1Val = testmodule.Count(u)
+	}
+}
+//ryegen:endconv
+res0 := testmodule.SetLevel(arg0Val, arg1Val)
+var res0Obj env.Object
+//ryegen:conv go-to-rye/enum testmodule.Level
+switch res0 {
+case testmodule.LevelDebug:
+	res0Obj = *env.NewWord(ps.Idx.IndexWord("debug"))
+case testmodule.LevelInfo:
+	res0Obj = *env.NewWord(ps.Idx.IndexWord("info"))
+case testmodule.LevelWarn:
+	res0Obj = *env.NewWord(ps.Idx.IndexWord("warn"))
+default:
+	res0Obj = *env.NewInteger(int64(res0))
+}
+//ryegen:endconv
+return res0Obj
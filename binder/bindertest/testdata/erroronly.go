@@ -0,0 +1,16 @@
+package testfile
+
+type File struct{}
+
+func Remove(name string) error {
+	return nil
+}
+
+func (f *File) Close() error {
+	return nil
+}
+
+// Closer returns a func whose result follows the same contract.
+func Closer(f *File) func() error {
+	return f.Close
+}
@@ -0,0 +1,82 @@
+var arg0Val string
+//ryegen:conv rye-to-go/builtin string
+if vc, ok := arg0.(env.String); ok {
+	arg0Val = string(vc.Value)
+} else {
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected string, but got "+objectDebugString(ps.Idx, arg0))
+}
+//ryegen:endconv
+resErr := testmodule.Remove(arg0Val)
+if resErr != nil {
+	ps.FailureFlag = true
+	return errorToRye(ps.Idx, resErr)
+}
+return env.Void{}
+
+var arg0Val *testmodule.File
+//ryegen:conv rye-to-go/native *testmodule.File
+switch v := arg0.(type) {
+case env.Native:
+	if vc, ok := v.Value.(*testmodule.File); ok {
+		arg0Val = vc
+	} else {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native of type *testmodule.File, but got "+objectDebugString(ps.Idx, v))
+	}
+case env.Void:
+	arg0Val = nil
+case env.Integer:
+	if v.Value != 0 {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected integer to be 0 or nil, but got "+strconv.FormatInt(v.Value, 10))
+	}
+	arg0Val = nil
+default:
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native, but got "+objectDebugString(ps.Idx, v))
+}
+//ryegen:endconv
+resErr := arg0Val.Close()
+if resErr != nil {
+	ps.FailureFlag = true
+	return errorToRye(ps.Idx, resErr)
+}
+return arg0
+
+var arg0Val *testmodule.File
+//ryegen:conv rye-to-go/native *testmodule.File
+switch v := arg0.(type) {
+case env.Native:
+	if vc, ok := v.Value.(*testmodule.File); ok {
+		arg0Val = vc
+	} else {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native of type *testmodule.File, but got "+objectDebugString(ps.Idx, v))
+	}
+case env.Void:
+	arg0Val = nil
+case env.Integer:
+	if v.Value != 0 {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected integer to be 0 or nil, but got "+strconv.FormatInt(v.Value, 10))
+	}
+	arg0Val = nil
+default:
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native, but got "+objectDebugString(ps.Idx, v))
+}
+//ryegen:endconv
+res0 := testmodule.Closer(arg0Val)
+var res0Obj env.Object
+//ryegen:conv go-to-rye/func func() (error)
+res0Obj = *env.NewBuiltin(func(ps *env.ProgramState, arg0, arg1, arg2, arg3, arg4 env.Object) env.Object {
+	resErr := res0()
+	if resErr != nil {
+		ps.FailureFlag = true
+		return errorToRye(ps.Idx, resErr)
+	}
+	return env.Void{}
+}, 0, false, false, "Returned func")
+//ryegen:endconv
+return res0Obj
@@ -0,0 +1,6 @@
+package testfile
+
+type Counter struct{}
+
+// Add adds n to the counter.
+func (c *Counter) Add(n int) {}
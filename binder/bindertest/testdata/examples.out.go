@@ -0,0 +1,11 @@
+Add adds n to the counter.
+
+Args:
+ * recv - Go(*testmodule.Counter)
+ * n - integer
+Example (Go):
+    var c Counter
+    c.Add(1)
+    fmt.Println(c)
+    // Output:
+    // {1}
@@ -0,0 +1,7 @@
+package testmodule
+
+type Celsius struct {
+	Degrees float64
+}
+
+func IsWarm(c Celsius) bool { return c.Degrees > 20 }
@@ -0,0 +1,17 @@
+var arg0Val testmodule.Celsius
+//ryegen:conv rye-to-go/native testmodule.Celsius
+switch v := arg0.(type) {
+case env.Decimal:
+	arg0Val = testmodule.Celsius{Degrees: math.Round(v.Value)}
+default:
+	// Only whole placeholders are replaced, not e.g. "$inner".
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected decimal for $inner, but got "+objectDebugString(ps.Idx, v))
+}
+//ryegen:endconv
+res0 := testmodule.IsWarm(arg0Val)
+var res0Obj env.Object
+//ryegen:conv go-to-rye/builtin bool
+res0Obj = *env.NewInteger(boolToInt64(res0))
+//ryegen:endconv
+return res0Obj
@@ -0,0 +1,15 @@
+package testmodule
+
+type Point struct {
+	X, Y int
+}
+
+// Generic alias of a func type.
+type Handler[T any] = func(T) error
+
+// Generic alias of a non-generic type.
+type Tagged[T any] = Point
+
+func Handle(h Handler[string]) Handler[int] { return nil }
+
+func Move(p Tagged[string], dx int) Tagged[string] { return p }
@@ -0,0 +1,119 @@
+var arg0Val func(string) (error)
+//ryegen:conv rye-to-go/func func(string) (error)
+switch fn := arg0.(type) {
+case env.Function:
+	if fn.Argsn != 1 {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected 1 function arguments, but got "+strconv.Itoa(fn.Argsn))
+	}
+	arg0Val = func(farg0 string) (error) {
+		var farg0Val env.Object
+		//ryegen:conv go-to-rye/builtin string
+		farg0Val = *env.NewString(farg0)
+		//ryegen:endconv
+		actualFn := fn
+		_ = actualFn
+		evaldo.CallFunctionArgsN(fn, ps, ps.Ctx, farg0Val)
+		var res error
+		//ryegen:conv rye-to-go/builtin error
+		switch v := ps.Res.(type) {
+		case env.String:
+			res = errors.New(v.Value)
+		case env.Error:
+			res = errors.New(v.Print(*ps.Idx))
+		case *env.Error:
+			if err, ok := nativeError(v); ok {
+				res = err
+			} else {
+				res = errors.New(v.Print(*ps.Idx))
+			}
+		case env.Void:
+			res = nil
+		case env.Integer:
+			if v.Value != 0 {
+				ps.FailureFlag = true
+				fmt.Printf("\033[31mError: \033[1m%v\033[m\n\033[31mFrom function \033[1m%v { %v }\033[m\n",
+					"((RYEGEN:FUNCNAME)): arg 1: callback result: "+"expected integer to be 0 or nil, but got "+strconv.FormatInt(v.Value, 10),
+					actualFn.Spec.Series.PositionAndSurroundingElements(*ps.Idx),
+					actualFn.Body.Series.PositionAndSurroundingElements(*ps.Idx),
+				)
+				return res
+			}
+			res = nil
+		default:
+			ps.FailureFlag = true
+			fmt.Printf("\033[31mError: \033[1m%v\033[m\n\033[31mFrom function \033[1m%v { %v }\033[m\n",
+				"((RYEGEN:FUNCNAME)): arg 1: callback result: "+"expected error, string or nil, but got "+objectDebugString(ps.Idx, v),
+				actualFn.Spec.Series.PositionAndSurroundingElements(*ps.Idx),
+				actualFn.Body.Series.PositionAndSurroundingElements(*ps.Idx),
+			)
+			return res
+		}
+		//ryegen:endconv
+		return res
+	}
+case env.Void:
+	arg0Val = nil
+case env.Integer:
+	if fn.Value != 0 {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected integer to be 0 or nil, but got "+strconv.FormatInt(fn.Value, 10))
+	}
+	arg0Val = nil
+default:
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected function or nil, but got "+objectDebugString(ps.Idx, fn))
+}
+//ryegen:endconv
+res0 := testmodule.Handle(arg0Val)
+var res0Obj env.Object
+//ryegen:conv go-to-rye/func func(int) (error)
+res0Obj = *env.NewBuiltin(func(ps *env.ProgramState, arg0, arg1, arg2, arg3, arg4 env.Object) env.Object {
+	var arg0Val int
+	//ryegen:conv rye-to-go/builtin int
+	if vc, ok := arg0.(env.Integer); ok {
+		arg0Val = int(vc.Value)
+	} else {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected integer, but got "+objectDebugString(ps.Idx, arg0))
+	}
+	//ryegen:endconv
+	resErr := res0(arg0Val)
+	if resErr != nil {
+		ps.FailureFlag = true
+		return errorToRye(ps.Idx, resErr)
+	}
+	return env.Void{}
+}, 1, false, false, "Returned func")
+//ryegen:endconv
+return res0Obj
+var arg0Val testmodule.Point
+//ryegen:conv rye-to-go/native testmodule.Point
+switch v := arg0.(type) {
+case env.Native:
+	if vc, ok := v.Value.(*testmodule.Point); ok {
+		arg0Val = *vc
+	} else {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native of type *testmodule.Point, but got "+objectDebugString(ps.Idx, v))
+	}
+default:
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native, but got "+objectDebugString(ps.Idx, v))
+}
+//ryegen:endconv
+var arg1Val int
+//ryegen:conv rye-to-go/builtin int
+if vc, ok := arg1.(env.Integer); ok {
+	arg1Val = int(vc.Value)
+} else {
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"expected integer, but got "+objectDebugString(ps.Idx, arg1))
+}
+//ryegen:endconv
+res0 := testmodule.Move(arg0Val, arg1Val)
+var res0Obj env.Object
+//ryegen:conv go-to-rye/native testmodule.Point
+res0Obj = *env.NewNative(ps.Idx, &res0, "Go(*testmodule.Point)")
+//ryegen:endconv
+return res0Obj
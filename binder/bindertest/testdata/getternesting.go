@@ -0,0 +1,19 @@
+package testfile
+
+type Node struct {
+	Value    int
+	Children []Node
+}
+
+type Tree struct {
+	Root Node
+	Meta Meta
+}
+
+type Meta struct {
+	Size Size
+}
+
+type Size struct {
+	W, H int
+}
@@ -0,0 +1,104 @@
+var self *testmodule.Tree
+//ryegen:conv rye-to-go/native *testmodule.Tree
+switch v := arg0.(type) {
+case env.Native:
+	if vc, ok := v.Value.(*testmodule.Tree); ok {
+		self = vc
+	} else {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native of type *testmodule.Tree, but got "+objectDebugString(ps.Idx, v))
+	}
+case env.Void:
+	self = nil
+case env.Integer:
+	if v.Value != 0 {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected integer to be 0 or nil, but got "+strconv.FormatInt(v.Value, 10))
+	}
+	self = nil
+default:
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native, but got "+objectDebugString(ps.Idx, v))
+}
+//ryegen:endconv
+if self == nil {
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected non-nil native")
+}
+fieldCopy := self.Root
+var resObj env.Object
+//ryegen:conv go-to-rye/native *testmodule.Node
+resObj = *env.NewNative(ps.Idx, &fieldCopy, "Go(*testmodule.Node)")
+//ryegen:endconv
+return resObj
+
+//================================//
+
+var self *testmodule.Tree
+//ryegen:conv rye-to-go/native *testmodule.Tree
+switch v := arg0.(type) {
+case env.Native:
+	if vc, ok := v.Value.(*testmodule.Tree); ok {
+		self = vc
+	} else {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native of type *testmodule.Tree, but got "+objectDebugString(ps.Idx, v))
+	}
+case env.Void:
+	self = nil
+case env.Integer:
+	if v.Value != 0 {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected integer to be 0 or nil, but got "+strconv.FormatInt(v.Value, 10))
+	}
+	self = nil
+default:
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native, but got "+objectDebugString(ps.Idx, v))
+}
+//ryegen:endconv
+if self == nil {
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected non-nil native")
+}
+fieldCopy := self.Meta
+var resObj env.Object
+//ryegen:conv go-to-rye/native *testmodule.Meta
+resObj = *env.NewNative(ps.Idx, &fieldCopy, "Go(*testmodule.Meta)")
+//ryegen:endconv
+return resObj
+
+//================================//
+
+var self *testmodule.Meta
+//ryegen:conv rye-to-go/native *testmodule.Meta
+switch v := arg0.(type) {
+case env.Native:
+	if vc, ok := v.Value.(*testmodule.Meta); ok {
+		self = vc
+	} else {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native of type *testmodule.Meta, but got "+objectDebugString(ps.Idx, v))
+	}
+case env.Void:
+	self = nil
+case env.Integer:
+	if v.Value != 0 {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected integer to be 0 or nil, but got "+strconv.FormatInt(v.Value, 10))
+	}
+	self = nil
+default:
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native, but got "+objectDebugString(ps.Idx, v))
+}
+//ryegen:endconv
+if self == nil {
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected non-nil native")
+}
+var resObj env.Object
+//ryegen:conv go-to-rye/native *testmodule.Size
+resObj = *env.NewNative(ps.Idx, &self.Size, "Go(*testmodule.Size)")
+//ryegen:endconv
+return resObj
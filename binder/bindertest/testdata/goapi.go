@@ -0,0 +1,9 @@
+package testfile
+
+type Counter struct {
+	N int
+}
+
+func (c *Counter) Add(x int) (int, error) { return 0, nil }
+
+func Reset() {}
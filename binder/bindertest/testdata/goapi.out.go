@@ -0,0 +1,41 @@
+// ((RYEGEN:GOAPINAME)) calls the ((RYEGEN:FUNCNAME)) builtin ((*testmodule.Counter).Add) with Go values.
+func ((RYEGEN:GOAPINAME))(ps *env.ProgramState, p0 *testmodule.Counter, p1 int) (goRes int, goErr error) {
+	var arg0, arg1, arg2, arg3, arg4 env.Object
+	//ryegen:conv go-to-rye/native *testmodule.Counter
+	arg0 = *env.NewNative(ps.Idx, p0, "Go(*testmodule.Counter)")
+	//ryegen:endconv
+	//ryegen:conv go-to-rye/builtin int
+	arg1 = *env.NewInteger(int64(p1))
+	//ryegen:endconv
+	resObj := builtinsGenerated["((RYEGEN:FUNCNAME))"].Fn(ps, arg0, arg1, arg2, arg3, arg4)
+	if ps.FailureFlag || ps.ErrorFlag {
+		ps.FailureFlag = false
+		ps.ErrorFlag = false
+		goErr = errors.New(resObj.Inspect(*ps.Idx))
+		return
+	}
+	//ryegen:conv rye-to-go/builtin int
+	if vc, ok := resObj.(env.Integer); ok {
+		goRes = int(vc.Value)
+	} else {
+		goErr = errors.New("((RYEGEN:FUNCNAME)): result: "+"expected integer, but got "+objectDebugString(ps.Idx, resObj))
+		return
+	}
+	//ryegen:endconv
+	return
+}
+
+//================================//
+
+// ((RYEGEN:GOAPINAME)) calls the ((RYEGEN:FUNCNAME)) builtin (testmodule.Reset) with Go values.
+func ((RYEGEN:GOAPINAME))(ps *env.ProgramState) (goErr error) {
+	var arg0, arg1, arg2, arg3, arg4 env.Object
+	resObj := builtinsGenerated["((RYEGEN:FUNCNAME))"].Fn(ps, arg0, arg1, arg2, arg3, arg4)
+	if ps.FailureFlag || ps.ErrorFlag {
+		ps.FailureFlag = false
+		ps.ErrorFlag = false
+		goErr = errors.New(resObj.Inspect(*ps.Idx))
+		return
+	}
+	return
+}
@@ -1,5 +1,6 @@
 res0 := testmodule.MakeChan()
 var res0Obj env.Object
+//ryegen:conv go-to-rye/chan chan int
 if res0 != nil {
 	ch := make(chan *env.Object)
 	go func() {
@@ -11,6 +12,7 @@ if res0 != nil {
 					return
 				}
 				var ov int
+				//ryegen:conv rye-to-go/builtin int
 				if vc, ok := (*v).(env.Integer); ok {
 					ov = int(vc.Value)
 				} else {
@@ -20,6 +22,7 @@ if res0 != nil {
 					)
 					return
 				}
+				//ryegen:endconv
 				res0 <- ov
 			case v, ok := <-res0:
 				if !ok {
@@ -27,18 +30,22 @@ if res0 != nil {
 					return
 				}
 				var ov env.Object
+				//ryegen:conv go-to-rye/builtin int
 				ov = *env.NewInteger(int64(v))
+				//ryegen:endconv
 				ch <- &ov
 			}
 		}
 	}()
 	res0Obj = *env.NewNative(ps.Idx, ch, "Rye-channel")
 }
+//ryegen:endconv
 return res0Obj
 
 //================================//
 
 var arg0Val chan int
+//ryegen:conv rye-to-go/chan chan int
 switch v := arg0.(type) {
 case env.Native:
 	ch, ok := v.Value.(chan *env.Object)
@@ -55,6 +62,7 @@ case env.Native:
 					return
 				}
 				var ov int
+				//ryegen:conv rye-to-go/builtin int
 				if vc, ok := (*v).(env.Integer); ok {
 					ov = int(vc.Value)
 				} else {
@@ -64,6 +72,7 @@ case env.Native:
 					)
 					return
 				}
+				//ryegen:endconv
 				arg0Val <- ov
 			case v, ok := <-arg0Val:
 				if !ok {
@@ -71,11 +80,15 @@ case env.Native:
 					return
 				}
 				var ov env.Object
+				//ryegen:conv go-to-rye/builtin int
 				ov = *env.NewInteger(int64(v))
+				//ryegen:endconv
 				ch <- &ov
 			}
 		}
 	}()
+case env.Void:
+	arg0Val = nil
 case env.Integer:
 	if v.Value != 0 {
 		ps.FailureFlag = true
@@ -83,5 +96,6 @@ case env.Integer:
 	}
 	arg0Val = nil
 }
+//ryegen:endconv
 testmodule.UseChan(arg0Val)
 return nil
@@ -0,0 +1,7 @@
+// Package testfile greets people. It has a synopsis
+// spanning two lines.
+//
+// The rest of the package doc isn't part of the help text.
+package testfile
+
+func Hello(name string) string { return "Hello, " + name }
@@ -0,0 +1,21 @@
+package testfile
+
+type Handle struct {
+	ID int
+}
+
+func Register(h *Handle) *Handle { return h }
+
+type Counter int
+
+func (c *Counter) Inc() {}
+
+func Track(c *Counter) *Counter { return c }
+
+type Opaque int
+
+func Keep(p *Opaque) *Opaque { return p }
+
+func Collect(hs []*Handle, byName map[string]*Handle) ([]*Handle, map[string]*Handle) {
+	return hs, byName
+}
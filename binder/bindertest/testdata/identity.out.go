@@ -0,0 +1,247 @@
+var arg0Val *testmodule.Handle
+//ryegen:conv rye-to-go/native *testmodule.Handle
+switch v := arg0.(type) {
+case env.Native:
+	if vc, ok := v.Value.(*testmodule.Handle); ok {
+		arg0Val = vc
+	} else {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native of type *testmodule.Handle, but got "+objectDebugString(ps.Idx, v))
+	}
+case env.Void:
+	arg0Val = nil
+case env.Integer:
+	if v.Value != 0 {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected integer to be 0 or nil, but got "+strconv.FormatInt(v.Value, 10))
+	}
+	arg0Val = nil
+default:
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native, but got "+objectDebugString(ps.Idx, v))
+}
+//ryegen:endconv
+res0 := testmodule.Register(arg0Val)
+var res0Obj env.Object
+//ryegen:conv go-to-rye/native *testmodule.Handle
+res0Obj = *env.NewNative(ps.Idx, res0, "Go(*testmodule.Handle)")
+//ryegen:endconv
+return res0Obj
+var arg0Val *testmodule.Counter
+//ryegen:conv rye-to-go/native *testmodule.Counter
+switch v := arg0.(type) {
+case env.Native:
+	if vc, ok := v.Value.(*testmodule.Counter); ok {
+		arg0Val = vc
+	} else {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native of type *testmodule.Counter, but got "+objectDebugString(ps.Idx, v))
+	}
+case env.Void:
+	arg0Val = nil
+case env.Integer:
+	if v.Value != 0 {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected integer to be 0 or nil, but got "+strconv.FormatInt(v.Value, 10))
+	}
+	arg0Val = nil
+default:
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native, but got "+objectDebugString(ps.Idx, v))
+}
+//ryegen:endconv
+res0 := testmodule.Track(arg0Val)
+var res0Obj env.Object
+//ryegen:conv go-to-rye/native *testmodule.Counter
+res0Obj = *env.NewNative(ps.Idx, res0, "Go(*testmodule.Counter)")
+//ryegen:endconv
+return res0Obj
+var arg0Val *testmodule.Opaque
+//ryegen:conv rye-to-go/native *testmodule.Opaque
+switch v := arg0.(type) {
+case env.Native:
+	if vc, ok := v.Value.(*testmodule.Opaque); ok {
+		arg0Val = vc
+	} else {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native of type *testmodule.Opaque, but got "+objectDebugString(ps.Idx, v))
+	}
+case env.Void:
+	arg0Val = nil
+case env.Integer:
+	if v.Value != 0 {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected integer to be 0 or nil, but got "+strconv.FormatInt(v.Value, 10))
+	}
+	arg0Val = nil
+default:
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native, but got "+objectDebugString(ps.Idx, v))
+}
+//ryegen:endconv
+res0 := testmodule.Keep(arg0Val)
+var res0Obj env.Object
+//ryegen:conv go-to-rye/native *testmodule.Opaque
+res0Obj = *env.NewNative(ps.Idx, res0, "Go(*testmodule.Opaque)")
+//ryegen:endconv
+return res0Obj
+var arg0Val []*testmodule.Handle
+//ryegen:conv rye-to-go/array []*testmodule.Handle
+switch v := arg0.(type) {
+case env.Block:
+	arg0Val = make([]*testmodule.Handle, len(v.Series.S))
+	for i, it := range v.Series.S {
+		iv := &arg0Val[i]
+		//ryegen:conv rye-to-go/native *testmodule.Handle
+		switch v := it.(type) {
+		case env.Native:
+			if vc, ok := v.Value.(*testmodule.Handle); ok {
+				(*iv) = vc
+			} else {
+				ps.FailureFlag = true
+				return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"block item "+strconv.Itoa(i)+": "+"expected native of type *testmodule.Handle, but got "+objectDebugString(ps.Idx, v))
+			}
+		case env.Void:
+			(*iv) = nil
+		case env.Integer:
+			if v.Value != 0 {
+				ps.FailureFlag = true
+				return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"block item "+strconv.Itoa(i)+": "+"expected integer to be 0 or nil, but got "+strconv.FormatInt(v.Value, 10))
+			}
+			(*iv) = nil
+		default:
+			ps.FailureFlag = true
+			return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"block item "+strconv.Itoa(i)+": "+"expected native, but got "+objectDebugString(ps.Idx, v))
+		}
+		//ryegen:endconv
+	}
+case env.Void:
+	arg0Val = nil
+case env.Integer:
+	if v.Value != 0 {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected integer to be 0 or nil, but got "+strconv.FormatInt(v.Value, 10))
+	}
+	arg0Val = nil
+default:
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected block or nil, but got "+objectDebugString(ps.Idx, v))
+}
+//ryegen:endconv
+var arg1Val map[string]*testmodule.Handle
+//ryegen:conv rye-to-go/map map[string]*testmodule.Handle
+switch v := arg1.(type) {
+case env.Block:
+	if len(v.Series.S) % 2 != 0 {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"expected block to have length of multiple of 2, but got block with length "+strconv.Itoa(len(v.Series.S)))
+	}
+	arg1Val = make(map[string]*testmodule.Handle, len(v.Series.S)/2)
+	for i := 0; i < len(v.Series.S); i += 2 {
+		var mapK string
+		//ryegen:conv rye-to-go/builtin string
+		if vc, ok := v.Series.S[i+0].(env.String); ok {
+			mapK = string(vc.Value)
+		} else {
+			ps.FailureFlag = true
+			return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"map key: "+"expected string, but got "+objectDebugString(ps.Idx, v.Series.S[i+0]))
+		}
+		//ryegen:endconv
+		var mapV *testmodule.Handle
+		//ryegen:conv rye-to-go/native *testmodule.Handle
+		switch v := v.Series.S[i+1].(type) {
+		case env.Native:
+			if vc, ok := v.Value.(*testmodule.Handle); ok {
+				mapV = vc
+			} else {
+				ps.FailureFlag = true
+				return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"map value: "+"expected native of type *testmodule.Handle, but got "+objectDebugString(ps.Idx, v))
+			}
+		case env.Void:
+			mapV = nil
+		case env.Integer:
+			if v.Value != 0 {
+				ps.FailureFlag = true
+				return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"map value: "+"expected integer to be 0 or nil, but got "+strconv.FormatInt(v.Value, 10))
+			}
+			mapV = nil
+		default:
+			ps.FailureFlag = true
+			return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"map value: "+"expected native, but got "+objectDebugString(ps.Idx, v))
+		}
+		//ryegen:endconv
+		arg1Val[mapK] = mapV
+	}
+case env.Dict:
+	arg1Val = make(map[string]*testmodule.Handle, len(v.Data))
+	for dictK, dictV := range v.Data {
+		mapK := dictK
+		var mapV *testmodule.Handle
+		//ryegen:conv rye-to-go/native *testmodule.Handle
+		switch v := dictV.(type) {
+		case env.Native:
+			if vc, ok := v.Value.(*testmodule.Handle); ok {
+				mapV = vc
+			} else {
+				ps.FailureFlag = true
+				return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"map value: "+"expected native of type *testmodule.Handle, but got "+objectDebugString(ps.Idx, v))
+			}
+		case env.Void:
+			mapV = nil
+		case env.Integer:
+			if v.Value != 0 {
+				ps.FailureFlag = true
+				return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"map value: "+"expected integer to be 0 or nil, but got "+strconv.FormatInt(v.Value, 10))
+			}
+			mapV = nil
+		default:
+			ps.FailureFlag = true
+			return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"map value: "+"expected native, but got "+objectDebugString(ps.Idx, v))
+		}
+		//ryegen:endconv
+		arg1Val[mapK] = mapV
+	}
+case env.Void:
+	arg1Val = nil
+case env.Integer:
+	if v.Value != 0 {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"expected integer to be 0 or nil, but got "+strconv.FormatInt(v.Value, 10))
+	}
+	arg1Val = nil
+default:
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"expected block, dict or nil, but got "+objectDebugString(ps.Idx, v))
+}
+//ryegen:endconv
+res0, res1 := testmodule.Collect(arg0Val, arg1Val)
+var res0Obj env.Object
+//ryegen:conv go-to-rye/array []*testmodule.Handle
+{
+	items := make([]env.Object, len(res0))
+	for i, it := range res0 {
+		//ryegen:conv go-to-rye/native *testmodule.Handle
+		items[i] = *env.NewNative(ps.Idx, it, "Go(*testmodule.Handle)")
+		//ryegen:endconv
+	}
+	res0Obj = *env.NewBlock(*env.NewTSeries(items))
+}
+//ryegen:endconv
+var res1Obj env.Object
+//ryegen:conv go-to-rye/map map[string]*testmodule.Handle
+{
+	data := make(map[string]any, len(res1))
+	for mKey, mVal := range res1 {
+		var dVal env.Object
+		//ryegen:conv go-to-rye/native *testmodule.Handle
+		dVal = *env.NewNative(ps.Idx, mVal, "Go(*testmodule.Handle)")
+		//ryegen:endconv
+		data[mKey] = dVal
+	}
+	res1Obj = *env.NewDict(data)
+}
+//ryegen:endconv
+return *env.NewBlock(*env.NewTSeries([]env.Object{
+	res0Obj,
+	res1Obj,
+}))
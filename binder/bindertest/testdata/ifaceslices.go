@@ -0,0 +1,13 @@
+package testfile
+
+type Shape interface {
+	Area() float64
+}
+
+type Square struct {
+	Side float64
+}
+
+func (s *Square) Area() float64 { return s.Side * s.Side }
+
+func TotalArea(shapes []Shape) float64 { return 0 }
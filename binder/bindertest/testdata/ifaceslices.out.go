@@ -0,0 +1,56 @@
+var arg0Val []testmodule.Shape
+//ryegen:conv rye-to-go/array []testmodule.Shape
+switch v := arg0.(type) {
+case env.Block:
+	arg0Val = make([]testmodule.Shape, len(v.Series.S))
+	for i, it := range v.Series.S {
+		iv := &arg0Val[i]
+		//ryegen:conv rye-to-go/native testmodule.Shape
+		switch v := it.(type) {
+		case env.RyeCtx:
+			var err error
+			(*iv), err = ctxTo_Shape(ps, v)
+			if err != nil {
+				ps.FailureFlag = true
+				return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"block item "+strconv.Itoa(i)+": "+err.Error())
+			}
+		case env.Native:
+			if vc, ok := v.Value.(testmodule.Shape); ok {
+				(*iv) = vc
+			} else {
+				ps.FailureFlag = true
+				return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"block item "+strconv.Itoa(i)+": "+"expected native implementing testmodule.Shape, but got "+objectDebugString(ps.Idx, v))
+			}
+		case env.Void:
+			(*iv) = nil
+		case env.Integer:
+			if v.Value != 0 {
+				ps.FailureFlag = true
+				return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"block item "+strconv.Itoa(i)+": "+"expected integer to be 0 or nil, but got "+strconv.FormatInt(v.Value, 10))
+			}
+			(*iv) = nil
+		default:
+			ps.FailureFlag = true
+			return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"block item "+strconv.Itoa(i)+": "+"expected native or context implementing testmodule.Shape, but got "+objectDebugString(ps.Idx, v))
+		}
+		//ryegen:endconv
+	}
+case env.Void:
+	arg0Val = nil
+case env.Integer:
+	if v.Value != 0 {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected integer to be 0 or nil, but got "+strconv.FormatInt(v.Value, 10))
+	}
+	arg0Val = nil
+default:
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected block or nil, but got "+objectDebugString(ps.Idx, v))
+}
+//ryegen:endconv
+res0 := testmodule.TotalArea(arg0Val)
+var res0Obj env.Object
+//ryegen:conv go-to-rye/builtin float64
+res0Obj = *env.NewDecimal(float64(res0))
+//ryegen:endconv
+return res0Obj
@@ -0,0 +1,14 @@
+package testfile
+
+type Point struct {
+	X, Y  int
+	Scale float64
+	Label string
+	Tags  []string
+	Next  *Point
+}
+
+type Node struct {
+	Children []*Node
+	Parent   *Node
+}
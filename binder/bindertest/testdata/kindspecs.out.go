@@ -0,0 +1 @@
+testmodule-point-kind: kind 'testmodule-point { x: optional 0 integer y: optional 0 integer scale: optional 0.0 decimal label: optional "" string }
\ No newline at end of file
@@ -0,0 +1,5 @@
+package testfile
+
+type Button struct{}
+
+func (b *Button) SetText(text string) {}
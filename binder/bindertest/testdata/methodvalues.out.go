@@ -0,0 +1,37 @@
+var self *testmodule.Button
+//ryegen:conv rye-to-go/native *testmodule.Button
+switch v := arg0.(type) {
+case env.Native:
+	if vc, ok := v.Value.(*testmodule.Button); ok {
+		self = vc
+	} else {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native of type *testmodule.Button, but got "+objectDebugString(ps.Idx, v))
+	}
+case env.Void:
+	self = nil
+case env.Integer:
+	if v.Value != 0 {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected integer to be 0 or nil, but got "+strconv.FormatInt(v.Value, 10))
+	}
+	self = nil
+default:
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native, but got "+objectDebugString(ps.Idx, v))
+}
+//ryegen:endconv
+methodValue := self.SetText
+return *env.NewBuiltin(func(ps *env.ProgramState, arg0, arg1, arg2, arg3, arg4 env.Object) env.Object {
+	var arg0Val string
+	//ryegen:conv rye-to-go/builtin string
+	if vc, ok := arg0.(env.String); ok {
+		arg0Val = string(vc.Value)
+	} else {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected string, but got "+objectDebugString(ps.Idx, arg0))
+	}
+	//ryegen:endconv
+	methodValue(arg0Val)
+	return nil
+}, 1, false, false, "(*testmodule.Button).SetText")
@@ -0,0 +1,9 @@
+package testfile
+
+func SplitHostPort(hostport string) (host, port string, portNum int, err error) {
+	return "", "", 0, nil
+}
+
+func MinMax(xs []int) (int, int) {
+	return 0, 0
+}
@@ -0,0 +1,91 @@
+Args:
+ * hostport - string
+Result:
+{
+    host: string
+    port: string
+    port-num: integer
+}
+ * error
+hostport:string -> {host:string port:string port-num:integer} error
+var arg0Val string
+//ryegen:conv rye-to-go/builtin string
+if vc, ok := arg0.(env.String); ok {
+	arg0Val = string(vc.Value)
+} else {
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected string, but got "+objectDebugString(ps.Idx, arg0))
+}
+//ryegen:endconv
+res0, res1, res2, resErr := testmodule.SplitHostPort(arg0Val)
+if resErr != nil {
+	ps.FailureFlag = true
+	return errorToRye(ps.Idx, resErr)
+}
+var res0Obj env.Object
+//ryegen:conv go-to-rye/builtin string
+res0Obj = *env.NewString(res0)
+//ryegen:endconv
+var res1Obj env.Object
+//ryegen:conv go-to-rye/builtin string
+res1Obj = *env.NewString(res1)
+//ryegen:endconv
+var res2Obj env.Object
+//ryegen:conv go-to-rye/builtin int
+res2Obj = *env.NewInteger(int64(res2))
+//ryegen:endconv
+return *env.NewDict(map[string]any{
+	"host": res0Obj,
+	"port": res1Obj,
+	"port-num": res2Obj,
+})
+Args:
+ * xs - block[integer]
+Result:
+[
+    integer
+    integer
+]
+xs:block[integer] -> [integer integer]
+var arg0Val []int
+//ryegen:conv rye-to-go/array []int
+switch v := arg0.(type) {
+case env.Block:
+	arg0Val = make([]int, len(v.Series.S))
+	for i, it := range v.Series.S {
+		iv := &arg0Val[i]
+		//ryegen:conv rye-to-go/builtin int
+		if vc, ok := it.(env.Integer); ok {
+			(*iv) = int(vc.Value)
+		} else {
+			ps.FailureFlag = true
+			return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"block item "+strconv.Itoa(i)+": "+"expected integer, but got "+objectDebugString(ps.Idx, it))
+		}
+		//ryegen:endconv
+	}
+case env.Void:
+	arg0Val = nil
+case env.Integer:
+	if v.Value != 0 {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected integer to be 0 or nil, but got "+strconv.FormatInt(v.Value, 10))
+	}
+	arg0Val = nil
+default:
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected block or nil, but got "+objectDebugString(ps.Idx, v))
+}
+//ryegen:endconv
+res0, res1 := testmodule.MinMax(arg0Val)
+var res0Obj env.Object
+//ryegen:conv go-to-rye/builtin int
+res0Obj = *env.NewInteger(int64(res0))
+//ryegen:endconv
+var res1Obj env.Object
+//ryegen:conv go-to-rye/builtin int
+res1Obj = *env.NewInteger(int64(res1))
+//ryegen:endconv
+return *env.NewBlock(*env.NewTSeries([]env.Object{
+	res0Obj,
+	res1Obj,
+}))
@@ -0,0 +1,20 @@
+package testfile
+
+type TestmoduleClient struct{}
+
+func NewTestmoduleClient() *TestmoduleClient { return nil }
+
+func TestmoduleServe() {}
+
+func Serve() {}
+
+func Open() {}
+
+// Stutter is removed going by the package name, not the unique module
+// name.
+
+type TestfileClient struct{}
+
+func NewTestfileClient() *TestfileClient { return nil }
+
+func TestfileServe() {}
@@ -0,0 +1,5 @@
+package testfile
+
+func SetLevels(a uint8, b int16, c uint64, d int) {
+	_, _, _, _ = a, b, c, d
+}
@@ -0,0 +1,76 @@
+var arg0Val uint8
+//ryegen:conv rye-to-go/builtin uint8
+if vc, ok := arg0.(env.Integer); ok {
+	switch newConvCtx(ps).numericChecks() {
+	case "strict":
+		if vc.Value < 0 || vc.Value > math.MaxUint8 {
+			ps.FailureFlag = true
+			return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"value "+strconv.FormatInt(vc.Value, 10)+" out of range for uint8")
+		}
+	case "wrap":
+		if vc.Value < math.MinInt8 || vc.Value > math.MaxUint8 {
+			ps.FailureFlag = true
+			return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"value "+strconv.FormatInt(vc.Value, 10)+" out of range for uint8")
+		}
+	}
+	arg0Val = uint8(vc.Value)
+} else {
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected integer, but got "+objectDebugString(ps.Idx, arg0))
+}
+//ryegen:endconv
+var arg1Val int16
+//ryegen:conv rye-to-go/builtin int16
+if vc, ok := arg1.(env.Integer); ok {
+	switch newConvCtx(ps).numericChecks() {
+	case "strict":
+		if vc.Value < math.MinInt16 || vc.Value > math.MaxInt16 {
+			ps.FailureFlag = true
+			return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"value "+strconv.FormatInt(vc.Value, 10)+" out of range for int16")
+		}
+	case "wrap":
+		if vc.Value < math.MinInt16 || vc.Value > math.MaxUint16 {
+			ps.FailureFlag = true
+			return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"value "+strconv.FormatInt(vc.Value, 10)+" out of range for int16")
+		}
+	}
+	arg1Val = int16(vc.Value)
+} else {
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"expected integer, but got "+objectDebugString(ps.Idx, arg1))
+}
+//ryegen:endconv
+var arg2Val uint64
+//ryegen:conv rye-to-go/builtin uint64
+if vc, ok := arg2.(env.Integer); ok {
+	switch newConvCtx(ps).numericChecks() {
+	case "strict":
+		if vc.Value < 0 {
+			ps.FailureFlag = true
+			return env.NewError("((RYEGEN:FUNCNAME)): arg 3: "+"value "+strconv.FormatInt(vc.Value, 10)+" out of range for uint64")
+		}
+	}
+	arg2Val = uint64(vc.Value)
+} else if vc, ok := arg2.(env.String); ok {
+	u, err := strconv.ParseUint(vc.Value, 10, 64)
+	if err != nil {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 3: "+err.Error())
+	}
+	arg2Val = uint64(u)
+} else {
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 3: "+"expected integer or string, but got "+objectDebugString(ps.Idx, arg2))
+}
+//ryegen:endconv
+var arg3Val int
+//ryegen:conv rye-to-go/builtin int
+if vc, ok := arg3.(env.Integer); ok {
+	arg3Val = int(vc.Value)
+} else {
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 4: "+"expected integer, but got "+objectDebugString(ps.Idx, arg3))
+}
+//ryegen:endconv
+testmodule.SetLevels(arg0Val, arg1Val, arg2Val, arg3Val)
+return nil
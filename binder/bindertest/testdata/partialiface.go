@@ -0,0 +1,6 @@
+package testfile
+
+type ResponseWriter interface {
+	Write(b []byte) (int, error)
+	WriteHeader(statusCode int)
+}
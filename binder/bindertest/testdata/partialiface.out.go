@@ -0,0 +1,164 @@
+type iface_ResponseWriter struct {
+	self env.RyeCtx
+	fn_Write func(self env.RyeCtx, arg0 []byte) (int, error)
+	fn_WriteHeader func(self env.RyeCtx, arg0 int)
+}
+
+func (self *iface_ResponseWriter) Write(arg0 []byte) (int, error) {
+	return self.fn_Write(self.self, arg0)
+}
+
+func (self *iface_ResponseWriter) WriteHeader(arg0 int) {
+	self.fn_WriteHeader(self.self, arg0)
+}
+
+func ctxTo_ResponseWriter(ps *env.ProgramState, v env.RyeCtx) (testmodule.ResponseWriter, error) {
+	words := v.GetWords(*ps.Idx).Series.S
+	wordToObj := make(map[string]env.Object, len(words))
+	for _, word := range words {
+		name := word.(env.String).Value
+		idx, ok := ps.Idx.GetIndex(name)
+		if !ok {
+			panic("expected valid word")
+		}
+		obj, ok := v.Get(idx)
+		if !ok {
+			panic("expected valid index")
+		}
+		wordToObj[name] = obj
+	}
+	impl := &iface_ResponseWriter{
+		self: v,
+	}
+	var missing []string
+	ctxObj0, ok := wordToObj["write"]
+	if !ok {
+		missing = append(missing, "Write")
+		impl.fn_Write = func(self env.RyeCtx, arg0 []byte) (res0 int, res1 error) {
+			res1 = errors.New("context to testmodule.ResponseWriter: method Write not implemented by context (missing: "+strings.Join(missing, ", ")+")")
+			return
+		}
+	} else {
+		switch fn := ctxObj0.(type) {
+		case env.Function:
+			if fn.Argsn != 1 {
+				return nil, errors.New("context to testmodule.ResponseWriter: context fn Write: "+"expected 1 function arguments, but got "+strconv.Itoa(fn.Argsn))
+			}
+			impl.fn_Write = func(ctx env.RyeCtx, farg0 []byte) (int, error) {
+				var farg0Val env.Object
+				//ryegen:conv go-to-rye/array []byte
+				{
+					items := make([]env.Object, len(farg0))
+					for i, it := range farg0 {
+						//ryegen:conv go-to-rye/builtin byte
+						items[i] = *env.NewInteger(int64(it))
+						//ryegen:endconv
+					}
+					farg0Val = *env.NewBlock(*env.NewTSeries(items))
+				}
+				//ryegen:endconv
+				actualFn := fn
+				_ = actualFn
+				evaldo.CallFunctionArgsN(fn, ps, &ctx, farg0Val)
+				var res0 int
+				var res1 error
+				res, ok := ps.Res.(env.Block)
+				if !ok {
+					ps.FailureFlag = true
+					fmt.Printf("\033[31mError: \033[1m%v\033[m\n\033[31mFrom function \033[1m%v { %v }\033[m\n",
+						"((RYEGEN:FUNCNAME)): arg 0: callback result: "+"expected block for multiple return values, but got "+objectDebugString(ps.Idx, ps.Res),
+						actualFn.Spec.Series.PositionAndSurroundingElements(*ps.Idx),
+						actualFn.Body.Series.PositionAndSurroundingElements(*ps.Idx),
+					)
+					return res0, res1
+				}
+				if len(res.Series.S) != 2 {
+					ps.FailureFlag = true
+					fmt.Printf("\033[31mError: \033[1m%v\033[m\n\033[31mFrom function \033[1m%v { %v }\033[m\n",
+						"((RYEGEN:FUNCNAME)): arg 0: callback result: "+"expected block with 2 return values, but got "+strconv.Itoa(len(res.Series.S))+" return values",
+						actualFn.Spec.Series.PositionAndSurroundingElements(*ps.Idx),
+						actualFn.Body.Series.PositionAndSurroundingElements(*ps.Idx),
+					)
+					return res0, res1
+				}
+				//ryegen:conv rye-to-go/builtin int
+				if vc, ok := res.Series.S[0].(env.Integer); ok {
+					res0 = int(vc.Value)
+				} else {
+					ps.FailureFlag = true
+					fmt.Printf("\033[31mError: \033[1m%v\033[m\n\033[31mFrom function \033[1m%v { %v }\033[m\n",
+						"((RYEGEN:FUNCNAME)): arg 0: callback result: "+"expected integer, but got "+objectDebugString(ps.Idx, res.Series.S[0]),
+						actualFn.Spec.Series.PositionAndSurroundingElements(*ps.Idx),
+						actualFn.Body.Series.PositionAndSurroundingElements(*ps.Idx),
+					)
+					return res0, res1
+				}
+				//ryegen:endconv
+				//ryegen:conv rye-to-go/builtin error
+				switch v := res.Series.S[1].(type) {
+				case env.String:
+					res1 = errors.New(v.Value)
+				case env.Error:
+					res1 = errors.New(v.Print(*ps.Idx))
+				case *env.Error:
+					if err, ok := nativeError(v); ok {
+						res1 = err
+					} else {
+						res1 = errors.New(v.Print(*ps.Idx))
+					}
+				case env.Void:
+					res1 = nil
+				case env.Integer:
+					if v.Value != 0 {
+						ps.FailureFlag = true
+						fmt.Printf("\033[31mError: \033[1m%v\033[m\n\033[31mFrom function \033[1m%v { %v }\033[m\n",
+							"((RYEGEN:FUNCNAME)): arg 0: callback result: "+"expected integer to be 0 or nil, but got "+strconv.FormatInt(v.Value, 10),
+							actualFn.Spec.Series.PositionAndSurroundingElements(*ps.Idx),
+							actualFn.Body.Series.PositionAndSurroundingElements(*ps.Idx),
+						)
+						return res0, res1
+					}
+					res1 = nil
+				default:
+					ps.FailureFlag = true
+					fmt.Printf("\033[31mError: \033[1m%v\033[m\n\033[31mFrom function \033[1m%v { %v }\033[m\n",
+						"((RYEGEN:FUNCNAME)): arg 0: callback result: "+"expected error, string or nil, but got "+objectDebugString(ps.Idx, v),
+						actualFn.Spec.Series.PositionAndSurroundingElements(*ps.Idx),
+						actualFn.Body.Series.PositionAndSurroundingElements(*ps.Idx),
+					)
+					return res0, res1
+				}
+				//ryegen:endconv
+				return res0, res1
+			}
+		default:
+			return nil, errors.New("context to testmodule.ResponseWriter: context fn Write: "+"expected function, but got "+objectDebugString(ps.Idx, fn))
+		}
+	}
+	ctxObj1, ok := wordToObj["write-header"]
+	if !ok {
+		missing = append(missing, "WriteHeader")
+		impl.fn_WriteHeader = func(self env.RyeCtx, arg0 int) {
+		}
+	} else {
+		switch fn := ctxObj1.(type) {
+		case env.Function:
+			if fn.Argsn != 1 {
+				return nil, errors.New("context to testmodule.ResponseWriter: context fn WriteHeader: "+"expected 1 function arguments, but got "+strconv.Itoa(fn.Argsn))
+			}
+			impl.fn_WriteHeader = func(ctx env.RyeCtx, farg0 int) {
+				var farg0Val env.Object
+				//ryegen:conv go-to-rye/builtin int
+				farg0Val = *env.NewInteger(int64(farg0))
+				//ryegen:endconv
+				actualFn := fn
+				_ = actualFn
+				evaldo.CallFunctionArgsN(fn, ps, &ctx, farg0Val)
+			}
+		default:
+			return nil, errors.New("context to testmodule.ResponseWriter: context fn WriteHeader: "+"expected function, but got "+objectDebugString(ps.Idx, fn))
+		}
+	}
+	return impl, nil
+}
+
@@ -0,0 +1,11 @@
+package testfile
+
+import "reflect"
+
+func Encode(v reflect.Value, t reflect.Type) error {
+	return nil
+}
+
+func TypeByName(name string) reflect.Type {
+	return nil
+}
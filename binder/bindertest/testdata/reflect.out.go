@@ -0,0 +1,123 @@
+var arg0Val reflect.Value
+//ryegen:conv rye-to-go/reflect reflect.Value
+switch v := arg0.(type) {
+case env.Native:
+	if vc, ok := v.Value.(reflect.Value); ok {
+		arg0Val = vc
+	} else {
+		arg0Val = reflect.ValueOf(v.Value)
+	}
+case env.Void:
+	arg0Val = reflect.Value{}
+default:
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native or nil, but got "+objectDebugString(ps.Idx, v))
+}
+//ryegen:endconv
+var arg1Val reflect.Type
+//ryegen:conv rye-to-go/reflect reflect.Type
+switch v := arg1.(type) {
+case env.Native:
+	if vc, ok := v.Value.(reflect.Type); ok {
+		arg1Val = vc
+	} else {
+		arg1Val = reflect.TypeOf(v.Value)
+	}
+case env.Void:
+	arg1Val = nil
+case env.Integer:
+	if v.Value != 0 {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"expected integer to be 0 or nil, but got "+strconv.FormatInt(v.Value, 10))
+	}
+	arg1Val = nil
+default:
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"expected native or nil, but got "+objectDebugString(ps.Idx, v))
+}
+//ryegen:endconv
+resErr := testmodule.Encode(arg0Val, arg1Val)
+if resErr != nil {
+	ps.FailureFlag = true
+	return errorToRye(ps.Idx, resErr)
+}
+return env.Void{}
+
+//================================//
+
+var arg0Val string
+//ryegen:conv rye-to-go/builtin string
+if vc, ok := arg0.(env.String); ok {
+	arg0Val = string(vc.Value)
+} else {
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected string, but got "+objectDebugString(ps.Idx, arg0))
+}
+//ryegen:endconv
+res0 := testmodule.TypeByName(arg0Val)
+var res0Obj env.Object
+//ryegen:conv go-to-rye/reflect reflect.Type
+if res0 == nil {
+	res0Obj = env.Void{}
+} else {
+	res0Obj = *env.NewNative(ps.Idx, res0, "Go(reflect.Type)")
+}
+//ryegen:endconv
+return res0Obj
+
+//================================//
+
+nat, ok := arg0.(env.Native)
+if !ok {
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native, but got "+objectDebugString(ps.Idx, arg0))
+}
+rv, ok := nat.Value.(reflect.Value)
+if !ok {
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native of type reflect.Value, but got "+objectDebugString(ps.Idx, arg0))
+}
+if !rv.IsValid() {
+	return env.Void{}
+}
+if !rv.CanInterface() {
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"value of unexported field")
+}
+var resObj env.Object
+switch rv.Kind() {
+case reflect.Bool:
+	//ryegen:conv go-to-rye/builtin bool
+	resObj = *env.NewInteger(boolToInt64(rv.Bool()))
+	//ryegen:endconv
+	return resObj
+case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+	//ryegen:conv go-to-rye/builtin int64
+	resObj = *env.NewInteger(int64(rv.Int()))
+	//ryegen:endconv
+	return resObj
+case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+	//ryegen:conv go-to-rye/builtin uint64
+	if uint64(rv.Uint()) > math.MaxInt64 {
+		resObj = *env.NewString(strconv.FormatUint(uint64(rv.Uint()), 10))
+	} else {
+		resObj = *env.NewInteger(int64(rv.Uint()))
+	}
+	//ryegen:endconv
+	return resObj
+case reflect.Float32, reflect.Float64:
+	//ryegen:conv go-to-rye/builtin float64
+	resObj = *env.NewDecimal(float64(rv.Float()))
+	//ryegen:endconv
+	return resObj
+case reflect.String:
+	//ryegen:conv go-to-rye/builtin string
+	resObj = *env.NewString(rv.String())
+	//ryegen:endconv
+	return resObj
+case reflect.Pointer, reflect.Interface, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+	if rv.IsNil() {
+		return env.Void{}
+	}
+}
+return ifaceToNative(ps.Idx, rv.Interface(), "Go(any)")
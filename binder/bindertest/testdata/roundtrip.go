@@ -0,0 +1,24 @@
+package testfile
+
+import (
+	"math/big"
+	"time"
+)
+
+type Node struct {
+	N int
+}
+
+func Times(a *time.Time, b time.Time) {}
+
+func Errors(err error) {}
+
+func BigInts(a *big.Int, b big.Int, c uint64) {}
+
+type Counter int
+
+func (c *Counter) Inc() { *c++ }
+
+func Pointers(n *Node, c *Counter) {}
+
+func Fuzz(i int, i8 int8, u uint64, f float64, s string, b bool, n *big.Int, t time.Time) {}
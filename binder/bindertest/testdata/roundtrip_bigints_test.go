@@ -0,0 +1,77 @@
+package check
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+	"testing"
+
+	"github.com/refaktor/rye/env"
+)
+
+func TestBigInts(t *testing.T) {
+	ps := &env.ProgramState{Idx: &env.Idxs{}}
+
+	maxUint64 := new(big.Int).SetUint64(math.MaxUint64)
+	maxInt64Plus1 := new(big.Int).Add(big.NewInt(math.MaxInt64), big.NewInt(1))
+	belowMinInt64 := new(big.Int).Sub(big.NewInt(math.MinInt64), big.NewInt(1))
+	for _, v := range []*big.Int{
+		big.NewInt(0),
+		big.NewInt(-42),
+		big.NewInt(math.MaxInt64),
+		big.NewInt(math.MinInt64),
+		maxInt64Plus1,
+		maxUint64,
+		belowMinInt64,
+		new(big.Int).Mul(maxUint64, maxUint64),
+	} {
+		obj := toRye0(ps, v)
+		// Integers which don't fit into a Rye integer become strings.
+		if _, isStr := obj.(env.String); isStr == v.IsInt64() {
+			t.Errorf("%v: got %#v", v, obj)
+		}
+		back, err := fromRye0(ps, obj)
+		if err != nil || back.Cmp(v) != 0 {
+			t.Errorf("*big.Int %v: got %v, %v", v, back, err)
+		}
+		if back == v {
+			t.Errorf("*big.Int %v: expected a copy", v)
+		}
+		val, err := fromRye1(ps, toRye1(ps, *v))
+		if err != nil || val.Cmp(v) != 0 {
+			t.Errorf("big.Int %v: got %v, %v", v, &val, err)
+		}
+	}
+
+	// A nil *big.Int becomes void and back, while 0 is a number.
+	if back, err := fromRye0(ps, toRye0(ps, nil)); err != nil || back != nil {
+		t.Errorf("expected nil, got %v, %v", back, err)
+	}
+	if back, err := fromRye0(ps, *env.NewInteger(0)); err != nil || back == nil || back.Sign() != 0 {
+		t.Errorf("expected 0, got %v, %v", back, err)
+	}
+	if _, err := fromRye0(ps, *env.NewString("12x")); err == nil {
+		t.Error("expected error for invalid integer string")
+	}
+
+	for _, v := range []uint64{0, 42, math.MaxInt64, math.MaxInt64 + 1, math.MaxUint64} {
+		obj := toRye2(ps, v)
+		if _, isStr := obj.(env.String); isStr != (v > math.MaxInt64) {
+			t.Errorf("%v: got %#v", v, obj)
+		}
+		back, err := fromRye2(ps, obj)
+		if err != nil || back != v {
+			t.Errorf("uint64 %v: got %v, %v", v, back, err)
+		}
+	}
+	for _, obj := range []env.Object{
+		*env.NewInteger(-1),
+		*env.NewString("-1"),
+		*env.NewString(new(big.Int).Add(maxUint64, big.NewInt(1)).String()),
+		*env.NewString(strconv.Quote("1")),
+	} {
+		if back, err := fromRye2(ps, obj); err == nil {
+			t.Errorf("expected error for %#v, got %v", obj, back)
+		}
+	}
+}
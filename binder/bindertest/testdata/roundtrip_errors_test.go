@@ -0,0 +1,43 @@
+package check
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/refaktor/rye/env"
+)
+
+var errNotFound = errors.New("not found")
+
+func TestErrors(t *testing.T) {
+	ps := &env.ProgramState{Idx: &env.Idxs{}}
+
+	// A nil error becomes void, which converts back to nil.
+	obj := toRye0(ps, nil)
+	if _, ok := obj.(env.Void); !ok {
+		t.Fatalf("expected void, got %#v", obj)
+	}
+	if back, err := fromRye0(ps, obj); err != nil || back != nil {
+		t.Fatalf("expected nil, got %v, %v", back, err)
+	}
+	if back, err := fromRye0(ps, *env.NewInteger(0)); err != nil || back != nil {
+		t.Fatalf("expected nil, got %v, %v", back, err)
+	}
+
+	// Errors made from Go errors convert back to the same error.
+	back, err := fromRye0(ps, toRye0(ps, errNotFound))
+	if err != nil || back != errNotFound {
+		t.Fatalf("expected %v, got %v, %v", errNotFound, back, err)
+	}
+
+	// Other Rye errors and strings become new errors.
+	if back, err := fromRye0(ps, env.NewError("failed")); err != nil || back == nil || back.Error() != "failed" {
+		t.Fatalf("expected error \"failed\", got %v, %v", back, err)
+	}
+	if back, err := fromRye0(ps, *env.NewString("failed")); err != nil || back == nil || back.Error() != "failed" {
+		t.Fatalf("expected error \"failed\", got %v, %v", back, err)
+	}
+	if _, err := fromRye0(ps, *env.NewInteger(1)); err == nil {
+		t.Fatal("expected error for integer 1")
+	}
+}
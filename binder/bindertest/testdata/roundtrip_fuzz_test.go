@@ -0,0 +1,50 @@
+package check
+
+import (
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/refaktor/rye/env"
+)
+
+// FuzzRoundTrip converts random values of the params of the Fuzz fixture
+// to Rye and back, which must give the same values.
+func FuzzRoundTrip(f *testing.F) {
+	f.Add(int64(0), int8(0), uint64(0), 0.0, "", false, "0", int64(0), 0)
+	f.Add(int64(math.MaxInt64), int8(math.MinInt8), uint64(math.MaxUint64), math.Inf(-1), "héllo\x00", true, "-18446744073709551616", int64(1714564800000000005), 7200)
+	f.Add(int64(math.MinInt64), int8(math.MaxInt8), uint64(math.MaxInt64+1), math.SmallestNonzeroFloat64, "\xff", false, "123456789012345678901234567890", int64(-1), -3600)
+	f.Fuzz(func(t *testing.T, i int64, i8 int8, u uint64, fl float64, s string, b bool, n string, nsec int64, zoneOffset int) {
+		ps := &env.ProgramState{Idx: &env.Idxs{}}
+
+		if back, err := fromRye0(ps, toRye0(ps, int(i))); err != nil || back != int(i) {
+			t.Errorf("int %v: got %v, %v", i, back, err)
+		}
+		if back, err := fromRye1(ps, toRye1(ps, i8)); err != nil || back != i8 {
+			t.Errorf("int8 %v: got %v, %v", i8, back, err)
+		}
+		if back, err := fromRye2(ps, toRye2(ps, u)); err != nil || back != u {
+			t.Errorf("uint64 %v: got %v, %v", u, back, err)
+		}
+		if back, err := fromRye3(ps, toRye3(ps, fl)); err != nil || math.Float64bits(back) != math.Float64bits(fl) {
+			t.Errorf("float64 %v: got %v, %v", fl, back, err)
+		}
+		if back, err := fromRye4(ps, toRye4(ps, s)); err != nil || back != s {
+			t.Errorf("string %q: got %q, %v", s, back, err)
+		}
+		if back, err := fromRye5(ps, toRye5(ps, b)); err != nil || back != b {
+			t.Errorf("bool %v: got %v, %v", b, back, err)
+		}
+		if v, ok := new(big.Int).SetString(n, 10); ok {
+			if back, err := fromRye6(ps, toRye6(ps, v)); err != nil || back == nil || back.Cmp(v) != 0 {
+				t.Errorf("*big.Int %v: got %v, %v", v, back, err)
+			}
+		}
+		// Zone offsets are limited to a day, as in real zones.
+		tm := time.Unix(0, nsec).In(time.FixedZone("Z", zoneOffset%86400))
+		if back, err := fromRye7(ps, toRye7(ps, tm)); err != nil || !back.Equal(tm) || back.Location() != tm.Location() {
+			t.Errorf("time.Time %v: got %v, %v", tm, back, err)
+		}
+	})
+}
@@ -0,0 +1,41 @@
+package check
+
+import (
+	"testing"
+
+	"github.com/refaktor/rye/env"
+
+	testmodule "test.module/tm"
+)
+
+func TestPointers(t *testing.T) {
+	ps := &env.ProgramState{Idx: &env.Idxs{}}
+
+	// A *T converts back to the same pointer, so changes through either
+	// are visible through both.
+	n := &testmodule.Node{N: 1}
+	back, err := fromRye0(ps, toRye0(ps, n))
+	if err != nil || back != n {
+		t.Fatalf("expected %p, got %p, %v", n, back, err)
+	}
+	back.N = 2
+	if n.N != 2 {
+		t.Fatalf("expected change through converted pointer, got %v", n.N)
+	}
+
+	// nil stays nil.
+	if back, err := fromRye0(ps, toRye0(ps, nil)); err != nil || back != nil {
+		t.Fatalf("expected nil, got %v, %v", back, err)
+	}
+
+	// The same holds for pointers to other named types.
+	c := new(testmodule.Counter)
+	backC, err := fromRye1(ps, toRye1(ps, c))
+	if err != nil || backC != c {
+		t.Fatalf("expected %p, got %p, %v", c, backC, err)
+	}
+	backC.Inc()
+	if *c != 1 {
+		t.Fatalf("expected change through converted pointer, got %v", *c)
+	}
+}
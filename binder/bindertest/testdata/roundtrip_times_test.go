@@ -0,0 +1,40 @@
+package check
+
+import (
+	"testing"
+	"time"
+
+	"github.com/refaktor/rye/env"
+)
+
+func TestTimes(t *testing.T) {
+	ps := &env.ProgramState{Idx: &env.Idxs{}}
+
+	// A nil *time.Time becomes void, which converts back to nil.
+	obj := toRye0(ps, nil)
+	if _, ok := obj.(env.Void); !ok {
+		t.Fatalf("expected void, got %#v", obj)
+	}
+	back, err := fromRye0(ps, obj)
+	if err != nil || back != nil {
+		t.Fatalf("expected nil, got %v, %v", back, err)
+	}
+	// 0 is accepted as nil too.
+	if back, err := fromRye0(ps, *env.NewInteger(0)); err != nil || back != nil {
+		t.Fatalf("expected nil, got %v, %v", back, err)
+	}
+
+	now := time.Date(2024, 5, 1, 12, 0, 0, 5, time.FixedZone("X", 7200))
+	back, err = fromRye0(ps, toRye0(ps, &now))
+	if err != nil || back == nil || !back.Equal(now) || back.Location() != now.Location() {
+		t.Fatalf("expected %v, got %v, %v", now, back, err)
+	}
+	val, err := fromRye1(ps, toRye1(ps, now))
+	if err != nil || !val.Equal(now) {
+		t.Fatalf("expected %v, got %v, %v", now, val, err)
+	}
+	// Time values have no nil.
+	if _, err := fromRye1(ps, env.Void{}); err == nil {
+		t.Fatal("expected error for void time.Time")
+	}
+}
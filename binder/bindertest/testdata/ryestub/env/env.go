@@ -0,0 +1,156 @@
+// Package env is a stub of the parts of Rye's env package used by the
+// generated code in tests, so it can be compiled without Rye.
+package env
+
+import "time"
+
+type Object interface {
+	Inspect(idx Idxs) string
+}
+
+type Idxs struct {
+	words map[string]int
+}
+
+func (idx *Idxs) IndexWord(w string) int {
+	if i, ok := idx.words[w]; ok {
+		return i
+	}
+	if idx.words == nil {
+		idx.words = make(map[string]int)
+	}
+	idx.words[w] = len(idx.words)
+	return idx.words[w]
+}
+
+func (idx *Idxs) GetIndex(w string) (int, bool) {
+	i, ok := idx.words[w]
+	return i, ok
+}
+
+type ProgramState struct {
+	Idx         *Idxs
+	Ctx         *RyeCtx
+	Gen         *Gen
+	Ser         TSeries
+	Res         Object
+	FailureFlag bool
+	ErrorFlag   bool
+}
+
+type RyeCtx struct {
+	state  map[int]Object
+	Parent *RyeCtx
+}
+
+func NewEnv(par *RyeCtx) *RyeCtx { return &RyeCtx{state: make(map[int]Object), Parent: par} }
+
+func (e RyeCtx) Get(word int) (Object, bool) {
+	if obj, ok := e.state[word]; ok {
+		return obj, true
+	}
+	if e.Parent != nil {
+		return e.Parent.Get(word)
+	}
+	return nil, false
+}
+
+func (e RyeCtx) Inspect(idx Idxs) string { return "" }
+
+func (e *RyeCtx) Set(word int, val Object) Object {
+	if _, ok := e.state[word]; ok {
+		return NewError("Can't set already set word")
+	}
+	e.state[word] = val
+	return val
+}
+
+// Function is called by the evaldo stub as Call, which stands in for
+// evaluating the function body.
+type Function struct {
+	Argsn int
+	Call  func(ps *ProgramState, args []Object)
+}
+
+func (f Function) Inspect(idx Idxs) string { return "" }
+
+type Integer struct{ Value int64 }
+
+func NewInteger(v int64) *Integer         { return &Integer{Value: v} }
+func (i Integer) Inspect(idx Idxs) string { return "" }
+
+type Decimal struct{ Value float64 }
+
+func NewDecimal(v float64) *Decimal       { return &Decimal{Value: v} }
+func (d Decimal) Inspect(idx Idxs) string { return "" }
+
+type String struct{ Value string }
+
+func NewString(v string) *String         { return &String{Value: v} }
+func (s String) Inspect(idx Idxs) string { return "" }
+
+type Time struct{ Value time.Time }
+
+func NewTime(v time.Time) *Time        { return &Time{Value: v} }
+func (t Time) Inspect(idx Idxs) string { return "" }
+
+type TSeries struct {
+	S []Object
+}
+
+type Block struct {
+	Series TSeries
+}
+
+func (b Block) Inspect(idx Idxs) string { return "" }
+
+type Void struct{}
+
+func (v Void) Inspect(idx Idxs) string { return "" }
+
+type Native struct {
+	Value any
+	Kind  string
+}
+
+func NewNative(idx *Idxs, val any, kind string) *Native { return &Native{Value: val, Kind: kind} }
+func (n Native) Inspect(idx Idxs) string                { return "" }
+
+type Error struct {
+	Message string
+	Values  map[string]Object
+}
+
+func NewError(msg string) *Error        { return &Error{Message: msg} }
+func (e Error) Inspect(idx Idxs) string { return e.Message }
+func (e *Error) Print(idx Idxs) string  { return e.Message }
+
+type BuiltinFunction func(ps *ProgramState, arg0, arg1, arg2, arg3, arg4 Object) Object
+
+type Builtin struct {
+	Fn    BuiltinFunction
+	Argsn int
+	Doc   string
+}
+
+func (b Builtin) Inspect(idx Idxs) string { return b.Doc }
+
+// Gen holds generic builtins by kind and word.
+type Gen struct {
+	dict map[int]map[int]Object
+}
+
+func NewGen() *Gen { return &Gen{dict: make(map[int]map[int]Object)} }
+
+func (g *Gen) Get(kind, word int) (Object, bool) {
+	obj, ok := g.dict[kind][word]
+	return obj, ok
+}
+
+func (g *Gen) Set(kind, word int, val Object) Object {
+	if g.dict[kind] == nil {
+		g.dict[kind] = make(map[int]Object)
+	}
+	g.dict[kind][word] = val
+	return val
+}
@@ -0,0 +1,31 @@
+// Package evaldo is a stub of the parts of Rye's evaldo package used by
+// the generated code in tests.
+package evaldo
+
+import "github.com/refaktor/rye/env"
+
+func CallFunctionArgsN(fn env.Function, ps *env.ProgramState, ctx *env.RyeCtx, args ...env.Object) {
+	fn.Call(ps, args)
+}
+
+// EvalBlock evaluates ps.Ser, which the stub only fails for a failure
+// word.
+func EvalBlock(ps *env.ProgramState) {
+	for _, obj := range ps.Ser.S {
+		if s, ok := obj.(env.String); ok && s.Value == "fail" {
+			ps.Res = env.NewError("failed")
+			ps.FailureFlag = true
+		}
+	}
+}
+
+// RegisterBuiltinsInContext registers builtins in a new context, which is
+// bound to name in the current context of ps.
+func RegisterBuiltinsInContext(builtins map[string]*env.Builtin, ps *env.ProgramState, name string) *env.RyeCtx {
+	ctx := env.NewEnv(ps.Ctx)
+	for word, b := range builtins {
+		ctx.Set(ps.Idx.IndexWord(word), *b)
+	}
+	ps.Ctx.Set(ps.Idx.IndexWord(name), *ctx)
+	return ctx
+}
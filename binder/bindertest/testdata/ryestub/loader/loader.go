@@ -0,0 +1,22 @@
+// Package loader is a stub of the parts of Rye's loader package used by
+// the generated code in tests.
+package loader
+
+import (
+	"strings"
+
+	"github.com/refaktor/rye/env"
+)
+
+// LoadStringNEW returns a block of the words of input as strings, or an
+// error if its braces are unbalanced.
+func LoadStringNEW(input string, sig bool, ps *env.ProgramState) env.Object {
+	if strings.Count(input, "{") != strings.Count(input, "}") {
+		return env.NewError("unbalanced braces")
+	}
+	var block env.Block
+	for _, word := range strings.Fields(input) {
+		block.Series.S = append(block.Series.S, env.String{Value: word})
+	}
+	return block
+}
@@ -0,0 +1,20 @@
+package testfile
+
+type Button struct{}
+
+type Widget interface {
+	Show()
+}
+
+type Celsius float64
+
+type HTTPClient struct{}
+
+type HttpClient struct{}
+
+type unexported struct{}
+
+// NewButton shows w on a Go(*testmodule.Button).
+func NewButton(w Widget) *Button { return nil }
+
+func (b *Button) SetText(text string) {}
@@ -0,0 +1,24 @@
+package testfile
+
+type Point struct {
+	X, Y float32
+}
+
+type Rect struct {
+	X, Y          float32
+	Width, Height float32
+}
+
+// Too many fields for the smallstruct converter.
+type Box struct {
+	X, Y, Z              float32
+	Width, Height, Depth float32
+}
+
+func Layout() map[string]Rect { return nil }
+
+func SetAnchors(anchors map[string]Point, boxes map[string]Box) {}
+
+func Center(r struct{ X, Y, W, H float32 }) struct{ X, Y float32 } {
+	return struct{ X, Y float32 }{r.X + r.W/2, r.Y + r.H/2}
+}
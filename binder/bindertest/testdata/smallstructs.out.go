@@ -0,0 +1,787 @@
+res0 := testmodule.Layout()
+var res0Obj env.Object
+//ryegen:conv go-to-rye/map map[string]testmodule.Rect
+{
+	data := make(map[string]any, len(res0))
+	for mKey, mVal := range res0 {
+		var dVal env.Object
+		//ryegen:conv go-to-rye/smallstruct testmodule.Rect
+		dVal = *env.NewDict(map[string]any{
+			"x": *env.NewDecimal(float64(mVal.X)),
+			"y": *env.NewDecimal(float64(mVal.Y)),
+			"width": *env.NewDecimal(float64(mVal.Width)),
+			"height": *env.NewDecimal(float64(mVal.Height)),
+		})
+		//ryegen:endconv
+		data[mKey] = dVal
+	}
+	res0Obj = *env.NewDict(data)
+}
+//ryegen:endconv
+return res0Obj
+
+//================================//
+
+var arg0Val map[string]testmodule.Point
+//ryegen:conv rye-to-go/map map[string]testmodule.Point
+switch v := arg0.(type) {
+case env.Block:
+	if len(v.Series.S) % 2 != 0 {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected block to have length of multiple of 2, but got block with length "+strconv.Itoa(len(v.Series.S)))
+	}
+	arg0Val = make(map[string]testmodule.Point, len(v.Series.S)/2)
+	for i := 0; i < len(v.Series.S); i += 2 {
+		var mapK string
+		//ryegen:conv rye-to-go/builtin string
+		if vc, ok := v.Series.S[i+0].(env.String); ok {
+			mapK = string(vc.Value)
+		} else {
+			ps.FailureFlag = true
+			return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map key: "+"expected string, but got "+objectDebugString(ps.Idx, v.Series.S[i+0]))
+		}
+		//ryegen:endconv
+		var mapV testmodule.Point
+		//ryegen:conv rye-to-go/smallstruct testmodule.Point
+		switch v := v.Series.S[i+1].(type) {
+		case env.Dict:
+			nFound := 0
+			{
+				dictV, ok := v.Data["X"]
+				if !ok {
+					dictV, ok = v.Data["x"]
+				}
+				if ok {
+					nFound++
+					//ryegen:conv rye-to-go/builtin float32
+					if vc, ok := dictV.(env.Decimal); ok {
+						mapV.X = float32(vc.Value)
+					} else {
+						ps.FailureFlag = true
+						return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map value: "+"field x: "+"expected decimal, but got "+objectDebugString(ps.Idx, dictV))
+					}
+					//ryegen:endconv
+				}
+			}
+			{
+				dictV, ok := v.Data["Y"]
+				if !ok {
+					dictV, ok = v.Data["y"]
+				}
+				if ok {
+					nFound++
+					//ryegen:conv rye-to-go/builtin float32
+					if vc, ok := dictV.(env.Decimal); ok {
+						mapV.Y = float32(vc.Value)
+					} else {
+						ps.FailureFlag = true
+						return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map value: "+"field y: "+"expected decimal, but got "+objectDebugString(ps.Idx, dictV))
+					}
+					//ryegen:endconv
+				}
+			}
+			if nFound != len(v.Data) {
+				for dictK := range v.Data {
+					switch dictK {
+					case "X", "x", "Y", "y":
+					default:
+						ps.FailureFlag = true
+						return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map value: "+"unknown struct field "+dictK)
+					}
+				}
+			}
+		case env.Block:
+			if len(v.Series.S) % 2 != 0 {
+				ps.FailureFlag = true
+				return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map value: "+"expected block to have length of multiple of 2, but got block with length "+strconv.Itoa(len(v.Series.S)))
+			}
+			for i := 0; i < len(v.Series.S); i += 2 {
+				var fieldName string
+				switch k := v.Series.S[i].(type) {
+				case env.String:
+					fieldName = k.Value
+				case env.Word:
+					fieldName = ps.Idx.GetWord(k.Index)
+				default:
+					ps.FailureFlag = true
+					return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map value: "+"expected struct field name to be string or word, but got "+objectDebugString(ps.Idx, k))
+				}
+				switch fieldName {
+				case "X", "x":
+					//ryegen:conv rye-to-go/builtin float32
+					if vc, ok := v.Series.S[i+1].(env.Decimal); ok {
+						mapV.X = float32(vc.Value)
+					} else {
+						ps.FailureFlag = true
+						return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map value: "+"field x: "+"expected decimal, but got "+objectDebugString(ps.Idx, v.Series.S[i+1]))
+					}
+					//ryegen:endconv
+				case "Y", "y":
+					//ryegen:conv rye-to-go/builtin float32
+					if vc, ok := v.Series.S[i+1].(env.Decimal); ok {
+						mapV.Y = float32(vc.Value)
+					} else {
+						ps.FailureFlag = true
+						return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map value: "+"field y: "+"expected decimal, but got "+objectDebugString(ps.Idx, v.Series.S[i+1]))
+					}
+					//ryegen:endconv
+				default:
+					ps.FailureFlag = true
+					return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map value: "+"unknown struct field "+fieldName)
+				}
+			}
+		case env.Native:
+			if vc, ok := v.Value.(testmodule.Point); ok {
+				mapV = vc
+			} else if vc, ok := v.Value.(*testmodule.Point); ok {
+				mapV = *vc
+			} else {
+				ps.FailureFlag = true
+				return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map value: "+"expected native of type testmodule.Point, but got "+objectDebugString(ps.Idx, v))
+			}
+		default:
+			ps.FailureFlag = true
+			return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map value: "+"expected dict, block or native, but got "+objectDebugString(ps.Idx, v))
+		}
+		//ryegen:endconv
+		arg0Val[mapK] = mapV
+	}
+case env.Dict:
+	arg0Val = make(map[string]testmodule.Point, len(v.Data))
+	for dictK, dictV := range v.Data {
+		mapK := dictK
+		var mapV testmodule.Point
+		//ryegen:conv rye-to-go/smallstruct testmodule.Point
+		switch v := dictV.(type) {
+		case env.Dict:
+			nFound := 0
+			{
+				dictV, ok := v.Data["X"]
+				if !ok {
+					dictV, ok = v.Data["x"]
+				}
+				if ok {
+					nFound++
+					//ryegen:conv rye-to-go/builtin float32
+					if vc, ok := dictV.(env.Decimal); ok {
+						mapV.X = float32(vc.Value)
+					} else {
+						ps.FailureFlag = true
+						return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map value: "+"field x: "+"expected decimal, but got "+objectDebugString(ps.Idx, dictV))
+					}
+					//ryegen:endconv
+				}
+			}
+			{
+				dictV, ok := v.Data["Y"]
+				if !ok {
+					dictV, ok = v.Data["y"]
+				}
+				if ok {
+					nFound++
+					//ryegen:conv rye-to-go/builtin float32
+					if vc, ok := dictV.(env.Decimal); ok {
+						mapV.Y = float32(vc.Value)
+					} else {
+						ps.FailureFlag = true
+						return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map value: "+"field y: "+"expected decimal, but got "+objectDebugString(ps.Idx, dictV))
+					}
+					//ryegen:endconv
+				}
+			}
+			if nFound != len(v.Data) {
+				for dictK := range v.Data {
+					switch dictK {
+					case "X", "x", "Y", "y":
+					default:
+						ps.FailureFlag = true
+						return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map value: "+"unknown struct field "+dictK)
+					}
+				}
+			}
+		case env.Block:
+			if len(v.Series.S) % 2 != 0 {
+				ps.FailureFlag = true
+				return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map value: "+"expected block to have length of multiple of 2, but got block with length "+strconv.Itoa(len(v.Series.S)))
+			}
+			for i := 0; i < len(v.Series.S); i += 2 {
+				var fieldName string
+				switch k := v.Series.S[i].(type) {
+				case env.String:
+					fieldName = k.Value
+				case env.Word:
+					fieldName = ps.Idx.GetWord(k.Index)
+				default:
+					ps.FailureFlag = true
+					return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map value: "+"expected struct field name to be string or word, but got "+objectDebugString(ps.Idx, k))
+				}
+				switch fieldName {
+				case "X", "x":
+					//ryegen:conv rye-to-go/builtin float32
+					if vc, ok := v.Series.S[i+1].(env.Decimal); ok {
+						mapV.X = float32(vc.Value)
+					} else {
+						ps.FailureFlag = true
+						return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map value: "+"field x: "+"expected decimal, but got "+objectDebugString(ps.Idx, v.Series.S[i+1]))
+					}
+					//ryegen:endconv
+				case "Y", "y":
+					//ryegen:conv rye-to-go/builtin float32
+					if vc, ok := v.Series.S[i+1].(env.Decimal); ok {
+						mapV.Y = float32(vc.Value)
+					} else {
+						ps.FailureFlag = true
+						return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map value: "+"field y: "+"expected decimal, but got "+objectDebugString(ps.Idx, v.Series.S[i+1]))
+					}
+					//ryegen:endconv
+				default:
+					ps.FailureFlag = true
+					return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map value: "+"unknown struct field "+fieldName)
+				}
+			}
+		case env.Native:
+			if vc, ok := v.Value.(testmodule.Point); ok {
+				mapV = vc
+			} else if vc, ok := v.Value.(*testmodule.Point); ok {
+				mapV = *vc
+			} else {
+				ps.FailureFlag = true
+				return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map value: "+"expected native of type testmodule.Point, but got "+objectDebugString(ps.Idx, v))
+			}
+		default:
+			ps.FailureFlag = true
+			return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"map value: "+"expected dict, block or native, but got "+objectDebugString(ps.Idx, v))
+		}
+		//ryegen:endconv
+		arg0Val[mapK] = mapV
+	}
+case env.Void:
+	arg0Val = nil
+case env.Integer:
+	if v.Value != 0 {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected integer to be 0 or nil, but got "+strconv.FormatInt(v.Value, 10))
+	}
+	arg0Val = nil
+default:
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected block, dict or nil, but got "+objectDebugString(ps.Idx, v))
+}
+//ryegen:endconv
+var arg1Val map[string]testmodule.Box
+//ryegen:conv rye-to-go/map map[string]testmodule.Box
+switch v := arg1.(type) {
+case env.Block:
+	if len(v.Series.S) % 2 != 0 {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"expected block to have length of multiple of 2, but got block with length "+strconv.Itoa(len(v.Series.S)))
+	}
+	arg1Val = make(map[string]testmodule.Box, len(v.Series.S)/2)
+	for i := 0; i < len(v.Series.S); i += 2 {
+		var mapK string
+		//ryegen:conv rye-to-go/builtin string
+		if vc, ok := v.Series.S[i+0].(env.String); ok {
+			mapK = string(vc.Value)
+		} else {
+			ps.FailureFlag = true
+			return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"map key: "+"expected string, but got "+objectDebugString(ps.Idx, v.Series.S[i+0]))
+		}
+		//ryegen:endconv
+		var mapV testmodule.Box
+		//ryegen:conv rye-to-go/struct testmodule.Box
+		switch v := v.Series.S[i+1].(type) {
+		case env.Dict:
+			for dictK, dictV := range v.Data {
+				switch dictK {
+				case "X", "x":
+					//ryegen:conv rye-to-go/builtin float32
+					if vc, ok := dictV.(env.Decimal); ok {
+						mapV.X = float32(vc.Value)
+					} else {
+						ps.FailureFlag = true
+						return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"map value: "+"field x: "+"expected decimal, but got "+objectDebugString(ps.Idx, dictV))
+					}
+					//ryegen:endconv
+				case "Y", "y":
+					//ryegen:conv rye-to-go/builtin float32
+					if vc, ok := dictV.(env.Decimal); ok {
+						mapV.Y = float32(vc.Value)
+					} else {
+						ps.FailureFlag = true
+						return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"map value: "+"field y: "+"expected decimal, but got "+objectDebugString(ps.Idx, dictV))
+					}
+					//ryegen:endconv
+				case "Z", "z":
+					//ryegen:conv rye-to-go/builtin float32
+					if vc, ok := dictV.(env.Decimal); ok {
+						mapV.Z = float32(vc.Value)
+					} else {
+						ps.FailureFlag = true
+						return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"map value: "+"field z: "+"expected decimal, but got "+objectDebugString(ps.Idx, dictV))
+					}
+					//ryegen:endconv
+				case "Width", "width":
+					//ryegen:conv rye-to-go/builtin float32
+					if vc, ok := dictV.(env.Decimal); ok {
+						mapV.Width = float32(vc.Value)
+					} else {
+						ps.FailureFlag = true
+						return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"map value: "+"field width: "+"expected decimal, but got "+objectDebugString(ps.Idx, dictV))
+					}
+					//ryegen:endconv
+				case "Height", "height":
+					//ryegen:conv rye-to-go/builtin float32
+					if vc, ok := dictV.(env.Decimal); ok {
+						mapV.Height = float32(vc.Value)
+					} else {
+						ps.FailureFlag = true
+						return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"map value: "+"field height: "+"expected decimal, but got "+objectDebugString(ps.Idx, dictV))
+					}
+					//ryegen:endconv
+				case "Depth", "depth":
+					//ryegen:conv rye-to-go/builtin float32
+					if vc, ok := dictV.(env.Decimal); ok {
+						mapV.Depth = float32(vc.Value)
+					} else {
+						ps.FailureFlag = true
+						return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"map value: "+"field depth: "+"expected decimal, but got "+objectDebugString(ps.Idx, dictV))
+					}
+					//ryegen:endconv
+				default:
+					ps.FailureFlag = true
+					return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"map value: "+"unknown struct field "+dictK)
+				}
+			}
+		case env.Block:
+			if len(v.Series.S) % 2 != 0 {
+				ps.FailureFlag = true
+				return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"map value: "+"expected block to have length of multiple of 2, but got block with length "+strconv.Itoa(len(v.Series.S)))
+			}
+			for i := 0; i < len(v.Series.S); i += 2 {
+				var fieldName string
+				switch k := v.Series.S[i].(type) {
+				case env.String:
+					fieldName = k.Value
+				case env.Word:
+					fieldName = ps.Idx.GetWord(k.Index)
+				default:
+					ps.FailureFlag = true
+					return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"map value: "+"expected struct field name to be string or word, but got "+objectDebugString(ps.Idx, k))
+				}
+				switch fieldName {
+				case "X", "x":
+					//ryegen:conv rye-to-go/builtin float32
+					if vc, ok := v.Series.S[i+1].(env.Decimal); ok {
+						mapV.X = float32(vc.Value)
+					} else {
+						ps.FailureFlag = true
+						return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"map value: "+"field x: "+"expected decimal, but got "+objectDebugString(ps.Idx, v.Series.S[i+1]))
+					}
+					//ryegen:endconv
+				case "Y", "y":
+					//ryegen:conv rye-to-go/builtin float32
+					if vc, ok := v.Series.S[i+1].(env.Decimal); ok {
+						mapV.Y = float32(vc.Value)
+					} else {
+						ps.FailureFlag = true
+						return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"map value: "+"field y: "+"expected decimal, but got "+objectDebugString(ps.Idx, v.Series.S[i+1]))
+					}
+					//ryegen:endconv
+				case "Z", "z":
+					//ryegen:conv rye-to-go/builtin float32
+					if vc, ok := v.Series.S[i+1].(env.Decimal); ok {
+						mapV.Z = float32(vc.Value)
+					} else {
+						ps.FailureFlag = true
+						return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"map value: "+"field z: "+"expected decimal, but got "+objectDebugString(ps.Idx, v.Series.S[i+1]))
+					}
+					//ryegen:endconv
+				case "Width", "width":
+					//ryegen:conv rye-to-go/builtin float32
+					if vc, ok := v.Series.S[i+1].(env.Decimal); ok {
+						mapV.Width = float32(vc.Value)
+					} else {
+						ps.FailureFlag = true
+						return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"map value: "+"field width: "+"expected decimal, but got "+objectDebugString(ps.Idx, v.Series.S[i+1]))
+					}
+					//ryegen:endconv
+				case "Height", "height":
+					//ryegen:conv rye-to-go/builtin float32
+					if vc, ok := v.Series.S[i+1].(env.Decimal); ok {
+						mapV.Height = float32(vc.Value)
+					} else {
+						ps.FailureFlag = true
+						return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"map value: "+"field height: "+"expected decimal, but got "+objectDebugString(ps.Idx, v.Series.S[i+1]))
+					}
+					//ryegen:endconv
+				case "Depth", "depth":
+					//ryegen:conv rye-to-go/builtin float32
+					if vc, ok := v.Series.S[i+1].(env.Decimal); ok {
+						mapV.Depth = float32(vc.Value)
+					} else {
+						ps.FailureFlag = true
+						return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"map value: "+"field depth: "+"expected decimal, but got "+objectDebugString(ps.Idx, v.Series.S[i+1]))
+					}
+					//ryegen:endconv
+				default:
+					ps.FailureFlag = true
+					return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"map value: "+"unknown struct field "+fieldName)
+				}
+			}
+		case env.Native:
+			if vc, ok := v.Value.(testmodule.Box); ok {
+				mapV = vc
+			} else if vc, ok := v.Value.(*testmodule.Box); ok {
+				mapV = *vc
+			} else {
+				ps.FailureFlag = true
+				return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"map value: "+"expected native of type testmodule.Box, but got "+objectDebugString(ps.Idx, v))
+			}
+		default:
+			ps.FailureFlag = true
+			return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"map value: "+"expected dict, block or native, but got "+objectDebugString(ps.Idx, v))
+		}
+		//ryegen:endconv
+		arg1Val[mapK] = mapV
+	}
+case env.Dict:
+	arg1Val = make(map[string]testmodule.Box, len(v.Data))
+	for dictK, dictV := range v.Data {
+		mapK := dictK
+		var mapV testmodule.Box
+		//ryegen:conv rye-to-go/struct testmodule.Box
+		switch v := dictV.(type) {
+		case env.Dict:
+			for dictK, dictV := range v.Data {
+				switch dictK {
+				case "X", "x":
+					//ryegen:conv rye-to-go/builtin float32
+					if vc, ok := dictV.(env.Decimal); ok {
+						mapV.X = float32(vc.Value)
+					} else {
+						ps.FailureFlag = true
+						return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"map value: "+"field x: "+"expected decimal, but got "+objectDebugString(ps.Idx, dictV))
+					}
+					//ryegen:endconv
+				case "Y", "y":
+					//ryegen:conv rye-to-go/builtin float32
+					if vc, ok := dictV.(env.Decimal); ok {
+						mapV.Y = float32(vc.Value)
+					} else {
+						ps.FailureFlag = true
+						return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"map value: "+"field y: "+"expected decimal, but got "+objectDebugString(ps.Idx, dictV))
+					}
+					//ryegen:endconv
+				case "Z", "z":
+					//ryegen:conv rye-to-go/builtin float32
+					if vc, ok := dictV.(env.Decimal); ok {
+						mapV.Z = float32(vc.Value)
+					} else {
+						ps.FailureFlag = true
+						return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"map value: "+"field z: "+"expected decimal, but got "+objectDebugString(ps.Idx, dictV))
+					}
+					//ryegen:endconv
+				case "Width", "width":
+					//ryegen:conv rye-to-go/builtin float32
+					if vc, ok := dictV.(env.Decimal); ok {
+						mapV.Width = float32(vc.Value)
+					} else {
+						ps.FailureFlag = true
+						return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"map value: "+"field width: "+"expected decimal, but got "+objectDebugString(ps.Idx, dictV))
+					}
+					//ryegen:endconv
+				case "Height", "height":
+					//ryegen:conv rye-to-go/builtin float32
+					if vc, ok := dictV.(env.Decimal); ok {
+						mapV.Height = float32(vc.Value)
+					} else {
+						ps.FailureFlag = true
+						return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"map value: "+"field height: "+"expected decimal, but got "+objectDebugString(ps.Idx, dictV))
+					}
+					//ryegen:endconv
+				case "Depth", "depth":
+					//ryegen:conv rye-to-go/builtin float32
+					if vc, ok := dictV.(env.Decimal); ok {
+						mapV.Depth = float32(vc.Value)
+					} else {
+						ps.FailureFlag = true
+						return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"map value: "+"field depth: "+"expected decimal, but got "+objectDebugString(ps.Idx, dictV))
+					}
+					//ryegen:endconv
+				default:
+					ps.FailureFlag = true
+					return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"map value: "+"unknown struct field "+dictK)
+				}
+			}
+		case env.Block:
+			if len(v.Series.S) % 2 != 0 {
+				ps.FailureFlag = true
+				return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"map value: "+"expected block to have length of multiple of 2, but got block with length "+strconv.Itoa(len(v.Series.S)))
+			}
+			for i := 0; i < len(v.Series.S); i += 2 {
+				var fieldName string
+				switch k := v.Series.S[i].(type) {
+				case env.String:
+					fieldName = k.Value
+				case env.Word:
+					fieldName = ps.Idx.GetWord(k.Index)
+				default:
+					ps.FailureFlag = true
+					return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"map value: "+"expected struct field name to be string or word, but got "+objectDebugString(ps.Idx, k))
+				}
+				switch fieldName {
+				case "X", "x":
+					//ryegen:conv rye-to-go/builtin float32
+					if vc, ok := v.Series.S[i+1].(env.Decimal); ok {
+						mapV.X = float32(vc.Value)
+					} else {
+						ps.FailureFlag = true
+						return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"map value: "+"field x: "+"expected decimal, but got "+objectDebugString(ps.Idx, v.Series.S[i+1]))
+					}
+					//ryegen:endconv
+				case "Y", "y":
+					//ryegen:conv rye-to-go/builtin float32
+					if vc, ok := v.Series.S[i+1].(env.Decimal); ok {
+						mapV.Y = float32(vc.Value)
+					} else {
+						ps.FailureFlag = true
+						return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"map value: "+"field y: "+"expected decimal, but got "+objectDebugString(ps.Idx, v.Series.S[i+1]))
+					}
+					//ryegen:endconv
+				case "Z", "z":
+					//ryegen:conv rye-to-go/builtin float32
+					if vc, ok := v.Series.S[i+1].(env.Decimal); ok {
+						mapV.Z = float32(vc.Value)
+					} else {
+						ps.FailureFlag = true
+						return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"map value: "+"field z: "+"expected decimal, but got "+objectDebugString(ps.Idx, v.Series.S[i+1]))
+					}
+					//ryegen:endconv
+				case "Width", "width":
+					//ryegen:conv rye-to-go/builtin float32
+					if vc, ok := v.Series.S[i+1].(env.Decimal); ok {
+						mapV.Width = float32(vc.Value)
+					} else {
+						ps.FailureFlag = true
+						return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"map value: "+"field width: "+"expected decimal, but got "+objectDebugString(ps.Idx, v.Series.S[i+1]))
+					}
+					//ryegen:endconv
+				case "Height", "height":
+					//ryegen:conv rye-to-go/builtin float32
+					if vc, ok := v.Series.S[i+1].(env.Decimal); ok {
+						mapV.Height = float32(vc.Value)
+					} else {
+						ps.FailureFlag = true
+						return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"map value: "+"field height: "+"expected decimal, but got "+objectDebugString(ps.Idx, v.Series.S[i+1]))
+					}
+					//ryegen:endconv
+				case "Depth", "depth":
+					//ryegen:conv rye-to-go/builtin float32
+					if vc, ok := v.Series.S[i+1].(env.Decimal); ok {
+						mapV.Depth = float32(vc.Value)
+					} else {
+						ps.FailureFlag = true
+						return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"map value: "+"field depth: "+"expected decimal, but got "+objectDebugString(ps.Idx, v.Series.S[i+1]))
+					}
+					//ryegen:endconv
+				default:
+					ps.FailureFlag = true
+					return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"map value: "+"unknown struct field "+fieldName)
+				}
+			}
+		case env.Native:
+			if vc, ok := v.Value.(testmodule.Box); ok {
+				mapV = vc
+			} else if vc, ok := v.Value.(*testmodule.Box); ok {
+				mapV = *vc
+			} else {
+				ps.FailureFlag = true
+				return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"map value: "+"expected native of type testmodule.Box, but got "+objectDebugString(ps.Idx, v))
+			}
+		default:
+			ps.FailureFlag = true
+			return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"map value: "+"expected dict, block or native, but got "+objectDebugString(ps.Idx, v))
+		}
+		//ryegen:endconv
+		arg1Val[mapK] = mapV
+	}
+case env.Void:
+	arg1Val = nil
+case env.Integer:
+	if v.Value != 0 {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"expected integer to be 0 or nil, but got "+strconv.FormatInt(v.Value, 10))
+	}
+	arg1Val = nil
+default:
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"expected block, dict or nil, but got "+objectDebugString(ps.Idx, v))
+}
+//ryegen:endconv
+testmodule.SetAnchors(arg0Val, arg1Val)
+return nil
+
+//================================//
+
+var arg0Val struct{X, Y, W, H float32}
+//ryegen:conv rye-to-go/smallstruct struct{X, Y, W, H float32}
+switch v := arg0.(type) {
+case env.Dict:
+	nFound := 0
+	{
+		dictV, ok := v.Data["X"]
+		if !ok {
+			dictV, ok = v.Data["x"]
+		}
+		if ok {
+			nFound++
+			//ryegen:conv rye-to-go/builtin float32
+			if vc, ok := dictV.(env.Decimal); ok {
+				arg0Val.X = float32(vc.Value)
+			} else {
+				ps.FailureFlag = true
+				return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"field x: "+"expected decimal, but got "+objectDebugString(ps.Idx, dictV))
+			}
+			//ryegen:endconv
+		}
+	}
+	{
+		dictV, ok := v.Data["Y"]
+		if !ok {
+			dictV, ok = v.Data["y"]
+		}
+		if ok {
+			nFound++
+			//ryegen:conv rye-to-go/builtin float32
+			if vc, ok := dictV.(env.Decimal); ok {
+				arg0Val.Y = float32(vc.Value)
+			} else {
+				ps.FailureFlag = true
+				return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"field y: "+"expected decimal, but got "+objectDebugString(ps.Idx, dictV))
+			}
+			//ryegen:endconv
+		}
+	}
+	{
+		dictV, ok := v.Data["W"]
+		if !ok {
+			dictV, ok = v.Data["w"]
+		}
+		if ok {
+			nFound++
+			//ryegen:conv rye-to-go/builtin float32
+			if vc, ok := dictV.(env.Decimal); ok {
+				arg0Val.W = float32(vc.Value)
+			} else {
+				ps.FailureFlag = true
+				return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"field w: "+"expected decimal, but got "+objectDebugString(ps.Idx, dictV))
+			}
+			//ryegen:endconv
+		}
+	}
+	{
+		dictV, ok := v.Data["H"]
+		if !ok {
+			dictV, ok = v.Data["h"]
+		}
+		if ok {
+			nFound++
+			//ryegen:conv rye-to-go/builtin float32
+			if vc, ok := dictV.(env.Decimal); ok {
+				arg0Val.H = float32(vc.Value)
+			} else {
+				ps.FailureFlag = true
+				return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"field h: "+"expected decimal, but got "+objectDebugString(ps.Idx, dictV))
+			}
+			//ryegen:endconv
+		}
+	}
+	if nFound != len(v.Data) {
+		for dictK := range v.Data {
+			switch dictK {
+			case "X", "x", "Y", "y", "W", "w", "H", "h":
+			default:
+				ps.FailureFlag = true
+				return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"unknown struct field "+dictK)
+			}
+		}
+	}
+case env.Block:
+	if len(v.Series.S) % 2 != 0 {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected block to have length of multiple of 2, but got block with length "+strconv.Itoa(len(v.Series.S)))
+	}
+	for i := 0; i < len(v.Series.S); i += 2 {
+		var fieldName string
+		switch k := v.Series.S[i].(type) {
+		case env.String:
+			fieldName = k.Value
+		case env.Word:
+			fieldName = ps.Idx.GetWord(k.Index)
+		default:
+			ps.FailureFlag = true
+			return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected struct field name to be string or word, but got "+objectDebugString(ps.Idx, k))
+		}
+		switch fieldName {
+		case "X", "x":
+			//ryegen:conv rye-to-go/builtin float32
+			if vc, ok := v.Series.S[i+1].(env.Decimal); ok {
+				arg0Val.X = float32(vc.Value)
+			} else {
+				ps.FailureFlag = true
+				return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"field x: "+"expected decimal, but got "+objectDebugString(ps.Idx, v.Series.S[i+1]))
+			}
+			//ryegen:endconv
+		case "Y", "y":
+			//ryegen:conv rye-to-go/builtin float32
+			if vc, ok := v.Series.S[i+1].(env.Decimal); ok {
+				arg0Val.Y = float32(vc.Value)
+			} else {
+				ps.FailureFlag = true
+				return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"field y: "+"expected decimal, but got "+objectDebugString(ps.Idx, v.Series.S[i+1]))
+			}
+			//ryegen:endconv
+		case "W", "w":
+			//ryegen:conv rye-to-go/builtin float32
+			if vc, ok := v.Series.S[i+1].(env.Decimal); ok {
+				arg0Val.W = float32(vc.Value)
+			} else {
+				ps.FailureFlag = true
+				return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"field w: "+"expected decimal, but got "+objectDebugString(ps.Idx, v.Series.S[i+1]))
+			}
+			//ryegen:endconv
+		case "H", "h":
+			//ryegen:conv rye-to-go/builtin float32
+			if vc, ok := v.Series.S[i+1].(env.Decimal); ok {
+				arg0Val.H = float32(vc.Value)
+			} else {
+				ps.FailureFlag = true
+				return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"field h: "+"expected decimal, but got "+objectDebugString(ps.Idx, v.Series.S[i+1]))
+			}
+			//ryegen:endconv
+		default:
+			ps.FailureFlag = true
+			return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"unknown struct field "+fieldName)
+		}
+	}
+case env.Native:
+	if vc, ok := v.Value.(struct{X, Y, W, H float32}); ok {
+		arg0Val = vc
+	} else {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native of type struct{X, Y, W, H float32}, but got "+objectDebugString(ps.Idx, v))
+	}
+default:
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected dict, block or native, but got "+objectDebugString(ps.Idx, v))
+}
+//ryegen:endconv
+res0 := testmodule.Center(arg0Val)
+var res0Obj env.Object
+//ryegen:conv go-to-rye/smallstruct struct{X, Y float32}
+res0Obj = *env.NewDict(map[string]any{
+	"x": *env.NewDecimal(float64(res0.X)),
+	"y": *env.NewDecimal(float64(res0.Y)),
+})
+//ryegen:endconv
+return res0Obj
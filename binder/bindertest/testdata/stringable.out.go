@@ -0,0 +1,61 @@
+var arg0Val string
+//ryegen:conv rye-to-go/builtin string
+if vc, ok := arg0.(env.String); ok {
+	arg0Val = string(vc.Value)
+} else {
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected string, but got "+objectDebugString(ps.Idx, arg0))
+}
+//ryegen:endconv
+res0, resErr := testmodule.Lookup(arg0Val)
+if resErr != nil {
+	ps.FailureFlag = true
+	return errorToRye(ps.Idx, resErr)
+}
+var res0Obj env.Object
+//ryegen:conv go-to-rye/stringable testmodule.Addr
+res0Obj = *env.NewString(res0.String())
+//ryegen:endconv
+return res0Obj
+
+//================================//
+
+var arg0Val *testmodule.Addr
+//ryegen:conv rye-to-go/stringable *testmodule.Addr
+switch v := arg0.(type) {
+case env.String:
+	parsed, err := testmodule.ParseAddr(v.Value)
+	if err != nil {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+err.Error())
+	}
+	arg0Val = &parsed
+case env.Native:
+	switch vc := v.Value.(type) {
+	case testmodule.Addr:
+		arg0Val = &vc
+	case *testmodule.Addr:
+		arg0Val = vc
+	default:
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native of type testmodule.Addr, but got "+objectDebugString(ps.Idx, v))
+	}
+case env.Void:
+	arg0Val = nil
+case env.Integer:
+	if v.Value != 0 {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected integer to be 0 or nil, but got "+strconv.FormatInt(v.Value, 10))
+	}
+	arg0Val = nil
+default:
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected string, native or nil, but got "+objectDebugString(ps.Idx, v))
+}
+//ryegen:endconv
+res0 := testmodule.Ping(arg0Val)
+var res0Obj env.Object
+//ryegen:conv go-to-rye/builtin bool
+res0Obj = *env.NewInteger(boolToInt64(res0))
+//ryegen:endconv
+return res0Obj
@@ -0,0 +1,10 @@
+package testfile
+
+type Event struct{}
+
+type Registry struct {
+	Name   string
+	Items  map[string]int
+	Events chan Event
+	closed bool
+}
@@ -0,0 +1,9 @@
+res := &testmodule.Registry{
+	Items: make(map[string]int),
+	Events: make(chan testmodule.Event, 8),
+}
+var resObj env.Object
+//ryegen:conv go-to-rye/native *testmodule.Registry
+resObj = *env.NewNative(ps.Idx, res, "Go(*testmodule.Registry)")
+//ryegen:endconv
+return resObj
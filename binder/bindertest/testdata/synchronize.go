@@ -0,0 +1,5 @@
+package testfile
+
+var Counter int
+
+var Name string
@@ -0,0 +1,32 @@
+globalsMu.RLock()
+val := testmodule.Counter
+globalsMu.RUnlock()
+var resObj env.Object
+//ryegen:conv go-to-rye/builtin int
+resObj = *env.NewInteger(int64(val))
+//ryegen:endconv
+return resObj
+
+//================================//
+
+var val int
+//ryegen:conv rye-to-go/builtin int
+if vc, ok := arg0.(env.Integer); ok {
+	val = int(vc.Value)
+} else {
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected integer, but got "+objectDebugString(ps.Idx, arg0))
+}
+//ryegen:endconv
+globalsMu.Lock()
+testmodule.Counter = val
+globalsMu.Unlock()
+return arg0
+
+//================================//
+
+var resObj env.Object
+//ryegen:conv go-to-rye/builtin string
+resObj = *env.NewString(testmodule.Name)
+//ryegen:endconv
+return resObj
@@ -0,0 +1,13 @@
+package testmodule
+
+import "time"
+
+type Event struct {
+	Name string
+	At   time.Time
+	Till *time.Time
+}
+
+func After(t time.Time, d time.Duration) time.Time { return t.Add(d) }
+
+func Deadline(t *time.Time) *time.Time { return t }
@@ -0,0 +1,190 @@
+Args:
+ * t - time
+ * d - Go(time.Duration)
+Result:
+ * time
+var arg0Val time.Time
+//ryegen:conv rye-to-go/time time.Time
+switch v := arg0.(type) {
+case env.Time:
+	arg0Val = v.Value
+case env.String:
+	parsed, err := time.Parse(time.RFC3339Nano, v.Value)
+	if err != nil {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+err.Error())
+	}
+	arg0Val = parsed
+case env.Native:
+	switch vc := v.Value.(type) {
+	case time.Time:
+		arg0Val = vc
+	case *time.Time:
+		arg0Val = *vc
+	default:
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native of type time.Time, but got "+objectDebugString(ps.Idx, v))
+	}
+default:
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected time, string or native, but got "+objectDebugString(ps.Idx, v))
+}
+//ryegen:endconv
+var arg1Val time.Duration
+//ryegen:conv rye-to-go/native time.Duration
+switch v := arg1.(type) {
+case env.Native:
+	if vc, ok := v.Value.(time.Duration); ok {
+		arg1Val = vc
+	} else {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"expected native of type time.Duration, but got "+objectDebugString(ps.Idx, v))
+	}
+default:
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"expected native, but got "+objectDebugString(ps.Idx, v))
+}
+//ryegen:endconv
+res0 := testmodule.After(arg0Val, arg1Val)
+var res0Obj env.Object
+//ryegen:conv go-to-rye/time time.Time
+res0Obj = *env.NewTime(res0)
+//ryegen:endconv
+return res0Obj
+Args:
+ * t - time
+Result:
+ * time
+var arg0Val *time.Time
+//ryegen:conv rye-to-go/time *time.Time
+switch v := arg0.(type) {
+case env.Time:
+	arg0Val = &v.Value
+case env.String:
+	parsed, err := time.Parse(time.RFC3339Nano, v.Value)
+	if err != nil {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+err.Error())
+	}
+	arg0Val = &parsed
+case env.Native:
+	switch vc := v.Value.(type) {
+	case time.Time:
+		arg0Val = &vc
+	case *time.Time:
+		arg0Val = vc
+	default:
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native of type time.Time, but got "+objectDebugString(ps.Idx, v))
+	}
+case env.Void:
+	arg0Val = nil
+case env.Integer:
+	if v.Value != 0 {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected integer to be 0 or nil, but got "+strconv.FormatInt(v.Value, 10))
+	}
+	arg0Val = nil
+default:
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected time, string or native, but got "+objectDebugString(ps.Idx, v))
+}
+//ryegen:endconv
+res0 := testmodule.Deadline(arg0Val)
+var res0Obj env.Object
+//ryegen:conv go-to-rye/time *time.Time
+if res0 == nil {
+	res0Obj = env.Void{}
+} else {
+	res0Obj = *env.NewTime(*res0)
+}
+//ryegen:endconv
+return res0Obj
+var self *testmodule.Event
+//ryegen:conv rye-to-go/native *testmodule.Event
+switch v := arg0.(type) {
+case env.Native:
+	if vc, ok := v.Value.(*testmodule.Event); ok {
+		self = vc
+	} else {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native of type *testmodule.Event, but got "+objectDebugString(ps.Idx, v))
+	}
+case env.Void:
+	self = nil
+case env.Integer:
+	if v.Value != 0 {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected integer to be 0 or nil, but got "+strconv.FormatInt(v.Value, 10))
+	}
+	self = nil
+default:
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native, but got "+objectDebugString(ps.Idx, v))
+}
+//ryegen:endconv
+if self == nil {
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected non-nil native")
+}
+var resObj env.Object
+//ryegen:conv go-to-rye/time time.Time
+resObj = *env.NewTime(self.At)
+//ryegen:endconv
+return resObj
+var self *testmodule.Event
+//ryegen:conv rye-to-go/native *testmodule.Event
+switch v := arg0.(type) {
+case env.Native:
+	if vc, ok := v.Value.(*testmodule.Event); ok {
+		self = vc
+	} else {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native of type *testmodule.Event, but got "+objectDebugString(ps.Idx, v))
+	}
+case env.Void:
+	self = nil
+case env.Integer:
+	if v.Value != 0 {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected integer to be 0 or nil, but got "+strconv.FormatInt(v.Value, 10))
+	}
+	self = nil
+default:
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native, but got "+objectDebugString(ps.Idx, v))
+}
+//ryegen:endconv
+if self == nil {
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected non-nil native")
+}
+var newVal time.Time
+//ryegen:conv rye-to-go/time time.Time
+switch v := arg1.(type) {
+case env.Time:
+	newVal = v.Value
+case env.String:
+	parsed, err := time.Parse(time.RFC3339Nano, v.Value)
+	if err != nil {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+err.Error())
+	}
+	newVal = parsed
+case env.Native:
+	switch vc := v.Value.(type) {
+	case time.Time:
+		newVal = vc
+	case *time.Time:
+		newVal = *vc
+	default:
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"expected native of type time.Time, but got "+objectDebugString(ps.Idx, v))
+	}
+default:
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"expected time, string or native, but got "+objectDebugString(ps.Idx, v))
+}
+//ryegen:endconv
+self.At = newVal
+return arg0
@@ -0,0 +1,10 @@
+// Stands in for the time package, which the time helpers are
+// generated for.
+package time
+
+type Time struct {
+	wall uint64
+	ext  int64
+}
+
+type Duration int64
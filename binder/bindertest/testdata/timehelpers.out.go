@@ -0,0 +1,275 @@
+Result:
+ * time
+res := testmodule.Now()
+var resObj env.Object
+//ryegen:conv go-to-rye/time testmodule.Time
+resObj = *env.NewTime(res)
+//ryegen:endconv
+return resObj
+
+//================================//
+
+Args:
+ * t - time or string
+Result:
+ * integer
+var t testmodule.Time
+//ryegen:conv rye-to-go/time testmodule.Time
+switch v := arg0.(type) {
+case env.Time:
+	t = v.Value
+case env.String:
+	parsed, err := testmodule.Parse(testmodule.RFC3339Nano, v.Value)
+	if err != nil {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+err.Error())
+	}
+	t = parsed
+case env.Native:
+	switch vc := v.Value.(type) {
+	case testmodule.Time:
+		t = vc
+	case *testmodule.Time:
+		t = *vc
+	default:
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native of type testmodule.Time, but got "+objectDebugString(ps.Idx, v))
+	}
+default:
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected time, string or native, but got "+objectDebugString(ps.Idx, v))
+}
+//ryegen:endconv
+res := testmodule.Since(t)
+var resObj env.Object
+//ryegen:conv go-to-rye/native testmodule.Duration
+//ryegen:conv go-to-rye/builtin int64
+resObj = *env.NewInteger(int64(int64(res)))
+//ryegen:endconv
+//ryegen:endconv
+return resObj
+
+//================================//
+
+Args:
+ * t - time or string
+ * d - native(time.Duration), integer or string
+Result:
+ * time
+var t testmodule.Time
+//ryegen:conv rye-to-go/time testmodule.Time
+switch v := arg0.(type) {
+case env.Time:
+	t = v.Value
+case env.String:
+	parsed, err := testmodule.Parse(testmodule.RFC3339Nano, v.Value)
+	if err != nil {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+err.Error())
+	}
+	t = parsed
+case env.Native:
+	switch vc := v.Value.(type) {
+	case testmodule.Time:
+		t = vc
+	case *testmodule.Time:
+		t = *vc
+	default:
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native of type testmodule.Time, but got "+objectDebugString(ps.Idx, v))
+	}
+default:
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected time, string or native, but got "+objectDebugString(ps.Idx, v))
+}
+//ryegen:endconv
+var d testmodule.Duration
+if s, ok := arg1.(env.String); ok {
+	var err error
+	d, err = testmodule.ParseDuration(s.Value)
+	if err != nil {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+err.Error())
+	}
+} else {
+	//ryegen:conv rye-to-go/typedef testmodule.Duration
+	{
+		nat, natOk := arg1.(env.Native)
+		var natValOk bool
+		var natVal testmodule.Duration
+		if natOk {
+			natVal, natValOk = nat.Value.(testmodule.Duration)
+		}
+		if natValOk {
+			d = natVal
+		} else {
+			var u int64
+			//ryegen:conv rye-to-go/builtin int64
+			if vc, ok := arg1.(env.Integer); ok {
+				u = int64(vc.Value)
+			} else {
+				ps.FailureFlag = true
+				return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"expected integer, but got "+objectDebugString(ps.Idx, arg1))
+			}
+			//ryegen:endconv
+			d = testmodule.Duration(u)
+		}
+	}
+	//ryegen:endconv
+}
+res := t.Add(d)
+var resObj env.Object
+//ryegen:conv go-to-rye/time testmodule.Time
+resObj = *env.NewTime(res)
+//ryegen:endconv
+return resObj
+
+//================================//
+
+Args:
+ * t - time or string
+ * layout - string
+Result:
+ * string
+var t testmodule.Time
+//ryegen:conv rye-to-go/time testmodule.Time
+switch v := arg0.(type) {
+case env.Time:
+	t = v.Value
+case env.String:
+	parsed, err := testmodule.Parse(testmodule.RFC3339Nano, v.Value)
+	if err != nil {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+err.Error())
+	}
+	t = parsed
+case env.Native:
+	switch vc := v.Value.(type) {
+	case testmodule.Time:
+		t = vc
+	case *testmodule.Time:
+		t = *vc
+	default:
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native of type testmodule.Time, but got "+objectDebugString(ps.Idx, v))
+	}
+default:
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected time, string or native, but got "+objectDebugString(ps.Idx, v))
+}
+//ryegen:endconv
+layoutNameObj, ok := arg1.(env.String)
+if !ok {
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"expected string, but got "+objectDebugString(ps.Idx, arg1))
+}
+layoutName := layoutNameObj.Value
+layout := layoutName
+switch layoutName {
+case "ansic":
+	layout = testmodule.ANSIC
+case "date-only":
+	layout = testmodule.DateOnly
+case "date-time":
+	layout = testmodule.DateTime
+case "kitchen":
+	layout = testmodule.Kitchen
+case "rfc1123":
+	layout = testmodule.RFC1123
+case "rfc1123z":
+	layout = testmodule.RFC1123Z
+case "rfc3339":
+	layout = testmodule.RFC3339
+case "rfc3339-nano":
+	layout = testmodule.RFC3339Nano
+case "rfc822":
+	layout = testmodule.RFC822
+case "rfc822z":
+	layout = testmodule.RFC822Z
+case "rfc850":
+	layout = testmodule.RFC850
+case "ruby-date":
+	layout = testmodule.RubyDate
+case "stamp":
+	layout = testmodule.Stamp
+case "stamp-micro":
+	layout = testmodule.StampMicro
+case "stamp-milli":
+	layout = testmodule.StampMilli
+case "stamp-nano":
+	layout = testmodule.StampNano
+case "time-only":
+	layout = testmodule.TimeOnly
+case "unix-date":
+	layout = testmodule.UnixDate
+}
+return *env.NewString(t.Format(layout))
+
+//================================//
+
+Args:
+ * layout - string
+ * value - string
+Result:
+ * time
+layoutNameObj, ok := arg0.(env.String)
+if !ok {
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected string, but got "+objectDebugString(ps.Idx, arg0))
+}
+layoutName := layoutNameObj.Value
+valueObj, ok := arg1.(env.String)
+if !ok {
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 2: "+"expected string, but got "+objectDebugString(ps.Idx, arg1))
+}
+value := valueObj.Value
+layout := layoutName
+switch layoutName {
+case "ansic":
+	layout = testmodule.ANSIC
+case "date-only":
+	layout = testmodule.DateOnly
+case "date-time":
+	layout = testmodule.DateTime
+case "kitchen":
+	layout = testmodule.Kitchen
+case "rfc1123":
+	layout = testmodule.RFC1123
+case "rfc1123z":
+	layout = testmodule.RFC1123Z
+case "rfc3339":
+	layout = testmodule.RFC3339
+case "rfc3339-nano":
+	layout = testmodule.RFC3339Nano
+case "rfc822":
+	layout = testmodule.RFC822
+case "rfc822z":
+	layout = testmodule.RFC822Z
+case "rfc850":
+	layout = testmodule.RFC850
+case "ruby-date":
+	layout = testmodule.RubyDate
+case "stamp":
+	layout = testmodule.Stamp
+case "stamp-micro":
+	layout = testmodule.StampMicro
+case "stamp-milli":
+	layout = testmodule.StampMilli
+case "stamp-nano":
+	layout = testmodule.StampNano
+case "time-only":
+	layout = testmodule.TimeOnly
+case "unix-date":
+	layout = testmodule.UnixDate
+}
+res, err := testmodule.Parse(layout, value)
+if err != nil {
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): "+err.Error())
+}
+var resObj env.Object
+//ryegen:conv go-to-rye/time testmodule.Time
+resObj = *env.NewTime(res)
+//ryegen:endconv
+return resObj
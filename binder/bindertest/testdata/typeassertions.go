@@ -0,0 +1,11 @@
+package testfile
+
+type Node interface {
+	Pos() int
+}
+
+type Ident struct {
+	Name string
+}
+
+func (x *Ident) Pos() int { return 0 }
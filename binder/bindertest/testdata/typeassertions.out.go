@@ -0,0 +1,72 @@
+var self testmodule.Node
+//ryegen:conv rye-to-go/native testmodule.Node
+switch v := arg0.(type) {
+case env.RyeCtx:
+	var err error
+	self, err = ctxTo_Node(ps, v)
+	if err != nil {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+err.Error())
+	}
+case env.Native:
+	if vc, ok := v.Value.(testmodule.Node); ok {
+		self = vc
+	} else {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native implementing testmodule.Node, but got "+objectDebugString(ps.Idx, v))
+	}
+case env.Void:
+	self = nil
+case env.Integer:
+	if v.Value != 0 {
+		ps.FailureFlag = true
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected integer to be 0 or nil, but got "+strconv.FormatInt(v.Value, 10))
+	}
+	self = nil
+default:
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native or context implementing testmodule.Node, but got "+objectDebugString(ps.Idx, v))
+}
+//ryegen:endconv
+if self == nil {
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected non-nil value")
+}
+return ifaceToNative(ps.Idx, self, "Go(testmodule.Node)").Kind
+
+//================================//
+
+nat, ok := arg0.(env.Native)
+if !ok {
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native, but got "+objectDebugString(ps.Idx, arg0))
+}
+var res *testmodule.Ident
+switch v := nat.Value.(type) {
+case *testmodule.Ident:
+	res = v
+case testmodule.Ident:
+	res = &v
+default:
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native of type *testmodule.Ident, but got "+objectDebugString(ps.Idx, arg0))
+}
+var resObj env.Object
+//ryegen:conv go-to-rye/native *testmodule.Ident
+resObj = *env.NewNative(ps.Idx, res, "Go(*testmodule.Ident)")
+//ryegen:endconv
+return resObj
+
+//================================//
+
+nat, ok := arg0.(env.Native)
+if !ok {
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native, but got "+objectDebugString(ps.Idx, arg0))
+}
+res, ok := nat.Value.(testmodule.Node)
+if !ok {
+	ps.FailureFlag = true
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native implementing testmodule.Node, but got "+objectDebugString(ps.Idx, arg0))
+}
+return *env.NewNative(ps.Idx, res, "Go(testmodule.Node)")
@@ -0,0 +1,16 @@
+package testfile
+
+type TextAlign int
+
+const (
+	TextAlignLeading TextAlign = iota
+	TextAlignCenter
+)
+
+type Mode int
+
+func (m Mode) String() string { return "" }
+
+const ModeFast Mode = 1
+
+const Plain = 3
@@ -0,0 +1,4 @@
+Result:
+ * Go(testmodule.TextAlign)
+
+return *env.NewNative(ps.Idx, testmodule.TextAlign(testmodule.TextAlignCenter), "Go(testmodule.TextAlign)")
@@ -0,0 +1,20 @@
+package testfile
+
+import "unsafe"
+
+func Deref(p unsafe.Pointer) int { return 0 }
+
+func Peek(addr uintptr) byte { return 0 }
+
+func NewFile(fd uintptr, name string) {}
+
+func Visit(cb func(data unsafe.Pointer)) {}
+
+func Addr() unsafe.Pointer { return nil }
+
+func Fd() uintptr { return 0 }
+
+type Buffer struct {
+	Data unsafe.Pointer
+	Len  int
+}
@@ -1,18 +1,18 @@
-type iface_testmodule_Example struct {
+type iface_Example struct {
 	self env.RyeCtx
 	fn_MyFn func(self env.RyeCtx, arg0 ...string)
 	fn_Unused func(self env.RyeCtx, arg0 int)
 }
 
-func (self *iface_testmodule_Example) MyFn(arg0 ...string) {
+func (self *iface_Example) MyFn(arg0 ...string) {
 	self.fn_MyFn(self.self, arg0)
 }
 
-func (self *iface_testmodule_Example) Unused(arg0 int) {
+func (self *iface_Example) Unused(arg0 int) {
 	self.fn_Unused(self.self, arg0)
 }
 
-func ctxTo_testmodule_Example(ps *env.ProgramState, v env.RyeCtx) (testmodule.Example, error) {
+func ctxTo_Example(ps *env.ProgramState, v env.RyeCtx) (testmodule.Example, error) {
 	words := v.GetWords(*ps.Idx).Series.S
 	wordToObj := make(map[string]env.Object, len(words))
 	for _, word := range words {
@@ -27,7 +27,7 @@ func ctxTo_testmodule_Example(ps *env.ProgramState, v env.RyeCtx) (testmodule.Ex
 		}
 		wordToObj[name] = obj
 	}
-	impl := &iface_testmodule_Example{
+	impl := &iface_Example{
 		self: v,
 	}
 	ctxObj0, ok := wordToObj["my-fn"]
@@ -41,13 +41,17 @@ func ctxTo_testmodule_Example(ps *env.ProgramState, v env.RyeCtx) (testmodule.Ex
 		}
 		impl.fn_MyFn = func(ctx env.RyeCtx, farg0 ...string) {
 			var farg0Val env.Object
+			//ryegen:conv go-to-rye/array []string
 			{
 				items := make([]env.Object, len(farg0))
 				for i, it := range farg0 {
+					//ryegen:conv go-to-rye/builtin string
 					items[i] = *env.NewString(it)
+					//ryegen:endconv
 				}
 				farg0Val = *env.NewBlock(*env.NewTSeries(items))
 			}
+			//ryegen:endconv
 			actualFn := fn
 			_ = actualFn
 			evaldo.CallFunctionArgsN(fn, ps, &ctx, farg0Val)
@@ -66,7 +70,9 @@ func ctxTo_testmodule_Example(ps *env.ProgramState, v env.RyeCtx) (testmodule.Ex
 		}
 		impl.fn_Unused = func(ctx env.RyeCtx, farg0 int) {
 			var farg0Val env.Object
+			//ryegen:conv go-to-rye/builtin int
 			farg0Val = *env.NewInteger(int64(farg0))
+			//ryegen:endconv
 			actualFn := fn
 			_ = actualFn
 			evaldo.CallFunctionArgsN(fn, ps, &ctx, farg0Val)
@@ -81,10 +87,11 @@ func ctxTo_testmodule_Example(ps *env.ProgramState, v env.RyeCtx) (testmodule.Ex
 //================================//
 
 var arg0Val testmodule.Example
+//ryegen:conv rye-to-go/native testmodule.Example
 switch v := arg0.(type) {
 case env.RyeCtx:
 	var err error
-	arg0Val, err = ctxTo_testmodule_Example(ps, v)
+	arg0Val, err = ctxTo_Example(ps, v)
 	if err != nil {
 		ps.FailureFlag = true
 		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+err.Error())
@@ -94,8 +101,10 @@ case env.Native:
 		arg0Val = vc
 	} else {
 		ps.FailureFlag = true
-		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native of type testmodule.Example, but got "+objectDebugString(ps.Idx, v))
+		return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native implementing testmodule.Example, but got "+objectDebugString(ps.Idx, v))
 	}
+case env.Void:
+	arg0Val = nil
 case env.Integer:
 	if v.Value != 0 {
 		ps.FailureFlag = true
@@ -104,14 +113,16 @@ case env.Integer:
 	arg0Val = nil
 default:
 	ps.FailureFlag = true
-	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native, but got "+objectDebugString(ps.Idx, v))
+	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected native or context implementing testmodule.Example, but got "+objectDebugString(ps.Idx, v))
 }
+//ryegen:endconv
 testmodule.DoSomething(arg0Val)
 return nil
 
 //================================//
 
 var arg0Val func(...any)
+//ryegen:conv rye-to-go/func func(...any)
 switch fn := arg0.(type) {
 case env.Function:
 	if fn.Argsn != 1 {
@@ -120,17 +131,23 @@ case env.Function:
 	}
 	arg0Val = func(farg0 ...any) {
 		var farg0Val env.Object
+		//ryegen:conv go-to-rye/array []any
 		{
 			items := make([]env.Object, len(farg0))
 			for i, it := range farg0 {
+				//ryegen:conv go-to-rye/native any
 				items[i] = *env.NewNative(ps.Idx, it, "Go(any)")
+				//ryegen:endconv
 			}
 			farg0Val = *env.NewBlock(*env.NewTSeries(items))
 		}
+		//ryegen:endconv
 		actualFn := fn
 		_ = actualFn
 		evaldo.CallFunctionArgsN(fn, ps, ps.Ctx, farg0Val)
 	}
+case env.Void:
+	arg0Val = nil
 case env.Integer:
 	if fn.Value != 0 {
 		ps.FailureFlag = true
@@ -141,5 +158,6 @@ default:
 	ps.FailureFlag = true
 	return env.NewError("((RYEGEN:FUNCNAME)): arg 1: "+"expected function or nil, but got "+objectDebugString(ps.Idx, fn))
 }
+//ryegen:endconv
 testmodule.Functor(arg0Val)
 return nil
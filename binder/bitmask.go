@@ -0,0 +1,175 @@
+package binder
+
+import (
+	"cmp"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"slices"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+	"github.com/refaktor/ryegen/binder/binderio"
+	"github.com/refaktor/ryegen/ir"
+)
+
+// Bitmasks lists the "<package path>.<Name>" of named integer types
+// holding sets of flags, which are converted to and from blocks of words
+// naming the flags (see [config.Config.Bitmasks] for adding more).
+// os.FileMode is an alias of io/fs.FileMode.
+var Bitmasks = []string{
+	"io/fs.FileMode",
+}
+
+// bitmaskFlag is a single-bit constant of a bitmask type.
+type bitmaskFlag struct {
+	// Rye word, e.g. "dir" for fs.ModeDir.
+	word string
+	// Go name of the constant (without qualifier), e.g. "ModeDir".
+	goName string
+	value  uint64
+}
+
+// bitmaskType reports whether typ is a bitmask type (see [Bitmasks]),
+// returning its name in generated code and its flags: the exported
+// single-bit constants of the type in its package, sorted by value.
+// The flags' words are their kebab-cased names without the prefix they
+// have in common (e.g. "mode-" for fs.ModeDir, fs.ModeAppend, ...).
+func bitmaskType(ctx *Context, typ ir.Ident) (name string, flags []bitmaskFlag, ok bool) {
+	modulePath, typeName, name, isPtr, ok := namedTypeRef(typ)
+	if !ok || isPtr {
+		return "", nil, false
+	}
+	key := modulePath + "." + typeName
+	ctx.bitmasks.mu.Lock()
+	defer ctx.bitmasks.mu.Unlock()
+	flags, ok = ctx.bitmasks.flags[key]
+	if !ok {
+		flags = bitmaskFlags(ctx, modulePath, typeName)
+		ctx.bitmasks.flags[key] = flags
+	}
+	if flags == nil {
+		return "", nil, false
+	}
+	return name, flags, true
+}
+
+// bitmaskFlags returns the flags of the named type typeName declared in
+// modulePath if it's a bitmask type (see [bitmaskType]), or else nil.
+func bitmaskFlags(ctx *Context, modulePath, typeName string) []bitmaskFlag {
+	key := modulePath + "." + typeName
+	if !slices.Contains(Bitmasks, key) && !slices.Contains(ctx.Config.Bitmasks, key) {
+		return nil
+	}
+	var flags []bitmaskFlag
+	for _, c := range typedConsts(ctx, modulePath, typeName) {
+		v, exact := constant.Uint64Val(c.val)
+		if !exact || v == 0 || v&(v-1) != 0 {
+			// Not a single flag, e.g. a mask like fs.ModePerm.
+			continue
+		}
+		flags = append(flags, bitmaskFlag{goName: c.goName, value: v})
+	}
+	if len(flags) == 0 {
+		return nil
+	}
+	slices.SortFunc(flags, func(a, b bitmaskFlag) int {
+		return cmp.Or(cmp.Compare(a.value, b.value), strings.Compare(a.goName, b.goName))
+	})
+	// Keep the first name of flags with the same value.
+	flags = slices.CompactFunc(flags, func(a, b bitmaskFlag) bool {
+		return a.value == b.value
+	})
+
+	goNames := make([]string, len(flags))
+	for i, f := range flags {
+		goNames[i] = f.goName
+	}
+	for i, word := range constWords(goNames) {
+		flags[i].word = word
+	}
+	return flags
+}
+
+// typedConst is an exported integer constant of a named type.
+type typedConst struct {
+	// Go name of the constant (without qualifier).
+	goName string
+	val    constant.Value
+}
+
+// typedConsts returns the exported integer constants of the named type
+// typeName declared in its package modulePath, in no particular order.
+func typedConsts(ctx *Context, modulePath, typeName string) []typedConst {
+	modName, ok := ctx.ModNames[modulePath]
+	if !ok {
+		return nil
+	}
+	var res []typedConst
+	for constKey, c := range ctx.IR.ConstValues {
+		goName, ok := strings.CutPrefix(constKey, modName+".")
+		if !ok || !token.IsExported(goName) || c.File == nil || c.File.ModulePath != modulePath {
+			continue
+		}
+		if id, ok := c.Type.(*ast.Ident); !ok || id.Name != typeName {
+			continue
+		}
+		val, err := ir.EvalConstExpr(ctx.IR.ConstValues, ctx.ModNames, c.File, &ast.Ident{Name: goName})
+		if err != nil || val.Kind() != constant.Int {
+			continue
+		}
+		res = append(res, typedConst{goName: goName, val: val})
+	}
+	return res
+}
+
+// constWords returns the Rye words naming the constants goNames: their
+// kebab-cased names without the prefix they have in common (e.g. "mode-"
+// for fs.ModeDir, fs.ModeAppend, ...).
+func constWords(goNames []string) []string {
+	words := make([][]string, len(goNames))
+	for i, goName := range goNames {
+		words[i] = strings.Split(strcase.ToKebab(goName), "-")
+	}
+	prefixLen := 0
+	if len(goNames) > 1 {
+	prefixLoop:
+		for ; ; prefixLen++ {
+			for _, w := range words {
+				if prefixLen >= len(w)-1 || w[prefixLen] != words[0][prefixLen] {
+					break prefixLoop
+				}
+			}
+		}
+	}
+	res := make([]string, len(goNames))
+	for i, w := range words {
+		res[i] = strings.Join(w[prefixLen:], "-")
+	}
+	return res
+}
+
+// bitmaskWords returns the words of flags, e.g. for error messages.
+func bitmaskWords(flags []bitmaskFlag) []string {
+	res := make([]string, len(flags))
+	for i, f := range flags {
+		res[i] = f.word
+	}
+	return res
+}
+
+// convRyeToGoAfter is like [ConvRyeToGo], but only tries the converters
+// following the one named after in [ConvListRyeToGo]. It lets converters
+// fall back to the regular conversion of a type for other inputs.
+func convRyeToGoAfter(after string, deps *Dependencies, ctx *Context, cb *binderio.CodeBuilder, typ ir.Ident, outVar, inVar string, argn int, makeRetConvErr func(inner string) string) bool {
+	idx := slices.IndexFunc(ConvListRyeToGo, func(conv Converter) bool { return conv.Name == after })
+	for _, conv := range ConvListRyeToGo[idx+1:] {
+		if converterDisabled(ctx, conv.Name) {
+			continue
+		}
+		if tryConv(deps, ctx, cb, ConvID{GoToRye: false, Name: conv.Name}, conv, typ, outVar, inVar, argn, makeRetConvErr) {
+			return true
+		}
+	}
+	return false
+}
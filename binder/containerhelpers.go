@@ -0,0 +1,317 @@
+package binder
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"slices"
+	"strings"
+
+	"github.com/refaktor/ryegen/binder/binderio"
+	"github.com/refaktor/ryegen/ir"
+)
+
+// ContainerHelperNames returns the Go-style names of the helper builtins
+// generated by [GenerateContainerHelper] for a named map or slice type
+// (e.g. http.Header), or nil if typ isn't one.
+//
+// Only types whose values are passed to Rye as natives (i.e. types with
+// methods) get helpers, since the others are converted to plain dicts
+// and blocks anyway. Helpers named like a method of the type are left
+// out, so e.g. http.Header's own Get, Set, Add and Del take precedence.
+func ContainerHelperNames(ctx *Context, typ ir.Ident) []string {
+	if typ.File == nil || !ir.IdentExprIsExported(typ.Expr) || ir.IdentIsInternal(ctx.ModNames, typ) {
+		return nil
+	}
+	if nativeGoToRyeShouldGetUnderlyingType(ctx, typ) {
+		return nil
+	}
+	underlying, ok := getUnderlyingType(ctx, typ)
+	if !ok {
+		return nil
+	}
+	var names []string
+	switch t := underlying.Expr.(type) {
+	case *ast.MapType:
+		names = []string{"Get", "Set!", "Del", "Len"}
+		if at, ok := t.Value.(*ast.ArrayType); ok && at.Len == nil {
+			// e.g. map[string][]string: append to a key's values
+			names = []string{"Get", "Set!", "Add", "Del", "Len"}
+		}
+	case *ast.ArrayType:
+		if t.Len != nil {
+			return nil
+		}
+		names = []string{"Get", "Set!", "Add", "Len"}
+	default:
+		return nil
+	}
+	return slices.DeleteFunc(names, func(name string) bool {
+		goName := strings.TrimSuffix(name, "!")
+		hasMethod := func(fn *ir.Func) bool { return fn.Name.Name == goName }
+		return slices.ContainsFunc(ctx.IR.TypeMethods[typ.Name], hasMethod) ||
+			slices.ContainsFunc(ctx.IR.TypeMethods["*"+typ.Name], hasMethod)
+	})
+}
+
+// GenerateContainerHelper generates one of the builtins returned by
+// [ContainerHelperNames] for a named map or slice type, which gives
+// access to its elements without converting it to a dict or block.
+func GenerateContainerHelper(deps *Dependencies, ctx *Context, typ ir.Ident, name string) (*BindingFunc, error) {
+	underlying, ok := getUnderlyingType(ctx, typ)
+	if !ok {
+		return nil, errors.New("expected named type " + typ.Name)
+	}
+
+	var keyTyp, elemTyp ir.Ident
+	isMap := false
+	switch t := underlying.Expr.(type) {
+	case *ast.MapType:
+		isMap = true
+		var err error
+		keyTyp, err = ir.NewIdent(ctx.IR.ConstValues, ctx.ModNames, underlying.File, t.Key)
+		if err != nil {
+			return nil, err
+		}
+		elemTyp, err = ir.NewIdent(ctx.IR.ConstValues, ctx.ModNames, underlying.File, t.Value)
+		if err != nil {
+			return nil, err
+		}
+	case *ast.ArrayType:
+		var err error
+		elemTyp, err = ir.NewIdent(ctx.IR.ConstValues, ctx.ModNames, underlying.File, t.Elt)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errors.New("expected map or slice type " + typ.Name)
+	}
+	if isMap && name == "Add" {
+		// Append to the values of a key.
+		at, ok := elemTyp.Expr.(*ast.ArrayType)
+		if !ok || at.Len != nil {
+			return nil, errors.New("expected map of slices " + typ.Name)
+		}
+		var err error
+		elemTyp, err = ir.NewIdent(ctx.IR.ConstValues, ctx.ModNames, elemTyp.File, at.Elt)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	recvTyp := typ
+	self := `self`
+	boxed := BoxesAsPointer(ctx, typ)
+	if boxed {
+		var err error
+		recvTyp, err = ir.NewIdent(ctx.IR.ConstValues, ctx.ModNames, typ.File, &ast.StarExpr{X: typ.Expr})
+		if err != nil {
+			return nil, err
+		}
+		self = `(*self)`
+	}
+
+	res := &BindingFunc{}
+	res.Category = "Container helpers"
+	res.Recv = recvTyp.RyeName()
+	res.Name = name
+	res.File = typ.File
+	res.Directives = typeExcludeDirectives(ctx, typ)
+
+	recvDesc, err := GetRyeTypeDesc(ctx, recvTyp.File, recvTyp.Expr)
+	if err != nil {
+		return nil, err
+	}
+	elemDesc, err := GetRyeTypeDesc(ctx, elemTyp.File, elemTyp.Expr)
+	if err != nil {
+		return nil, err
+	}
+	keyDesc := "integer"
+	if isMap {
+		keyDesc, err = GetRyeTypeDesc(ctx, keyTyp.File, keyTyp.Expr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var cb binderio.CodeBuilder
+
+	convArg := func(typ ir.Ident, outVar string, argn int) error {
+		cb.Linef(`var %v %v`, outVar, typ.Name)
+		deps.MarkUsed(typ)
+		if _, found := ConvRyeToGo(
+			deps,
+			ctx,
+			&cb,
+			typ,
+			outVar,
+			fmt.Sprintf(`arg%v`, argn),
+			argn,
+			makeMakeRetArgErr(argn),
+		); !found {
+			return errors.New("unhandled type conversion (rye to go): " + typ.Name)
+		}
+		return nil
+	}
+	convIndexArg := func(outVar string, argn int) {
+		cb.Linef(`%vObj, ok := arg%v.(env.Integer)`, outVar, argn)
+		cb.Linef(`if !ok {`)
+		cb.Indent++
+		cb.Append(makeMakeRetArgErr(argn)(fmt.Sprintf(`"expected integer, but got "+objectDebugString(ps.Idx, arg%v)`, argn)))
+		cb.Indent--
+		cb.Linef(`}`)
+		cb.Linef(`if %vObj.Value < 0 || %vObj.Value >= int64(len(%v)) {`, outVar, outVar, self)
+		cb.Indent++
+		cb.Append(makeMakeRetArgErr(argn)(fmt.Sprintf(`"index "+strconv.FormatInt(%vObj.Value, 10)+" out of range"`, outVar)))
+		deps.Imports["strconv"] = struct{}{}
+		cb.Indent--
+		cb.Linef(`}`)
+		cb.Linef(`%v := int(%vObj.Value)`, outVar, outVar)
+	}
+	convKeyArg := func(outVar string, argn int) error {
+		if isMap {
+			return convArg(keyTyp, outVar, argn)
+		}
+		convIndexArg(outVar, argn)
+		return nil
+	}
+	convResult := func(typ ir.Ident, inVar string) error {
+		cb.Linef(`var resObj env.Object`)
+		if _, found := ConvGoToRye(
+			deps,
+			ctx,
+			&cb,
+			typ,
+			`resObj`,
+			inVar,
+			-1,
+			nil,
+		); !found {
+			return errors.New("unhandled type conversion (go to rye): " + typ.Name)
+		}
+		cb.Linef(`return resObj`)
+		return nil
+	}
+
+	if err := convArg(recvTyp, `self`, 0); err != nil {
+		return nil, err
+	}
+	if boxed {
+		cb.Linef(`if self == nil {`)
+		cb.Indent++
+		cb.Append(makeMakeRetArgErr(0)(`"expected non-nil native"`))
+		cb.Indent--
+		cb.Linef(`}`)
+	}
+	if isMap && (name == "Set!" || name == "Add") {
+		cb.Linef(`if %v == nil {`, self)
+		cb.Indent++
+		cb.Append(makeMakeRetArgErr(0)(`"assignment to nil map"`))
+		cb.Indent--
+		cb.Linef(`}`)
+	}
+
+	switch name {
+	case "Get":
+		if isMap {
+			res.Doc = fmt.Sprintf("Get the value of a %v key", typ.Name)
+		} else {
+			res.Doc = fmt.Sprintf("Get a %v element by index", typ.Name)
+		}
+		res.Signature = Signature{Args: []SignatureArg{{"recv", recvDesc}, {"key", keyDesc}}, Results: []string{elemDesc}}
+		res.DocComment = res.Signature.DocComment()
+		res.Argsn = 2
+		if err := convKeyArg(`key`, 1); err != nil {
+			return nil, err
+		}
+		if isMap {
+			cb.Linef(`res, ok := %v[key]`, self)
+			cb.Linef(`if !ok {`)
+			cb.Indent++
+			cb.Linef(`ps.FailureFlag = true`)
+			cb.Linef(`return env.NewError("((RYEGEN:FUNCNAME)): key not found")`)
+			cb.Indent--
+			cb.Linef(`}`)
+		} else {
+			cb.Linef(`res := %v[key]`, self)
+		}
+		if err := convResult(elemTyp, `res`); err != nil {
+			return nil, err
+		}
+	case "Set!":
+		if isMap {
+			res.Doc = fmt.Sprintf("Set the value of a %v key", typ.Name)
+		} else {
+			res.Doc = fmt.Sprintf("Set a %v element by index", typ.Name)
+		}
+		res.Signature = Signature{Args: []SignatureArg{{"recv", recvDesc}, {"key", keyDesc}, {"value", elemDesc}}, Results: []string{recvDesc}}
+		res.DocComment = res.Signature.DocComment()
+		res.Argsn = 3
+		if err := convKeyArg(`key`, 1); err != nil {
+			return nil, err
+		}
+		if err := convArg(elemTyp, `value`, 2); err != nil {
+			return nil, err
+		}
+		cb.Linef(`%v[key] = value`, self)
+		cb.Linef(`return arg0`)
+	case "Add":
+		if isMap {
+			res.Doc = fmt.Sprintf("Append a value to the values of a %v key", typ.Name)
+			res.Signature = Signature{Args: []SignatureArg{{"recv", recvDesc}, {"key", keyDesc}, {"value", elemDesc}}, Results: []string{recvDesc}}
+			res.DocComment = res.Signature.DocComment()
+			res.Argsn = 3
+			if err := convArg(keyTyp, `key`, 1); err != nil {
+				return nil, err
+			}
+			if err := convArg(elemTyp, `value`, 2); err != nil {
+				return nil, err
+			}
+			cb.Linef(`%v[key] = append(%v[key], value)`, self, self)
+			cb.Linef(`return arg0`)
+		} else {
+			if boxed {
+				res.Doc = fmt.Sprintf("Append a value to a %v", typ.Name)
+			} else {
+				res.Doc = fmt.Sprintf("Get a copy of a %v with a value appended", typ.Name)
+			}
+			res.Signature = Signature{Args: []SignatureArg{{"recv", recvDesc}, {"value", elemDesc}}, Results: []string{recvDesc}}
+			res.DocComment = res.Signature.DocComment()
+			res.Argsn = 2
+			if err := convArg(elemTyp, `value`, 1); err != nil {
+				return nil, err
+			}
+			if boxed {
+				cb.Linef(`*self = append(*self, value)`)
+				cb.Linef(`return arg0`)
+			} else {
+				cb.Linef(`res := append(self, value)`)
+				if err := convResult(typ, `res`); err != nil {
+					return nil, err
+				}
+			}
+		}
+	case "Del":
+		res.Doc = fmt.Sprintf("Delete a %v key", typ.Name)
+		res.Signature = Signature{Args: []SignatureArg{{"recv", recvDesc}, {"key", keyDesc}}, Results: []string{recvDesc}}
+		res.DocComment = res.Signature.DocComment()
+		res.Argsn = 2
+		if err := convArg(keyTyp, `key`, 1); err != nil {
+			return nil, err
+		}
+		cb.Linef(`delete(%v, key)`, self)
+		cb.Linef(`return arg0`)
+	case "Len":
+		res.Doc = fmt.Sprintf("Get the number of elements in a %v", typ.Name)
+		res.Signature = Signature{Args: []SignatureArg{{"recv", recvDesc}}, Results: []string{"integer"}}
+		res.DocComment = res.Signature.DocComment()
+		res.Argsn = 1
+		cb.Linef(`return *env.NewInteger(int64(len(%v)))`, self)
+	default:
+		return nil, errors.New("unknown container helper " + name)
+	}
+
+	res.Body = cb.String()
+
+	return res, nil
+}
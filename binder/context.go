@@ -1,21 +1,96 @@
 package binder
 
 import (
+	"sync"
+
 	"github.com/refaktor/ryegen/config"
 	"github.com/refaktor/ryegen/ir"
 )
 
-// Immutable
+// Context holds the configuration and input of code generation. Its
+// exported fields must not be modified once generation starts, so it's
+// safe for concurrent use, e.g. by parallel binding generation. Its
+// caches (bitmask flags and interface package paths) are filled lazily,
+// each guarded by its own mutex or sync.Once. They're shared with the
+// copies made for nested conversions.
 type Context struct {
 	Config   *config.Config
 	IR       *ir.IR
 	ModNames ir.UniqueModuleNames
+	// Hand-patched code replacing generated conversions by key (see
+	// [ConvKey] and [ParseFrozenConvs]).
+	FrozenConvs map[string]string
+	// Short native kinds by their Go kinds (see [ShortKinds]), used in
+	// place of the Go kinds if set (see [KindName]).
+	Kinds map[string]string
+
+	// Remaining nesting levels of struct values converted to dicts
+	// (see [config.Config.DictStructs]).
+	dictStructDepth int
+	// Number of enclosing block conversion loops, used to give their
+	// index variables distinct names.
+	blockDepth int
+
+	// Flags of bitmask types, shared by derived contexts (see
+	// [bitmaskType]).
+	bitmasks *bitmaskCache
+	// Package paths of interfaces by type name, shared by derived
+	// contexts (see [StableTypeName]).
+	ifacePaths *ifacePathCache
+}
+
+// bitmaskCache holds the flags of bitmask types by
+// "<package path>.<Name>", nil for named types which aren't bitmasks,
+// since finding them scans all constants.
+type bitmaskCache struct {
+	mu    sync.Mutex
+	flags map[string][]bitmaskFlag
+}
+
+// ifacePathCache holds the package paths of the interfaces in the IR by
+// type name, computed on first use.
+type ifacePathCache struct {
+	once  sync.Once
+	paths map[string]map[string]struct{}
 }
 
 func NewContext(cfg *config.Config, irData *ir.IR, modNames ir.UniqueModuleNames) *Context {
 	return &Context{
-		Config:   cfg,
-		IR:       irData,
-		ModNames: modNames,
+		Config:     cfg,
+		IR:         irData,
+		ModNames:   modNames,
+		bitmasks:   &bitmaskCache{flags: make(map[string][]bitmaskFlag)},
+		ifacePaths: &ifacePathCache{},
+	}
+}
+
+// KindName returns the native kind of values of typ, e.g.
+// "Go(*widget.Button)", or its short kind (e.g. "widget-button") if in
+// ctx.Kinds.
+func KindName(ctx *Context, typ ir.Ident) string {
+	return ctx.kind(typ.RyeName())
+}
+
+// kind returns the short kind of the Go kind long if in ctx.Kinds, or
+// else long.
+func (ctx *Context) kind(long string) string {
+	if short, ok := ctx.Kinds[long]; ok {
+		return short
+	}
+	return long
+}
+
+// Maximum nesting depth of struct values converted to dicts, bounding
+// the generated code for recursive types.
+const maxDictStructDepth = 4
+
+// mapValueContext returns the context to convert map values in, which
+// enables converting structs in [config.Config.DictStructs] to dicts.
+func (ctx *Context) mapValueContext() *Context {
+	if len(ctx.Config.DictStructs) == 0 || ctx.dictStructDepth > 0 {
+		return ctx
 	}
+	res := *ctx
+	res.dictStructDepth = maxDictStructDepth
+	return &res
 }
@@ -10,7 +10,9 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/iancoleman/strcase"
 	"github.com/refaktor/ryegen/binder/binderio"
+	"github.com/refaktor/ryegen/config"
 	"github.com/refaktor/ryegen/ir"
 )
 
@@ -19,6 +21,28 @@ func GetRyeTypeDesc(ctx *Context, file *ir.File, expr ast.Expr) (ident string, e
 	if err != nil {
 		return "", err
 	}
+	if _, _, ok := bigIntType(exprId); ok {
+		return "integer or string", nil
+	}
+	if _, _, ok := timeType(exprId); ok {
+		return "time", nil
+	}
+	if _, _, _, _, ok := stringableType(ctx, exprId); ok {
+		return "string or native", nil
+	}
+	if _, _, ok := bitmaskType(ctx, exprId); ok {
+		return "block[word or integer]", nil
+	}
+	if _, _, ok := enumType(ctx, exprId); ok {
+		return "word, string or integer", nil
+	}
+	if cases, ok := oneofCases(ctx, exprId); ok {
+		names := make([]string, len(cases))
+		for i, c := range cases {
+			names[i] = c.field.ryeName
+		}
+		return "dict with one of " + strings.Join(names, ", "), nil
+	}
 	shouldGetUnderlying := nativeGoToRyeShouldGetUnderlyingType(ctx, exprId)
 	if shouldGetUnderlying {
 		underlying, ok := getUnderlyingType(ctx, exprId)
@@ -46,7 +70,7 @@ func GetRyeTypeDesc(ctx *Context, file *ir.File, expr ast.Expr) (ident string, e
 			if err != nil {
 				return "", err
 			}
-			return id.RyeName(), nil
+			return KindName(ctx, id), nil
 		}
 		return name, nil
 	case *ast.StarExpr:
@@ -75,7 +99,7 @@ func GetRyeTypeDesc(ctx *Context, file *ir.File, expr ast.Expr) (ident string, e
 			}
 		}
 		if shouldGetRyeGoName {
-			return exprId.RyeName(), nil
+			return KindName(ctx, exprId), nil
 		} else {
 			name, err := GetRyeTypeDesc(ctx, file, expr.X)
 			if err != nil {
@@ -93,12 +117,35 @@ func GetRyeTypeDesc(ctx *Context, file *ir.File, expr ast.Expr) (ident string, e
 			return "", fmt.Errorf("module %v imported by %v not found", mod.Name, file.Name)
 		}
 		return GetRyeTypeDesc(ctx, f, expr.Sel)
-	case *ast.InterfaceType, *ast.StructType, *ast.ChanType:
+	case *ast.StructType:
+		exprId, err := ir.NewIdent(ctx.IR.ConstValues, ctx.ModNames, file, expr)
+		if err != nil {
+			return "", err
+		}
+		fields, ok := getAnonStructFields(ctx, exprId)
+		if !ok {
+			return KindName(ctx, exprId), nil
+		}
+		var res strings.Builder
+		res.WriteString("dict{")
+		for i, f := range fields {
+			if i != 0 {
+				res.WriteString(", ")
+			}
+			name, err := GetRyeTypeDesc(ctx, f.typ.File, f.typ.Expr)
+			if err != nil {
+				return "", err
+			}
+			fmt.Fprintf(&res, "%v: %v", f.ryeName, name)
+		}
+		res.WriteString("}")
+		return res.String(), nil
+	case *ast.InterfaceType, *ast.ChanType:
 		id, err := ir.NewIdent(ctx.IR.ConstValues, ctx.ModNames, file, expr)
 		if err != nil {
 			return "", err
 		}
-		return id.RyeName(), nil
+		return KindName(ctx, id), nil
 	case *ast.Ellipsis:
 		name, err := GetRyeTypeDesc(ctx, file, expr.Elt)
 		if err != nil {
@@ -195,7 +242,10 @@ type Converter struct {
 
 func ConvRyeToGo(deps *Dependencies, ctx *Context, cb *binderio.CodeBuilder, typ ir.Ident, outVar, inVar string, argn int, makeRetConvErr func(inner string) string) (string, bool) {
 	for _, conv := range ConvListRyeToGo {
-		if conv.TryConv(deps, ctx, cb, typ, outVar, inVar, argn, makeRetConvErr) {
+		if converterDisabled(ctx, conv.Name) {
+			continue
+		}
+		if tryConv(deps, ctx, cb, ConvID{GoToRye: false, Name: conv.Name}, conv, typ, outVar, inVar, argn, makeRetConvErr) {
 			return conv.Name, true
 		}
 	}
@@ -204,13 +254,205 @@ func ConvRyeToGo(deps *Dependencies, ctx *Context, cb *binderio.CodeBuilder, typ
 
 func ConvGoToRye(deps *Dependencies, ctx *Context, cb *binderio.CodeBuilder, typ ir.Ident, outVar, inVar string, argn int, makeRetConvErr func(inner string) string) (string, bool) {
 	for _, conv := range ConvListGoToRye {
-		if conv.TryConv(deps, ctx, cb, typ, outVar, inVar, argn, makeRetConvErr) {
+		if converterDisabled(ctx, conv.Name) {
+			continue
+		}
+		if tryConv(deps, ctx, cb, ConvID{GoToRye: true, Name: conv.Name}, conv, typ, outVar, inVar, argn, makeRetConvErr) {
 			return conv.Name, true
 		}
 	}
 	return "", false
 }
 
+// converterDisabled reports whether the converter with the given name is
+// disabled by [config.Config.DisableConverters]. Values of the affected
+// types are passed as natives instead.
+func converterDisabled(ctx *Context, name string) bool {
+	return slices.Contains(ctx.Config.DisableConverters, name)
+}
+
+// namedTypeRef reports the module path and Go name of the named type
+// typ refers to, also looking through one pointer. name is the
+// (non-pointer) type name as used in generated code.
+func namedTypeRef(typ ir.Ident) (modulePath, typeName, name string, isPtr bool, ok bool) {
+	if typ.File == nil {
+		return "", "", "", false, false
+	}
+	expr := typ.Expr
+	name = typ.Name
+	if se, ok := expr.(*ast.StarExpr); ok {
+		expr = se.X
+		name = strings.TrimPrefix(name, "*")
+		isPtr = true
+	}
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		return typ.File.ModulePath, expr.Name, name, isPtr, true
+	case *ast.SelectorExpr:
+		x, ok := expr.X.(*ast.Ident)
+		if !ok {
+			return "", "", "", false, false
+		}
+		imp, ok := typ.File.ImportsByName[x.Name]
+		if !ok {
+			return "", "", "", false, false
+		}
+		return imp.ModulePath, expr.Sel.Name, name, isPtr, true
+	default:
+		return "", "", "", false, false
+	}
+}
+
+// bigIntType reports whether typ is math/big.Int or *math/big.Int,
+// returning the type name of the (non-pointer) big.Int.
+func bigIntType(typ ir.Ident) (name string, isPtr bool, ok bool) {
+	modulePath, typeName, name, isPtr, ok := namedTypeRef(typ)
+	if !ok || modulePath != "math/big" || typeName != "Int" {
+		return "", false, false
+	}
+	return name, isPtr, true
+}
+
+// timeType reports whether typ is time.Time or *time.Time, returning
+// the type name of the (non-pointer) time.Time. Times are converted to
+// and from Rye times, keeping their location.
+func timeType(typ ir.Ident) (name string, isPtr bool, ok bool) {
+	modulePath, typeName, name, isPtr, ok := namedTypeRef(typ)
+	if !ok || modulePath != "time" || typeName != "Time" {
+		return "", false, false
+	}
+	return name, isPtr, true
+}
+
+// reflectType reports whether typ is reflect.Value or reflect.Type,
+// returning the type name and "Value" or "Type".
+func reflectType(typ ir.Ident) (name, typeName string, ok bool) {
+	modulePath, typeName, name, isPtr, ok := namedTypeRef(typ)
+	if !ok || isPtr || modulePath != "reflect" || (typeName != "Value" && typeName != "Type") {
+		return "", "", false
+	}
+	return name, typeName, true
+}
+
+// defaultStringables maps "<package path>.<Name>" of types with a
+// canonical string form to how they are converted to and from Rye
+// strings. Values of these types and pointers to them are passed as
+// strings unless listed in [config.Config.NativeStringable]. More types
+// can be added with [config.Config.Stringables].
+var defaultStringables = map[string]config.Stringable{
+	"net/netip.Addr":                {ParseFunc: "ParseAddr"},
+	"net/netip.AddrPort":            {ParseFunc: "ParseAddrPort"},
+	"net/netip.Prefix":              {ParseFunc: "ParsePrefix"},
+	"net/url.URL":                   {ParseFunc: "Parse", ParseReturnsPtr: true, PtrString: true},
+	"net/mail.Address":              {ParseFunc: "ParseAddress", ParseReturnsPtr: true, PtrString: true},
+	"github.com/google/uuid.UUID":   {ParseFunc: "Parse"},
+	"github.com/gofrs/uuid.UUID":    {ParseFunc: "FromString"},
+	"github.com/gofrs/uuid/v5.UUID": {ParseFunc: "FromString"},
+}
+
+// stringableType reports whether typ is (a pointer to) a type in
+// [config.Config.Stringables] or [defaultStringables], returning the
+// (non-pointer) type name and "<package path>.<Name>".
+func stringableType(ctx *Context, typ ir.Ident) (name, key string, st config.Stringable, isPtr bool, ok bool) {
+	modulePath, typeName, name, isPtr, ok := namedTypeRef(typ)
+	if !ok {
+		return "", "", config.Stringable{}, false, false
+	}
+	key = modulePath + "." + typeName
+	st, ok = ctx.Config.Stringables[key]
+	if !ok {
+		st, ok = defaultStringables[key]
+	}
+	if !ok {
+		return "", "", config.Stringable{}, false, false
+	}
+	return name, key, st, isPtr, true
+}
+
+// getDictStructFields returns the fields of a named struct type in
+// [config.Config.DictStructs] if it is converted to and from a dict in
+// the current context, i.e. inside a map value (see
+// [Context.mapValueContext]), along with the context to convert the
+// fields in. The struct is recorded in deps.DictStructs.
+func getDictStructFields(deps *Dependencies, ctx *Context, typ ir.Ident) (fields []anonStructField, fieldCtx *Context, ok bool) {
+	if ctx.dictStructDepth <= 0 {
+		return nil, nil, false
+	}
+	modulePath, typeName, _, isPtr, ok := namedTypeRef(typ)
+	if !ok || isPtr {
+		return nil, nil, false
+	}
+	if !slices.Contains(ctx.Config.DictStructs, "*") && !slices.Contains(ctx.Config.DictStructs, modulePath+"."+typeName) {
+		return nil, nil, false
+	}
+	struc, ok := ctx.IR.Structs[typ.Name]
+	if !ok || len(struc.Inherits) > 0 {
+		// Embedded fields wouldn't survive a round trip.
+		return nil, nil, false
+	}
+	for _, f := range struc.Fields {
+		fields = append(fields, anonStructField{
+			goName:  f.Name.Name,
+			ryeName: strcase.ToKebab(f.Name.Name),
+			typ:     f.Type,
+		})
+	}
+	deps.DictStructs[typ.Name] = struct{}{}
+	nested := *ctx
+	nested.dictStructDepth--
+	return fields, &nested, true
+}
+
+type oneofCase struct {
+	wrapper ir.Ident // wrapper type implementing the oneof interface
+	isPtr   bool     // whether wrapper is a pointer
+	field   anonStructField
+}
+
+// oneofCases returns the cases of a oneof field type as generated by
+// protoc-gen-go: an unexported interface with a marker method of the
+// same name, implemented by wrapper structs with a single field. Only
+// returns ok if the "codegen-friendly" option is set.
+func oneofCases(ctx *Context, typ ir.Ident) (cases []oneofCase, ok bool) {
+	if !ctx.Config.CodegenFriendly {
+		return nil, false
+	}
+	impls, ok := ctx.IR.MarkerImpls[typ.Name]
+	if !ok || ir.IdentExprIsExported(typ.Expr) {
+		return nil, false
+	}
+	for _, impl := range impls {
+		struc, ok := ctx.IR.Structs[strings.TrimPrefix(impl.Name, "*")]
+		if !ok || len(struc.Fields) != 1 {
+			return nil, false
+		}
+		f := struc.Fields[0]
+		_, isPtr := impl.Expr.(*ast.StarExpr)
+		cases = append(cases, oneofCase{
+			wrapper: impl,
+			isPtr:   isPtr,
+			field: anonStructField{
+				goName:  f.Name.Name,
+				ryeName: strcase.ToKebab(f.Name.Name),
+				typ:     f.Type,
+			},
+		})
+	}
+	slices.SortFunc(cases, func(a, b oneofCase) int {
+		return strings.Compare(a.field.ryeName, b.field.ryeName)
+	})
+	return cases, true
+}
+
+// coercionEnabled reports whether the implicit conversion is enabled for
+// the package typ is used in.
+func coercionEnabled(ctx *Context, typ ir.Ident, coercion string) bool {
+	if typ.File == nil {
+		return false
+	}
+	return ctx.Config.CoercionEnabled(typ.File.ModulePath, coercion)
+}
+
 // Resolves the typedef chain. Won't resolve to an internal type.
 func getUnderlyingType(ctx *Context, typ ir.Ident) (ir.Ident, bool) {
 	retOk := false
@@ -226,6 +468,127 @@ func getUnderlyingType(ctx *Context, typ ir.Ident) (ir.Ident, bool) {
 	return typ, retOk
 }
 
+type anonStructField struct {
+	goName  string
+	ryeName string
+	typ     ir.Ident
+}
+
+// Returns the fields of an anonymous struct type. Only returns ok if
+// all fields are named and exported, since otherwise the struct
+// cannot be constructed outside its package. Embedded interfaces (e.g.
+// struct{ io.Reader; Name string }) are fields named by their type;
+// other embedded fields are unsupported, since their promoted fields
+// wouldn't survive a round trip.
+func getAnonStructFields(ctx *Context, typ ir.Ident) (fields []anonStructField, ok bool) {
+	st, ok := typ.Expr.(*ast.StructType)
+	if !ok {
+		return nil, false
+	}
+	for _, f := range st.Fields.List {
+		fTyp, err := ir.NewIdent(ctx.IR.ConstValues, ctx.ModNames, typ.File, f.Type)
+		if err != nil {
+			return nil, false
+		}
+		if len(f.Names) == 0 {
+			name, ok := embeddedInterfaceName(ctx, fTyp)
+			if !ok {
+				return nil, false
+			}
+			fields = append(fields, anonStructField{
+				goName:  name,
+				ryeName: strcase.ToKebab(name),
+				typ:     fTyp,
+			})
+			continue
+		}
+		for _, name := range f.Names {
+			if !name.IsExported() {
+				return nil, false
+			}
+			fields = append(fields, anonStructField{
+				goName:  name.Name,
+				ryeName: strcase.ToKebab(name.Name),
+				typ:     fTyp,
+			})
+		}
+	}
+	return fields, true
+}
+
+// embeddedInterfaceName returns the field name of the embedded field
+// of type typ (e.g. Reader for io.Reader) if typ is an exported
+// interface.
+func embeddedInterfaceName(ctx *Context, typ ir.Ident) (string, bool) {
+	var name *ast.Ident
+	switch expr := typ.Expr.(type) {
+	case *ast.Ident:
+		name = expr
+	case *ast.SelectorExpr:
+		name = expr.Sel
+	default:
+		return "", false
+	}
+	if !name.IsExported() {
+		return "", false
+	}
+	if _, ok := ctx.IR.Interfaces[typ.Name]; !ok {
+		return "", false
+	}
+	return name.Name, true
+}
+
+// maxSmallStructFields is the maximum number of fields of structs
+// converted by the "smallstruct" converters.
+const maxSmallStructFields = 4
+
+// getSmallStructFields returns the fields of anonymous and dict structs
+// (see [getDictStructFields]) with at most [maxSmallStructFields]
+// fields of basic types, e.g. points, sizes and rectangles. They're
+// frequently converted (e.g. in GUI callbacks), so they're converted
+// field by field without going through the generic struct code.
+func getSmallStructFields(deps *Dependencies, ctx *Context, typ ir.Ident) (fields []anonStructField, fieldCtx *Context, ok bool) {
+	fields, ok = getAnonStructFields(ctx, typ)
+	fieldCtx = ctx
+	if !ok {
+		fields, fieldCtx, ok = getDictStructFields(deps, ctx, typ)
+		if !ok {
+			return nil, nil, false
+		}
+	}
+	if len(fields) == 0 || len(fields) > maxSmallStructFields {
+		return nil, nil, false
+	}
+	for _, f := range fields {
+		if _, ok := basicGoToRyeExpr(f.typ, ""); !ok {
+			return nil, nil, false
+		}
+	}
+	return fields, fieldCtx, true
+}
+
+// basicGoToRyeExpr returns an expression converting inVar of the basic
+// type typ to a Rye object. Returns false for other types, error, and
+// uint and uint64 (whose values may not fit into a Rye integer).
+func basicGoToRyeExpr(typ ir.Ident, inVar string) (string, bool) {
+	id, ok := typ.Expr.(*ast.Ident)
+	if !ok {
+		return "", false
+	}
+	switch id.Name {
+	case "int", "uint8", "uint16", "uint32", "int8", "int16", "int32", "int64", "byte":
+		return fmt.Sprintf(`*env.NewInteger(int64(%v))`, inVar), true
+	case "bool":
+		return fmt.Sprintf(`*env.NewInteger(boolToInt64(%v))`, inVar), true
+	case "float32", "float64":
+		return fmt.Sprintf(`*env.NewDecimal(float64(%v))`, inVar), true
+	case "string":
+		return fmt.Sprintf(`*env.NewString(%v)`, inVar), true
+	default:
+		return "", false
+	}
+}
+
 // If conversion lists are declared directly, the compiler falsely complains of an initialization cycle.
 var ConvListRyeToGo []Converter
 var ConvListGoToRye []Converter
@@ -235,7 +598,12 @@ func init() {
 	ConvListGoToRye = convListGoToRye
 }
 
+// convRyeToGoCodeCaseNil writes the type switch cases converting Rye's
+// representations of nil to nil: void, and the integer 0 accepted by
+// older bindings. Go's nil is converted to void (see
+// [convGoToRyeCodeNil]).
 func convRyeToGoCodeCaseNil(deps *Dependencies, cb *binderio.CodeBuilder, outVar, inVar string, makeRetConvErr func(inner string) string) {
+	convRyeToGoCodeCaseVoid(cb, outVar, "nil")
 	cb.Linef(`case env.Integer:`)
 	cb.Indent++
 	cb.Linef(`if %v.Value != 0 {`, inVar)
@@ -248,6 +616,276 @@ func convRyeToGoCodeCaseNil(deps *Dependencies, cb *binderio.CodeBuilder, outVar
 	cb.Indent--
 }
 
+// convRyeToGoCodeCaseVoid writes only the void case of
+// [convRyeToGoCodeCaseNil], assigning zero, for types with a zero value
+// other than nil (e.g. reflect.Value) or an integer representation (e.g.
+// *big.Int).
+func convRyeToGoCodeCaseVoid(cb *binderio.CodeBuilder, outVar, zero string) {
+	cb.Linef(`case env.Void:`)
+	cb.Indent++
+	cb.Linef(`%v = %v`, outVar, zero)
+	cb.Indent--
+}
+
+// convGoToRyeCodeNil writes the conversion of a Go value to void if the
+// condition isNil holds (see [convRyeToGoCodeCaseNil]), and by writeConv
+// otherwise.
+func convGoToRyeCodeNil(cb *binderio.CodeBuilder, outVar, isNil string, writeConv func()) {
+	cb.Linef(`if %v {`, isNil)
+	cb.Indent++
+	cb.Linef(`%v = env.Void{}`, outVar)
+	cb.Indent--
+	cb.Linef(`} else {`)
+	cb.Indent++
+	writeConv()
+	cb.Indent--
+	cb.Linef(`}`)
+}
+
+// convRyeToGoCodeStruct writes the conversion of a dict, block or native
+// to an anonymous or dict struct with the given fields. If direct is set,
+// dicts are converted by looking up each field instead of iterating over
+// the dict (see [getSmallStructFields]).
+func convRyeToGoCodeStruct(deps *Dependencies, ctx, fieldCtx *Context, cb *binderio.CodeBuilder, typ ir.Ident, fields []anonStructField, direct bool, outVar, inVar string, argn int, makeRetConvErr func(inner string) string) bool {
+	convField := func(inFieldVar, inValVar string) bool {
+		cb.Linef(`switch %v {`, inFieldVar)
+		for _, f := range fields {
+			if f.ryeName == f.goName {
+				cb.Linef(`case "%v":`, f.goName)
+			} else {
+				cb.Linef(`case "%v", "%v":`, f.goName, f.ryeName)
+			}
+			cb.Indent++
+			if _, found := ConvRyeToGo(
+				deps,
+				fieldCtx,
+				cb,
+				f.typ,
+				outVar+`.`+f.goName,
+				inValVar,
+				argn,
+				func(inner string) string {
+					return makeRetConvErr(`"field ` + f.ryeName + `: "+` + inner)
+				},
+			); !found {
+				return false
+			}
+			cb.Indent--
+		}
+		cb.Linef(`default:`)
+		cb.Indent++
+		cb.Append(makeRetConvErr(fmt.Sprintf(`"unknown struct field "+%v`, inFieldVar)))
+		cb.Indent--
+		cb.Linef(`}`)
+		return true
+	}
+
+	cb.Linef(`switch v := %v.(type) {`, inVar)
+	cb.Linef(`case env.Dict:`)
+	cb.Indent++
+	if direct {
+		cb.Linef(`nFound := 0`)
+		var names []string
+		for _, f := range fields {
+			names = append(names, `"`+f.goName+`"`)
+			cb.Linef(`{`)
+			cb.Indent++
+			cb.Linef(`dictV, ok := v.Data["%v"]`, f.goName)
+			if f.ryeName != f.goName {
+				names = append(names, `"`+f.ryeName+`"`)
+				cb.Linef(`if !ok {`)
+				cb.Indent++
+				cb.Linef(`dictV, ok = v.Data["%v"]`, f.ryeName)
+				cb.Indent--
+				cb.Linef(`}`)
+			}
+			cb.Linef(`if ok {`)
+			cb.Indent++
+			cb.Linef(`nFound++`)
+			if _, found := ConvRyeToGo(
+				deps,
+				fieldCtx,
+				cb,
+				f.typ,
+				outVar+`.`+f.goName,
+				`dictV`,
+				argn,
+				func(inner string) string {
+					return makeRetConvErr(`"field ` + f.ryeName + `: "+` + inner)
+				},
+			); !found {
+				return false
+			}
+			cb.Indent--
+			cb.Linef(`}`)
+			cb.Indent--
+			cb.Linef(`}`)
+		}
+		cb.Linef(`if nFound != len(v.Data) {`)
+		cb.Indent++
+		cb.Linef(`for dictK := range v.Data {`)
+		cb.Indent++
+		cb.Linef(`switch dictK {`)
+		cb.Linef(`case %v:`, strings.Join(names, ", "))
+		cb.Linef(`default:`)
+		cb.Indent++
+		cb.Append(makeRetConvErr(`"unknown struct field "+dictK`))
+		cb.Indent--
+		cb.Linef(`}`)
+		cb.Indent--
+		cb.Linef(`}`)
+		cb.Indent--
+		cb.Linef(`}`)
+	} else {
+		cb.Linef(`for dictK, dictV := range v.Data {`)
+		cb.Indent++
+		if !convField(`dictK`, `dictV`) {
+			return false
+		}
+		cb.Indent--
+		cb.Linef(`}`)
+	}
+	cb.Indent--
+	cb.Linef(`case env.Block:`)
+	cb.Indent++
+	cb.Linef(`if len(v.Series.S) %% 2 != 0 {`)
+	cb.Indent++
+	cb.Append(makeRetConvErr(`"expected block to have length of multiple of 2, but got block with length "+strconv.Itoa(len(v.Series.S))`))
+	deps.Imports["strconv"] = struct{}{}
+	cb.Indent--
+	cb.Linef(`}`)
+	cb.Linef(`for i := 0; i < len(v.Series.S); i += 2 {`)
+	cb.Indent++
+	cb.Linef(`var fieldName string`)
+	cb.Linef(`switch k := v.Series.S[i].(type) {`)
+	cb.Linef(`case env.String:`)
+	cb.Indent++
+	cb.Linef(`fieldName = k.Value`)
+	cb.Indent--
+	cb.Linef(`case env.Word:`)
+	cb.Indent++
+	cb.Linef(`fieldName = ps.Idx.GetWord(k.Index)`)
+	cb.Indent--
+	cb.Linef(`default:`)
+	cb.Indent++
+	cb.Append(makeRetConvErr(`"expected struct field name to be string or word, but got "+objectDebugString(ps.Idx, k)`))
+	cb.Indent--
+	cb.Linef(`}`)
+	if !convField(`fieldName`, `v.Series.S[i+1]`) {
+		return false
+	}
+	cb.Indent--
+	cb.Linef(`}`)
+	cb.Indent--
+	cb.Linef(`case env.Native:`)
+	cb.Indent++
+	cb.Linef(`if vc, ok := v.Value.(%v); ok {`, typ.Name)
+	deps.MarkUsed(typ)
+	cb.Indent++
+	cb.Linef(`%v = vc`, outVar)
+	cb.Indent--
+	if BoxesAsPointer(ctx, typ) {
+		// Natives of named structs hold pointers (see
+		// BoxesAsPointer).
+		cb.Linef(`} else if vc, ok := v.Value.(*%v); ok {`, typ.Name)
+		cb.Indent++
+		cb.Linef(`%v = *vc`, outVar)
+		cb.Indent--
+	}
+	cb.Linef(`} else {`)
+	cb.Indent++
+	cb.Append(makeRetConvErr(fmt.Sprintf(`"expected native of type %v, but got "+objectDebugString(ps.Idx, v)`, typ.Name)))
+	cb.Indent--
+	cb.Linef(`}`)
+	cb.Indent--
+	cb.Linef(`default:`)
+	cb.Indent++
+	cb.Append(makeRetConvErr(`"expected dict, block or native, but got "+objectDebugString(ps.Idx, v)`))
+	cb.Indent--
+	cb.Linef(`}`)
+
+	return true
+}
+
+// Integer range bounds (min, max) as Go constant expressions by type name.
+// Empty strings mean no check is needed.
+// int is assumed to be 64 bits wide, like Rye integers.
+var intRangesStrict = map[string][2]string{
+	"int8":   {"math.MinInt8", "math.MaxInt8"},
+	"int16":  {"math.MinInt16", "math.MaxInt16"},
+	"int32":  {"math.MinInt32", "math.MaxInt32"},
+	"uint8":  {"0", "math.MaxUint8"},
+	"byte":   {"0", "math.MaxUint8"},
+	"uint16": {"0", "math.MaxUint16"},
+	"uint32": {"0", "math.MaxUint32"},
+	"uint64": {"0", ""},
+	"uint":   {"0", ""},
+}
+
+// Like intRangesStrict, but allows any value fitting into the bit width,
+// regardless of signedness.
+var intRangesWrap = map[string][2]string{
+	"int8":   {"math.MinInt8", "math.MaxUint8"},
+	"int16":  {"math.MinInt16", "math.MaxUint16"},
+	"int32":  {"math.MinInt32", "math.MaxUint32"},
+	"uint8":  {"math.MinInt8", "math.MaxUint8"},
+	"byte":   {"math.MinInt8", "math.MaxUint8"},
+	"uint16": {"math.MinInt16", "math.MaxUint16"},
+	"uint32": {"math.MinInt32", "math.MaxUint32"},
+}
+
+// convRyeToGoCodeIntRangeCheck writes code checking that inVar fits into
+// the Go integer type typName. The check depends on the numeric checks
+// mode of the conversion context (see [config.Config.NumericChecks]),
+// which can be changed at runtime.
+func convRyeToGoCodeIntRangeCheck(deps *Dependencies, cb *binderio.CodeBuilder, typName, inVar string, makeRetConvErr func(inner string) string) {
+	type modeCheck struct {
+		mode  string
+		conds []string
+	}
+	var checks []modeCheck
+	for _, mode := range []string{config.NumericChecksStrict, config.NumericChecksWrap} {
+		ranges := intRangesStrict
+		if mode == config.NumericChecksWrap {
+			ranges = intRangesWrap
+		}
+		rng, ok := ranges[typName]
+		if !ok {
+			continue
+		}
+		var conds []string
+		if rng[0] != "" {
+			conds = append(conds, fmt.Sprintf(`%v < %v`, inVar, rng[0]))
+		}
+		if rng[1] != "" {
+			conds = append(conds, fmt.Sprintf(`%v > %v`, inVar, rng[1]))
+		}
+		if len(conds) == 0 {
+			continue
+		}
+		if strings.Contains(rng[0]+rng[1], "math.") {
+			deps.Imports["math"] = struct{}{}
+		}
+		checks = append(checks, modeCheck{mode: mode, conds: conds})
+	}
+	if len(checks) == 0 {
+		return
+	}
+	cb.Linef(`switch newConvCtx(ps).numericChecks() {`)
+	for _, check := range checks {
+		cb.Linef(`case "%v":`, check.mode)
+		cb.Indent++
+		cb.Linef(`if %v {`, strings.Join(check.conds, " || "))
+		cb.Indent++
+		cb.Append(makeRetConvErr(fmt.Sprintf(`"value "+strconv.FormatInt(%v, 10)+" out of range for %v"`, inVar, typName)))
+		deps.Imports["strconv"] = struct{}{}
+		cb.Indent--
+		cb.Linef(`}`)
+		cb.Indent--
+	}
+	cb.Linef(`}`)
+}
+
 func ConvRyeToGoCodeFunc(deps *Dependencies, ctx *Context, cb *binderio.CodeBuilder, outVar, inVar string, canBeNil bool, argn int, makeRetConvErr func(inner string) string, ctxAsArg0 bool, params, results []ir.NamedIdent) bool {
 	var fnTyp string
 	{
@@ -291,8 +929,19 @@ func ConvRyeToGoCodeFunc(deps *Dependencies, ctx *Context, cb *binderio.CodeBuil
 	cb.Indent--
 	cb.Linef(`}`)
 
+	ctxCallback := isContextCallback(params, results)
+	if ctxCallback {
+		// Context callbacks may be called concurrently (e.g. by
+		// errgroup.Group.Go), so each call gets its own program state,
+		// copied while the builtin still runs on the caller's goroutine.
+		cb.Linef(`fnPs := *ps`)
+	}
 	cb.Linef(`%v = %v {`, outVar, fnTyp)
 	cb.Indent++
+	if ctxCallback {
+		cb.Linef(`psCopy := fnPs`)
+		cb.Linef(`ps := &psCopy`)
+	}
 	var argVals strings.Builder
 	for i := range params {
 		if i != 0 {
@@ -363,7 +1012,28 @@ func ConvRyeToGoCodeFunc(deps *Dependencies, ctx *Context, cb *binderio.CodeBuil
 		argValsComma = ", "
 	}
 	cb.Linef(`evaldo.CallFunctionArgsN(fn, ps, %v%v%v)`, ctxIdent, argValsComma, argVals.String())
-	if len(results) == 1 {
+	if ctxCallback {
+		// Like a Go function, the callback succeeds unless it fails or
+		// returns an error (e.g. for errgroup.Group.Go or retry helpers).
+		// Errors made from Go errors are passed on unchanged.
+		cb.Linef(`if e, ok := ps.Res.(*env.Error); ok {`)
+		cb.Indent++
+		cb.Linef(`if err, ok := nativeError(e); ok {`)
+		cb.Indent++
+		cb.Linef(`return err`)
+		cb.Indent--
+		cb.Linef(`}`)
+		cb.Linef(`return errors.New(e.Print(*ps.Idx))`)
+		cb.Indent--
+		cb.Linef(`}`)
+		cb.Linef(`if ps.FailureFlag || ps.ErrorFlag {`)
+		cb.Indent++
+		cb.Linef(`return errors.New(ps.Res.Inspect(*ps.Idx))`)
+		cb.Indent--
+		cb.Linef(`}`)
+		cb.Linef(`return nil`)
+		deps.Imports["errors"] = struct{}{}
+	} else if len(results) == 1 {
 		cb.Linef(`var res %v`, results[0].Type.Name)
 		deps.MarkUsed(results[0].Type)
 		if _, found := ConvRyeToGo(
@@ -432,20 +1102,90 @@ func ConvRyeToGoCodeFunc(deps *Dependencies, ctx *Context, cb *binderio.CodeBuil
 	return true
 }
 
-func ConvGoToRyeCodeFuncBody(deps *Dependencies, ctx *Context, cb *binderio.CodeBuilder, inVar string, makeRetConvErr func(inner string) string, recv *ir.Ident, params, results []ir.NamedIdent) error {
-	params = slices.Clone(params)
-	if recv != nil {
-		recvName, _ := ir.NewIdent(ctx.IR.ConstValues, ctx.ModNames, nil, &ast.Ident{Name: "__recv"})
-		params = append([]ir.NamedIdent{{Name: recvName, Type: *recv}}, params...)
+// isContextCallback reports whether a callback has the shape
+// func(ctx context.Context, ...) error, in which case any result of the
+// Rye function other than a failure or error means success.
+func isContextCallback(params, results []ir.NamedIdent) bool {
+	if len(params) == 0 || len(results) != 1 || results[0].Type.Name != "error" {
+		return false
 	}
+	modulePath, typeName, _, isPtr, ok := namedTypeRef(params[0].Type)
+	return ok && !isPtr && modulePath == "context" && typeName == "Context"
+}
 
-	if len(params) > 5 {
+// IsCommaOkResults reports whether results has the shape (T, bool) and
+// should be returned as a single value with the bool being mapped to
+// a failure or void (see [config.Config.CommaOk]).
+func IsCommaOkResults(ctx *Context, results []ir.NamedIdent) bool {
+	switch ctx.Config.CommaOk {
+	case config.CommaOkFailure, config.CommaOkVoid:
+	default:
+		return false
+	}
+	return len(results) == 2 &&
+		results[1].Type.Name == "bool" &&
+		results[0].Type.Name != "error" &&
+		!ir.IdentIsInternal(ctx.ModNames, results[0].Type)
+}
+
+// DictResultKeys returns the dict keys of results (without a final
+// error) if they should be returned as a dict (see
+// [config.Config.MultiResults]), i.e. there are multiple and all are
+// named in Go.
+func DictResultKeys(ctx *Context, results []ir.NamedIdent) ([]string, bool) {
+	if ctx.Config.MultiResults != config.MultiResultsDict || len(results) < 2 {
+		return nil, false
+	}
+	keys := make([]string, len(results))
+	for i, result := range results {
+		name := result.Name.Name
+		if result.Unnamed || name == "_" {
+			// Unnamed results are named by their position.
+			return nil, false
+		}
+		keys[i] = strcase.ToKebab(name)
+	}
+	return keys, true
+}
+
+// ConvGoToRyeCodeFuncBody writes the body of a builtin calling the Go
+// function inVar (a method of its first argument if recv is non-nil),
+// converting the Rye arguments to params and results back to Rye.
+//
+// A final error result is returned as a failure: if it is non-nil, the
+// builtin sets ps.FailureFlag and returns an *env.Error with the error's
+// message, which also wraps the error itself as a native under the
+// "native" key (see errorToRye in the generated code), so it can be
+// passed back to Go unchanged. The other results are then ignored. If it
+// is nil, the other results are returned as usual. If there are none,
+// functions return void and methods their receiver, like methods without
+// results, so calls can be chained.
+//
+// boundArgs maps parameter names to Go expressions passed instead of
+// a Rye argument (see [config.Config.BindArgs]); may be nil.
+func ConvGoToRyeCodeFuncBody(deps *Dependencies, ctx *Context, cb *binderio.CodeBuilder, inVar string, makeRetConvErr func(inner string) string, recv *ir.Ident, params, results []ir.NamedIdent, boundArgs map[string]string) error {
+	params = slices.Clone(params)
+	if recv != nil {
+		recvName, _ := ir.NewIdent(ctx.IR.ConstValues, ctx.ModNames, nil, &ast.Ident{Name: "__recv"})
+		params = append([]ir.NamedIdent{{Name: recvName, Type: *recv}}, params...)
+	}
+
+	if len(params)-len(boundArgs) > 5 {
 		return errors.New("can only handle at most 5 parameters")
 	}
 
 	hasOpaqueParam := false
 	derefParam := make([]bool, len(params))
+	ryeArgn := 0
 	for i, param := range params {
+		if expr, ok := boundArgs[param.Name.Name]; ok {
+			if ir.IdentIsInternal(ctx.ModNames, param.Type) || param.Type.IsEllipsis {
+				return errors.New("cannot bind argument " + param.Name.Name + " of type " + param.Type.Name)
+			}
+			cb.Linef(`var arg%vVal %v = %v`, i, param.Type.Name, expr)
+			deps.MarkUsed(param.Type)
+			continue
+		}
 		if ir.IdentIsInternal(ctx.ModNames, param.Type) {
 			// Internal types cannot be imported, meaning
 			// we have to do everything opaquely using reflect
@@ -461,12 +1201,13 @@ func ConvGoToRyeCodeFuncBody(deps *Dependencies, ctx *Context, cb *binderio.Code
 			cb,
 			param.Type,
 			fmt.Sprintf(`arg%vVal`, i),
-			fmt.Sprintf(`arg%v`, i),
-			i,
-			makeMakeRetArgErr(i),
+			fmt.Sprintf(`arg%v`, ryeArgn),
+			ryeArgn,
+			makeMakeRetArgErr(ryeArgn),
 		); !found {
 			return errors.New("unhandled type conversion (rye to go): " + param.Type.Name)
 		}
+		ryeArgn++
 	}
 
 	var args strings.Builder
@@ -505,6 +1246,8 @@ func ConvGoToRyeCodeFuncBody(deps *Dependencies, ctx *Context, cb *binderio.Code
 		errResult = &results[len(results)-1]
 	}
 
+	commaOk := IsCommaOkResults(ctx, results)
+
 	resultIdxName := func(i int) string {
 		if errResult != nil && i == len(results)-1 {
 			return "Err"
@@ -521,7 +1264,24 @@ func ConvGoToRyeCodeFuncBody(deps *Dependencies, ctx *Context, cb *binderio.Code
 		}
 	}
 	if hasOpaqueParam {
-		cb.Linef(`ress := reflect.ValueOf(%v%v).Call([]reflect.Value{%v})`, recvStr, inVar, args.String())
+		if recv != nil && ir.IdentIsInternal(ctx.ModNames, *recv) {
+			// Methods of internal types (see [config.Config.AllowInternal])
+			// can't be named, so they're looked up by name.
+			cb.Linef(`var method reflect.Value`)
+			cb.Linef(`if arg0Val != nil {`)
+			cb.Indent++
+			cb.Linef(`method = reflect.ValueOf(arg0Val).MethodByName("%v")`, inVar)
+			cb.Indent--
+			cb.Linef(`}`)
+			cb.Linef(`if !method.IsValid() {`)
+			cb.Indent++
+			cb.Append(makeMakeRetArgErr(0)(fmt.Sprintf(`"expected native of type %v, but got "+objectDebugString(ps.Idx, arg0)`, recv.Name)))
+			cb.Indent--
+			cb.Linef(`}`)
+			cb.Linef(`ress := method.Call([]reflect.Value{%v})`, args.String())
+		} else {
+			cb.Linef(`ress := reflect.ValueOf(%v%v).Call([]reflect.Value{%v})`, recvStr, inVar, args.String())
+		}
 		deps.Imports["reflect"] = struct{}{}
 		cb.Linef(`if len(ress) != %v {`, len(results))
 		cb.Indent++
@@ -531,7 +1291,7 @@ func ConvGoToRyeCodeFuncBody(deps *Dependencies, ctx *Context, cb *binderio.Code
 		for i, result := range results {
 			if ir.IdentIsInternal(ctx.ModNames, result.Type) {
 				cb.Linef(`var res%v any`, resultIdxName(i))
-				cb.Linef(`if !ress[%v].IsNil() {`, i)
+				cb.Linef(`if !ress[%v].IsZero() {`, i)
 				cb.Indent++
 				cb.Linef(`res%v = ress[%v].Interface()`, resultIdxName(i), i)
 				cb.Indent--
@@ -539,7 +1299,7 @@ func ConvGoToRyeCodeFuncBody(deps *Dependencies, ctx *Context, cb *binderio.Code
 			} else {
 				cb.Linef(`var res%v %v`, resultIdxName(i), result.Type.Name)
 				deps.MarkUsed(result.Type)
-				cb.Linef(`if !ress[%v].IsNil() {`, i)
+				cb.Linef(`if !ress[%v].IsZero() {`, i)
 				cb.Indent++
 				cb.Linef(`res%v = ress[%v].Interface().(%v)`, resultIdxName(i), i, result.Type.Name)
 				deps.MarkUsed(result.Type)
@@ -563,12 +1323,24 @@ func ConvGoToRyeCodeFuncBody(deps *Dependencies, ctx *Context, cb *binderio.Code
 		cb.Linef(`%v%v%v(%v)`, assign.String(), recvStr, inVar, args.String())
 	}
 
-	for i, result := range results {
+	if errResult != nil {
+		cb.Linef(`if resErr != nil {`)
+		cb.Indent++
+		cb.Linef(`ps.FailureFlag = true`)
+		cb.Linef(`return errorToRye(ps.Idx, resErr)`)
+		cb.Indent--
+		cb.Linef(`}`)
+	}
+	for i, result := range resultsWithoutErr {
+		if commaOk && i == 1 {
+			// Handled below
+			continue
+		}
 		if ir.IdentIsInternal(ctx.ModNames, result.Type) {
 			cb.Linef(
 				`res%vObj := ifaceToNative(ps.Idx, res%v, "%v")`,
 				resultIdxName(i), resultIdxName(i),
-				result.Type.RyeName(),
+				KindName(ctx, result.Type),
 			)
 		} else {
 			cb.Linef(`var res%vObj env.Object`, resultIdxName(i))
@@ -586,17 +1358,29 @@ func ConvGoToRyeCodeFuncBody(deps *Dependencies, ctx *Context, cb *binderio.Code
 			}
 		}
 	}
-	if errResult != nil {
-		cb.Linef(`if resErrObj != nil {`)
+	if commaOk {
+		cb.Linef(`if !res1 {`)
 		cb.Indent++
-		cb.Linef(`ps.FailureFlag = true`)
-		cb.Linef(`return resErrObj`)
+		if ctx.Config.CommaOk == config.CommaOkFailure {
+			cb.Linef(`ps.FailureFlag = true`)
+			cb.Linef(`return env.NewError("((RYEGEN:FUNCNAME)): not ok")`)
+		} else {
+			cb.Linef(`return env.Void{}`)
+		}
 		cb.Indent--
 		cb.Linef(`}`)
-	}
-	if len(resultsWithoutErr) > 0 {
+		cb.Linef(`return res0Obj`)
+	} else if len(resultsWithoutErr) > 0 {
 		if len(resultsWithoutErr) == 1 {
 			cb.Linef(`return res0Obj`)
+		} else if keys, ok := DictResultKeys(ctx, resultsWithoutErr); ok {
+			cb.Linef(`return *env.NewDict(map[string]any{`)
+			cb.Indent++
+			for i, key := range keys {
+				cb.Linef(`"%v": res%vObj,`, key, i)
+			}
+			cb.Indent--
+			cb.Linef(`})`)
 		} else {
 			cb.Linef(`return *env.NewBlock(*env.NewTSeries([]env.Object{`)
 			cb.Indent++
@@ -606,6 +1390,8 @@ func ConvGoToRyeCodeFuncBody(deps *Dependencies, ctx *Context, cb *binderio.Code
 			cb.Indent--
 			cb.Linef(`}))`)
 		}
+	} else if errResult != nil && recv == nil {
+		cb.Linef(`return env.Void{}`)
 	} else {
 		if recv == nil {
 			cb.Linef(`return nil`)
@@ -717,7 +1503,16 @@ var convListRyeToGo = []Converter{
 				return false
 			}
 
+			isBytes := !fixedSize && (elTyp.Name == "byte" || elTyp.Name == "uint8") &&
+				coercionEnabled(ctx, typ, config.CoercionStringToBytes)
+
 			cb.Linef(`switch v := %v.(type) {`, inVar)
+			if isBytes {
+				cb.Linef(`case env.String:`)
+				cb.Indent++
+				cb.Linef(`%v = %v(v.Value)`, outVar, typ.Name)
+				cb.Indent--
+			}
 			cb.Linef(`case env.Block:`)
 			cb.Indent++
 			if fixedSize {
@@ -731,23 +1526,32 @@ var convListRyeToGo = []Converter{
 				cb.Linef(`%v = make(%v, len(v.Series.S))`, outVar, typ.Name)
 				deps.MarkUsed(typ)
 			}
-			cb.Linef(`for i, it := range v.Series.S {`)
+			// Nested loops get distinct index variables, so errors can
+			// name the item at each level.
+			idxVar := "i"
+			if ctx.blockDepth > 0 {
+				idxVar = "i" + strconv.Itoa(ctx.blockDepth)
+			}
+			elemCtx := *ctx
+			elemCtx.blockDepth++
+			cb.Linef(`for %v, it := range v.Series.S {`, idxVar)
 			cb.Indent++
-			cb.Linef(`iv := &%v[i]`, outVar)
+			cb.Linef(`iv := &%v[%v]`, outVar, idxVar)
 			if _, found := ConvRyeToGo(
 				deps,
-				ctx,
+				&elemCtx,
 				cb,
 				elTyp,
 				`(*iv)`,
 				`it`,
 				argn,
 				func(inner string) string {
-					return makeRetConvErr(`"block item: "+` + inner)
+					return makeRetConvErr(`"block item "+strconv.Itoa(` + idxVar + `)+": "+` + inner)
 				},
 			); !found {
 				return false
 			}
+			deps.Imports["strconv"] = struct{}{}
 			cb.Indent--
 			cb.Linef(`}`)
 			cb.Indent--
@@ -756,7 +1560,11 @@ var convListRyeToGo = []Converter{
 			}
 			cb.Linef(`default:`)
 			cb.Indent++
-			cb.Append(makeRetConvErr(`"expected block or nil, but got "+objectDebugString(ps.Idx, v)`))
+			if isBytes {
+				cb.Append(makeRetConvErr(`"expected block, string or nil, but got "+objectDebugString(ps.Idx, v)`))
+			} else {
+				cb.Append(makeRetConvErr(`"expected block or nil, but got "+objectDebugString(ps.Idx, v)`))
+			}
 			cb.Indent--
 			cb.Linef(`}`)
 
@@ -808,7 +1616,7 @@ var convListRyeToGo = []Converter{
 				deps.MarkUsed(vTyp)
 				if _, found := ConvRyeToGo(
 					deps,
-					ctx,
+					ctx.mapValueContext(),
 					cb,
 					vTyp,
 					`mapV`,
@@ -919,14 +1727,473 @@ var convListRyeToGo = []Converter{
 			cb.Linef(`ch, ok := v.Value.(chan *env.Object)`)
 			cb.Linef(`if !ok {`)
 			cb.Indent++
-			cb.Append(makeRetConvErr(fmt.Sprintf(`"expected Rye-channel (native of type chan *env.Object) or nil, but got "+objectDebugString(ps.Idx, %v)`, inVar)))
+			cb.Append(makeRetConvErr(fmt.Sprintf(`"expected Rye-channel (native of type chan *env.Object) or nil, but got "+objectDebugString(ps.Idx, %v)`, inVar)))
+			cb.Indent--
+			cb.Linef(`}`)
+			convCodeTranslateChannel(deps, ctx, cb, chTyp, `ch`, outVar, argn)
+			cb.Indent--
+			convRyeToGoCodeCaseNil(deps, cb, outVar, `v`, makeRetConvErr)
+			cb.Linef(`}`)
+
+			return true
+		},
+	},
+	{
+		Name: "time",
+		TryConv: func(deps *Dependencies, ctx *Context, cb *binderio.CodeBuilder, typ ir.Ident, outVar, inVar string, argn int, makeRetConvErr func(inner string) string) bool {
+			name, isPtr, ok := timeType(typ)
+			if !ok {
+				return false
+			}
+			deps.MarkUsed(typ)
+			qual, _, _ := strings.Cut(name, ".")
+
+			ref := ""
+			if isPtr {
+				ref = "&"
+			}
+			cb.Linef(`switch v := %v.(type) {`, inVar)
+			cb.Linef(`case env.Time:`)
+			cb.Indent++
+			cb.Linef(`%v = %vv.Value`, outVar, ref)
+			cb.Indent--
+			cb.Linef(`case env.String:`)
+			cb.Indent++
+			cb.Linef(`parsed, err := %v.Parse(%v.RFC3339Nano, v.Value)`, qual, qual)
+			cb.Linef(`if err != nil {`)
+			cb.Indent++
+			cb.Append(makeRetConvErr(`err.Error()`))
+			cb.Indent--
+			cb.Linef(`}`)
+			cb.Linef(`%v = %vparsed`, outVar, ref)
+			cb.Indent--
+			cb.Linef(`case env.Native:`)
+			cb.Indent++
+			cb.Linef(`switch vc := v.Value.(type) {`)
+			cb.Linef(`case %v:`, name)
+			cb.Indent++
+			cb.Linef(`%v = %vvc`, outVar, ref)
+			cb.Indent--
+			cb.Linef(`case *%v:`, name)
+			cb.Indent++
+			if isPtr {
+				cb.Linef(`%v = vc`, outVar)
+			} else {
+				cb.Linef(`%v = *vc`, outVar)
+			}
+			cb.Indent--
+			cb.Linef(`default:`)
+			cb.Indent++
+			cb.Append(makeRetConvErr(fmt.Sprintf(`"expected native of type %v, but got "+objectDebugString(ps.Idx, v)`, name)))
+			cb.Indent--
+			cb.Linef(`}`)
+			cb.Indent--
+			if isPtr {
+				convRyeToGoCodeCaseNil(deps, cb, outVar, `v`, makeRetConvErr)
+			}
+			cb.Linef(`default:`)
+			cb.Indent++
+			cb.Append(makeRetConvErr(`"expected time, string or native, but got "+objectDebugString(ps.Idx, v)`))
+			cb.Indent--
+			cb.Linef(`}`)
+			return true
+		},
+	},
+	{
+		Name: "smallstruct",
+		TryConv: func(deps *Dependencies, ctx *Context, cb *binderio.CodeBuilder, typ ir.Ident, outVar, inVar string, argn int, makeRetConvErr func(inner string) string) bool {
+			fields, fieldCtx, ok := getSmallStructFields(deps, ctx, typ)
+			if !ok {
+				return false
+			}
+			return convRyeToGoCodeStruct(deps, ctx, fieldCtx, cb, typ, fields, true, outVar, inVar, argn, makeRetConvErr)
+		},
+	},
+	{
+		Name: "struct",
+		TryConv: func(deps *Dependencies, ctx *Context, cb *binderio.CodeBuilder, typ ir.Ident, outVar, inVar string, argn int, makeRetConvErr func(inner string) string) bool {
+			fields, ok := getAnonStructFields(ctx, typ)
+			fieldCtx := ctx
+			if !ok {
+				fields, fieldCtx, ok = getDictStructFields(deps, ctx, typ)
+				if !ok {
+					return false
+				}
+			}
+			return convRyeToGoCodeStruct(deps, ctx, fieldCtx, cb, typ, fields, false, outVar, inVar, argn, makeRetConvErr)
+		},
+	},
+	{
+		Name: "oneof",
+		TryConv: func(deps *Dependencies, ctx *Context, cb *binderio.CodeBuilder, typ ir.Ident, outVar, inVar string, argn int, makeRetConvErr func(inner string) string) bool {
+			cases, ok := oneofCases(ctx, typ)
+			if !ok {
+				return false
+			}
+
+			cb.Linef(`switch v := %v.(type) {`, inVar)
+			cb.Linef(`case env.Dict:`)
+			cb.Indent++
+			cb.Linef(`if len(v.Data) > 1 {`)
+			cb.Indent++
+			cb.Append(makeRetConvErr(`"expected dict with at most one key, but got "+strconv.Itoa(len(v.Data))`))
+			deps.Imports["strconv"] = struct{}{}
+			cb.Indent--
+			cb.Linef(`}`)
+			cb.Linef(`%v = nil`, outVar)
+			cb.Linef(`for dictK, dictV := range v.Data {`)
+			cb.Indent++
+			cb.Linef(`switch dictK {`)
+			for _, c := range cases {
+				if c.field.ryeName == c.field.goName {
+					cb.Linef(`case "%v":`, c.field.goName)
+				} else {
+					cb.Linef(`case "%v", "%v":`, c.field.goName, c.field.ryeName)
+				}
+				cb.Indent++
+				cb.Linef(`var val %v`, c.field.typ.Name)
+				deps.MarkUsed(c.field.typ)
+				if _, found := ConvRyeToGo(
+					deps,
+					ctx,
+					cb,
+					c.field.typ,
+					`val`,
+					`dictV`,
+					argn,
+					func(inner string) string {
+						return makeRetConvErr(`"field ` + c.field.ryeName + `: "+` + inner)
+					},
+				); !found {
+					return false
+				}
+				addr := ""
+				if c.isPtr {
+					addr = "&"
+				}
+				cb.Linef(`%v = %v%v{%v: val}`, outVar, addr, strings.TrimPrefix(c.wrapper.Name, "*"), c.field.goName)
+				deps.MarkUsed(c.wrapper)
+				cb.Indent--
+			}
+			cb.Linef(`default:`)
+			cb.Indent++
+			cb.Append(makeRetConvErr(`"unknown oneof field "+dictK`))
+			cb.Indent--
+			cb.Linef(`}`)
+			cb.Indent--
+			cb.Linef(`}`)
+			cb.Indent--
+			cb.Linef(`case env.Native:`)
+			cb.Indent++
+			cb.Linef(`switch vc := v.Value.(type) {`)
+			for _, c := range cases {
+				cb.Linef(`case %v:`, c.wrapper.Name)
+				cb.Indent++
+				cb.Linef(`%v = vc`, outVar)
+				cb.Indent--
+			}
+			cb.Linef(`default:`)
+			cb.Indent++
+			cb.Append(makeRetConvErr(`"expected native of a oneof wrapper type, but got "+objectDebugString(ps.Idx, v)`))
+			cb.Indent--
+			cb.Linef(`}`)
+			cb.Indent--
+			convRyeToGoCodeCaseNil(deps, cb, outVar, `v`, makeRetConvErr)
+			cb.Linef(`default:`)
+			cb.Indent++
+			cb.Append(makeRetConvErr(`"expected dict, native or nil, but got "+objectDebugString(ps.Idx, v)`))
+			cb.Indent--
+			cb.Linef(`}`)
+			return true
+		},
+	},
+	{
+		Name: "stringable",
+		TryConv: func(deps *Dependencies, ctx *Context, cb *binderio.CodeBuilder, typ ir.Ident, outVar, inVar string, argn int, makeRetConvErr func(inner string) string) bool {
+			name, _, st, isPtr, ok := stringableType(ctx, typ)
+			if !ok {
+				return false
+			}
+			deps.MarkUsed(typ)
+			qual, _, _ := strings.Cut(name, ".")
+
+			// Convert the parse result (or native) of type T or *T in
+			// inExpr to the type of outVar.
+			assign := func(inExpr string, inIsPtr bool) {
+				switch {
+				case inIsPtr == isPtr:
+					cb.Linef(`%v = %v`, outVar, inExpr)
+				case inIsPtr:
+					cb.Linef(`%v = *%v`, outVar, inExpr)
+				default:
+					cb.Linef(`%v = &%v`, outVar, inExpr)
+				}
+			}
+
+			cb.Linef(`switch v := %v.(type) {`, inVar)
+			cb.Linef(`case env.String:`)
+			cb.Indent++
+			cb.Linef(`parsed, err := %v.%v(v.Value)`, qual, st.ParseFunc)
+			cb.Linef(`if err != nil {`)
+			cb.Indent++
+			cb.Append(makeRetConvErr(`err.Error()`))
+			cb.Indent--
+			cb.Linef(`}`)
+			assign(`parsed`, st.ParseReturnsPtr)
+			cb.Indent--
+			cb.Linef(`case env.Native:`)
+			cb.Indent++
+			cb.Linef(`switch vc := v.Value.(type) {`)
+			cb.Linef(`case %v:`, name)
+			cb.Indent++
+			assign(`vc`, false)
+			cb.Indent--
+			cb.Linef(`case *%v:`, name)
+			cb.Indent++
+			assign(`vc`, true)
+			cb.Indent--
+			cb.Linef(`default:`)
+			cb.Indent++
+			cb.Append(makeRetConvErr(fmt.Sprintf(`"expected native of type %v, but got "+objectDebugString(ps.Idx, v)`, name)))
+			cb.Indent--
+			cb.Linef(`}`)
+			cb.Indent--
+			if isPtr {
+				convRyeToGoCodeCaseNil(deps, cb, outVar, `v`, makeRetConvErr)
+			}
+			cb.Linef(`default:`)
+			cb.Indent++
+			if isPtr {
+				cb.Append(makeRetConvErr(`"expected string, native or nil, but got "+objectDebugString(ps.Idx, v)`))
+			} else {
+				cb.Append(makeRetConvErr(`"expected string or native, but got "+objectDebugString(ps.Idx, v)`))
+			}
+			cb.Indent--
+			cb.Linef(`}`)
+			return true
+		},
+	},
+	{
+		Name: "bigint",
+		TryConv: func(deps *Dependencies, ctx *Context, cb *binderio.CodeBuilder, typ ir.Ident, outVar, inVar string, argn int, makeRetConvErr func(inner string) string) bool {
+			name, isPtr, ok := bigIntType(typ)
+			if !ok {
+				return false
+			}
+			deps.MarkUsed(typ)
+
+			deref := "*"
+			if isPtr {
+				deref = ""
+			}
+			cb.Linef(`switch v := %v.(type) {`, inVar)
+			cb.Linef(`case env.Integer:`)
+			cb.Indent++
+			cb.Linef(`%v = %vnew(%v).SetInt64(v.Value)`, outVar, deref, name)
+			cb.Indent--
+			cb.Linef(`case env.String:`)
+			cb.Indent++
+			cb.Linef(`bi, ok := new(%v).SetString(v.Value, 10)`, name)
+			cb.Linef(`if !ok {`)
+			cb.Indent++
+			cb.Append(makeRetConvErr(`"invalid integer string "+strconv.Quote(v.Value)`))
+			deps.Imports["strconv"] = struct{}{}
+			cb.Indent--
+			cb.Linef(`}`)
+			cb.Linef(`%v = %vbi`, outVar, deref)
+			cb.Indent--
+			cb.Linef(`case env.Native:`)
+			cb.Indent++
+			cb.Linef(`if vc, ok := v.Value.(*%v); ok {`, name)
+			cb.Indent++
+			cb.Linef(`%v = %vvc`, outVar, deref)
+			cb.Indent--
+			cb.Linef(`} else {`)
+			cb.Indent++
+			cb.Append(makeRetConvErr(fmt.Sprintf(`"expected native of type *%v, but got "+objectDebugString(ps.Idx, v)`, name)))
+			cb.Indent--
+			cb.Linef(`}`)
+			cb.Indent--
+			if isPtr {
+				// 0 is a big integer, so only void is nil.
+				convRyeToGoCodeCaseVoid(cb, outVar, "nil")
+			}
+			cb.Linef(`default:`)
+			cb.Indent++
+			if isPtr {
+				cb.Append(makeRetConvErr(`"expected integer, string, native or void, but got "+objectDebugString(ps.Idx, v)`))
+			} else {
+				cb.Append(makeRetConvErr(`"expected integer, string or native, but got "+objectDebugString(ps.Idx, v)`))
+			}
+			cb.Indent--
+			cb.Linef(`}`)
+			return true
+		},
+	},
+	{
+		Name: "reflect",
+		TryConv: func(deps *Dependencies, ctx *Context, cb *binderio.CodeBuilder, typ ir.Ident, outVar, inVar string, argn int, makeRetConvErr func(inner string) string) bool {
+			name, typeName, ok := reflectType(typ)
+			if !ok {
+				return false
+			}
+			deps.MarkUsed(typ)
+			reflectMod := strings.TrimSuffix(name, "."+typeName)
+
+			// Natives of other types are reflected on, so e.g.
+			// reflection-based encoders can be called with any native.
+			cb.Linef(`switch v := %v.(type) {`, inVar)
+			cb.Linef(`case env.Native:`)
+			cb.Indent++
+			cb.Linef(`if vc, ok := v.Value.(%v); ok {`, name)
+			cb.Indent++
+			cb.Linef(`%v = vc`, outVar)
+			cb.Indent--
+			cb.Linef(`} else {`)
+			cb.Indent++
+			cb.Linef(`%v = %v.%vOf(v.Value)`, outVar, reflectMod, typeName)
+			cb.Indent--
+			cb.Linef(`}`)
+			cb.Indent--
+			if typeName == "Value" {
+				convRyeToGoCodeCaseVoid(cb, outVar, name+"{}")
+			} else {
+				convRyeToGoCodeCaseNil(deps, cb, outVar, `v`, makeRetConvErr)
+			}
+			cb.Linef(`default:`)
+			cb.Indent++
+			cb.Append(makeRetConvErr(`"expected native or nil, but got "+objectDebugString(ps.Idx, v)`))
+			cb.Indent--
+			cb.Linef(`}`)
+			return true
+		},
+	},
+	{
+		Name: "bitmask",
+		TryConv: func(deps *Dependencies, ctx *Context, cb *binderio.CodeBuilder, typ ir.Ident, outVar, inVar string, argn int, makeRetConvErr func(inner string) string) bool {
+			name, flags, ok := bitmaskType(ctx, typ)
+			if !ok {
+				return false
+			}
+			deps.MarkUsed(typ)
+			qual, _, _ := strings.Cut(name, ".")
+
+			cb.Linef(`if v, ok := %v.(env.Block); ok {`, inVar)
+			cb.Indent++
+			cb.Linef(`var bits %v`, name)
+			cb.Linef(`for _, it := range v.Series.S {`)
+			cb.Indent++
+			cb.Linef(`switch it := it.(type) {`)
+			cb.Linef(`case env.Word:`)
+			cb.Indent++
+			cb.Linef(`switch ps.Idx.GetWord(it.Index) {`)
+			for _, f := range flags {
+				cb.Linef(`case %v:`, strconv.Quote(f.word))
+				cb.Indent++
+				cb.Linef(`bits |= %v.%v`, qual, f.goName)
+				cb.Indent--
+			}
+			cb.Linef(`default:`)
+			cb.Indent++
+			cb.Append(makeRetConvErr(fmt.Sprintf(`"unknown flag "+ps.Idx.GetWord(it.Index)+" (expected %v)"`, strings.Join(bitmaskWords(flags), ", "))))
+			cb.Indent--
+			cb.Linef(`}`)
+			cb.Indent--
+			cb.Linef(`case env.Integer:`)
+			cb.Indent++
+			cb.Linef(`bits |= %v(it.Value)`, name)
+			cb.Indent--
+			cb.Linef(`default:`)
+			cb.Indent++
+			cb.Append(makeRetConvErr(`"expected block of words or integers, but got item "+objectDebugString(ps.Idx, it)`))
+			cb.Indent--
+			cb.Linef(`}`)
+			cb.Indent--
+			cb.Linef(`}`)
+			cb.Linef(`%v = bits`, outVar)
+			cb.Indent--
+			cb.Linef(`} else {`)
+			cb.Indent++
+			if !convRyeToGoAfter("bitmask", deps, ctx, cb, typ, outVar, inVar, argn, makeRetConvErr) {
+				return false
+			}
+			cb.Indent--
+			cb.Linef(`}`)
+			return true
+		},
+	},
+	{
+		Name: "enum",
+		TryConv: func(deps *Dependencies, ctx *Context, cb *binderio.CodeBuilder, typ ir.Ident, outVar, inVar string, argn int, makeRetConvErr func(inner string) string) bool {
+			name, values, ok := enumType(ctx, typ)
+			if !ok {
+				return false
+			}
+			deps.MarkUsed(typ)
+			qual, _, _ := strings.Cut(name, ".")
+			expected := strings.Join(enumWords(values), ", ")
+
+			cb.Linef(`switch v := %v.(type) {`, inVar)
+			cb.Linef(`case env.Word:`)
+			cb.Indent++
+			cb.Linef(`switch ps.Idx.GetWord(v.Index) {`)
+			for _, v := range values {
+				cb.Linef(`case %v:`, strconv.Quote(v.word))
+				cb.Indent++
+				cb.Linef(`%v = %v.%v`, outVar, qual, v.goName)
+				cb.Indent--
+			}
+			cb.Linef(`default:`)
+			cb.Indent++
+			cb.Append(makeRetConvErr(fmt.Sprintf(`"unknown value "+ps.Idx.GetWord(v.Index)+" (expected %v)"`, expected)))
+			cb.Indent--
+			cb.Linef(`}`)
+			cb.Indent--
+			cb.Linef(`case env.String:`)
+			cb.Indent++
+			cb.Linef(`switch v.Value {`)
+			for _, v := range values {
+				cb.Linef(`case %v:`, strconv.Quote(v.word))
+				cb.Indent++
+				cb.Linef(`%v = %v.%v`, outVar, qual, v.goName)
+				cb.Indent--
+			}
+			cb.Linef(`default:`)
+			cb.Indent++
+			cb.Linef(`// Also accept the result of the String method.`)
+			cb.Linef(`found := false`)
+			cb.Linef(`for _, c := range []%v{`, name)
+			cb.Indent++
+			for _, v := range values {
+				cb.Linef(`%v.%v,`, qual, v.goName)
+			}
+			cb.Indent--
+			cb.Linef(`} {`)
+			cb.Indent++
+			cb.Linef(`if c.String() == v.Value {`)
+			cb.Indent++
+			cb.Linef(`%v, found = c, true`, outVar)
+			cb.Linef(`break`)
+			cb.Indent--
+			cb.Linef(`}`)
+			cb.Indent--
+			cb.Linef(`}`)
+			cb.Linef(`if !found {`)
+			cb.Indent++
+			cb.Append(makeRetConvErr(fmt.Sprintf(`"unknown value "+strconv.Quote(v.Value)+" (expected %v)"`, expected)))
+			deps.Imports["strconv"] = struct{}{}
 			cb.Indent--
 			cb.Linef(`}`)
-			convCodeTranslateChannel(deps, ctx, cb, chTyp, `ch`, outVar, argn)
 			cb.Indent--
-			convRyeToGoCodeCaseNil(deps, cb, outVar, `v`, makeRetConvErr)
 			cb.Linef(`}`)
-
+			cb.Indent--
+			cb.Linef(`default:`)
+			cb.Indent++
+			if !convRyeToGoAfter("enum", deps, ctx, cb, typ, outVar, inVar, argn, makeRetConvErr) {
+				return false
+			}
+			cb.Indent--
+			cb.Linef(`}`)
 			return true
 		},
 	},
@@ -950,6 +2217,19 @@ var convListRyeToGo = []Converter{
 				cb.Linef(`%v = errors.New(v.Print(*ps.Idx))`, outVar)
 				deps.Imports["errors"] = struct{}{}
 				cb.Indent--
+				cb.Linef(`case *env.Error:`)
+				cb.Indent++
+				// Failures of bound functions pass on the original error.
+				cb.Linef(`if err, ok := nativeError(v); ok {`)
+				cb.Indent++
+				cb.Linef(`%v = err`, outVar)
+				cb.Indent--
+				cb.Linef(`} else {`)
+				cb.Indent++
+				cb.Linef(`%v = errors.New(v.Print(*ps.Idx))`, outVar)
+				cb.Indent--
+				cb.Linef(`}`)
+				cb.Indent--
 				convRyeToGoCodeCaseNil(deps, cb, outVar, `v`, makeRetConvErr)
 				cb.Linef(`default:`)
 				cb.Indent++
@@ -978,12 +2258,37 @@ var convListRyeToGo = []Converter{
 
 				cb.Linef(`if vc, ok := %v.(env.%v); ok {`, inVar, ryeObj)
 				cb.Indent++
+				if ryeObj == "Integer" && id.Name != "bool" {
+					convRyeToGoCodeIntRangeCheck(deps, cb, id.Name, `vc.Value`, makeRetConvErr)
+				}
 				if id.Name == "bool" {
 					cb.Linef(`%v = vc.Value != 0`, outVar)
 				} else {
 					cb.Linef(`%v = %v(vc.Value)`, outVar, id.Name)
 				}
 				cb.Indent--
+				if id.Name == "uint" || id.Name == "uint64" {
+					// Values which don't fit into a Rye integer are represented as strings.
+					ryeObjType = "integer or string"
+					cb.Linef(`} else if vc, ok := %v.(env.String); ok {`, inVar)
+					cb.Indent++
+					cb.Linef(`u, err := strconv.ParseUint(vc.Value, 10, 64)`)
+					cb.Linef(`if err != nil {`)
+					cb.Indent++
+					cb.Append(makeRetConvErr(`err.Error()`))
+					cb.Indent--
+					cb.Linef(`}`)
+					cb.Linef(`%v = %v(u)`, outVar, id.Name)
+					deps.Imports["strconv"] = struct{}{}
+					cb.Indent--
+				}
+				if ryeObj == "Decimal" && coercionEnabled(ctx, typ, config.CoercionIntegerToDecimal) {
+					ryeObjType = "decimal or integer"
+					cb.Linef(`} else if vc, ok := %v.(env.Integer); ok {`, inVar)
+					cb.Indent++
+					cb.Linef(`%v = %v(vc.Value)`, outVar, id.Name)
+					cb.Indent--
+				}
 				cb.Linef(`} else {`)
 				cb.Indent++
 				cb.Append(makeRetConvErr(fmt.Sprintf(`"expected %v, but got "+objectDebugString(ps.Idx, %v)`, ryeObjType, inVar)))
@@ -1023,6 +2328,13 @@ var convListRyeToGo = []Converter{
 				cb.Indent++
 				cb.Linef(`natVal, natValOk = nat.Value.(%v)`, typ.Name)
 				deps.MarkUsed(typ)
+				if BoxesAsPointer(ctx, typ) {
+					cb.Linef(`if natPtr, ok := nat.Value.(*%v); ok {`, typ.Name)
+					cb.Indent++
+					cb.Linef(`natVal, natValOk = *natPtr, true`)
+					cb.Indent--
+					cb.Linef(`}`)
+				}
 				cb.Indent--
 				cb.Linef(`}`)
 			}
@@ -1082,16 +2394,27 @@ var convListRyeToGo = []Converter{
 				isNillable = true
 			}
 
-			cb.Linef(`switch v := %v.(type) {`, inVar)
 			iface, isIface := ctx.IR.Interfaces[typ.Name]
-			if isIface &&
+			acceptsCtx := isIface &&
+				!converterDisabled(ctx, config.DisableInterfaceAdapters) &&
 				!iface.HasPrivateFields &&
-				!ir.IdentIsInternal(ctx.ModNames, iface.Name) {
+				!ir.IdentIsInternal(ctx.ModNames, iface.Name)
+			var ifaceTypeName string
+			if acceptsCtx {
+				var err error
+				ifaceTypeName, err = StableTypeName(ctx, iface.Name)
+				if err != nil {
+					return false
+				}
+			}
+
+			cb.Linef(`switch v := %v.(type) {`, inVar)
+			if acceptsCtx {
 				deps.GenericInterfaceImpls[iface.Name.Name] = iface
 				cb.Linef(`case env.RyeCtx:`)
 				cb.Indent++
 				cb.Linef(`var err error`)
-				cb.Linef(`%v, err = ctxTo_%v(ps, v)`, outVar, strings.ReplaceAll(iface.Name.Name, ".", "_"))
+				cb.Linef(`%v, err = ctxTo_%v(ps, v)`, outVar, ifaceTypeName)
 				cb.Linef(`if err != nil {`)
 				cb.Indent++
 				cb.Append(makeRetConvErr(`err.Error()`))
@@ -1118,7 +2441,7 @@ var convListRyeToGo = []Converter{
 			} else {
 				deref := ""
 				ty := typ
-				if _, ok := ctx.IR.Structs[typ.Name]; ok {
+				if BoxesAsPointer(ctx, typ) {
 					var err error
 					ty, err = ir.NewIdent(ctx.IR.ConstValues, ctx.ModNames, ty.File, &ast.StarExpr{X: ty.Expr})
 					if err != nil {
@@ -1126,6 +2449,9 @@ var convListRyeToGo = []Converter{
 					}
 					deref = "*"
 				}
+				// Pointers are passed on as held by the native, so a
+				// pointer converted to Rye and back stays the same
+				// pointer (e.g. for maps keyed by pointer).
 				cb.Linef(`if vc, ok := v.Value.(%v); ok {`, ty.Name)
 				deps.MarkUsed(ty)
 				cb.Indent++
@@ -1133,26 +2459,27 @@ var convListRyeToGo = []Converter{
 				cb.Indent--
 				cb.Linef(`} else {`)
 				cb.Indent++
-				cb.Append(makeRetConvErr(fmt.Sprintf(`"expected native of type %v, but got "+objectDebugString(ps.Idx, v)`, ty.Name)))
+				if isIface {
+					cb.Append(makeRetConvErr(fmt.Sprintf(`"expected native implementing %v, but got "+objectDebugString(ps.Idx, v)`, ty.Name)))
+				} else {
+					cb.Append(makeRetConvErr(fmt.Sprintf(`"expected native of type %v, but got "+objectDebugString(ps.Idx, v)`, ty.Name)))
+				}
 				cb.Indent--
 				cb.Linef(`}`)
 			}
 			cb.Indent--
 			if isNillable {
-				cb.Linef(`case env.Integer:`)
-				cb.Indent++
-				cb.Linef(`if v.Value != 0 {`)
-				cb.Indent++
-				cb.Append(makeRetConvErr(`"expected integer to be 0 or nil, but got "+strconv.FormatInt(v.Value, 10)`))
-				deps.Imports["strconv"] = struct{}{}
-				cb.Indent--
-				cb.Linef(`}`)
-				cb.Linef(`%v = nil`, outVar)
-				cb.Indent--
+				convRyeToGoCodeCaseNil(deps, cb, outVar, `v`, makeRetConvErr)
 			}
 			cb.Linef(`default:`)
 			cb.Indent++
-			cb.Append(makeRetConvErr(fmt.Sprintf(`"expected native, but got "+objectDebugString(ps.Idx, v)`)))
+			if acceptsCtx {
+				cb.Append(makeRetConvErr(fmt.Sprintf(`"expected native or context implementing %v, but got "+objectDebugString(ps.Idx, v)`, typ.Name)))
+			} else if isIface {
+				cb.Append(makeRetConvErr(fmt.Sprintf(`"expected native implementing %v, but got "+objectDebugString(ps.Idx, v)`, typ.Name)))
+			} else {
+				cb.Append(makeRetConvErr(`"expected native, but got "+objectDebugString(ps.Idx, v)`))
+			}
 			cb.Indent--
 			cb.Linef(`}`)
 
@@ -1161,8 +2488,113 @@ var convListRyeToGo = []Converter{
 	},
 }
 
+// BoxesAsPointer reports whether natives of the named type typ hold a
+// *typ instead of a typ value. Structs and types with pointer-receiver
+// methods are boxed, so all of the type's methods (registered on the
+// pointer type) can be called on natives created from values.
+func BoxesAsPointer(ctx *Context, typ ir.Ident) bool {
+	if _, ok := ctx.IR.Structs[typ.Name]; ok {
+		return true
+	}
+	if _, ok := typ.Expr.(*ast.StarExpr); ok {
+		return false
+	}
+	if _, ok := ctx.IR.Interfaces[typ.Name]; ok {
+		return false
+	}
+	return len(ctx.IR.TypeMethods["*"+typ.Name]) > 0
+}
+
+// fieldElemStructs returns the named struct types a field type refers to,
+// looking through pointers, arrays, slices and map values. value is true
+// for the struct stored by value in the field itself.
+func fieldElemStructs(ctx *Context, typ ir.Ident) (structs []*ir.Struct, value *ir.Struct) {
+	if struc, ok := ctx.IR.Structs[typ.Name]; ok {
+		return []*ir.Struct{struc}, struc
+	}
+	var elem ast.Expr
+	switch t := typ.Expr.(type) {
+	case *ast.StarExpr:
+		elem = t.X
+	case *ast.ArrayType:
+		elem = t.Elt
+	case *ast.MapType:
+		elem = t.Value
+	default:
+		return nil, nil
+	}
+	elemTyp, err := ir.NewIdent(ctx.IR.ConstValues, ctx.ModNames, typ.File, elem)
+	if err != nil {
+		return nil, nil
+	}
+	structs, _ = fieldElemStructs(ctx, elemTyp)
+	return structs, nil
+}
+
+// structNesting returns how deeply struct values are nested in the struct
+// type typ (1 if it has no struct-valued fields), and whether typ is
+// recursive, i.e. refers to itself through its fields (e.g. via pointers
+// or slices).
+func structNesting(ctx *Context, typ ir.Ident) (depth int, recursive bool) {
+	root, ok := ctx.IR.Structs[typ.Name]
+	if !ok {
+		return 0, false
+	}
+
+	visited := make(map[*ir.Struct]bool)
+	var reaches func(struc *ir.Struct) bool
+	reaches = func(struc *ir.Struct) bool {
+		for _, f := range struc.Fields {
+			structs, _ := fieldElemStructs(ctx, f.Type)
+			for _, s := range structs {
+				if s == root {
+					return true
+				}
+				if !visited[s] {
+					visited[s] = true
+					if reaches(s) {
+						return true
+					}
+				}
+			}
+		}
+		return false
+	}
+	recursive = reaches(root)
+
+	onStack := make(map[*ir.Struct]bool)
+	var valueDepth func(struc *ir.Struct) int
+	valueDepth = func(struc *ir.Struct) int {
+		if onStack[struc] {
+			// Impossible in valid Go.
+			return 0
+		}
+		onStack[struc] = true
+		defer delete(onStack, struc)
+		res := 1
+		for _, f := range struc.Fields {
+			if _, value := fieldElemStructs(ctx, f.Type); value != nil {
+				res = max(res, 1+valueDepth(value))
+			}
+		}
+		return res
+	}
+	return valueDepth(root), recursive
+}
+
+// getterReturnsCopy reports whether the getter of a struct-valued field
+// returns a pointer to a copy of the field instead of a pointer into the
+// parent struct (see [config.Config.MaxGetterNesting]).
+func getterReturnsCopy(ctx *Context, fieldTyp ir.Ident) bool {
+	if ctx.Config.MaxGetterNesting <= 0 {
+		return false
+	}
+	depth, recursive := structNesting(ctx, fieldTyp)
+	return recursive || depth > ctx.Config.MaxGetterNesting
+}
+
 func nativeGoToRyeShouldGetUnderlyingType(ctx *Context, typ ir.Ident) bool {
-	if len(ctx.IR.TypeMethods[typ.Name]) == 0 {
+	if len(ctx.IR.TypeMethods[typ.Name]) == 0 && !BoxesAsPointer(ctx, typ) {
 		// Get underlying if we have no attached methods to lose
 		return true
 	} else {
@@ -1257,7 +2689,7 @@ var convListGoToRye = []Converter{
 			cb.Linef(`var dVal env.Object`)
 			if _, found := ConvGoToRye(
 				deps,
-				ctx,
+				ctx.mapValueContext(),
 				cb,
 				vTyp,
 				`dVal`,
@@ -1301,6 +2733,9 @@ var convListGoToRye = []Converter{
 				return false
 			}
 
+			// Returned funcs follow the same contract as bound functions
+			// (see [ConvGoToRyeCodeFuncBody]), e.g. func() error fails
+			// with the error or returns void.
 			cb.Linef(`%v = *env.NewBuiltin(func(ps *env.ProgramState, arg0, arg1, arg2, arg3, arg4 env.Object) env.Object {`, outVar)
 			cb.Indent++
 			if err := ConvGoToRyeCodeFuncBody(
@@ -1312,6 +2747,7 @@ var convListGoToRye = []Converter{
 				nil,
 				fnParams,
 				fnResults,
+				nil,
 			); err != nil {
 				return false
 			}
@@ -1348,37 +2784,283 @@ var convListGoToRye = []Converter{
 		},
 	},
 	{
-		Name: "builtin",
+		Name: "time",
 		TryConv: func(deps *Dependencies, ctx *Context, cb *binderio.CodeBuilder, typ ir.Ident, outVar, inVar string, argn int, makeRetConvErr func(inner string) string) bool {
-			id, ok := typ.Expr.(*ast.Ident)
+			_, isPtr, ok := timeType(typ)
 			if !ok {
 				return false
 			}
 
-			if id.Name == "error" {
-				cb.Linef(`if %v != nil {`, inVar)
+			if isPtr {
+				convGoToRyeCodeNil(cb, outVar, inVar+" == nil", func() {
+					cb.Linef(`%v = *env.NewTime(*%v)`, outVar, inVar)
+				})
+			} else {
+				cb.Linef(`%v = *env.NewTime(%v)`, outVar, inVar)
+			}
+			return true
+		},
+	},
+	{
+		Name: "smallstruct",
+		TryConv: func(deps *Dependencies, ctx *Context, cb *binderio.CodeBuilder, typ ir.Ident, outVar, inVar string, argn int, makeRetConvErr func(inner string) string) bool {
+			fields, _, ok := getSmallStructFields(deps, ctx, typ)
+			if !ok {
+				return false
+			}
+
+			cb.Linef(`%v = *env.NewDict(map[string]any{`, outVar)
+			cb.Indent++
+			for _, f := range fields {
+				expr, _ := basicGoToRyeExpr(f.typ, inVar+`.`+f.goName)
+				cb.Linef(`"%v": %v,`, f.ryeName, expr)
+			}
+			cb.Indent--
+			cb.Linef(`})`)
+
+			return true
+		},
+	},
+	{
+		Name: "struct",
+		TryConv: func(deps *Dependencies, ctx *Context, cb *binderio.CodeBuilder, typ ir.Ident, outVar, inVar string, argn int, makeRetConvErr func(inner string) string) bool {
+			fields, ok := getAnonStructFields(ctx, typ)
+			fieldCtx := ctx
+			if !ok {
+				fields, fieldCtx, ok = getDictStructFields(deps, ctx, typ)
+				if !ok {
+					return false
+				}
+			}
+
+			cb.Linef(`{`)
+			cb.Indent++
+			cb.Linef(`data := make(map[string]any, %v)`, len(fields))
+			for _, f := range fields {
+				cb.Linef(`{`)
 				cb.Indent++
-				cb.Linef(`%v = env.NewError(%v.Error())`, outVar, inVar)
+				cb.Linef(`var dVal env.Object`)
+				if _, found := ConvGoToRye(
+					deps,
+					fieldCtx,
+					cb,
+					f.typ,
+					`dVal`,
+					inVar+`.`+f.goName,
+					argn,
+					nil,
+				); !found {
+					return false
+				}
+				cb.Linef(`data["%v"] = dVal`, f.ryeName)
 				cb.Indent--
 				cb.Linef(`}`)
-			} else {
-				var convFmt string
-				if id.Name == "int" || id.Name == "uint" ||
-					id.Name == "uint8" || id.Name == "uint16" || id.Name == "uint32" || id.Name == "uint64" ||
-					id.Name == "int8" || id.Name == "int16" || id.Name == "int32" || id.Name == "int64" ||
-					id.Name == "byte" {
-					convFmt = `*env.NewInteger(int64(%v))`
-				} else if id.Name == "bool" {
-					convFmt = `*env.NewInteger(boolToInt64(%v))`
-				} else if id.Name == "float32" || id.Name == "float64" {
-					convFmt = `*env.NewDecimal(float64(%v))`
-				} else if id.Name == "string" {
-					convFmt = `*env.NewString(%v)`
-				} else {
+			}
+			cb.Linef(`%v = *env.NewDict(data)`, outVar)
+			cb.Indent--
+			cb.Linef(`}`)
+
+			return true
+		},
+	},
+	{
+		Name: "oneof",
+		TryConv: func(deps *Dependencies, ctx *Context, cb *binderio.CodeBuilder, typ ir.Ident, outVar, inVar string, argn int, makeRetConvErr func(inner string) string) bool {
+			cases, ok := oneofCases(ctx, typ)
+			if !ok {
+				return false
+			}
+
+			cb.Linef(`switch v := %v.(type) {`, inVar)
+			for _, c := range cases {
+				cb.Linef(`case %v:`, c.wrapper.Name)
+				deps.MarkUsed(c.wrapper)
+				cb.Indent++
+				cb.Linef(`var dVal env.Object`)
+				if _, found := ConvGoToRye(
+					deps,
+					ctx,
+					cb,
+					c.field.typ,
+					`dVal`,
+					`v.`+c.field.goName,
+					argn,
+					nil,
+				); !found {
 					return false
 				}
+				cb.Linef(`%v = *env.NewDict(map[string]any{"%v": dVal})`, outVar, c.field.ryeName)
+				cb.Indent--
+			}
+			cb.Linef(`default:`)
+			cb.Indent++
+			cb.Linef(`%v = env.Void{}`, outVar)
+			cb.Indent--
+			cb.Linef(`}`)
+			return true
+		},
+	},
+	{
+		Name: "stringable",
+		TryConv: func(deps *Dependencies, ctx *Context, cb *binderio.CodeBuilder, typ ir.Ident, outVar, inVar string, argn int, makeRetConvErr func(inner string) string) bool {
+			_, key, st, isPtr, ok := stringableType(ctx, typ)
+			if !ok || slices.Contains(ctx.Config.NativeStringable, key) {
+				return false
+			}
+
+			if isPtr {
+				convGoToRyeCodeNil(cb, outVar, inVar+" == nil", func() {
+					cb.Linef(`%v = *env.NewString(%v.String())`, outVar, inVar)
+				})
+			} else if st.PtrString {
+				cb.Linef(`%v = *env.NewString((&%v).String())`, outVar, inVar)
+			} else {
+				cb.Linef(`%v = *env.NewString(%v.String())`, outVar, inVar)
+			}
+			return true
+		},
+	},
+	{
+		Name: "bigint",
+		TryConv: func(deps *Dependencies, ctx *Context, cb *binderio.CodeBuilder, typ ir.Ident, outVar, inVar string, argn int, makeRetConvErr func(inner string) string) bool {
+			_, isPtr, ok := bigIntType(typ)
+			if !ok {
+				return false
+			}
+
+			// Integers which don't fit into a Rye integer become strings.
+			writeConv := func(in string) {
+				cb.Linef(`if %v.IsInt64() {`, in)
+				cb.Indent++
+				cb.Linef(`%v = *env.NewInteger(%v.Int64())`, outVar, in)
+				cb.Indent--
+				cb.Linef(`} else {`)
+				cb.Indent++
+				cb.Linef(`%v = *env.NewString(%v.String())`, outVar, in)
+				cb.Indent--
+				cb.Linef(`}`)
+			}
+			if isPtr {
+				convGoToRyeCodeNil(cb, outVar, inVar+" == nil", func() {
+					writeConv(inVar)
+				})
+			} else {
+				writeConv("(&" + inVar + ")")
+			}
+			return true
+		},
+	},
+	{
+		Name: "reflect",
+		TryConv: func(deps *Dependencies, ctx *Context, cb *binderio.CodeBuilder, typ ir.Ident, outVar, inVar string, argn int, makeRetConvErr func(inner string) string) bool {
+			_, typeName, ok := reflectType(typ)
+			if !ok {
+				return false
+			}
+
+			isNil := inVar + " == nil"
+			if typeName == "Value" {
+				isNil = "!" + inVar + ".IsValid()"
+			}
+			convGoToRyeCodeNil(cb, outVar, isNil, func() {
+				cb.Linef(`%v = *env.NewNative(ps.Idx, %v, "%v")`, outVar, inVar, KindName(ctx, typ))
+			})
+			return true
+		},
+	},
+	{
+		Name: "bitmask",
+		TryConv: func(deps *Dependencies, ctx *Context, cb *binderio.CodeBuilder, typ ir.Ident, outVar, inVar string, argn int, makeRetConvErr func(inner string) string) bool {
+			name, flags, ok := bitmaskType(ctx, typ)
+			if !ok {
+				return false
+			}
+			deps.MarkUsed(typ)
+			qual, _, _ := strings.Cut(name, ".")
+
+			cb.Linef(`{`)
+			cb.Indent++
+			cb.Linef(`rest := %v`, inVar)
+			cb.Linef(`var items []env.Object`)
+			for _, f := range flags {
+				cb.Linef(`if rest&%v.%v != 0 {`, qual, f.goName)
+				cb.Indent++
+				cb.Linef(`items = append(items, *env.NewWord(ps.Idx.IndexWord(%v)))`, strconv.Quote(f.word))
+				cb.Linef(`rest &^= %v.%v`, qual, f.goName)
+				cb.Indent--
+				cb.Linef(`}`)
+			}
+			cb.Linef(`if rest != 0 {`)
+			cb.Indent++
+			cb.Linef(`// Bits without a flag name (e.g. permission bits)`)
+			cb.Linef(`items = append(items, *env.NewInteger(int64(rest)))`)
+			cb.Indent--
+			cb.Linef(`}`)
+			cb.Linef(`%v = *env.NewBlock(*env.NewTSeries(items))`, outVar)
+			cb.Indent--
+			cb.Linef(`}`)
+			return true
+		},
+	},
+	{
+		Name: "enum",
+		TryConv: func(deps *Dependencies, ctx *Context, cb *binderio.CodeBuilder, typ ir.Ident, outVar, inVar string, argn int, makeRetConvErr func(inner string) string) bool {
+			name, values, ok := enumType(ctx, typ)
+			if !ok {
+				return false
+			}
+			deps.MarkUsed(typ)
+			qual, _, _ := strings.Cut(name, ".")
+
+			cb.Linef(`switch %v {`, inVar)
+			for _, v := range values {
+				cb.Linef(`case %v.%v:`, qual, v.goName)
+				cb.Indent++
+				cb.Linef(`%v = *env.NewWord(ps.Idx.IndexWord(%v))`, outVar, strconv.Quote(v.word))
+				cb.Indent--
+			}
+			cb.Linef(`default:`)
+			cb.Indent++
+			cb.Linef(`%v = *env.NewInteger(int64(%v))`, outVar, inVar)
+			cb.Indent--
+			cb.Linef(`}`)
+			return true
+		},
+	},
+	{
+		Name: "builtin",
+		TryConv: func(deps *Dependencies, ctx *Context, cb *binderio.CodeBuilder, typ ir.Ident, outVar, inVar string, argn int, makeRetConvErr func(inner string) string) bool {
+			id, ok := typ.Expr.(*ast.Ident)
+			if !ok {
+				return false
+			}
+
+			if id.Name == "error" {
+				convGoToRyeCodeNil(cb, outVar, inVar+" == nil", func() {
+					cb.Linef(`%v = errorToRye(ps.Idx, %v)`, outVar, inVar)
+				})
+			} else {
+				if id.Name == "uint" || id.Name == "uint64" {
+					// Values which don't fit into a Rye integer become strings.
+					cb.Linef(`if uint64(%v) > math.MaxInt64 {`, inVar)
+					cb.Indent++
+					cb.Linef(`%v = *env.NewString(strconv.FormatUint(uint64(%v), 10))`, outVar, inVar)
+					cb.Indent--
+					cb.Linef(`} else {`)
+					cb.Indent++
+					cb.Linef(`%v = *env.NewInteger(int64(%v))`, outVar, inVar)
+					cb.Indent--
+					cb.Linef(`}`)
+					deps.Imports["math"] = struct{}{}
+					deps.Imports["strconv"] = struct{}{}
+					return true
+				}
 
-				cb.Linef(`%v = %v`, outVar, fmt.Sprintf(convFmt, inVar))
+				expr, ok := basicGoToRyeExpr(typ, inVar)
+				if !ok {
+					return false
+				}
+				cb.Linef(`%v = %v`, outVar, expr)
 			}
 			return true
 		},
@@ -1417,11 +3099,11 @@ var convListGoToRye = []Converter{
 				}
 			} else {
 				if _, ok := ctx.IR.Interfaces[typ.Name]; ok {
-					cb.Linef(`%v = ifaceToNative(ps.Idx, %v, "%v")`, outVar, inVar, typ.RyeName())
+					cb.Linef(`%v = ifaceToNative(ps.Idx, %v, "%v")`, outVar, inVar, KindName(ctx, typ))
 				} else {
 					addr := ""
 					ty := typ
-					if _, ok := ctx.IR.Structs[ty.Name]; ok {
+					if BoxesAsPointer(ctx, ty) {
 						var err error
 						ty, err = ir.NewIdent(ctx.IR.ConstValues, ctx.ModNames, ty.File, &ast.StarExpr{X: ty.Expr})
 						if err != nil {
@@ -1429,7 +3111,9 @@ var convListGoToRye = []Converter{
 						}
 						addr = "&"
 					}
-					cb.Linef(`%v = *env.NewNative(ps.Idx, %v%v, "%v")`, outVar, addr, inVar, ty.RyeName())
+					// Pointers are held as they are, never copied (see
+					// the Rye to Go native converter).
+					cb.Linef(`%v = *env.NewNative(ps.Idx, %v%v, "%v")`, outVar, addr, inVar, KindName(ctx, ty))
 				}
 			}
 			return true
@@ -0,0 +1,178 @@
+// Package convtest fuzzes binding converters (see [binder.ConvListRyeToGo]
+// and [binder.ConvListGoToRye]) with random Go types.
+//
+// Values are converted to Rye and back by the generated code in the
+// FuzzRoundTrip target run by bindertest (against a stub of Rye), for a
+// fixed set of types. Random types are converted in both directions at
+// code generation time, checking that:
+//   - no converter panics,
+//   - the generated code is syntactically valid Go,
+//   - generating the same conversion twice yields the same code,
+//   - every type which can be converted to Rye can be converted back,
+//     so values returned by bindings can be passed to bindings again.
+package convtest
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/refaktor/ryegen/binder"
+	"github.com/refaktor/ryegen/binder/binderio"
+	"github.com/refaktor/ryegen/config"
+	"github.com/refaktor/ryegen/ir"
+	"github.com/refaktor/ryegen/ir/irtest"
+)
+
+// Types random types are built from.
+var leafTypes = []reflect.Type{
+	reflect.TypeFor[bool](),
+	reflect.TypeFor[int](),
+	reflect.TypeFor[int8](),
+	reflect.TypeFor[int64](),
+	reflect.TypeFor[uint](),
+	reflect.TypeFor[uint8](),
+	reflect.TypeFor[uint32](),
+	reflect.TypeFor[float32](),
+	reflect.TypeFor[float64](),
+	reflect.TypeFor[string](),
+	reflect.TypeFor[error](),
+	reflect.TypeFor[any](),
+}
+
+// RandomType returns a random Go type built from basic types, error and
+// any, nesting slices, arrays, maps, pointers, channels, funcs and
+// structs at most depth levels deep.
+func RandomType(r *rand.Rand, depth int) reflect.Type {
+	if depth <= 0 || r.Intn(3) == 0 {
+		return leafTypes[r.Intn(len(leafTypes))]
+	}
+	switch r.Intn(7) {
+	case 0:
+		return reflect.SliceOf(RandomType(r, depth-1))
+	case 1:
+		return reflect.ArrayOf(1+r.Intn(4), RandomType(r, depth-1))
+	case 2:
+		key := RandomType(r, 0)
+		if !key.Comparable() {
+			key = reflect.TypeFor[string]()
+		}
+		return reflect.MapOf(key, RandomType(r, depth-1))
+	case 3:
+		return reflect.PointerTo(RandomType(r, depth-1))
+	case 4:
+		return reflect.ChanOf(reflect.BothDir, RandomType(r, depth-1))
+	case 5:
+		in := make([]reflect.Type, r.Intn(3))
+		for i := range in {
+			in[i] = RandomType(r, depth-1)
+		}
+		out := make([]reflect.Type, r.Intn(3))
+		for i := range out {
+			out[i] = RandomType(r, depth-1)
+		}
+		return reflect.FuncOf(in, out, false)
+	default:
+		fields := make([]reflect.StructField, 1+r.Intn(3))
+		for i := range fields {
+			fields[i] = reflect.StructField{
+				Name: string(rune('A' + i)),
+				Type: RandomType(r, depth-1),
+			}
+		}
+		return reflect.StructOf(fields)
+	}
+}
+
+// Result is the outcome of converting a type in both directions.
+type Result struct {
+	// Names of the converters used at the top level, or "" if the type
+	// can't be converted in that direction.
+	RyeToGo, GoToRye string
+	// Generated conversion code.
+	RyeToGoCode, GoToRyeCode string
+}
+
+// Convert converts the Go type expression typ in both directions with
+// the converters enabled in cfg. The error describes the first check
+// (see package doc) that failed.
+func Convert(t *testing.T, cfg *config.Config, typ string) (Result, error) {
+	t.Helper()
+
+	src := filepath.Join(t.TempDir(), "fuzz.go")
+	code := fmt.Sprintf("package testfile\n\nfunc F(v %v) %v { return v }\n", typ, typ)
+	if err := os.WriteFile(src, []byte(code), 0666); err != nil {
+		t.Fatal(err)
+	}
+	irData, modNames := irtest.ParseSingleFile(t, src)
+	ctx := binder.NewContext(cfg, irData, modNames)
+	ident := irData.Funcs["testmodule.F"].Params[0].Type
+
+	res, err := convert(ctx, ident)
+	if err != nil {
+		return Result{}, err
+	}
+	again, err := convert(ctx, ident)
+	if err != nil {
+		return Result{}, err
+	}
+	if again != res {
+		return Result{}, fmt.Errorf("conversion of %v is not deterministic", typ)
+	}
+	if res.GoToRye != "" && res.RyeToGo == "" {
+		return Result{}, fmt.Errorf("%v can be converted to Rye (%v), but not back", typ, res.GoToRye)
+	}
+	return res, nil
+}
+
+func convert(ctx *binder.Context, typ ir.Ident) (res Result, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("converting %v panicked: %v", typ.Name, r)
+		}
+	}()
+
+	makeRetConvErr := func(inner string) string {
+		return fmt.Sprintf("return env.NewError(%v)\n", inner)
+	}
+	dirs := []struct {
+		conv func(deps *binder.Dependencies, ctx *binder.Context, cb *binderio.CodeBuilder, typ ir.Ident, outVar, inVar string, argn int, makeRetConvErr func(inner string) string) (string, bool)
+		name *string
+		code *string
+	}{
+		{binder.ConvRyeToGo, &res.RyeToGo, &res.RyeToGoCode},
+		{binder.ConvGoToRye, &res.GoToRye, &res.GoToRyeCode},
+	}
+	for _, d := range dirs {
+		var cb binderio.CodeBuilder
+		name, found := d.conv(binder.NewDependencies(), ctx, &cb, typ, "out", "in", 0, makeRetConvErr)
+		if !found {
+			continue
+		}
+		*d.name = name
+		*d.code = cb.String()
+		if _, err := parser.ParseFile(token.NewFileSet(), "", "package p\n\nfunc f() {\n"+*d.code+"\n}\n", 0); err != nil {
+			return Result{}, fmt.Errorf("invalid code converting %v with %v: %w\n%v", typ.Name, name, err, *d.code)
+		}
+	}
+	return res, nil
+}
+
+// Fuzz converts n random types of at most the given nesting depth,
+// generated from seed, failing t for each type failing a check.
+func Fuzz(t *testing.T, cfg *config.Config, seed int64, n, depth int) {
+	t.Helper()
+
+	r := rand.New(rand.NewSource(seed))
+	for range n {
+		typ := RandomType(r, depth)
+		if _, err := Convert(t, cfg, typ.String()); err != nil {
+			t.Errorf("seed %v: %v", seed, err)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package convtest
+
+import (
+	"testing"
+
+	"github.com/refaktor/ryegen/config"
+)
+
+func TestConverters(t *testing.T) {
+	n := 500
+	if testing.Short() {
+		n = 100
+	}
+	Fuzz(t, &config.Config{}, 1, n, 3)
+	Fuzz(t, &config.Config{
+		DisableConverters: []string{config.DisableChan, config.DisableFunc, config.DisableInterfaceAdapters},
+	}, 2, n, 3)
+}
+
+func FuzzConverters(f *testing.F) {
+	f.Add(int64(0))
+	f.Fuzz(func(t *testing.T, seed int64) {
+		Fuzz(t, &config.Config{}, seed, 1, 4)
+	})
+}
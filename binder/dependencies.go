@@ -1,18 +1,104 @@
 package binder
 
-import "github.com/refaktor/ryegen/ir"
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/refaktor/ryegen/ir"
+)
 
 // Dependencies tracks the dependencies used while generating code.
 type Dependencies struct {
 	Imports               map[string]struct{}
 	GenericInterfaceImpls map[string]*ir.Interface
+	// Number of generated conversions by converter.
+	ConvUsage map[ConvID]int
+	// Number of conversions generated directly by the code of another
+	// converter (e.g. of slice elements), by pair of converters.
+	ConvDeps map[ConvEdge]int
+	// Number of conversions replaced by frozen code by key (see
+	// [Context.FrozenConvs]).
+	FrozenConvUsage map[string]int
+	// Names of the structs converted to and from dicts (see
+	// [config.Config.DictStructs]).
+	DictStructs map[string]struct{}
+
+	// Converter whose code the dependencies are collected for (see
+	// tryConv), nil if not in a conversion.
+	conv *ConvID
 }
 
 func NewDependencies() *Dependencies {
 	return &Dependencies{
 		Imports:               make(map[string]struct{}),
 		GenericInterfaceImpls: make(map[string]*ir.Interface),
+		ConvUsage:             make(map[ConvID]int),
+		ConvDeps:              make(map[ConvEdge]int),
+		FrozenConvUsage:       make(map[string]int),
+		DictStructs:           make(map[string]struct{}),
+	}
+}
+
+// Merge adds the dependencies of other to deps, so bindings can be
+// generated in isolation (e.g. in parallel) with separate dependencies
+// and emitted together. Converter usage is summed up.
+// Fails without modifying deps if both have a generic interface
+// implementation of the same name for different interfaces, since only
+// one of them could be emitted.
+func (deps *Dependencies) Merge(other *Dependencies) error {
+	for _, name := range slices.Sorted(maps.Keys(other.GenericInterfaceImpls)) {
+		iface, ok := deps.GenericInterfaceImpls[name]
+		if ok && iface.Name.Name != other.GenericInterfaceImpls[name].Name.Name {
+			return fmt.Errorf("conflicting generic interface implementations %v: %v and %v", name, iface.Name.Name, other.GenericInterfaceImpls[name].Name.Name)
+		}
+	}
+	maps.Copy(deps.Imports, other.Imports)
+	maps.Copy(deps.GenericInterfaceImpls, other.GenericInterfaceImpls)
+	maps.Copy(deps.DictStructs, other.DictStructs)
+	for id, n := range other.ConvUsage {
+		deps.ConvUsage[id] += n
+	}
+	for edge, n := range other.ConvDeps {
+		deps.ConvDeps[edge] += n
+	}
+	for key, n := range other.FrozenConvUsage {
+		deps.FrozenConvUsage[key] += n
+	}
+	return nil
+}
+
+// ConvID identifies a converter in [ConvListRyeToGo] or [ConvListGoToRye].
+type ConvID struct {
+	GoToRye bool
+	Name    string
+}
+
+func (id ConvID) String() string {
+	if id.GoToRye {
+		return "go-to-rye/" + id.Name
+	} else {
+		return "rye-to-go/" + id.Name
+	}
+}
+
+// ConvEdge is a dependency of converter From on converter To, whose
+// conversions are generated by the code of From.
+type ConvEdge struct {
+	From, To ConvID
+}
+
+// AllConvIDs returns the IDs of all converters in [ConvListRyeToGo]
+// and [ConvListGoToRye].
+func AllConvIDs() []ConvID {
+	var res []ConvID
+	for _, conv := range ConvListRyeToGo {
+		res = append(res, ConvID{GoToRye: false, Name: conv.Name})
+	}
+	for _, conv := range ConvListGoToRye {
+		res = append(res, ConvID{GoToRye: true, Name: conv.Name})
 	}
+	return res
 }
 
 func (deps *Dependencies) MarkUsed(id ir.Ident) {
@@ -0,0 +1,96 @@
+package binder
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"slices"
+	"strings"
+
+	"github.com/refaktor/ryegen/ir"
+)
+
+// enumValue is a constant of an enum type.
+type enumValue struct {
+	// Rye word, e.g. "tuesday" for time.Tuesday.
+	word string
+	// Go name of the constant (without qualifier), e.g. "Tuesday".
+	goName string
+}
+
+// enumType reports whether typ is an enum type: a named integer type
+// implementing fmt.Stringer (e.g. generated by the stringer tool), with
+// exported constants in its package. Values of enums are converted to
+// and from words named after the constants like flags of bitmasks (see
+// [bitmaskType]), falling back to integers for other values. Returns the
+// type's name in generated code and its constants, sorted by value.
+//
+// Types for which [config.Config.IsNativeEnum] reports true aren't
+// enums.
+func enumType(ctx *Context, typ ir.Ident) (name string, values []enumValue, ok bool) {
+	modulePath, typeName, name, isPtr, ok := namedTypeRef(typ)
+	if !ok || isPtr || ir.IdentIsInternal(ctx.ModNames, typ) {
+		return "", nil, false
+	}
+	key := modulePath + "." + typeName
+	if ctx.Config.IsNativeEnum(key) {
+		return "", nil, false
+	}
+	if _, _, ok := bitmaskType(ctx, typ); ok {
+		return "", nil, false
+	}
+	underlying, ok := getUnderlyingType(ctx, typ)
+	if !ok {
+		return "", nil, false
+	}
+	if id, ok := underlying.Expr.(*ast.Ident); !ok || !slices.Contains([]string{
+		"int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64", "byte",
+	}, id.Name) {
+		return "", nil, false
+	}
+	str, ok := ctx.IR.Funcs[name+".String"]
+	if !ok || len(str.Params) != 0 || len(str.Results) != 1 {
+		return "", nil, false
+	}
+	if id, ok := str.Results[0].Type.Expr.(*ast.Ident); !ok || id.Name != "string" {
+		return "", nil, false
+	}
+
+	consts := typedConsts(ctx, modulePath, typeName)
+	if len(consts) == 0 {
+		return "", nil, false
+	}
+	slices.SortFunc(consts, func(a, b typedConst) int {
+		switch {
+		case constant.Compare(a.val, token.LSS, b.val):
+			return -1
+		case constant.Compare(a.val, token.GTR, b.val):
+			return 1
+		default:
+			return strings.Compare(a.goName, b.goName)
+		}
+	})
+	// Keep the first name of constants with the same value.
+	consts = slices.CompactFunc(consts, func(a, b typedConst) bool {
+		return constant.Compare(a.val, token.EQL, b.val)
+	})
+
+	goNames := make([]string, len(consts))
+	for i, c := range consts {
+		goNames[i] = c.goName
+	}
+	for i, word := range constWords(goNames) {
+		values = append(values, enumValue{word: word, goName: goNames[i]})
+	}
+	return name, values, true
+}
+
+// enumWords returns the words of values, e.g. for error messages.
+func enumWords(values []enumValue) []string {
+	res := make([]string, len(values))
+	for i, v := range values {
+		res[i] = v.word
+	}
+	return res
+}
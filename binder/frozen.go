@@ -0,0 +1,207 @@
+package binder
+
+import (
+	"bufio"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/refaktor/ryegen/binder/binderio"
+	"github.com/refaktor/ryegen/ir"
+)
+
+// Markers around the code of each conversion in generated code, e.g.
+//
+//	//ryegen:conv rye-to-go/struct testmodule.Point
+//	...
+//	//ryegen:endconv
+//
+// The key following [ConvMarkerPrefix] is [ConvKey].
+const (
+	ConvMarkerPrefix = "//ryegen:conv "
+	ConvEndMarker    = "//ryegen:endconv"
+)
+
+// ConvKey returns the key identifying the conversions of typ by the
+// converter id, e.g. "rye-to-go/struct testmodule.Point".
+func ConvKey(id ConvID, typ ir.Ident) string {
+	return id.String() + " " + typ.Name
+}
+
+// ParseFrozenConvs parses the frozen conversions in src by key (see
+// [Context.FrozenConvs]). src consists of blocks of code in conversion
+// markers as in generated code; text outside of them is ignored. In the
+// code, "$in" and "$out" stand for the input and output variables of the
+// conversion, a line "$fail(<Go string expression>)" for failing with
+// that message, and a line "$import <quoted package path>" imports a
+// package the code uses (the package of the converted type is imported
+// anyway). name is used in errors.
+func ParseFrozenConvs(name, src string) (map[string]string, error) {
+	res := make(map[string]string)
+	var key string
+	var lines []string
+	depth := 0
+	sc := bufio.NewScanner(strings.NewReader(src))
+	lineNum := 0
+	for sc.Scan() {
+		lineNum++
+		ln := sc.Text()
+		trimmed := strings.TrimSpace(ln)
+		switch {
+		case strings.HasPrefix(trimmed, ConvMarkerPrefix):
+			depth++
+			if depth == 1 {
+				key = strings.TrimSpace(strings.TrimPrefix(trimmed, ConvMarkerPrefix))
+				if _, ok := res[key]; ok {
+					return nil, fmt.Errorf("%v:%v: duplicate frozen conversion %v", name, lineNum, key)
+				}
+				lines = nil
+				continue
+			}
+		case trimmed == ConvEndMarker:
+			if depth == 0 {
+				return nil, fmt.Errorf("%v:%v: %v without %v", name, lineNum, ConvEndMarker, strings.TrimSpace(ConvMarkerPrefix))
+			}
+			depth--
+			if depth == 0 {
+				res[key] = dedentLines(lines)
+				continue
+			}
+		}
+		if path, ok := strings.CutPrefix(trimmed, "$import "); ok && depth > 0 {
+			if _, err := strconv.Unquote(strings.TrimSpace(path)); err != nil {
+				return nil, fmt.Errorf("%v:%v: expected quoted package path after $import", name, lineNum)
+			}
+		}
+		if depth > 0 {
+			lines = append(lines, ln)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	if depth > 0 {
+		return nil, fmt.Errorf("%v: unterminated frozen conversion %v", name, key)
+	}
+	return res, nil
+}
+
+// dedentLines joins lines, removing the leading tabs all non-empty lines
+// have in common.
+func dedentLines(lines []string) string {
+	indent := -1
+	for _, ln := range lines {
+		if strings.TrimSpace(ln) == "" {
+			continue
+		}
+		n := len(ln) - len(strings.TrimLeft(ln, "\t"))
+		if indent == -1 || n < indent {
+			indent = n
+		}
+	}
+	var b strings.Builder
+	for _, ln := range lines {
+		if len(ln) >= indent && indent > 0 {
+			ln = ln[indent:]
+		}
+		b.WriteString(strings.TrimRight(ln, " \t"))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// tryConv runs conv, wrapping the generated code in conversion markers.
+// If the conversion is frozen (see [Context.FrozenConvs]), the frozen code
+// is written instead. If tracing is enabled ("trace-tag" in config.toml),
+// the conversion is timed by calling traceConvBegin and traceConvEnd,
+// which the generated code defines. The frame of the conversion shadows
+// the one of its parent (convTrace), so nested conversions aren't timed
+// twice. traceConvEnd isn't reached if the conversion fails, so only
+// successful conversions are recorded.
+func tryConv(deps *Dependencies, ctx *Context, cb *binderio.CodeBuilder, id ConvID, conv Converter, typ ir.Ident, outVar, inVar string, argn int, makeRetConvErr func(inner string) string) bool {
+	traced := ctx.Config.TraceTag != ""
+	indent := cb.Indent
+	if traced {
+		indent++
+	}
+	// The dependencies of the generated code only count if it's used
+	// instead of frozen code.
+	subDeps := NewDependencies()
+	subDeps.conv = &id
+	sub := &binderio.CodeBuilder{Indent: indent}
+	if !conv.TryConv(subDeps, ctx, sub, typ, outVar, inVar, argn, makeRetConvErr) {
+		return false
+	}
+	key := ConvKey(id, typ)
+	code, frozen := ctx.FrozenConvs[key]
+	if !frozen {
+		if err := deps.Merge(subDeps); err != nil {
+			return false
+		}
+	}
+	deps.ConvUsage[id]++
+	if deps.conv != nil {
+		deps.ConvDeps[ConvEdge{From: *deps.conv, To: id}]++
+	}
+	if traced {
+		// In a block, so nested and subsequent conversions can use the
+		// same variable name. The right-hand convTrace is the parent's
+		// (or the package-level nil frame), since the new variable's
+		// scope only starts after the statement.
+		cb.Linef(`{`)
+		cb.Indent++
+		cb.Linef(`convTrace := traceConvBegin(convTrace)`)
+	}
+	cb.Linef("%v%v", ConvMarkerPrefix, key)
+	if frozen {
+		deps.FrozenConvUsage[key]++
+		deps.MarkUsed(typ)
+		writeFrozenConv(deps, cb, code, outVar, inVar, makeRetConvErr)
+	} else {
+		cb.Write(sub.String())
+	}
+	cb.Linef("%v", ConvEndMarker)
+	if traced {
+		cb.Linef(`traceConvEnd(convTrace, %q)`, key)
+		cb.Indent--
+		cb.Linef(`}`)
+	}
+	return true
+}
+
+// frozenPlaceholderRe matches the "$in" and "$out" placeholders of frozen
+// conversions, but not e.g. "$inner".
+var frozenPlaceholderRe = regexp.MustCompile(`\$(in|out)\b`)
+
+// writeFrozenConv writes the frozen conversion code with its placeholders
+// replaced and adds its imports to deps (see [ParseFrozenConvs]).
+func writeFrozenConv(deps *Dependencies, cb *binderio.CodeBuilder, code, outVar, inVar string, makeRetConvErr func(inner string) string) {
+	replace := func(s string) string {
+		return frozenPlaceholderRe.ReplaceAllStringFunc(s, func(m string) string {
+			if m == "$in" {
+				return inVar
+			}
+			return outVar
+		})
+	}
+	sc := bufio.NewScanner(strings.NewReader(code))
+	for sc.Scan() {
+		ln := sc.Text()
+		trimmed := strings.TrimLeft(ln, "\t")
+		indent := len(ln) - len(trimmed)
+		cb.Indent += indent
+		if path, ok := strings.CutPrefix(trimmed, "$import "); ok {
+			if path, err := strconv.Unquote(strings.TrimSpace(path)); err == nil {
+				deps.Imports[path] = struct{}{}
+			}
+		} else if inner, ok := strings.CutPrefix(trimmed, "$fail("); ok && strings.HasSuffix(inner, ")") {
+			cb.Append(makeRetConvErr(replace(strings.TrimSuffix(inner, ")"))))
+		} else if trimmed == "" {
+			cb.Write("\n")
+		} else {
+			cb.Linef("%v", replace(trimmed))
+		}
+		cb.Indent -= indent
+	}
+}
@@ -0,0 +1,132 @@
+package binder
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+	"github.com/refaktor/ryegen/binder/binderio"
+	"github.com/refaktor/ryegen/ir"
+)
+
+// GoAPINamePlaceholder is replaced by the name of the Go API function
+// (see [GenerateGoAPI]) once the final binding name is known.
+const GoAPINamePlaceholder = `((RYEGEN:GOAPINAME))`
+
+// GoAPIName returns the name of the Go API function of a builtin
+// (e.g. "CallFyneNewApp" for "fyne-new-app").
+func GoAPIName(bindingName string) string {
+	var b strings.Builder
+	for _, r := range bindingName {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('-')
+		}
+	}
+	return "Call" + strcase.ToCamel(b.String())
+}
+
+// GenerateGoAPI generates an exported, strongly-typed Go function calling
+// the builtin generated by [GenerateBinding] for fn. Arguments and the
+// result are converted using the same converters as the builtins, so the
+// function can be used to test bindings from Go.
+//
+// The generated code contains [GoAPINamePlaceholder] and
+// ((RYEGEN:FUNCNAME)), and calls the builtin through builtinsGenerated.
+func GenerateGoAPI(deps *Dependencies, ctx *Context, fn *ir.Func) (string, error) {
+	params := fn.Params
+	if fn.Recv != nil {
+		recvName, _ := ir.NewIdent(ctx.IR.ConstValues, ctx.ModNames, nil, &ast.Ident{Name: "recv"})
+		params = append([]ir.NamedIdent{{Name: recvName, Type: *fn.Recv}}, params...)
+	}
+	if len(params) > 5 {
+		return "", errors.New("can only handle at most 5 parameters")
+	}
+
+	results := fn.Results
+	if len(results) > 0 && results[len(results)-1].Type.Name == "error" {
+		results = results[:len(results)-1]
+	}
+	if len(results) > 1 {
+		return "", errors.New("multiple results not supported in Go API")
+	}
+
+	for _, p := range append(params, results...) {
+		if p.Type.IsEllipsis {
+			return "", errors.New("variadic parameters not supported in Go API")
+		}
+		if ir.IdentIsInternal(ctx.ModNames, p.Type) {
+			return "", errors.New("internal types not supported in Go API")
+		}
+	}
+
+	var cb binderio.CodeBuilder
+
+	var paramsStr strings.Builder
+	paramsStr.WriteString(`ps *env.ProgramState`)
+	for i, p := range params {
+		fmt.Fprintf(&paramsStr, `, p%v %v`, i, p.Type.Name)
+		deps.MarkUsed(p.Type)
+	}
+	resultsStr := `(goErr error)`
+	if len(results) == 1 {
+		resultsStr = fmt.Sprintf(`(goRes %v, goErr error)`, results[0].Type.Name)
+		deps.MarkUsed(results[0].Type)
+	}
+
+	cb.Linef(`// %v calls the ((RYEGEN:FUNCNAME)) builtin (%v) with Go values.`, GoAPINamePlaceholder, ir.FuncGoIdent(fn))
+	cb.Linef(`func %v(%v) %v {`, GoAPINamePlaceholder, paramsStr.String(), resultsStr)
+	cb.Indent++
+	cb.Linef(`var arg0, arg1, arg2, arg3, arg4 env.Object`)
+	for i, p := range params {
+		if _, found := ConvGoToRye(
+			deps,
+			ctx,
+			&cb,
+			p.Type,
+			fmt.Sprintf(`arg%v`, i),
+			fmt.Sprintf(`p%v`, i),
+			i,
+			nil,
+		); !found {
+			return "", errors.New("unhandled type conversion (go to rye): " + p.Type.Name)
+		}
+	}
+	cb.Linef(`resObj := builtinsGenerated["((RYEGEN:FUNCNAME))"].Fn(ps, arg0, arg1, arg2, arg3, arg4)`)
+	cb.Linef(`if ps.FailureFlag || ps.ErrorFlag {`)
+	cb.Indent++
+	cb.Linef(`ps.FailureFlag = false`)
+	cb.Linef(`ps.ErrorFlag = false`)
+	cb.Linef(`goErr = errors.New(resObj.Inspect(*ps.Idx))`)
+	cb.Linef(`return`)
+	cb.Indent--
+	cb.Linef(`}`)
+	deps.Imports["errors"] = struct{}{}
+	if len(results) == 1 {
+		if _, found := ConvRyeToGo(
+			deps,
+			ctx,
+			&cb,
+			results[0].Type,
+			`goRes`,
+			`resObj`,
+			-1,
+			func(inner string) string {
+				var cb binderio.CodeBuilder
+				cb.Linef(`goErr = errors.New("((RYEGEN:FUNCNAME)): result: "+%v)`, inner)
+				cb.Linef(`return`)
+				return cb.String()
+			},
+		); !found {
+			return "", errors.New("unhandled type conversion (rye to go): " + results[0].Type.Name)
+		}
+	}
+	cb.Linef(`return`)
+	cb.Indent--
+	cb.Linef(`}`)
+
+	return cb.String(), nil
+}
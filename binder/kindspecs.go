@@ -0,0 +1,65 @@
+package binder
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+	"github.com/refaktor/ryegen/ir"
+)
+
+// Zero values of the Rye types understood by the validation dialect.
+var kindSpecZeroValues = map[string]string{
+	"integer": "0",
+	"decimal": "0.0",
+	"string":  `""`,
+}
+
+// KindSpecWord returns the Rye word the kind of a struct is assigned
+// to by [GenerateKindSpec] (e.g. "image-point-kind"). Fails for
+// structs which aren't named types of a package, e.g. instantiated
+// generics.
+func KindSpecWord(ctx *Context, struc *ir.Struct) (string, error) {
+	id, ok := struc.Name.Expr.(*ast.Ident)
+	if !ok || struc.Name.File == nil {
+		return "", fmt.Errorf("expected named struct, got %v", struc.Name.Name)
+	}
+	return strcase.ToKebab(ctx.ModNames[struc.Name.File.ModulePath]+id.Name) + "-kind", nil
+}
+
+// GenerateKindSpec generates Rye code defining a kind (validation spec)
+// for a struct, so dicts can be checked with Rye's validation dialect
+// before they're converted to the struct. All fields are optional with
+// Go's zero value as default, like in Go. Only fields converted to
+// integers, decimals or strings can be validated, so the others are
+// left out of the spec.
+func GenerateKindSpec(ctx *Context, struc *ir.Struct) (string, error) {
+	word, err := KindSpecWord(ctx, struc)
+	if err != nil {
+		return "", err
+	}
+	if len(struc.Inherits) > 0 {
+		return "", errors.New("structs with embedded fields are unsupported")
+	}
+
+	var spec strings.Builder
+	for _, f := range struc.Fields {
+		typName, err := GetRyeTypeDesc(ctx, f.Type.File, f.Type.Expr)
+		if err != nil {
+			return "", err
+		}
+		zero, ok := kindSpecZeroValues[typName]
+		if !ok {
+			continue
+		}
+		fmt.Fprintf(&spec, " %v: optional %v %v", strcase.ToKebab(f.Name.Name), zero, typName)
+	}
+	if spec.Len() == 0 {
+		return "", errors.New("no fields with validatable types")
+	}
+
+	name := strings.TrimSuffix(word, "-kind")
+	return fmt.Sprintf("%v: kind '%v {%v }", word, name, spec.String()), nil
+}
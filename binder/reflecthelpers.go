@@ -0,0 +1,147 @@
+package binder
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"strings"
+
+	"github.com/refaktor/ryegen/binder/binderio"
+	"github.com/refaktor/ryegen/ir"
+)
+
+// ReflectHelperNames are the Go-style names of the helper builtins
+// generated by [GenerateReflectHelper] if bound functions take or return
+// reflect.Value or reflect.Type.
+var ReflectHelperNames = []string{
+	"TypeOf",
+	"ValueOf",
+	"Interface",
+}
+
+// UsesReflectConverters reports whether a binding converts reflect.Value
+// or reflect.Type values, in which case the reflect helpers are useful.
+func UsesReflectConverters(bind *BindingFunc) bool {
+	return bind.ConvUsage[ConvID{GoToRye: false, Name: "reflect"}] > 0 ||
+		bind.ConvUsage[ConvID{GoToRye: true, Name: "reflect"}] > 0
+}
+
+// GenerateReflectHelper generates one of the builtins in
+// [ReflectHelperNames], which create reflect.Type and reflect.Value
+// natives from other natives and get the value held by a reflect.Value,
+// so reflection-based APIs can be called from Rye.
+func GenerateReflectHelper(deps *Dependencies, ctx *Context, name string) (*BindingFunc, error) {
+	const modulePath = "reflect"
+
+	reflectMod, ok := ctx.ModNames[modulePath]
+	if !ok {
+		return nil, errors.New("unknown module path " + modulePath)
+	}
+
+	res := &BindingFunc{}
+	res.Category = "Reflect helpers"
+	res.Name = name
+	res.File = &ir.File{
+		ModuleName: reflectMod,
+		ModulePath: modulePath,
+	}
+
+	var cb binderio.CodeBuilder
+
+	cb.Linef(`nat, ok := arg0.(env.Native)`)
+	cb.Linef(`if !ok {`)
+	cb.Indent++
+	cb.Append(makeMakeRetArgErr(0)(`"expected native, but got "+objectDebugString(ps.Idx, arg0)`))
+	cb.Indent--
+	cb.Linef(`}`)
+
+	switch name {
+	case "TypeOf", "ValueOf":
+		typeName := "Type"
+		if name == "ValueOf" {
+			typeName = "Value"
+		}
+		res.Doc = fmt.Sprintf("Get the reflect.%v of the value held by a native", typeName)
+		res.Signature = Signature{Args: []SignatureArg{{"value", "native"}}, Results: []string{fmt.Sprintf("native(Go(%v.%v))", reflectMod, typeName)}}
+		res.DocComment = res.Signature.DocComment()
+		res.Argsn = 1
+		cb.Linef(`return *env.NewNative(ps.Idx, %v.%v(nat.Value), "%v")`, reflectMod, name, ctx.kind(fmt.Sprintf("Go(%v.%v)", reflectMod, typeName)))
+	case "Interface":
+		res.Doc = "Get the value held by a reflect.Value"
+		res.Signature = Signature{Args: []SignatureArg{{"value", fmt.Sprintf("native(Go(%v.Value))", reflectMod)}}, Results: []string{"any"}}
+		res.DocComment = res.Signature.DocComment()
+		res.Argsn = 1
+		cb.Linef(`rv, ok := nat.Value.(%v.Value)`, reflectMod)
+		cb.Linef(`if !ok {`)
+		cb.Indent++
+		cb.Append(makeMakeRetArgErr(0)(fmt.Sprintf(`"expected native of type %v.Value, but got "+objectDebugString(ps.Idx, arg0)`, reflectMod)))
+		cb.Indent--
+		cb.Linef(`}`)
+		cb.Linef(`if !rv.IsValid() {`)
+		cb.Indent++
+		cb.Linef(`return env.Void{}`)
+		cb.Indent--
+		cb.Linef(`}`)
+		cb.Linef(`if !rv.CanInterface() {`)
+		cb.Indent++
+		cb.Append(makeMakeRetArgErr(0)(`"value of unexported field"`))
+		cb.Indent--
+		cb.Linef(`}`)
+		// Basic kinds (including named types of them) become Rye values.
+		cb.Linef(`var resObj env.Object`)
+		cb.Linef(`switch rv.Kind() {`)
+		for _, k := range []struct {
+			kinds  []string
+			typ    string
+			getter string
+		}{
+			{[]string{"Bool"}, "bool", "Bool"},
+			{[]string{"Int", "Int8", "Int16", "Int32", "Int64"}, "int64", "Int"},
+			{[]string{"Uint", "Uint8", "Uint16", "Uint32", "Uint64", "Uintptr"}, "uint64", "Uint"},
+			{[]string{"Float32", "Float64"}, "float64", "Float"},
+			{[]string{"String"}, "string", "String"},
+		} {
+			typ, err := ir.NewIdent(ctx.IR.ConstValues, ctx.ModNames, nil, &ast.Ident{Name: k.typ})
+			if err != nil {
+				return nil, err
+			}
+			kinds := make([]string, len(k.kinds))
+			for i, kind := range k.kinds {
+				kinds[i] = reflectMod + "." + kind
+			}
+			cb.Linef(`case %v:`, strings.Join(kinds, ", "))
+			cb.Indent++
+			if _, found := ConvGoToRye(
+				deps,
+				ctx,
+				&cb,
+				typ,
+				`resObj`,
+				fmt.Sprintf(`rv.%v()`, k.getter),
+				-1,
+				nil,
+			); !found {
+				return nil, errors.New("unhandled type conversion (go to rye): " + typ.Name)
+			}
+			cb.Linef(`return resObj`)
+			cb.Indent--
+		}
+		cb.Linef(`case %[1]v.Pointer, %[1]v.Interface, %[1]v.Map, %[1]v.Slice, %[1]v.Func, %[1]v.Chan:`, reflectMod)
+		cb.Indent++
+		cb.Linef(`if rv.IsNil() {`)
+		cb.Indent++
+		cb.Linef(`return env.Void{}`)
+		cb.Indent--
+		cb.Linef(`}`)
+		cb.Indent--
+		cb.Linef(`}`)
+		cb.Linef(`return ifaceToNative(ps.Idx, rv.Interface(), "Go(any)")`)
+	default:
+		return nil, errors.New("unknown reflect helper " + name)
+	}
+	deps.Imports[modulePath] = struct{}{}
+
+	res.Body = cb.String()
+
+	return res, nil
+}
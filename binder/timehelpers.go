@@ -0,0 +1,249 @@
+package binder
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"maps"
+	"slices"
+
+	"github.com/refaktor/ryegen/binder/binderio"
+	"github.com/refaktor/ryegen/ir"
+)
+
+// TimeHelperNames are the Go-style names of the helper builtins
+// generated by [GenerateTimeHelper] if the time package is bound.
+var TimeHelperNames = []string{
+	"Now",
+	"Since",
+	"AddDuration",
+	"FormatLayout",
+	"ParseLayout",
+}
+
+// TimeLayouts maps Rye-friendly layout names to the names of the
+// corresponding layout constants in the time package.
+var TimeLayouts = map[string]string{
+	"ansic":        "ANSIC",
+	"unix-date":    "UnixDate",
+	"ruby-date":    "RubyDate",
+	"rfc822":       "RFC822",
+	"rfc822z":      "RFC822Z",
+	"rfc850":       "RFC850",
+	"rfc1123":      "RFC1123",
+	"rfc1123z":     "RFC1123Z",
+	"rfc3339":      "RFC3339",
+	"rfc3339-nano": "RFC3339Nano",
+	"kitchen":      "Kitchen",
+	"stamp":        "Stamp",
+	"stamp-milli":  "StampMilli",
+	"stamp-micro":  "StampMicro",
+	"stamp-nano":   "StampNano",
+	"date-time":    "DateTime",
+	"date-only":    "DateOnly",
+	"time-only":    "TimeOnly",
+}
+
+// timeLayoutCode writes code resolving the layout name in the Go string
+// inVar to a Go layout string stored in outVar. Unknown names are used
+// as Go layout strings as-is.
+func timeLayoutCode(cb *binderio.CodeBuilder, timeMod, outVar, inVar string) {
+	cb.Linef(`%v := %v`, outVar, inVar)
+	cb.Linef(`switch %v {`, inVar)
+	for _, name := range slices.Sorted(maps.Keys(TimeLayouts)) {
+		cb.Linef(`case "%v":`, name)
+		cb.Indent++
+		cb.Linef(`%v = %v.%v`, outVar, timeMod, TimeLayouts[name])
+		cb.Indent--
+	}
+	cb.Linef(`}`)
+}
+
+// GenerateTimeHelper generates one of the builtins in [TimeHelperNames],
+// which make working with time.Time and time.Duration easier from Rye.
+// Durations are accepted as native time.Duration, integer nanoseconds or
+// a string like "1h30m" (see time.ParseDuration).
+func GenerateTimeHelper(deps *Dependencies, ctx *Context, name string) (*BindingFunc, error) {
+	const modulePath = "time"
+
+	timeMod, ok := ctx.ModNames[modulePath]
+	if !ok {
+		return nil, errors.New("unknown module path " + modulePath)
+	}
+	timeStruct, ok := ctx.IR.Structs[timeMod+".Time"]
+	if !ok {
+		return nil, errors.New("time.Time not found")
+	}
+	timeTyp := timeStruct.Name
+	durationTyp, err := ir.NewIdent(ctx.IR.ConstValues, ctx.ModNames, timeTyp.File, &ast.Ident{Name: "Duration"})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &BindingFunc{}
+	res.Category = "Time helpers"
+	res.Name = name
+	res.File = &ir.File{
+		ModuleName: timeMod,
+		ModulePath: modulePath,
+	}
+
+	var cb binderio.CodeBuilder
+
+	convTimeArg := func(outVar string, argn int) error {
+		cb.Linef(`var %v %v`, outVar, timeTyp.Name)
+		deps.MarkUsed(timeTyp)
+		if _, found := ConvRyeToGo(
+			deps,
+			ctx,
+			&cb,
+			timeTyp,
+			outVar,
+			fmt.Sprintf(`arg%v`, argn),
+			argn,
+			makeMakeRetArgErr(argn),
+		); !found {
+			return errors.New("unhandled type conversion (rye to go): " + timeTyp.Name)
+		}
+		return nil
+	}
+	convDurationArg := func(outVar string, argn int) error {
+		inVar := fmt.Sprintf(`arg%v`, argn)
+		cb.Linef(`var %v %v`, outVar, durationTyp.Name)
+		deps.MarkUsed(durationTyp)
+		cb.Linef(`if s, ok := %v.(env.String); ok {`, inVar)
+		cb.Indent++
+		cb.Linef(`var err error`)
+		cb.Linef(`%v, err = %v.ParseDuration(s.Value)`, outVar, timeMod)
+		cb.Linef(`if err != nil {`)
+		cb.Indent++
+		cb.Append(makeMakeRetArgErr(argn)(`err.Error()`))
+		cb.Indent--
+		cb.Linef(`}`)
+		cb.Indent--
+		cb.Linef(`} else {`)
+		cb.Indent++
+		if _, found := ConvRyeToGo(
+			deps,
+			ctx,
+			&cb,
+			durationTyp,
+			outVar,
+			inVar,
+			argn,
+			makeMakeRetArgErr(argn),
+		); !found {
+			return errors.New("unhandled type conversion (rye to go): " + durationTyp.Name)
+		}
+		cb.Indent--
+		cb.Linef(`}`)
+		return nil
+	}
+	convStringArg := func(outVar string, argn int) {
+		cb.Linef(`%vObj, ok := arg%v.(env.String)`, outVar, argn)
+		cb.Linef(`if !ok {`)
+		cb.Indent++
+		cb.Append(makeMakeRetArgErr(argn)(fmt.Sprintf(`"expected string, but got "+objectDebugString(ps.Idx, arg%v)`, argn)))
+		cb.Indent--
+		cb.Linef(`}`)
+		cb.Linef(`%v := %vObj.Value`, outVar, outVar)
+	}
+	convResult := func(typ ir.Ident, inVar string) error {
+		cb.Linef(`var resObj env.Object`)
+		if _, found := ConvGoToRye(
+			deps,
+			ctx,
+			&cb,
+			typ,
+			`resObj`,
+			inVar,
+			-1,
+			nil,
+		); !found {
+			return errors.New("unhandled type conversion (go to rye): " + typ.Name)
+		}
+		cb.Linef(`return resObj`)
+		return nil
+	}
+
+	switch name {
+	case "Now":
+		res.Doc = "Get the current local time"
+		res.Signature = Signature{Results: []string{"time"}}
+		res.DocComment = res.Signature.DocComment()
+		res.Argsn = 0
+		cb.Linef(`res := %v.Now()`, timeMod)
+		if err := convResult(timeTyp, `res`); err != nil {
+			return nil, err
+		}
+	case "Since":
+		res.Doc = "Get the time elapsed since a time"
+		// Durations are returned however they're converted (e.g. as
+		// integer nanoseconds).
+		durationDesc, err := GetRyeTypeDesc(ctx, durationTyp.File, durationTyp.Expr)
+		if err != nil {
+			return nil, err
+		}
+		res.Signature = Signature{Args: []SignatureArg{{"t", "time or string"}}, Results: []string{durationDesc}}
+		res.DocComment = res.Signature.DocComment()
+		res.Argsn = 1
+		if err := convTimeArg(`t`, 0); err != nil {
+			return nil, err
+		}
+		cb.Linef(`res := %v.Since(t)`, timeMod)
+		if err := convResult(durationTyp, `res`); err != nil {
+			return nil, err
+		}
+	case "AddDuration":
+		res.Doc = "Add a duration (native, integer nanoseconds or string like \"1h30m\") to a time"
+		res.Signature = Signature{Args: []SignatureArg{{"t", "time or string"}, {"d", "native(time.Duration), integer or string"}}, Results: []string{"time"}}
+		res.DocComment = res.Signature.DocComment()
+		res.Argsn = 2
+		if err := convTimeArg(`t`, 0); err != nil {
+			return nil, err
+		}
+		if err := convDurationArg(`d`, 1); err != nil {
+			return nil, err
+		}
+		cb.Linef(`res := t.Add(d)`)
+		if err := convResult(timeTyp, `res`); err != nil {
+			return nil, err
+		}
+	case "FormatLayout":
+		res.Doc = "Format a time using a named layout (e.g. \"rfc3339\", \"date-time\", \"kitchen\") or a Go layout string"
+		res.Signature = Signature{Args: []SignatureArg{{"t", "time or string"}, {"layout", "string"}}, Results: []string{"string"}}
+		res.DocComment = res.Signature.DocComment()
+		res.Argsn = 2
+		if err := convTimeArg(`t`, 0); err != nil {
+			return nil, err
+		}
+		convStringArg(`layoutName`, 1)
+		timeLayoutCode(&cb, timeMod, `layout`, `layoutName`)
+		cb.Linef(`return *env.NewString(t.Format(layout))`)
+	case "ParseLayout":
+		res.Doc = "Parse a time using a named layout (e.g. \"rfc3339\", \"date-time\", \"kitchen\") or a Go layout string"
+		res.Signature = Signature{Args: []SignatureArg{{"layout", "string"}, {"value", "string"}}, Results: []string{"time"}}
+		res.DocComment = res.Signature.DocComment()
+		res.Argsn = 2
+		convStringArg(`layoutName`, 0)
+		convStringArg(`value`, 1)
+		timeLayoutCode(&cb, timeMod, `layout`, `layoutName`)
+		cb.Linef(`res, err := %v.Parse(layout, value)`, timeMod)
+		cb.Linef(`if err != nil {`)
+		cb.Indent++
+		cb.Linef(`ps.FailureFlag = true`)
+		cb.Linef(`return env.NewError("((RYEGEN:FUNCNAME)): "+err.Error())`)
+		cb.Indent--
+		cb.Linef(`}`)
+		if err := convResult(timeTyp, `res`); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errors.New("unknown time helper " + name)
+	}
+	deps.Imports[modulePath] = struct{}{}
+
+	res.Body = cb.String()
+
+	return res, nil
+}
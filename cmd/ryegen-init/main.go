@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"unicode"
 
@@ -49,6 +50,10 @@ type MainGo string
 var FileDefaultMainGo MainGo = `package main
 
 import (
+	"encoding/json"
+	"fmt"
+	"os"
+
 	/*RYEGEN: BEGIN IMPORTS*/
 	/*RYEGEN: END IMPORTS*/
 
@@ -57,25 +62,74 @@ import (
 	"github.com/refaktor/rye/runner"
 )
 
+// ryegenBindings lists the bindings built into the interpreter, for
+// --ryegen-list.
+var ryegenBindings = []struct {
+	Name     string   ` + "`json:\"name\"`" + `
+	Package  string   ` + "`json:\"package\"`" + `
+	Builtins []string ` + "`json:\"builtins\"`" + `
+}{
+	/*RYEGEN: BEGIN LIST*/
+	/*RYEGEN: END LIST*/
+}
+
+// ryegenList prints ryegenBindings as text, or as JSON if format is "json".
+func ryegenList(format string) error {
+	if format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(ryegenBindings)
+	}
+	for _, b := range ryegenBindings {
+		fmt.Printf("%v (%v, %v builtins)\n", b.Name, b.Package, len(b.Builtins))
+		for _, name := range b.Builtins {
+			fmt.Printf("  %v\n", name)
+		}
+	}
+	return nil
+}
+
 func main() {
+	// Handled before runner.DoMain, which treats other arguments as
+	// scripts.
+	if len(os.Args) > 1 && (os.Args[1] == "--ryegen-list" || os.Args[1] == "--ryegen-list=json") {
+		format := "text"
+		if os.Args[1] == "--ryegen-list=json" {
+			format = "json"
+		}
+		if err := ryegenList(format); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	runner.DoMain(func(ps *env.ProgramState) {
 		/*RYEGEN: BEGIN BUILTINS*/
 		/*RYEGEN: END BUILTINS*/
 	})
 }`
 
-func (mg MainGo) AppendGen(pkgPath, fullName, shortName string) (MainGo, error) {
+// AppendGen adds the binding of goPkg, generated into the package
+// fullName of the module pkgPath, to mg. The list for --ryegen-list is
+// optional, since main.go files created by older versions lack it.
+// Fails if the binding has been added already.
+func (mg MainGo) AppendGen(pkgPath, goPkg, fullName, shortName string) (MainGo, error) {
+	importPath := strconv.Quote(pkgPath + "/ryegen_bindings/" + fullName)
 	var res strings.Builder
 	sc := bufio.NewScanner(strings.NewReader(string(mg)))
-	var foundImports, foundBuiltins bool
+	var foundImports, foundBuiltins, foundList bool
 	for sc.Scan() {
 		ln := sc.Text()
+		if strings.TrimSpace(ln) == importPath {
+			return "", fmt.Errorf("%v is already imported", importPath)
+		}
 		if strings.TrimSpace(ln) == `/*RYEGEN: END IMPORTS*/` {
 			if foundImports {
 				return "", errors.New("duplicate '/*RYEGEN: END IMPORTS*/' comment")
 			}
 			foundImports = true
-			fmt.Fprintf(&res, "\t\"%v/ryegen_bindings/%v\"\n", pkgPath, fullName)
+			fmt.Fprintf(&res, "\t%v\n", importPath)
 		}
 		if strings.TrimSpace(ln) == `/*RYEGEN: END BUILTINS*/` {
 			if foundBuiltins {
@@ -83,6 +137,14 @@ func (mg MainGo) AppendGen(pkgPath, fullName, shortName string) (MainGo, error)
 			}
 			foundBuiltins = true
 			fmt.Fprintf(&res, "\t\tevaldo.RegisterBuiltinsInContext(%v.Builtins, ps, \"%v\")\n", fullName, shortName)
+			fmt.Fprintf(&res, "\t\tif err := %v.LoadPrelude(ps); err != nil {\n\t\t\tpanic(err)\n\t\t}\n", fullName)
+		}
+		if strings.TrimSpace(ln) == `/*RYEGEN: END LIST*/` {
+			if foundList {
+				return "", errors.New("duplicate '/*RYEGEN: END LIST*/' comment")
+			}
+			foundList = true
+			fmt.Fprintf(&res, "\t{Name: %q, Package: %q, Builtins: %v.BuiltinNames},\n", shortName, goPkg, fullName)
 		}
 		fmt.Fprintf(&res, "%v\n", ln)
 	}
@@ -196,7 +258,7 @@ func main() {
 	if actualVer == "" {
 		fmt.Printf("Looking up latest version of %v...", optPkg)
 		var err error
-		actualVer, err = repo.GetLatestVersion(optPkg)
+		actualVer, err = repo.GetLatestVersion("", optPkg)
 		if err != nil {
 			fmt.Println("Error getting latest package version:", err)
 			os.Exit(1)
@@ -236,7 +298,7 @@ func main() {
 	}
 	{
 		var err error
-		mg, err = mg.AppendGen(userPkgPath, fullBindingName, optName)
+		mg, err = mg.AppendGen(userPkgPath, optPkg, fullBindingName, optName)
 		if err != nil {
 			fmt.Println("Error in main.go:", err)
 			os.Exit(1)
@@ -273,7 +335,11 @@ package %v
 
 import "github.com/refaktor/rye/env"
 
-var Builtins = map[string]*env.Builtin{}`, fullBindingName)),
+var Builtins = map[string]*env.Builtin{}
+
+var BuiltinNames []string
+
+func LoadPrelude(ps *env.ProgramState) error { return nil }`, fullBindingName)),
 		0666,
 	); err != nil {
 		fmt.Println("Error writing gen.go:", err)
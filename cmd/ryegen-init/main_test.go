@@ -0,0 +1,138 @@
+package main
+
+import (
+	"encoding/json"
+	"go/format"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppendGen(t *testing.T) {
+	assert := assert.New(t)
+
+	// Empty list.
+	mg, err := FileDefaultMainGo.AppendGen("example.com/app", "fyne.io/fyne/v2", "fyne_io_fyne_v2", "fyne")
+	if !assert.NoError(err) {
+		return
+	}
+	_, err = format.Source([]byte(mg))
+	assert.NoError(err)
+	assert.Contains(string(mg), "\t\"example.com/app/ryegen_bindings/fyne_io_fyne_v2\"\n\t/*RYEGEN: END IMPORTS*/\n")
+	assert.Contains(string(mg), "\t\tevaldo.RegisterBuiltinsInContext(fyne_io_fyne_v2.Builtins, ps, \"fyne\")\n")
+	assert.Contains(string(mg), "\t{Name: \"fyne\", Package: \"fyne.io/fyne/v2\", Builtins: fyne_io_fyne_v2.BuiltinNames},\n\t/*RYEGEN: END LIST*/\n")
+
+	// Existing list.
+	mg, err = mg.AppendGen("example.com/app", "github.com/lib/pq", "github_com_lib_pq", "pq")
+	if !assert.NoError(err) {
+		return
+	}
+	_, err = format.Source([]byte(mg))
+	assert.NoError(err)
+	assert.Contains(string(mg), "\t{Name: \"fyne\", Package: \"fyne.io/fyne/v2\", Builtins: fyne_io_fyne_v2.BuiltinNames},\n"+
+		"\t{Name: \"pq\", Package: \"github.com/lib/pq\", Builtins: github_com_lib_pq.BuiltinNames},\n\t/*RYEGEN: END LIST*/\n")
+
+	// Duplicate.
+	_, err = mg.AppendGen("example.com/app", "fyne.io/fyne/v2", "fyne_io_fyne_v2", "fyne2")
+	assert.EqualError(err, "\"example.com/app/ryegen_bindings/fyne_io_fyne_v2\" is already imported")
+
+	// main.go of an older version without a list.
+	old := MainGo(strings.ReplaceAll(string(FileDefaultMainGo), "\t/*RYEGEN: END LIST*/\n", ""))
+	mg, err = old.AppendGen("example.com/app", "fyne.io/fyne/v2", "fyne_io_fyne_v2", "fyne")
+	if assert.NoError(err) {
+		assert.NotContains(string(mg), "{Name: ")
+	}
+}
+
+// runnerStub stands in for Rye's runner package.
+const runnerStub = `package runner
+
+import "github.com/refaktor/rye/env"
+
+func DoMain(regfn func(ps *env.ProgramState)) {}
+`
+
+func TestRyegenList(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go run")
+	}
+
+	mod := t.TempDir()
+	write := func(name string, data []byte) {
+		t.Helper()
+		path := filepath.Join(mod, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0777); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, data, 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, name := range []string{"env/env.go", "evaldo/evaldo.go"} {
+		stub, err := os.ReadFile("../../binder/bindertest/testdata/ryestub/" + name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		write("rye/"+name, stub)
+	}
+	write("rye/runner/runner.go", []byte(runnerStub))
+	write("rye/go.mod", []byte("module github.com/refaktor/rye\n\ngo 1.22\n"))
+	write("go.mod", []byte("module example.com/app\n\ngo 1.22\n\nrequire github.com/refaktor/rye v0.0.0\n\nreplace github.com/refaktor/rye => ./rye\n"))
+
+	mg := FileDefaultMainGo
+	for _, b := range []struct{ goPkg, fullName, shortName, builtins string }{
+		{"fyne.io/fyne/v2", "fyne_io_fyne_v2", "fyne", `"fyne-app", "fyne-window"`},
+		{"github.com/lib/pq", "github_com_lib_pq", "pq", ""},
+	} {
+		var err error
+		mg, err = mg.AppendGen("example.com/app", b.goPkg, b.fullName, b.shortName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		write("ryegen_bindings/"+b.fullName+"/generated.go", []byte("package "+b.fullName+`
+
+import "github.com/refaktor/rye/env"
+
+var Builtins = map[string]*env.Builtin{}
+
+var BuiltinNames = []string{`+b.builtins+`}
+
+func LoadPrelude(ps *env.ProgramState) error { return nil }
+`))
+	}
+	write("main.go", []byte(mg))
+
+	run := func(arg string) string {
+		t.Helper()
+		cmd := exec.Command("go", "run", ".", arg)
+		cmd.Dir = mod
+		cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOPROXY=off", "GOWORK=off")
+		var stderr strings.Builder
+		cmd.Stderr = &stderr
+		out, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("go run . %v: %v\n%v", arg, err, stderr.String())
+		}
+		return string(out)
+	}
+
+	type entry struct {
+		Name     string   `json:"name"`
+		Package  string   `json:"package"`
+		Builtins []string `json:"builtins"`
+	}
+	var list []entry
+	if err := json.Unmarshal([]byte(run("--ryegen-list=json")), &list); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []entry{
+		{"fyne", "fyne.io/fyne/v2", []string{"fyne-app", "fyne-window"}},
+		{"pq", "github.com/lib/pq", []string{}},
+	}, list)
+
+	assert.Equal(t, "fyne (fyne.io/fyne/v2, 2 builtins)\n  fyne-app\n  fyne-window\npq (github.com/lib/pq, 0 builtins)\n", run("--ryegen-list"))
+}
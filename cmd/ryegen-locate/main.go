@@ -0,0 +1,58 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/refaktor/ryegen"
+)
+
+func init() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), `usage: ryegen-locate <file:line>
+
+Prints which binding (and converters) or declaration produced a line of
+generated code, e.g. to find the cause of a compiler error.
+
+examples:
+  ryegen-locate ../ryegen_bindings/fyne_io_fyne_v2/generated.go:12345
+`)
+	}
+}
+
+func main() {
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	arg := flag.Arg(0)
+	// Also accept compiler output like "file.go:12:3".
+	parts := strings.Split(arg, ":")
+	if len(parts) > 2 {
+		if _, err := strconv.Atoi(parts[len(parts)-1]); err == nil {
+			parts = parts[:len(parts)-1]
+		}
+	}
+	if len(parts) < 2 {
+		fmt.Println("Error: expected <file:line>, got", arg)
+		os.Exit(1)
+	}
+	path := strings.Join(parts[:len(parts)-1], ":")
+	line, err := strconv.Atoi(parts[len(parts)-1])
+	if err != nil {
+		fmt.Println("Error: invalid line number:", parts[len(parts)-1])
+		os.Exit(1)
+	}
+
+	res, err := ryegen.Locate(path, line)
+	if err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(1)
+	}
+	fmt.Println(res)
+}
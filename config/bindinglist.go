@@ -11,18 +11,103 @@ import (
 	"unicode"
 )
 
+// BindingList holds the rules for individual bindings (by unique name),
+// as read from bindings.txt and "//ryegen:" source directives. The rules
+// combine as follows:
+//
+//  1. Bindings are enabled unless in the [disabled] section or excluded
+//     by a directive (see [BindingList.Disabled]).
+//  2. A rename ("=>" in bindings.txt or a rename directive) is the
+//     binding's preferred name. If it conflicts with a name of higher
+//     priority, the regular name candidates are used.
+//  3. bindings.txt configures a binding if it's in the [disabled]
+//     section or renamed there. Since every binding is listed, the
+//     [enabled] section alone doesn't count. Directives of configured
+//     bindings are ignored (see [BindingList.SetDefaultEnabled] and
+//     [BindingList.SetDefaultRename]).
+//  4. Directives of other bindings apply regardless of their order: an
+//     exclude directive disables the binding and a rename directive
+//     renames it, so a binding can be both. The generator reports more
+//     than one rename directive per binding as an error.
+//  5. Rules set by directives are written to bindings.txt for reference,
+//     marked with [DirectiveMarker], and ignored when it's read. Running
+//     again with the same sources gives the same rules and bindings.txt,
+//     and changes of the directives take effect on the next run.
+//  6. The [export] section is independent of the other rules.
 type BindingList struct {
 	Enabled map[string]bool
 	Renames map[string]string
 	Export  map[string]struct{}
+
+	// Bindings with rules set by directives.
+	fromDirectives map[string]struct{}
 }
 
+// DirectiveMarker ends the bindings.txt lines of bindings with rules set
+// by "//ryegen:" directives. Remove it to configure the binding in
+// bindings.txt instead.
+const DirectiveMarker = "# ryegen:directive"
+
 func NewBindingList() *BindingList {
 	return &BindingList{
-		Enabled: make(map[string]bool),
-		Renames: make(map[string]string),
-		Export:  make(map[string]struct{}),
+		Enabled:        make(map[string]bool),
+		Renames:        make(map[string]string),
+		Export:         make(map[string]struct{}),
+		fromDirectives: make(map[string]struct{}),
+	}
+}
+
+// Disabled reports whether the binding name is disabled.
+func (bl *BindingList) Disabled(name string) bool {
+	enabled, ok := bl.Enabled[name]
+	return ok && !enabled
+}
+
+// Rename returns the name the binding name is renamed to, if any.
+func (bl *BindingList) Rename(name string) (string, bool) {
+	rename, ok := bl.Renames[name]
+	return rename, ok
+}
+
+// Exported reports whether the binding name is in the [export] section.
+func (bl *BindingList) Exported(name string) bool {
+	_, ok := bl.Export[name]
+	return ok
+}
+
+// SetDefaultEnabled enables or disables the binding name as set by a
+// directive, unless bindings.txt configures it: it's in the [disabled]
+// section, or renamed. Since every binding is listed, the [enabled]
+// section alone doesn't count. Returns whether it was set.
+func (bl *BindingList) SetDefaultEnabled(name string, enabled bool) bool {
+	if bl.configured(name) {
+		return false
+	}
+	bl.Enabled[name] = enabled
+	bl.fromDirectives[name] = struct{}{}
+	return true
+}
+
+// SetDefaultRename renames the binding name as set by a directive, unless
+// bindings.txt configures it (see [BindingList.SetDefaultEnabled]).
+// Returns whether it was set.
+func (bl *BindingList) SetDefaultRename(name, rename string) bool {
+	if bl.configured(name) {
+		return false
+	}
+	bl.Renames[name] = rename
+	bl.fromDirectives[name] = struct{}{}
+	return true
+}
+
+// configured reports whether bindings.txt sets rules for the binding
+// name (not counting rules of directives).
+func (bl *BindingList) configured(name string) bool {
+	if _, ok := bl.fromDirectives[name]; ok {
+		return false
 	}
+	_, renamed := bl.Renames[name]
+	return renamed || bl.Disabled(name)
 }
 
 func LoadBindingListFromFile(filename string) (*BindingList, error) {
@@ -50,7 +135,8 @@ func LoadBindingListFromFile(filename string) (*BindingList, error) {
 		}
 
 		line := strings.TrimSpace(sc.Text())
-		if line == "" || strings.HasPrefix(line, "#") {
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasSuffix(line, DirectiveMarker) {
+			// Rules of directives are set again from the source.
 			continue
 		}
 		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
@@ -107,6 +193,13 @@ func LoadBindingListFromFile(filename string) (*BindingList, error) {
 }
 
 func (bl *BindingList) SaveToFile(filename string, bindingFuncsToDocstrs map[string]string) error {
+	return os.WriteFile(filename, bl.Marshal(bindingFuncsToDocstrs), 0666)
+}
+
+// Marshal returns the contents of a bindings.txt file listing the
+// bindings in bindingFuncsToDocstrs (unique name to doc string), with
+// the sections and renames of bl.
+func (bl *BindingList) Marshal(bindingFuncsToDocstrs map[string]string) []byte {
 	isEnabled := maps.Clone(bl.Enabled)
 	for name := range bindingFuncsToDocstrs {
 		if _, ok := isEnabled[name]; !ok {
@@ -131,6 +224,7 @@ func (bl *BindingList) SaveToFile(filename string, bindingFuncsToDocstrs map[str
 	fmt.Fprintln(&res, "# Re-run `go generate ./...` to update and sort the list.")
 	fmt.Fprintln(&res, "# Renaming a binding: e.g. `some-func => my-some-func` or `Go(*X)//method => my-method`")
 	fmt.Fprintln(&res, "# Bindings placed in the export section will be exposed as a public function in the generated file.")
+	fmt.Fprintf(&res, "# Lines ending in `%v` are set by //ryegen: directives in the source; remove it to override them.\n", DirectiveMarker)
 
 	fmt.Fprintln(&res)
 	writeBindings := func(bs []string, allowRename bool) {
@@ -155,18 +249,23 @@ func (bl *BindingList) SaveToFile(filename string, bindingFuncsToDocstrs map[str
 		for _, name := range bs {
 			if docstr, ok := bindingFuncsToDocstrs[name]; ok {
 				col0 := name + getRenameStr(name)
+				marker := ""
+				if _, ok := bl.fromDirectives[name]; ok && allowRename {
+					marker = " " + DirectiveMarker
+				}
 				fmt.Fprintf(
 					&res,
-					"%v %v\"%v\"\n",
+					"%v %v\"%v\"%v\n",
 					col0,
 					strings.Repeat(" ", maxCol0Len-len(col0)),
 					docstr,
+					marker,
 				)
 			}
 		}
 	}
 	fmt.Fprintln(&res, "[export]")
-	writeBindings(slices.Collect(maps.Keys(bl.Export)), false)
+	writeBindings(slices.Sorted(maps.Keys(bl.Export)), false)
 	fmt.Fprintln(&res)
 	fmt.Fprintln(&res, "[enabled]")
 	writeBindings(enabledBindings, true)
@@ -174,8 +273,5 @@ func (bl *BindingList) SaveToFile(filename string, bindingFuncsToDocstrs map[str
 	fmt.Fprintln(&res, "[disabled]")
 	writeBindings(disabledBindings, true)
 
-	if err := os.WriteFile(filename, res.Bytes(), 0666); err != nil {
-		return err
-	}
-	return nil
+	return res.Bytes()
 }
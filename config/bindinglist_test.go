@@ -0,0 +1,213 @@
+package config_test
+
+import (
+	"math/rand"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/refaktor/ryegen/config"
+)
+
+// run simulates a generator run: it reads path (if it exists), applies
+// the directives (binding name to rename, or "" to exclude) and writes
+// path again.
+func run(t *testing.T, path string, directives map[string]string) *config.BindingList {
+	t.Helper()
+	bl := config.NewBindingList()
+	if _, err := os.Stat(path); err == nil {
+		var err error
+		bl, err = config.LoadBindingListFromFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	for name, rename := range directives {
+		if rename == "" {
+			bl.SetDefaultEnabled(name, false)
+		} else {
+			bl.SetDefaultRename(name, rename)
+		}
+	}
+	docs := map[string]string{"a": "A", "b": "B", "c": "C"}
+	if err := bl.SaveToFile(path, docs); err != nil {
+		t.Fatal(err)
+	}
+	return bl
+}
+
+func TestBindingListDirectivesBetweenRuns(t *testing.T) {
+	assert := assert.New(t)
+	path := filepath.Join(t.TempDir(), "bindings.txt")
+
+	bl := run(t, path, map[string]string{"a": "", "b": "bee"})
+	assert.True(bl.Disabled("a"))
+	rename, _ := bl.Rename("b")
+	assert.Equal("bee", rename)
+
+	// Changed directives take effect, although the previous ones were
+	// written to bindings.txt.
+	bl = run(t, path, map[string]string{"b": "be", "c": ""})
+	assert.False(bl.Disabled("a"))
+	rename, _ = bl.Rename("b")
+	assert.Equal("be", rename)
+	assert.True(bl.Disabled("c"))
+
+	bl = run(t, path, nil)
+	assert.False(bl.Disabled("c"))
+	_, ok := bl.Rename("b")
+	assert.False(ok)
+}
+
+func TestBindingListFileOverridesDirectives(t *testing.T) {
+	assert := assert.New(t)
+	path := filepath.Join(t.TempDir(), "bindings.txt")
+
+	if err := os.WriteFile(path, []byte("[enabled]\na => my-a \"A\"\n\n[disabled]\nb \"B\"\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	bl := run(t, path, map[string]string{"a": "", "b": "bee"})
+	assert.False(bl.Disabled("a"))
+	assert.True(bl.Disabled("b"))
+	_, ok := bl.Rename("b")
+	assert.False(ok)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NotContains(string(data), "\""+config.DirectiveMarker)
+}
+
+// ruleSet is the effective rules of a [config.BindingList].
+type ruleSet struct {
+	Disabled map[string]bool
+	Renames  map[string]string
+	Export   map[string]bool
+}
+
+func rulesOf(bl *config.BindingList, names []string) ruleSet {
+	rs := ruleSet{Disabled: map[string]bool{}, Renames: map[string]string{}, Export: map[string]bool{}}
+	for _, name := range names {
+		rs.Disabled[name] = bl.Disabled(name)
+		if rename, ok := bl.Rename(name); ok {
+			rs.Renames[name] = rename
+		}
+		rs.Export[name] = bl.Exported(name)
+	}
+	return rs
+}
+
+type directive struct {
+	name    string
+	exclude bool
+	rename  string
+}
+
+// TestBindingListProperties checks the rules of [config.BindingList]
+// (numbered as in its doc comment) for random bindings.txt files and
+// directives.
+func TestBindingListProperties(t *testing.T) {
+	names := []string{"a", "b", "c", "d", "e", "f"}
+	docs := make(map[string]string)
+	for _, name := range names {
+		docs[name] = strings.ToUpper(name)
+	}
+	dir := t.TempDir()
+	load := func(data []byte) *config.BindingList {
+		t.Helper()
+		path := filepath.Join(dir, "bindings.txt")
+		if err := os.WriteFile(path, data, 0666); err != nil {
+			t.Fatal(err)
+		}
+		bl, err := config.LoadBindingListFromFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return bl
+	}
+	apply := func(bl *config.BindingList, ds []directive) {
+		for _, d := range ds {
+			if d.exclude {
+				bl.SetDefaultEnabled(d.name, false)
+			} else {
+				bl.SetDefaultRename(d.name, d.rename)
+			}
+		}
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+	for i := range 500 {
+		// Random bindings.txt, written as by a previous run without
+		// directives.
+		file := config.NewBindingList()
+		for _, name := range names {
+			file.Enabled[name] = rnd.Intn(3) != 0
+			if rnd.Intn(3) == 0 {
+				file.Renames[name] = "file-" + name
+			}
+			if rnd.Intn(4) == 0 {
+				file.Export[name] = struct{}{}
+			}
+		}
+		fileData := file.Marshal(docs)
+		fileRules := rulesOf(load(fileData), names)
+
+		// Random directives, with at most one rename per binding.
+		var ds []directive
+		for _, name := range names {
+			if rnd.Intn(2) == 0 {
+				ds = append(ds, directive{name: name, exclude: true})
+			}
+			if rnd.Intn(2) == 0 {
+				ds = append(ds, directive{name: name, rename: "dir-" + name})
+			}
+		}
+		rnd.Shuffle(len(ds), func(i, j int) { ds[i], ds[j] = ds[j], ds[i] })
+
+		bl := load(fileData)
+		apply(bl, ds)
+		rules := rulesOf(bl, names)
+		data := bl.Marshal(docs)
+
+		for _, name := range names {
+			_, renamedInFile := fileRules.Renames[name]
+			configured := renamedInFile || fileRules.Disabled[name]
+			if configured {
+				// 3: bindings.txt takes precedence.
+				assert.Equal(t, fileRules.Disabled[name], rules.Disabled[name], "%v: %v disabled", i, name)
+				assert.Equal(t, fileRules.Renames[name], rules.Renames[name], "%v: %v rename", i, name)
+				continue
+			}
+			// 1, 2, 4: directives apply independently.
+			assert.Equal(t, slices.Contains(ds, directive{name: name, exclude: true}), rules.Disabled[name], "%v: %v disabled", i, name)
+			wantRename := ""
+			if slices.Contains(ds, directive{name: name, rename: "dir-" + name}) {
+				wantRename = "dir-" + name
+			}
+			assert.Equal(t, wantRename, rules.Renames[name], "%v: %v rename", i, name)
+		}
+		// 6: export is unaffected.
+		assert.Equal(t, fileRules.Export, rules.Export, i)
+
+		// 4: the order of directives doesn't matter.
+		reversedDs := slices.Clone(ds)
+		slices.Reverse(reversedDs)
+		reversed := load(fileData)
+		apply(reversed, reversedDs)
+		assert.Equal(t, rules, rulesOf(reversed, names), "%v: reversed directives", i)
+
+		// 5: running again with the same directives changes nothing.
+		again := load(data)
+		apply(again, ds)
+		assert.Equal(t, rules, rulesOf(again, names), "%v: second run", i)
+		assert.Equal(t, string(data), string(again.Marshal(docs)), "%v: second run", i)
+
+		// 5: removed directives no longer apply.
+		assert.Equal(t, fileRules, rulesOf(load(data), names), "%v: directives removed", i)
+	}
+}
@@ -2,22 +2,245 @@ package config
 
 import (
 	"fmt"
+	"go/token"
 	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/BurntSushi/toml"
 )
 
 type Config struct {
-	OutDir         string      `toml:"out-dir"`
-	Package        string      `toml:"package"`
-	Version        string      `toml:"version"`
-	CutNew         bool        `toml:"cut-new"`
-	DontBuildFlag  string      `toml:"dont-build-flag,omitempty"`
-	NoPrefix       []string    `toml:"no-prefix,omitempty"`
-	CustomPrefixes [][2]string `toml:"custom-prefixes,omitempty"` // {prefix, package}
-	IncludeStdLibs []string    `toml:"include-std-libs"`
+	OutDir            string      `toml:"out-dir"`
+	Package           string      `toml:"package"`
+	Version           string      `toml:"version"`
+	CutNew            bool        `toml:"cut-new"`
+	DontBuildFlag     string      `toml:"dont-build-flag,omitempty"`
+	NoPrefix          []string    `toml:"no-prefix,omitempty"`
+	CustomPrefixes    [][2]string `toml:"custom-prefixes,omitempty"` // {prefix, package}
+	IncludeStdLibs    []string    `toml:"include-std-libs"`
+	NumericChecks     string      `toml:"numeric-checks,omitempty"`
+	CommaOk           string      `toml:"comma-ok,omitempty"`
+	MultiResults      string      `toml:"multi-results,omitempty"`
+	SourceDirectives  bool        `toml:"source-directives,omitempty"`
+	MethodValues      bool        `toml:"method-values,omitempty"`
+	TypeAssertions    bool        `toml:"type-assertions,omitempty"`
+	GoAPI             bool        `toml:"go-api,omitempty"`
+	BuildTags         []string    `toml:"build-tags,omitempty"`
+	Strict            bool        `toml:"strict,omitempty"`
+	Proxy             string      `toml:"proxy,omitempty"`
+	Synchronize       []string    `toml:"synchronize,omitempty"` // "<package path>.<Name>"
+	Prelude           string      `toml:"prelude,omitempty"`
+	Assets            []string    `toml:"assets,omitempty"`
+	LowMemory         bool        `toml:"low-memory,omitempty"`
+	MaxSize           int         `toml:"max-size,omitempty"`
+	NativeStringable  []string    `toml:"native-stringable,omitempty"`  // "<package path>.<Name>"
+	Bitmasks          []string    `toml:"bitmasks,omitempty"`           // "<package path>.<Name>"
+	NativeEnums       []string    `toml:"native-enums,omitempty"`       // "<package path>.<Name>"
+	PartialInterfaces []string    `toml:"partial-interfaces,omitempty"` // "<package path>.<Name>" or "*"
+	Presets           []string    `toml:"presets,omitempty"`
+	Verify            bool        `toml:"verify,omitempty"`
+	DisableConverters []string    `toml:"disable-converters,omitempty"`
+	UsageTag          string      `toml:"usage-tag,omitempty"`
+	TraceTag          string      `toml:"trace-tag,omitempty"`
+	Library           string      `toml:"library,omitempty"`
+	CodegenFriendly   bool        `toml:"codegen-friendly,omitempty"`
+	Naming            string      `toml:"naming,omitempty"`
+	ReceiverNaming    string      `toml:"receiver-naming,omitempty"`
+	RenameAliases     bool        `toml:"rename-aliases,omitempty"`
+	DictStructs       []string    `toml:"dict-structs,omitempty"` // "<package path>.<Name>" or "*"
+	BlankImports      []string    `toml:"blank-imports,omitempty"`
+	ExcludeImports    []string    `toml:"exclude-imports,omitempty"`
+	InitReport        bool        `toml:"init-report,omitempty"`
+	TargetReport      bool        `toml:"target-report,omitempty"`
+	TypedConsts       bool        `toml:"typed-consts,omitempty"`
+	KindSpecs         bool        `toml:"kind-specs,omitempty"`
+	MaxGetterNesting  int         `toml:"max-getter-nesting,omitempty"`
+	AllowUnsafe       []string    `toml:"allow-unsafe,omitempty"` // "<package path>.<Func>", "<package path>.<Type>.<Method>" or "<package path>.<Type>.<Field>"
+	UnsafeAck         bool        `toml:"unsafe-acknowledged,omitempty"`
+	AllowInternal     []string    `toml:"allow-internal,omitempty"`
+	// "<package path>.<Func>" or "<package path>.<Type>.<Method>" to
+	// parameter name to Go expression passed instead of a Rye argument.
+	BindArgs map[string]map[string]string `toml:"bind-args,omitempty"`
+	// "<package path>.<Struct>" to field name to Go expression
+	// initializing it in the generated struct initializer.
+	StructDefaults map[string]map[string]string `toml:"struct-defaults,omitempty"`
+	// Package path (prefix) to coercion name to whether it's enabled.
+	Coercions map[string]map[string]bool `toml:"coercions,omitempty"`
+	// Environment variable name to value set by LoadPrelude.
+	Env map[string]string `toml:"env,omitempty"`
+	// Package path (prefix) to whether struct field setters are
+	// generated. Global variables only get setters if they're listed in
+	// Synchronize, regardless of this.
+	Setters map[string]bool `toml:"setters,omitempty"`
+	// "<package path>.<Name>" of types converted to and from Rye
+	// strings in addition to the built-in ones (e.g. net/netip.Addr).
+	Stringables map[string]Stringable `toml:"stringables,omitempty"`
 }
 
+// Stringable describes a type with a canonical string form, which is
+// converted from a Rye string by a parse function and to a Rye string by
+// its String method (see [Config.Stringables]).
+type Stringable struct {
+	// Name of the parse function in the type's package, returning
+	// (T, error) or (*T, error).
+	ParseFunc string `toml:"parse-func"`
+	// Whether ParseFunc returns *T.
+	ParseReturnsPtr bool `toml:"parse-returns-ptr,omitempty"`
+	// Whether the String method has a pointer receiver.
+	PtrString bool `toml:"ptr-string,omitempty"`
+}
+
+// Values for [Config.NumericChecks].
+const (
+	// Fail if a Rye integer doesn't fit into the Go integer type (default).
+	NumericChecksStrict = "strict"
+	// Fail only if a Rye integer doesn't fit into the bit width of the Go
+	// integer type, interpreted as either signed or unsigned (e.g. -1 => uint8(255)).
+	NumericChecksWrap = "wrap"
+	// Don't check, silently truncate.
+	NumericChecksOff = "off"
+)
+
+// Sets of helper builtins enabled by [Config.Presets].
+const (
+	// Read and encode fixed-size integers from/to binary data in Rye
+	// strings (encoding/binary).
+	PresetBinary = "binary"
+)
+
+// Values for [Config.Naming].
+const (
+	// Prefix names with the package name unless listed in
+	// [Config.NoPrefix] (default).
+	NamingPrefixed = "prefixed"
+	// Never prefix names if avoidable, cut "New" from constructors and
+	// remove package name stutter (e.g. http.HTTPClient => client).
+	NamingShort = "short"
+)
+
+// Values for [Config.ReceiverNaming].
+const (
+	// Name natives and method receivers after the Go type
+	// (e.g. "Go(*widget.Button)//set-text") (default).
+	ReceiverNamingGo = "go"
+	// Name them after the package and type
+	// (e.g. "widget-button//set-text"), unless that collides.
+	ReceiverNamingShort = "short"
+)
+
+// Converters which can be disabled by [Config.DisableConverters].
+const (
+	// Rye blocks/channel natives to and from Go channels.
+	DisableChan = "chan"
+	// Rye functions to and from Go funcs (callbacks).
+	DisableFunc = "func"
+	// Rye contexts to Go interfaces.
+	DisableInterfaceAdapters = "interface-adapters"
+)
+
+// Implicit conversions configurable in [Config.Coercions].
+const (
+	// Accept integers for float32/float64 arguments.
+	CoercionIntegerToDecimal = "integer-to-decimal"
+	// Accept strings for []byte arguments.
+	CoercionStringToBytes = "string-to-bytes"
+)
+
+// builtinNativeEnums lists the "<package path>.<Name>" of named integer
+// types with a String method and constants which aren't enums, in
+// addition to [Config.NativeEnums].
+var builtinNativeEnums = []string{
+	"time.Duration",
+}
+
+// IsNativeEnum reports whether the named integer type "<package
+// path>.<Name>" is never converted to words as an enum, since it's in
+// [Config.NativeEnums] or isn't an enum (e.g. time.Duration).
+func (c *Config) IsNativeEnum(key string) bool {
+	return slices.Contains(builtinNativeEnums, key) || slices.Contains(c.NativeEnums, key)
+}
+
+// CoercionEnabled reports whether the coercion is enabled for the given
+// package path. The most specific matching entry in [Config.Coercions]
+// wins, where an entry matches its package and all subpackages, and the
+// "*" entry matches all packages. Coercions are disabled by default.
+func (c *Config) CoercionEnabled(pkg, coercion string) bool {
+	res := false
+	matchLen := -1
+	for prefix, coercions := range c.Coercions {
+		enabled, ok := coercions[coercion]
+		if !ok {
+			continue
+		}
+		if l, ok := packageMatchLen(prefix, pkg); ok && l > matchLen {
+			res = enabled
+			matchLen = l
+		}
+	}
+	return res
+}
+
+// SettersEnabled reports whether struct field setters are generated for
+// the given package path, matching [Config.Setters] like
+// [Config.CoercionEnabled]. Setters are enabled by default.
+func (c *Config) SettersEnabled(pkg string) bool {
+	res := true
+	matchLen := -1
+	for prefix, enabled := range c.Setters {
+		if l, ok := packageMatchLen(prefix, pkg); ok && l > matchLen {
+			res = enabled
+			matchLen = l
+		}
+	}
+	return res
+}
+
+// packageMatchLen reports whether the per-package config entry prefix
+// matches pkg, i.e. is pkg, a parent package of it or "*", and how
+// specific the match is (longer is more specific).
+func packageMatchLen(prefix, pkg string) (int, bool) {
+	switch {
+	case prefix == "*":
+		return 0, true
+	case pkg == prefix || strings.HasPrefix(pkg, prefix+"/"):
+		return len(prefix), true
+	default:
+		return 0, false
+	}
+}
+
+// CustomPrefix returns the prefix configured for the package path in
+// [Config.CustomPrefixes], if any.
+func (c *Config) CustomPrefix(pkg string) (string, bool) {
+	for _, p := range c.CustomPrefixes {
+		if p[1] == pkg {
+			return p[0], true
+		}
+	}
+	return "", false
+}
+
+// Values for [Config.CommaOk].
+const (
+	// Return (T, bool) results as a block of two values (default).
+	CommaOkBlock = "block"
+	// Return T, or a failure if the bool is false.
+	CommaOkFailure = "failure"
+	// Return T, or void if the bool is false.
+	CommaOkVoid = "void"
+)
+
+// Values for [Config.MultiResults].
+const (
+	// Return multiple results as a block (default).
+	MultiResultsBlock = "block"
+	// Return multiple results as a dict keyed by their kebab-cased
+	// names if all are named in Go, otherwise as a block.
+	MultiResultsDict = "dict"
+)
+
 func ReadConfigFromFileOrCreateDefault(path string) (cfg *Config, createdDefault bool, err error) {
 	if _, err := os.Stat(path); err != nil {
 		if err := os.WriteFile(path, []byte(DefaultConfig("", "", "", "")), 0666); err != nil {
@@ -29,6 +252,116 @@ func ReadConfigFromFileOrCreateDefault(path string) (cfg *Config, createdDefault
 	if _, err := toml.DecodeFile(path, cfg); err != nil {
 		return nil, false, err
 	}
+	switch cfg.NumericChecks {
+	case "":
+		cfg.NumericChecks = NumericChecksStrict
+	case NumericChecksStrict, NumericChecksWrap, NumericChecksOff:
+	default:
+		return nil, false, fmt.Errorf("%v: invalid numeric-checks value %q (expected %q, %q or %q)", path, cfg.NumericChecks, NumericChecksStrict, NumericChecksWrap, NumericChecksOff)
+	}
+	switch cfg.CommaOk {
+	case "":
+		cfg.CommaOk = CommaOkBlock
+	case CommaOkBlock, CommaOkFailure, CommaOkVoid:
+	default:
+		return nil, false, fmt.Errorf("%v: invalid comma-ok value %q (expected %q, %q or %q)", path, cfg.CommaOk, CommaOkBlock, CommaOkFailure, CommaOkVoid)
+	}
+	switch cfg.MultiResults {
+	case "":
+		cfg.MultiResults = MultiResultsBlock
+	case MultiResultsBlock, MultiResultsDict:
+	default:
+		return nil, false, fmt.Errorf("%v: invalid multi-results value %q (expected %q or %q)", path, cfg.MultiResults, MultiResultsBlock, MultiResultsDict)
+	}
+	switch cfg.Naming {
+	case "":
+		cfg.Naming = NamingPrefixed
+	case NamingPrefixed, NamingShort:
+	default:
+		return nil, false, fmt.Errorf("%v: invalid naming value %q (expected %q or %q)", path, cfg.Naming, NamingPrefixed, NamingShort)
+	}
+	switch cfg.ReceiverNaming {
+	case "":
+		cfg.ReceiverNaming = ReceiverNamingGo
+	case ReceiverNamingGo, ReceiverNamingShort:
+	default:
+		return nil, false, fmt.Errorf("%v: invalid receiver-naming value %q (expected %q or %q)", path, cfg.ReceiverNaming, ReceiverNamingGo, ReceiverNamingShort)
+	}
+	for _, pkg := range cfg.BlankImports {
+		if slices.Contains(cfg.ExcludeImports, pkg) {
+			return nil, false, fmt.Errorf("%v: package %v is both in blank-imports and exclude-imports", path, pkg)
+		}
+	}
+	if cfg.Library != "" && (!token.IsIdentifier(cfg.Library) || cfg.Library == "main") {
+		return nil, false, fmt.Errorf("%v: invalid library name %q (expected a Go package name)", path, cfg.Library)
+	}
+	if len(cfg.AllowUnsafe) > 0 && !cfg.UnsafeAck {
+		return nil, false, fmt.Errorf("%v: allow-unsafe requires unsafe-acknowledged = true", path)
+	}
+	for _, pkg := range cfg.AllowInternal {
+		if !slices.Contains(strings.Split(pkg, "/"), "internal") {
+			return nil, false, fmt.Errorf("%v: allow-internal: %v is not an internal package", path, pkg)
+		}
+	}
+	for i, p := range cfg.CustomPrefixes {
+		if p[0] == "" {
+			return nil, false, fmt.Errorf("%v: custom-prefixes: empty prefix for package %v", path, p[1])
+		}
+		for _, other := range cfg.CustomPrefixes[:i] {
+			if other[1] == p[1] {
+				return nil, false, fmt.Errorf("%v: custom-prefixes: package %v listed more than once", path, p[1])
+			}
+		}
+	}
+	for _, preset := range cfg.Presets {
+		switch preset {
+		case PresetBinary:
+		default:
+			return nil, false, fmt.Errorf("%v: unknown preset %q (expected %q)", path, preset, PresetBinary)
+		}
+	}
+	for _, name := range cfg.DisableConverters {
+		switch name {
+		case DisableChan, DisableFunc, DisableInterfaceAdapters:
+		default:
+			return nil, false, fmt.Errorf("%v: disable-converters: unknown converter %q (expected %q, %q or %q)", path, name, DisableChan, DisableFunc, DisableInterfaceAdapters)
+		}
+	}
+	if cfg.MaxSize < 0 {
+		return nil, false, fmt.Errorf("%v: max-size must not be negative", path)
+	}
+	for _, asset := range cfg.Assets {
+		if !filepath.IsLocal(asset) {
+			return nil, false, fmt.Errorf("%v: assets: %v must be a relative path inside the directory of %v", path, asset, path)
+		}
+	}
+	for name, val := range cfg.Env {
+		if name == "" || strings.ContainsAny(name, "=\x00") {
+			return nil, false, fmt.Errorf("%v: env: invalid variable name %q", path, name)
+		}
+		if len(cfg.Assets) == 0 && (strings.Contains(val, "$ASSETS") || strings.Contains(val, "${ASSETS}")) {
+			return nil, false, fmt.Errorf("%v: env: %v refers to $ASSETS, but no assets are set", path, name)
+		}
+	}
+	for pkg := range cfg.Setters {
+		if pkg == "" {
+			return nil, false, fmt.Errorf("%v: setters: empty package path", path)
+		}
+	}
+	for name, st := range cfg.Stringables {
+		if !token.IsIdentifier(st.ParseFunc) {
+			return nil, false, fmt.Errorf("%v: stringables: %v: invalid parse-func %q", path, name, st.ParseFunc)
+		}
+	}
+	for pkg, coercions := range cfg.Coercions {
+		for name := range coercions {
+			switch name {
+			case CoercionIntegerToDecimal, CoercionStringToBytes:
+			default:
+				return nil, false, fmt.Errorf("%v: coercions: %v: unknown coercion %q (expected %q or %q)", path, pkg, name, CoercionIntegerToDecimal, CoercionStringToBytes)
+			}
+		}
+	}
 	return
 }
 
@@ -63,6 +396,12 @@ cut-new = true
 %v# Add a build flag to exclude the binding (optional).
 %v
 
+## Name of the generated package (by default derived from the package
+## path). If set, it also gets a RegisterInto function registering the
+## builtins in a context of this name and loading the prelude, so the
+## bindings can be plugged into an existing Rye runner setup.
+#library = "mylib"
+
 ## Descending priority. Packages not listed will always be prefixed.
 ## In case of conflicting function names, only the function from the
 ## package with the highest priority is not prefixed.
@@ -71,16 +410,299 @@ cut-new = true
 #  "github.com/<user>/<repo>/important",
 #]
 
-## Set custom prefix for all symbols in the package (if applicable: see "no-prefix").
+## Set custom prefix for all symbols in the package (if applicable: see "no-prefix"),
+## e.g. so libraries with overlapping names can be bound together. The
+## prefix replaces the package name in Rye names; renames in bindings.txt
+## take precedence over it.
 #custom-prefixes = [
 #  ["my-fyne", "fyne.io/fyne/v2"],
 #  ["my-widget", "fyne.io/fyne/v2/widget"],
 #]
 
+## Naming preset.
+## "prefixed" (default): names are prefixed with the package name,
+## except for packages in no-prefix.
+## "short": names are only prefixed on conflicts, "New" is cut from
+## constructors (as with cut-new) and package name stutter is removed
+## (e.g. http.HTTPClient => client, unless http.Client exists).
+#naming = "prefixed"
+
+## Native kind and method receiver naming.
+## "go" (default): named after the Go type, e.g. "Go(*widget.Button)//set-text".
+## "short": named after the package and type, e.g. "widget-button//set-text".
+## Types whose short names collide keep their Go names (with a warning).
+## Names in bindings.txt are unaffected.
+#receiver-naming = "go"
+
+## Keep the names builtins renamed in bindings.txt (or by "//ryegen:rename"
+## directives) would have had otherwise as deprecated aliases, printing a
+## warning the first time they're called, so existing Rye scripts keep
+## working. Aliases are listed in the generated Aliases map.
+#rename-aliases = false
+
 ## Generate bindings for selected parts of the go standard library.
 #include-std-libs = [
 #  "image",
-#]`,
+#]
+
+## Range checking when converting Rye integers to sized Go integers (e.g. uint8).
+## "strict" (default): fail if the value is out of range.
+## "wrap": fail only if the value doesn't fit into the bit width (e.g. -1 => uint8(255)).
+## "off": silently truncate.
+## Can be changed per interpreter at runtime with SetConvOptions.
+#numeric-checks = "strict"
+
+## How to return results of functions returning (T, bool), e.g. lookups.
+## "block" (default): return a block of both values.
+## "failure": return T, or a failure if the bool is false.
+## "void": return T, or void if the bool is false.
+#comma-ok = "block"
+
+## How to return results of functions returning multiple values (apart
+## from a final error).
+## "block" (default): return a block of the values.
+## "dict": return a dict keyed by the kebab-cased result names if all
+## results are named in Go (e.g. "func Split(s string) (head, tail string)"),
+## otherwise a block. Doc strings list the keys.
+#multi-results = "block"
+
+## Respect "//ryegen:exclude" and "//ryegen:rename <name>" comments on
+## declarations in the bound packages' source. Renames and [disabled]
+## entries in bindings.txt take precedence. Rules set by directives are
+## listed in bindings.txt with a "# ryegen:directive" comment.
+#source-directives = true
+
+## Generate a builtin for each method which takes a receiver and returns
+## a builtin calling the method on it (e.g. "Go(*widget.Button)//tapped-fn").
+## Useful for passing methods to functions expecting callbacks.
+#method-values = true
+
+## Generate a "kind-of" builtin for each interface, returning a word naming
+## the concrete type of a value (e.g. "ast-node-kind-of"), and an "as"
+## builtin for each struct and interface, converting a native to it or
+## failing on mismatch (e.g. "ast-as-ident", "io-as-reader").
+#type-assertions = true
+
+## Generate an exported Go function for each function/method builtin
+## (e.g. "CallFyneNewApp" for "fyne-new-app"), which takes and returns
+## Go values, converting them like the builtin does. Useful for testing
+## bindings from Go. Functions with variadic parameters or multiple
+## results are skipped.
+#go-api = true
+
+## Build tags (including GOOS and GOARCH) to consider satisfied when parsing
+## packages. Files with unsatisfied build constraints are skipped. Bindings
+## from files with build constraints are annotated with the constraint in
+## their docs and in bindings.txt.
+#build-tags = ["linux", "amd64"]
+
+## Run "go vet" on the output package before writing it (on a copy next
+## to the output directory) and fail without writing it if the generated
+## code doesn't compile, naming the binding each error is in. Requires
+## the output directory to be inside a module requiring Rye.
+#verify = true
+
+## Don't generate the given kinds of conversions, passing the affected
+## values as opaque natives instead. Each saves a significant amount of
+## generated code (and binary size) for libraries where they're common.
+## "chan": Go channels (watching and sending via Rye).
+## "func": Go funcs to and from Rye functions (callbacks).
+## "interface-adapters": Rye contexts implementing Go interfaces.
+#disable-converters = ["chan", "func", "interface-adapters"]
+
+## Generate a usage hook (see SetUsageHook and UsageRecorder in the
+## output package), called by every builtin with its package path and
+## name (as in bindings.txt). Calls are only compiled in if the
+## interpreter is built with this tag, e.g. to find out which bindings
+## users actually invoke and disable the rest in bindings.txt.
+#usage-tag = "ryegen_usage"
+
+## Generate conversion tracing: every conversion records its number of
+## calls and time spent, see ConvStats in the output package and the
+## "ryegen-stats" builtin. Only compiled in if the interpreter is built
+## with this tag, e.g. to find conversion hotspots in real workloads.
+#trace-tag = "ryegen_trace"
+
+## Handle code generated by protoc-gen-go, protoc-gen-go-grpc and
+## similar tools: skip XXX_* fields and methods (and SWIG's Swigcptr
+## and SwigIs* methods) and convert oneof fields (sealed
+## interfaces implemented by single-field wrapper structs) to and from
+## Rye dicts with a single key, e.g. dict { "text" "hi" }.
+#codegen-friendly = true
+
+## Packages imported only for the side effects of their init funcs,
+## e.g. database drivers registering themselves.
+#blank-imports = ["github.com/lib/pq"]
+
+## Packages whose init funcs must not run (e.g. opening devices).
+## Bindings whose generated code imports them, directly or through
+## other packages, are dropped with a warning.
+#exclude-imports = ["github.com/<user>/<repo>/devices"]
+
+## Write inits.txt, listing the packages imported by the generated
+## code (directly or transitively) which have init funcs, each with an
+## import chain leading to it.
+#init-report = true
+
+## Check exported funcs, methods and types of the bound packages declared
+## separately per build target (e.g. in foo_linux.go and foo_windows.go),
+## regardless of build-tags. Warns about each whose signature differs
+## between targets and writes targets.txt, listing the variants.
+#target-report = true
+
+## Constants of named types without methods (e.g. enums like
+## fyne.TextAlignCenter) are converted to their underlying type (e.g.
+## integer). If set, an additional "<name>-native" builtin returns each
+## as a native of its named type, preserving type identity when passed
+## back to Go (e.g. as an interface value).
+#typed-consts = true
+
+## Fail generation if any binding can't be generated, listing all dropped
+## bindings and the reasons (instead of only warning).
+#strict = true
+
+## Go module proxy list (same syntax as GOPROXY), overriding the GOPROXY,
+## GOPRIVATE and GONOPROXY environment variables. Useful for binding internal
+## libraries served by a private proxy. Credentials are read from the URL or
+## netrc (e.g. ~/.netrc).
+#proxy = "https://goproxy.example.com"
+
+## Global variables (as "<package path>.<Name>") whose getter and setter
+## bindings are guarded by a mutex. Avoids races between Rye callbacks
+## running in library goroutines. Note that the library itself won't
+## take the mutex when accessing the variable.
+#synchronize = ["net/http.DefaultClient"]
+
+## Rye file embedded into the bindings and evaluated by LoadPrelude
+## after the builtins are registered. Useful for shipping higher-level
+## Rye words built on top of the generated bindings.
+#prelude = "prelude.rye"
+
+## Files and directories (relative) embedded into the bindings as the
+## Assets file system, e.g. fonts or shaders a library loads at runtime,
+## so the interpreter binary is self-contained.
+#assets = ["fonts", "shaders/blur.frag"]
+
+## Environment variables set by LoadPrelude (before the prelude runs)
+## unless already set. $ASSETS expands to a directory the assets are
+## extracted to on first use (in the user's cache directory).
+#env = { FYNE_FONT = "$ASSETS/fonts/NotoSans-Regular.ttf" }
+
+## Getters of struct-valued fields return pointers into the parent
+## struct, which keep the whole parent alive and can be chained without
+## end on recursive types. If set, getters of fields whose struct type
+## is recursive or nests struct values deeper than this return a pointer
+## to a copy instead (changes to it don't affect the parent).
+#max-getter-nesting = 3
+
+## Define a Rye kind (validation spec) for each bound struct in
+## LoadPrelude, e.g. "image-point-kind", so dicts can be validated with
+## Rye's validation dialect before being converted to the struct. Only
+## integer, decimal and string fields are part of the specs.
+#kind-specs = true
+
+## Functions taking or returning unsafe.Pointer, or uintptr values named
+## like pointers (e.g. "addr", "ptr"), and struct fields of these types
+## are not bound, since Rye code using them can corrupt memory. Bindings
+## listed here (as "<package path>.<Func>",
+## "<package path>.<Type>.<Method>" or "<package path>.<Type>.<Field>")
+## are bound anyway, but only if unsafe-acknowledged is also set.
+#allow-unsafe = ["github.com/<user>/<repo>.FromPtr"]
+#unsafe-acknowledged = true
+
+## Internal packages (as package paths) whose types' methods are bound.
+## Internal packages are excluded by default, but public APIs sometimes
+## return values of internally defined types. Their methods are called
+## through reflection, since the generated code can't import the
+## package. Functions and fields of internal packages are still not bound
+## (values can't be constructed from Rye).
+#allow-internal = ["github.com/<user>/<repo>/internal/impl"]
+
+## Reduce peak memory usage when binding very large packages (e.g.
+## kubernetes), at the cost of slower generation: function bodies are
+## dropped right after parsing, and packages are bound one at a time
+## instead of concurrently.
+#low-memory = true
+
+## Budget for the generated builtins of each bound package, in bytes of
+## generated Go source (not of the compiled binary). The run summary lists
+## the source size per package; for packages exceeding the budget, it
+## proposes the largest builtins (including their inlined conversion code)
+## to disable in bindings.txt to get under it.
+#max-size = 1000000
+
+## Struct types (as "<package path>.<Name>", or "*" for all) converted
+## to and from nested dicts instead of natives when used as map values,
+## e.g. map[string]Server => dict { "web" dict { "port" 80 } }. Useful
+## for config-style APIs. Applies recursively to fields (up to a depth
+## of 4), in both directions.
+#dict-structs = ["github.com/<user>/<repo>.Server"]
+
+## Value types with a canonical string form (e.g. net/netip.Addr,
+## net/url.URL) are converted to and from Rye strings by default. Types
+## listed here (as "<package path>.<Name>") are returned as natives
+## instead. Strings and natives are accepted as arguments either way.
+#native-stringable = ["net/url.URL"]
+
+## Named integer types holding sets of flags (as "<package path>.<Name>"),
+## converted to and from blocks of words naming the flags, e.g. [dir append]
+## for os.FileMode. The words are the type's single-bit constants, without
+## their common prefix; other bits are listed as an integer. io/fs.FileMode
+## (os.FileMode) is always treated as a bitmask.
+#bitmasks = ["github.com/<user>/<repo>.Flags"]
+
+## Named integer types with a String method (e.g. generated by stringer)
+## and constants are converted to and from words naming the constants,
+## e.g. 'tuesday for time.Tuesday, or integers for other values. Strings
+## (the constant's word or String result) are accepted too. Types listed
+## here (as "<package path>.<Name>") are passed as natives instead.
+#native-enums = ["github.com/<user>/<repo>.Level"]
+
+## Interfaces (as "<package path>.<Name>", or "*" for all) which Rye
+## contexts may implement partially. Missing methods are stubbed,
+## returning zero values, or an error naming all missing methods if the
+## method returns an error. By default, converting a context lacking a
+## method fails.
+#partial-interfaces = ["net/http.ResponseWriter"]
+
+## Additional helper builtins for common tasks.
+## "binary": read and encode 16/32/64-bit integers in little/big endian
+## directly from/to Rye strings holding binary data (e.g.
+## "binary-read-uint-32-le"), for binary protocol libraries.
+#presets = ["binary"]
+
+## Pass fixed Go expressions for some parameters of a function or method
+## (as "<package path>.<Func>" or "<package path>.<Type>.<Method>"),
+## generating a builtin with fewer arguments. Package names in the
+## expressions refer to the function's package and its imports, and bare
+## identifiers (e.g. ModeFast) to the function's package. Unnamed
+## parameters go by the names in the builtin's doc string, derived from
+## their types (e.g. ctx for context.Context, s for string).
+#[bind-args]
+#"net/http.NewRequestWithContext" = { ctx = "context.Background()" }
+
+## Initial field values of structs whose zero value is unusable, set by
+## the generated struct initializers (e.g. "cache-new-store"), by Go
+## expression. Package names in the expressions refer to the struct's
+## package and its imports, and bare identifiers to the struct's package.
+#[struct-defaults]
+#"example.com/cache.Store" = { Entries = "make(map[string]Entry)" }
+
+## Implicit conversions of Rye values, enabled per package path (including
+## subpackages). The most specific entry wins, "*" applies to all packages.
+## "integer-to-decimal": accept integers for float arguments.
+## "string-to-bytes": accept strings for []byte arguments.
+#[coercions]
+#"*" = { integer-to-decimal = true }
+#"fyne.io/fyne/v2" = { string-to-bytes = true }
+
+## Whether struct field setters (e.g. "Go(*widget.Label)//text!") are
+## generated, per package path (including subpackages). The most specific
+## entry wins, "*" applies to all packages. Enabled by default. Global
+## variables only get setters through "synchronize", regardless of this.
+#[setters]
+#"*" = false
+#"fyne.io/fyne/v2/widget" = true`,
 		outDir, pkg, version, dontBuildFlagCommentComment, dontBuildFlagLine,
 	)
 }
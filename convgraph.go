@@ -0,0 +1,69 @@
+package ryegen
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/refaktor/ryegen/binder"
+)
+
+// convGraphBinding is a binding root node in the converter graph.
+type convGraphBinding struct {
+	// Module path of the package the binding originates from.
+	Pkg string
+	// Rye name of the binding.
+	Name      string
+	ConvUsage map[binder.ConvID]int
+	ConvDeps  map[binder.ConvEdge]int
+}
+
+// convGraphDOT creates a graphviz DOT graph with edges from each
+// binding to every converter it uses and from each converter to the
+// converters used by its code (labeled with the number of
+// conversions), so it's visible which API pulled in which converters.
+func convGraphDOT(bindings []convGraphBinding) string {
+	var b strings.Builder
+	b.WriteString("digraph conversions {\n")
+	b.WriteString("\trankdir=LR;\n")
+	b.WriteString("\tnode [shape=ellipse];\n")
+	usedConvs := make(map[binder.ConvID]struct{})
+	convDeps := make(map[binder.ConvEdge]int)
+	for _, bind := range bindings {
+		for id := range bind.ConvUsage {
+			usedConvs[id] = struct{}{}
+		}
+		for edge, n := range bind.ConvDeps {
+			convDeps[edge] += n
+		}
+	}
+	for _, id := range binder.AllConvIDs() {
+		if _, ok := usedConvs[id]; ok {
+			fmt.Fprintf(&b, "\t%v;\n", strconv.Quote(id.String()))
+		}
+	}
+	for _, bind := range bindings {
+		if len(bind.ConvUsage) == 0 {
+			continue
+		}
+		node := strconv.Quote(bind.Pkg + "::" + bind.Name)
+		fmt.Fprintf(&b, "\t%v [shape=box];\n", node)
+		ids := slices.SortedFunc(maps.Keys(bind.ConvUsage), func(a, b binder.ConvID) int {
+			return strings.Compare(a.String(), b.String())
+		})
+		for _, id := range ids {
+			fmt.Fprintf(&b, "\t%v -> %v [label=%v];\n", node, strconv.Quote(id.String()), bind.ConvUsage[id])
+		}
+	}
+	edges := slices.SortedFunc(maps.Keys(convDeps), func(a, b binder.ConvEdge) int {
+		return cmp.Or(strings.Compare(a.From.String(), b.From.String()), strings.Compare(a.To.String(), b.To.String()))
+	})
+	for _, edge := range edges {
+		fmt.Fprintf(&b, "\t%v -> %v [label=%v];\n", strconv.Quote(edge.From.String()), strconv.Quote(edge.To.String()), convDeps[edge])
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
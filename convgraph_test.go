@@ -0,0 +1,31 @@
+package ryegen
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvGraph(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Setenv("RYEGEN_CONV_GRAPH", "1")
+	dir := t.TempDir()
+	writeSrcRepos(t, dir, greetSrc+"\nfunc Sum(xs []int) int { return 0 }\n")
+	if err := os.WriteFile(filepath.Join(dir, "config.toml"), []byte("out-dir = \"out\"\npackage = \"example.com/greet\"\nversion = \"v1.0.0\"\nproxy = \"off\"\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	var sink MemorySink
+	if _, err := TryRunWithOptions(RunOptions{Dir: dir, Sink: &sink}); !assert.NoError(err) {
+		return
+	}
+	graph := string(sink.Files()[filepath.ToSlash(filepath.Join(dir, "conv_graph.dot"))])
+	assert.Contains(graph, "\t\"example.com/greet::greet-sum\" [shape=box];\n")
+	assert.Contains(graph, "\t\"example.com/greet::greet-sum\" -> \"rye-to-go/array\" [label=1];\n")
+	// The array converter converts the elements with the builtin one.
+	assert.Contains(graph, "\t\"rye-to-go/array\" -> \"rye-to-go/builtin\" [label=1];\n")
+	assert.NotContains(graph, "\"go-to-rye/builtin\" ->")
+}
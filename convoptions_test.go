@@ -0,0 +1,94 @@
+package ryegen
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/refaktor/ryegen/binder/binderio"
+)
+
+const convOptionsTestSrc = `package check
+
+import (
+	"testing"
+
+	"github.com/refaktor/rye/env"
+)
+
+func TestConvOptions(t *testing.T) {
+	newPs := func() *env.ProgramState {
+		return &env.ProgramState{Idx: &env.Idxs{}, Ctx: env.NewEnv(nil)}
+	}
+	a, b := newPs(), newPs()
+	if got := newConvCtx(a).numericChecks(); got != "strict" {
+		t.Fatalf("expected default, got %v", got)
+	}
+
+	SetConvOptions(a, ConvOptions{NumericChecks: "wrap"})
+	if got := newConvCtx(a).numericChecks(); got != "wrap" {
+		t.Fatalf("expected wrap, got %v", got)
+	}
+	if got := newConvCtx(b).numericChecks(); got != "strict" {
+		t.Fatalf("options leaked to another interpreter: %v", got)
+	}
+
+	// Child contexts (e.g. of function calls) see the options.
+	root := a.Ctx
+	a.Ctx = env.NewEnv(root)
+	if got := newConvCtx(a).numericChecks(); got != "wrap" {
+		t.Fatalf("expected wrap in child context, got %v", got)
+	}
+	a.Ctx = root
+
+	SetConvOptions(a, ConvOptions{NumericChecks: "off"})
+	if got := newConvCtx(a).numericChecks(); got != "off" {
+		t.Fatalf("expected off, got %v", got)
+	}
+	SetConvOptions(a, ConvOptions{})
+	if got := newConvCtx(a).numericChecks(); got != "strict" {
+		t.Fatalf("expected reset to default, got %v", got)
+	}
+}
+`
+
+func TestConvOptionsPerInterpreter(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go test")
+	}
+
+	mod := t.TempDir()
+	write := func(name string, data []byte) {
+		t.Helper()
+		path := filepath.Join(mod, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0777); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, data, 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	stub, err := os.ReadFile("binder/bindertest/testdata/ryestub/env/env.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cb binderio.CodeBuilder
+	cb.Linef(`package check`)
+	cb.Linef(``)
+	cb.Linef(`import "github.com/refaktor/rye/env"`)
+	cb.Linef(``)
+	writeConvOptions(&cb, "strict")
+	write("go.mod", []byte("module example.com/check\n\ngo 1.22\n\nrequire github.com/refaktor/rye v0.0.0\n\nreplace github.com/refaktor/rye => ./rye\n"))
+	write("rye/go.mod", []byte("module github.com/refaktor/rye\n\ngo 1.22\n"))
+	write("rye/env/env.go", stub)
+	write("check.go", []byte(cb.String()))
+	write("check_test.go", []byte(convOptionsTestSrc))
+
+	cmd := exec.Command("go", "test", ".")
+	cmd.Dir = mod
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOPROXY=off", "GOWORK=off")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("go test: %v\n%s", err, out)
+	}
+}
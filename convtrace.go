@@ -0,0 +1,254 @@
+package ryegen
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/refaktor/ryegen/binder/binderio"
+)
+
+// Files holding the conversion statistics API and the tag-dependent
+// implementation of conversion tracing, relative to the output directory.
+const (
+	convTraceFileName    = "convtrace.go"
+	convTraceOnFileName  = "convtrace_on.go"
+	convTraceOffFileName = "convtrace_off.go"
+)
+
+// convTraceBuiltinName is the builtin returning the conversion statistics
+// if tracing is enabled.
+const convTraceBuiltinName = "ryegen-stats"
+
+// writeConvTraceFiles writes the conversion tracing files into outDir, or
+// removes them if traceTag is empty (see [OutputSink]).
+//
+// If tracing is enabled, each conversion calls traceConvBegin and
+// traceConvEnd (see [binder.ConvKey] for how conversions are named),
+// which only record anything if the bindings are built with traceTag.
+// Otherwise they are empty functions, which the compiler inlines away.
+// Each conversion gets a frame, which shadows convTrace in the generated
+// code, so the time spent in nested conversions is only recorded for
+// them and not for their parents.
+func writeConvTraceFiles(sink OutputSink, outDir, pkgName, traceTag, dontBuildFlag string) error {
+	paths := []string{
+		filepath.Join(outDir, convTraceFileName),
+		filepath.Join(outDir, convTraceOnFileName),
+		filepath.Join(outDir, convTraceOffFileName),
+	}
+	if traceTag == "" {
+		for _, path := range paths {
+			if err := removeOutput(sink, path); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	constraint := func(tag string) string {
+		if dontBuildFlag == "" {
+			return tag
+		}
+		return tag + " && !" + dontBuildFlag
+	}
+
+	header := func(cb *binderio.CodeBuilder) {
+		cb.Linef(`// Code generated by ryegen. DO NOT EDIT.`)
+		cb.Linef(``)
+	}
+
+	{
+		var cb binderio.CodeBuilder
+		header(&cb)
+		if dontBuildFlag != "" {
+			cb.Linef(`//go:build !%v`, dontBuildFlag)
+			cb.Linef(``)
+		}
+		cb.Linef(`package %v`, pkgName)
+		cb.Linef(``)
+		cb.Linef(`import (`)
+		cb.Indent++
+		cb.Linef(`"fmt"`)
+		cb.Linef(`"strings"`)
+		cb.Linef(`"time"`)
+		cb.Linef(``)
+		cb.Linef(`"github.com/refaktor/rye/env"`)
+		cb.Indent--
+		cb.Linef(`)`)
+		cb.Linef(``)
+		cb.Linef(`// ConvStat is the recorded usage of a conversion, named by converter`)
+		cb.Linef(`// and Go type (e.g. "rye-to-go/struct image.Point"). Times exclude`)
+		cb.Linef(`// the conversions nested in it (e.g. of struct fields), which are`)
+		cb.Linef(`// recorded separately.`)
+		cb.Linef(`type ConvStat struct {`)
+		cb.Indent++
+		cb.Linef(`Conv  string`)
+		cb.Linef(`Calls int`)
+		cb.Linef(`Total time.Duration`)
+		cb.Linef(`Max   time.Duration`)
+		cb.Indent--
+		cb.Linef(`}`)
+		cb.Linef(``)
+		cb.Linef(`// convTrace is the frame of the innermost traced conversion, which`)
+		cb.Linef(`// conversions shadow with their own. Nil outside of conversions.`)
+		cb.Linef(`var convTrace *convTraceFrame`)
+		cb.Linef(``)
+		cb.Linef(`// ConvStats returns the recorded usage of each conversion, sorted by`)
+		cb.Linef(`// descending total time. Only successful conversions are recorded.`)
+		cb.Linef(`//`)
+		cb.Linef(`// Conversions are only recorded if the bindings are built with the`)
+		cb.Linef(`// %q tag, otherwise it returns nil.`, traceTag)
+		cb.Linef(`func ConvStats() []ConvStat {`)
+		cb.Indent++
+		cb.Linef(`return convTraceStats()`)
+		cb.Indent--
+		cb.Linef(`}`)
+		cb.Linef(``)
+		cb.Linef(`// FormatConvStats formats stats as a table, one conversion per line.`)
+		cb.Linef(`func FormatConvStats(stats []ConvStat) string {`)
+		cb.Indent++
+		cb.Linef(`if stats == nil {`)
+		cb.Indent++
+		cb.Linef(`return "no conversions recorded (build with -tags %v to record them)\n"`, traceTag)
+		cb.Indent--
+		cb.Linef(`}`)
+		cb.Linef(`var b strings.Builder`)
+		cb.Linef(`fmt.Fprintf(&b, "%%10v %%14v %%14v %%14v  %%v\n", "CALLS", "TOTAL", "AVERAGE", "MAX", "CONVERSION")`)
+		cb.Linef(`for _, s := range stats {`)
+		cb.Indent++
+		cb.Linef(`fmt.Fprintf(&b, "%%10v %%14v %%14v %%14v  %%v\n", s.Calls, s.Total, s.Total/time.Duration(s.Calls), s.Max, s.Conv)`)
+		cb.Indent--
+		cb.Linef(`}`)
+		cb.Linef(`return b.String()`)
+		cb.Indent--
+		cb.Linef(`}`)
+		cb.Linef(``)
+		cb.Linef(`var convTraceBuiltin = &env.Builtin{`)
+		cb.Indent++
+		cb.Linef(`Doc:   "Get the number of calls and time spent per conversion between Rye and Go values as a table",`)
+		cb.Linef(`Argsn: 0,`)
+		cb.Linef(`Fn: func(ps *env.ProgramState, arg0, arg1, arg2, arg3, arg4 env.Object) env.Object {`)
+		cb.Indent++
+		cb.Linef(`return *env.NewString(FormatConvStats(ConvStats()))`)
+		cb.Indent--
+		cb.Linef(`},`)
+		cb.Indent--
+		cb.Linef(`}`)
+
+		if fmtErr, err := saveCode(sink, &cb, paths[0]); err != nil || fmtErr != nil {
+			return fmt.Errorf("save %v: general=%w, fmt=%v", convTraceFileName, err, fmtErr)
+		}
+	}
+
+	{
+		var cb binderio.CodeBuilder
+		header(&cb)
+		cb.Linef(`//go:build %v`, constraint(traceTag))
+		cb.Linef(``)
+		cb.Linef(`package %v`, pkgName)
+		cb.Linef(``)
+		cb.Linef(`import (`)
+		cb.Indent++
+		cb.Linef(`"sort"`)
+		cb.Linef(`"sync"`)
+		cb.Linef(`"time"`)
+		cb.Indent--
+		cb.Linef(`)`)
+		cb.Linef(``)
+		cb.Linef(`var (`)
+		cb.Indent++
+		cb.Linef(`convTraceMu       sync.Mutex`)
+		cb.Linef(`convTraceRecorded = make(map[string]*ConvStat)`)
+		cb.Indent--
+		cb.Linef(`)`)
+		cb.Linef(``)
+		cb.Linef(`// convTraceFrame is a conversion in progress.`)
+		cb.Linef(`type convTraceFrame struct {`)
+		cb.Indent++
+		cb.Linef(`parent *convTraceFrame`)
+		cb.Linef(`start  time.Time`)
+		cb.Linef(`// Time spent in nested conversions, guarded by convTraceMu.`)
+		cb.Linef(`nested time.Duration`)
+		cb.Indent--
+		cb.Linef(`}`)
+		cb.Linef(``)
+		cb.Linef(`func traceConvBegin(parent *convTraceFrame) *convTraceFrame {`)
+		cb.Indent++
+		cb.Linef(`return &convTraceFrame{parent: parent, start: time.Now()}`)
+		cb.Indent--
+		cb.Linef(`}`)
+		cb.Linef(``)
+		cb.Linef(`func traceConvEnd(f *convTraceFrame, conv string) {`)
+		cb.Indent++
+		cb.Linef(`total := time.Since(f.start)`)
+		cb.Linef(`convTraceMu.Lock()`)
+		cb.Linef(`defer convTraceMu.Unlock()`)
+		cb.Linef(`if f.parent != nil {`)
+		cb.Indent++
+		cb.Linef(`f.parent.nested += total`)
+		cb.Indent--
+		cb.Linef(`}`)
+		cb.Linef(`d := total - f.nested`)
+		cb.Linef(`s, ok := convTraceRecorded[conv]`)
+		cb.Linef(`if !ok {`)
+		cb.Indent++
+		cb.Linef(`s = &ConvStat{Conv: conv}`)
+		cb.Linef(`convTraceRecorded[conv] = s`)
+		cb.Indent--
+		cb.Linef(`}`)
+		cb.Linef(`s.Calls++`)
+		cb.Linef(`s.Total += d`)
+		cb.Linef(`s.Max = max(s.Max, d)`)
+		cb.Indent--
+		cb.Linef(`}`)
+		cb.Linef(``)
+		cb.Linef(`func convTraceStats() []ConvStat {`)
+		cb.Indent++
+		cb.Linef(`convTraceMu.Lock()`)
+		cb.Linef(`defer convTraceMu.Unlock()`)
+		cb.Linef(`res := make([]ConvStat, 0, len(convTraceRecorded))`)
+		cb.Linef(`for _, s := range convTraceRecorded {`)
+		cb.Indent++
+		cb.Linef(`res = append(res, *s)`)
+		cb.Indent--
+		cb.Linef(`}`)
+		cb.Linef(`sort.Slice(res, func(i, j int) bool {`)
+		cb.Indent++
+		cb.Linef(`if res[i].Total != res[j].Total {`)
+		cb.Indent++
+		cb.Linef(`return res[i].Total > res[j].Total`)
+		cb.Indent--
+		cb.Linef(`}`)
+		cb.Linef(`return res[i].Conv < res[j].Conv`)
+		cb.Indent--
+		cb.Linef(`})`)
+		cb.Linef(`return res`)
+		cb.Indent--
+		cb.Linef(`}`)
+
+		if fmtErr, err := saveCode(sink, &cb, paths[1]); err != nil || fmtErr != nil {
+			return fmt.Errorf("save %v: general=%w, fmt=%v", convTraceOnFileName, err, fmtErr)
+		}
+	}
+
+	{
+		var cb binderio.CodeBuilder
+		header(&cb)
+		cb.Linef(`//go:build %v`, constraint("!"+traceTag))
+		cb.Linef(``)
+		cb.Linef(`package %v`, pkgName)
+		cb.Linef(``)
+		cb.Linef(`type convTraceFrame struct{}`)
+		cb.Linef(``)
+		cb.Linef(`func traceConvBegin(parent *convTraceFrame) *convTraceFrame { return nil }`)
+		cb.Linef(``)
+		cb.Linef(`func traceConvEnd(f *convTraceFrame, conv string) {}`)
+		cb.Linef(``)
+		cb.Linef(`func convTraceStats() []ConvStat { return nil }`)
+
+		if fmtErr, err := saveCode(sink, &cb, paths[2]); err != nil || fmtErr != nil {
+			return fmt.Errorf("save %v: general=%w, fmt=%v", convTraceOffFileName, err, fmtErr)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,149 @@
+package ryegen
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const convTraceTestSrc = `package check
+
+import (
+	"time"
+
+	"github.com/refaktor/rye/env"
+)
+
+// convert is traced like a generated conversion with an optionally
+// nested one.
+func convert(nested bool) {
+	convTrace := traceConvBegin(convTrace)
+	time.Sleep(20 * time.Millisecond)
+	if nested {
+		convTrace := traceConvBegin(convTrace)
+		time.Sleep(50 * time.Millisecond)
+		traceConvEnd(convTrace, "inner")
+	}
+	traceConvEnd(convTrace, "outer")
+}
+
+func builtinOutput() string {
+	return convTraceBuiltin.Fn(nil, nil, nil, nil, nil, nil).(env.String).Value
+}
+`
+
+const convTraceOnTestSrc = `//go:build ryegen_trace
+
+package check
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConvTrace(t *testing.T) {
+	convert(true)
+	convert(false)
+	stats := ConvStats()
+	if len(stats) != 2 || stats[0].Conv != "inner" || stats[1].Conv != "outer" {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+	inner, outer := stats[0], stats[1]
+	if inner.Calls != 1 || inner.Total < 50*time.Millisecond {
+		t.Errorf("unexpected inner stats %+v", inner)
+	}
+	// Only lower bounds are checked, since a loaded machine can make
+	// any conversion arbitrarily slow.
+	if outer.Calls != 2 || outer.Total < 40*time.Millisecond || outer.Max < 20*time.Millisecond {
+		t.Errorf("unexpected outer stats %+v", outer)
+	}
+	if out := builtinOutput(); !strings.Contains(out, "CALLS") || !strings.Contains(out, "  inner\n") {
+		t.Errorf("unexpected builtin output %q", out)
+	}
+}
+`
+
+const convTraceOffTestSrc = `//go:build !ryegen_trace
+
+package check
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConvTrace(t *testing.T) {
+	convert(true)
+	if stats := ConvStats(); stats != nil {
+		t.Fatalf("expected no stats, got %+v", stats)
+	}
+	if out := builtinOutput(); !strings.Contains(out, "build with -tags ryegen_trace") {
+		t.Errorf("unexpected builtin output %q", out)
+	}
+}
+`
+
+func TestWriteConvTraceFiles(t *testing.T) {
+	assert := assert.New(t)
+
+	var sink MemorySink
+	if !assert.NoError(writeConvTraceFiles(&sink, "out", "check", "ryegen_trace", "ryegen_nobuild")) {
+		return
+	}
+	files := sink.Files()
+	assert.Len(files, 3)
+	assert.Contains(string(files["out/convtrace_on.go"]), "//go:build ryegen_trace && !ryegen_nobuild\n")
+	assert.Contains(string(files["out/convtrace_off.go"]), "//go:build !ryegen_trace && !ryegen_nobuild\n")
+
+	// Disabling tracing removes the files.
+	assert.NoError(writeConvTraceFiles(&sink, "out", "check", "", "ryegen_nobuild"))
+	assert.Empty(sink.Files())
+}
+
+func TestConvTraceBuild(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go test")
+	}
+
+	mod := t.TempDir()
+	write := func(name string, data []byte) {
+		t.Helper()
+		path := filepath.Join(mod, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0777); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, data, 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	stub, err := os.ReadFile("binder/bindertest/testdata/ryestub/env/env.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sink MemorySink
+	if err := writeConvTraceFiles(&sink, "", "check", "ryegen_trace", ""); err != nil {
+		t.Fatal(err)
+	}
+	for name, data := range sink.Files() {
+		write(name, data)
+	}
+	write("go.mod", []byte("module example.com/check\n\ngo 1.22\n\nrequire github.com/refaktor/rye v0.0.0\n\nreplace github.com/refaktor/rye => ./rye\n"))
+	write("rye/go.mod", []byte("module github.com/refaktor/rye\n\ngo 1.22\n"))
+	write("rye/env/env.go", stub)
+	write("check_test.go", []byte(convTraceTestSrc))
+	write("check_on_test.go", []byte(convTraceOnTestSrc))
+	write("check_off_test.go", []byte(convTraceOffTestSrc))
+
+	for _, tags := range []string{"", "ryegen_trace"} {
+		cmd := exec.Command("go", "test", "-tags="+tags, ".")
+		cmd.Dir = mod
+		cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOPROXY=off", "GOWORK=off")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("go test -tags=%v: %v\n%s", tags, err, out)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package ryegen
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/refaktor/ryegen/binder/binderio"
+)
+
+// bindingDataDir is the directory, relative to the bindings, of the
+// package the generated data tables are split into.
+const bindingDataDir = "data"
+
+// bindingDataImportName is the name the data package is imported as.
+const bindingDataImportName = "bindingdata"
+
+// bindingDataVar is a data table generated by ryegen, along with its name
+// in the binding package and in the separate data package.
+type bindingDataVar struct {
+	Local    string
+	Exported string
+}
+
+// bindingDataVars lists the data tables in the order they're generated.
+var bindingDataVars = [...]bindingDataVar{
+	{"ryeStructNameLookup", "StructNames"},
+	{"Categories", "Categories"},
+	{"Prefixes", "Prefixes"},
+	{"Aliases", "Aliases"},
+	{"builtinNamesGenerated", "BuiltinNames"},
+}
+
+// packageImportPath returns the import path of the package in dir, going
+// by the go.mod of the module containing it (see [findGoMod]). Returns ""
+// if there is no go.mod.
+func packageImportPath(dir string) (string, error) {
+	modDir, f, err := findGoMod(dir)
+	if err != nil || f == nil {
+		return "", err
+	}
+	if f.Module == nil {
+		return "", fmt.Errorf("%v: no module directive", filepath.Join(modDir, "go.mod"))
+	}
+	dir, err = filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(modDir, dir)
+	if err != nil {
+		return "", err
+	}
+	if rel == "." {
+		return f.Module.Mod.Path, nil
+	}
+	if strings.HasPrefix(rel, "..") {
+		return "", fmt.Errorf("%v is outside of module %v", dir, f.Module.Mod.Path)
+	}
+	return path.Join(f.Module.Mod.Path, filepath.ToSlash(rel)), nil
+}
+
+// writeBindingDataVars writes variables to cb referring to the data
+// tables in the data package, which is imported as pkgName, so the
+// binding code can use them as if they were generated next to it.
+// Aliases is only generated with renameAliases.
+func writeBindingDataVars(cb *binderio.CodeBuilder, pkgName string, renameAliases bool) {
+	cb.Linef(`// The data tables are generated into a package of their own, so they`)
+	cb.Linef(`// aren't recompiled along with the builtins (see %v).`, pkgName)
+	cb.Linef(`var (`)
+	cb.Indent++
+	for _, v := range bindingDataVars {
+		if v.Local == "Aliases" && !renameAliases {
+			continue
+		}
+		cb.Linef(`%v = %v.%v`, v.Local, pkgName, v.Exported)
+	}
+	cb.Indent--
+	cb.Linef(`)`)
+	cb.Linef(``)
+}
+
+// bindingDataName returns the name of the data table named local in the
+// binding package in the data package if split is set, or local.
+func bindingDataName(local string, split bool) string {
+	if split {
+		for _, v := range bindingDataVars {
+			if v.Local == local {
+				return v.Exported
+			}
+		}
+	}
+	return local
+}
@@ -0,0 +1,91 @@
+package ryegen
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPackageImportPath(t *testing.T) {
+	assert := assert.New(t)
+
+	mod := t.TempDir()
+	dir := filepath.Join(mod, "out", "example_com_greet", "data")
+	if err := os.MkdirAll(dir, 0777); err != nil {
+		t.Fatal(err)
+	}
+	path, err := packageImportPath(dir)
+	assert.NoError(err)
+	assert.Empty(path, "no go.mod")
+
+	if err := os.WriteFile(filepath.Join(mod, "go.mod"), []byte("module example.com/app\n\ngo 1.22\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	path, err = packageImportPath(dir)
+	assert.NoError(err)
+	assert.Equal("example.com/app/out/example_com_greet/data", path)
+	path, err = packageImportPath(mod)
+	assert.NoError(err)
+	assert.Equal("example.com/app", path)
+
+	if err := os.WriteFile(filepath.Join(mod, "go.mod"), []byte("go 1.22\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	_, err = packageImportPath(dir)
+	assert.ErrorContains(err, "no module directive")
+}
+
+func TestSplitBindingData(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	writeSrcRepos(t, dir, greetSrc)
+	for name, content := range map[string]string{
+		"go.mod":      "module example.com/app\n\ngo 1.22\n",
+		"config.toml": "out-dir = \"out\"\npackage = \"example.com/greet\"\nversion = \"v1.0.0\"\nproxy = \"off\"\ndont-build-flag = \"b_no_greet\"\n",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var sink MemorySink
+	res, err := TryRunWithOptions(RunOptions{Dir: dir, Sink: &sink})
+	if !assert.NoError(err) {
+		return
+	}
+	files := sink.Files()
+	outDir := filepath.Dir(res.OutFile)
+	dataFile := filepath.ToSlash(filepath.Join(outDir, "data", "generated.go"))
+	assert.NotContains(files, filepath.ToSlash(filepath.Join(outDir, "generated_data.go")))
+	code := string(files[filepath.ToSlash(res.OutFile)])
+	assert.Contains(code, `bindingdata "example.com/app/out/example_com_greet/data"`)
+	assert.Contains(code, "builtinNamesGenerated = bindingdata.BuiltinNames")
+	data := string(files[dataFile])
+	assert.Contains(data, "package data\n")
+	assert.Contains(data, `"greet-hello",`)
+	// The dont-build flag only excludes the binding package, which
+	// imports the data package.
+	assert.NotContains(data, "go:build")
+
+	if testing.Short() {
+		return
+	}
+	// The data package compiles on its own.
+	path := filepath.Join(dir, "out", "example_com_greet", "data", "generated.go")
+	if err := os.MkdirAll(filepath.Dir(path), 0777); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, files[dataFile], 0666); err != nil {
+		t.Fatal(err)
+	}
+	cmd := exec.Command("go", "build", "./out/example_com_greet/data")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOPROXY=off", "GOWORK=off")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("go build: %v\n%s", err, out)
+	}
+}
@@ -0,0 +1,121 @@
+package ryegen
+
+import (
+	"cmp"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// docsDirPath is the directory API references (see [RunOptions.Docs])
+// are written to, relative to [RunOptions.Dir].
+const docsDirPath = "docs"
+
+// docsEnv sets [RunOptions.Docs] in [Run], like the "docs" command.
+const docsEnv = "RYEGEN_DOCS"
+
+// docEntry is a written builtin as listed in an API reference.
+type docEntry struct {
+	Word      string // name of the builtin
+	GoSymbol  string // see [binder.BindingFunc.GoSymbol]
+	Signature string // see [binder.Signature.String]
+	Summary   string // see [docSummary]
+}
+
+// docSummary returns the first sentence of the Go doc comment at the
+// start of a builtin's doc comment (see [binder.BindingFunc.DocComment]),
+// on a single line.
+func docSummary(docComment string) string {
+	var para []string
+	for _, line := range strings.Split(docComment, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || line == "Args:" || line == "Result:" || line == "Example (Go):" {
+			break
+		}
+		para = append(para, line)
+	}
+	s := strings.Join(para, " ")
+	for i := 0; i < len(s); i++ {
+		if s[i] == '.' && i+1 < len(s) && s[i+1] == ' ' && sentenceEnd(s[:i+1], s[i+2:]) {
+			return s[:i+1]
+		}
+	}
+	return s
+}
+
+// abbreviations don't end sentences in [docSummary].
+var abbreviations = []string{"e.g.", "i.e.", "etc.", "vs.", "cf."}
+
+// sentenceEnd reports whether a sentence ends between before, ending
+// with a period, and after, which follows the space after it.
+func sentenceEnd(before, after string) bool {
+	word := before[strings.LastIndexAny(before, " (")+1:]
+	if slices.Contains(abbreviations, strings.ToLower(word)) {
+		return false
+	}
+	r, _ := utf8.DecodeRuneInString(after)
+	return !unicode.IsLower(r)
+}
+
+// docFileName returns the name of the API reference file of pkg, e.g.
+// "fyne_io_fyne_v2_widget.md".
+func docFileName(pkg string) string {
+	return snakeModulePath(pkg) + ".md"
+}
+
+// markdownCell escapes s for a markdown table cell.
+func markdownCell(s string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(s)
+}
+
+// markdownCode formats s as inline code in a markdown table cell.
+func markdownCode(s string) string {
+	if s == "" {
+		return ""
+	}
+	return "`" + markdownCell(s) + "`"
+}
+
+// apiReference returns the markdown API reference of pkg, listing
+// entries sorted by word. pkgDoc is the package's doc comment.
+func apiReference(pkg, pkgDoc string, entries []docEntry) string {
+	entries = slices.Clone(entries)
+	slices.SortFunc(entries, func(a, b docEntry) int {
+		return cmp.Compare(a.Word, b.Word)
+	})
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %v\n\n", pkg)
+	fmt.Fprintf(&b, "<!-- Code generated by ryegen. DO NOT EDIT. -->\n\n")
+	if summary := docSummary(pkgDoc); summary != "" {
+		fmt.Fprintf(&b, "%v\n\n", summary)
+	}
+	fmt.Fprintf(&b, "%v builtins.\n\n", len(entries))
+	fmt.Fprintf(&b, "| Rye word | Go symbol | Signature | Summary |\n")
+	fmt.Fprintf(&b, "|----------|-----------|-----------|---------|\n")
+	for _, e := range entries {
+		fmt.Fprintf(&b, "| %v | %v | %v | %v |\n",
+			markdownCode(e.Word),
+			markdownCode(e.GoSymbol),
+			markdownCode(e.Signature),
+			markdownCell(e.Summary),
+		)
+	}
+	return b.String()
+}
+
+// writeDocs writes the API reference of each package in entries (by
+// module path) to dir (normally [docsDirPath]). pkgDocs are the package
+// doc comments by module path.
+func writeDocs(sink OutputSink, dir string, entries map[string][]docEntry, pkgDocs map[string]string) error {
+	for pkg, pkgEntries := range sortedMapAll(entries) {
+		path := filepath.Join(dir, docFileName(pkg))
+		if err := sink.WriteFile(path, []byte(apiReference(pkg, pkgDocs[pkg], pkgEntries))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
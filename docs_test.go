@@ -0,0 +1,47 @@
+package ryegen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocSummary(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal("", docSummary(""))
+	assert.Equal("", docSummary("Args:\n * a - integer\n"))
+	assert.Equal("Open opens a file.", docSummary("Open opens a file. It returns an error\nif it fails.\nArgs:\n * name - string\n"))
+	assert.Equal("Open opens the named file for reading.", docSummary("Open opens the named\nfile for reading.\n\nMore text.\n"))
+	assert.Equal("Get fetches a resource, e.g. a web page (i.e. HTML).", docSummary("Get fetches a resource, e.g. a web page (i.e. HTML). Returns the body."))
+	assert.Equal("Use v1.2 or later.", docSummary("Use v1.2 or later. Or don't."))
+	assert.Equal("No period", docSummary("No period\nResult:\n * string\n"))
+}
+
+func TestMarkdownCell(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal(`a \| b c`, markdownCell("a | b\nc"))
+	assert.Equal("", markdownCode(""))
+	assert.Equal("`x:integer -> [a \\| b]`", markdownCode("x:integer -> [a | b]"))
+}
+
+func TestAPIReference(t *testing.T) {
+	assert.Equal(t, strings.Join([]string{
+		"# example.com/m",
+		"",
+		"<!-- Code generated by ryegen. DO NOT EDIT. -->",
+		"",
+		"Package m does things.",
+		"",
+		"2 builtins.",
+		"",
+		"| Rye word | Go symbol | Signature | Summary |",
+		"|----------|-----------|-----------|---------|",
+		"| `m-a` | `m.A` | `x:integer -> string` | A does a \\| b. |",
+		"| `m-b` |  |  |  |",
+		"",
+	}, "\n"), apiReference("example.com/m", "Package m does things. In detail.", []docEntry{
+		{Word: "m-b"},
+		{Word: "m-a", GoSymbol: "m.A", Signature: "x:integer -> string", Summary: "A does a | b."},
+	}))
+}
@@ -0,0 +1,66 @@
+package ryegen
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/refaktor/ryegen/binder"
+)
+
+// frozenDirPath is the directory of hand-patched conversions replacing
+// generated ones (see [binder.ParseFrozenConvs]), relative to the working
+// directory.
+const frozenDirPath = "frozen"
+
+// readFrozenConvs reads the frozen conversions in the "*.conv" files in
+// dir by key. Returns nil if dir doesn't exist.
+func readFrozenConvs(dir string) (map[string]string, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string]string)
+	from := make(map[string]string) // key to file name
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".conv" {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		convs, err := binder.ParseFrozenConvs(path, string(b))
+		if err != nil {
+			return nil, err
+		}
+		for key, code := range sortedMapAll(convs) {
+			if other, ok := from[key]; ok {
+				return nil, fmt.Errorf("frozen conversion %v in both %v and %v", key, other, path)
+			}
+			res[key] = code
+			from[key] = path
+		}
+	}
+	return res, nil
+}
+
+// unusedFrozenConvs returns the keys of the frozen conversions which
+// weren't used, e.g. since the converted type changed, sorted.
+func unusedFrozenConvs(frozen map[string]string, usage map[string]int) []string {
+	var res []string
+	for key := range frozen {
+		if usage[key] == 0 {
+			res = append(res, key)
+		}
+	}
+	slices.Sort(res)
+	return res
+}
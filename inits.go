@@ -0,0 +1,170 @@
+package ryegen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/refaktor/ryegen/parser"
+)
+
+// initReportPath is the file the init report (see "init-report" in
+// config.toml) is written to, relative to the working directory.
+const initReportPath = "inits.txt"
+
+type importGraphPkg struct {
+	imports []string
+	hasInit bool
+}
+
+// importGraph reads the imports and init funcs of packages from source
+// on demand. Packages without a known directory (e.g. "unsafe", "C")
+// are treated as having no imports and no init funcs.
+type importGraph struct {
+	dirs      map[string]string // module path to directory
+	buildTags []string
+	excluded  []string // see [config.Config.ExcludeImports]
+	pkgs      map[string]*importGraphPkg
+	// Package to the excluded package it (transitively) imports, or
+	// "" if none.
+	reachesExcluded map[string]string
+}
+
+func newImportGraph(dirs map[string]string, buildTags []string, excluded []string) *importGraph {
+	return &importGraph{
+		dirs:            dirs,
+		buildTags:       buildTags,
+		excluded:        excluded,
+		pkgs:            make(map[string]*importGraphPkg),
+		reachesExcluded: make(map[string]string),
+	}
+}
+
+func (g *importGraph) pkg(path string) (*importGraphPkg, error) {
+	if p, ok := g.pkgs[path]; ok {
+		return p, nil
+	}
+	p := &importGraphPkg{}
+	g.pkgs[path] = p
+	dir, ok := g.dirs[path]
+	if !ok {
+		return p, nil
+	}
+	pkgs, err := parser.ParseDir(token.NewFileSet(), dir, path, 1, g.buildTags, false)
+	if err != nil {
+		return nil, fmt.Errorf("read imports of %v: %w", path, err)
+	}
+	imports := make(map[string]struct{})
+	for _, pkg := range pkgs {
+		for _, f := range pkg.Files {
+			for _, imp := range f.Imports {
+				impPath, err := strconv.Unquote(imp.Path.Value)
+				if err != nil {
+					return nil, fmt.Errorf("read imports of %v: %w", path, err)
+				}
+				imports[impPath] = struct{}{}
+			}
+			if hasInitFunc(f) {
+				p.hasInit = true
+			}
+		}
+	}
+	for imp := range imports {
+		p.imports = append(p.imports, imp)
+	}
+	slices.Sort(p.imports)
+	return p, nil
+}
+
+func hasInitFunc(f *ast.File) bool {
+	for _, decl := range f.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil && fn.Name.Name == "init" {
+			return true
+		}
+	}
+	return false
+}
+
+// ExcludedImport returns the package in [config.Config.ExcludeImports]
+// that path is or (transitively) imports, if any.
+func (g *importGraph) ExcludedImport(path string) (string, bool, error) {
+	if excl, ok := g.reachesExcluded[path]; ok {
+		return excl, excl != "", nil
+	}
+	// Guard against import cycles (only possible with broken sources).
+	g.reachesExcluded[path] = ""
+	if slices.Contains(g.excluded, path) {
+		g.reachesExcluded[path] = path
+		return path, true, nil
+	}
+	p, err := g.pkg(path)
+	if err != nil {
+		return "", false, err
+	}
+	for _, imp := range p.imports {
+		excl, ok, err := g.ExcludedImport(imp)
+		if err != nil {
+			return "", false, err
+		}
+		if ok {
+			g.reachesExcluded[path] = excl
+			return excl, true, nil
+		}
+	}
+	return "", false, nil
+}
+
+// InitChains returns the packages with init funcs (transitively)
+// imported by roots, each with an import chain leading to it from one
+// of the roots.
+func (g *importGraph) InitChains(roots []string) (map[string][]string, error) {
+	parent := make(map[string]string)
+	var queue []string
+	for _, root := range roots {
+		if _, ok := parent[root]; !ok {
+			parent[root] = ""
+			queue = append(queue, root)
+		}
+	}
+	res := make(map[string][]string)
+	for len(queue) > 0 {
+		path := queue[0]
+		queue = queue[1:]
+		p, err := g.pkg(path)
+		if err != nil {
+			return nil, err
+		}
+		if p.hasInit {
+			var chain []string
+			for pp := path; pp != ""; pp = parent[pp] {
+				chain = append(chain, pp)
+			}
+			slices.Reverse(chain)
+			res[path] = chain
+		}
+		for _, imp := range p.imports {
+			if _, ok := parent[imp]; !ok {
+				parent[imp] = path
+				queue = append(queue, imp)
+			}
+		}
+	}
+	return res, nil
+}
+
+// writeInitReport writes the packages with init funcs imported by the
+// generated code to path in sink.
+func writeInitReport(sink OutputSink, path string, chains map[string][]string) error {
+	var b strings.Builder
+	b.WriteString("# Packages whose init funcs run in binaries including the bindings,\n")
+	b.WriteString("# with an import chain from the generated code. Written by ryegen\n")
+	b.WriteString("# (see \"init-report\" in config.toml).\n")
+	for _, pkg := range slices.Sorted(maps.Keys(chains)) {
+		fmt.Fprintf(&b, "%v (%v)\n", pkg, strings.Join(chains[pkg], " -> "))
+	}
+	return sink.WriteFile(path, []byte(b.String()))
+}
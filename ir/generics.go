@@ -0,0 +1,423 @@
+package ir
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+)
+
+// GenericAlias is a generic alias declaration (type A[T any] = B[T]).
+// Unlike other aliases (see [IR.Aliases]) it only denotes a type once
+// instantiated, so instantiations are expanded in the declarations
+// using them (see [IR.expandGenericAliases]).
+type GenericAlias struct {
+	Name       string
+	File       *File
+	TypeParams []string
+	// Aliased type in terms of TypeParams, e.g. B[T].
+	Type ast.Expr
+}
+
+// maxGenericAliasDepth limits how deep instantiations of generic aliases
+// forwarding to other generic aliases are expanded. Chains are finite in
+// valid Go; the limit only guards against cycles in broken input.
+const maxGenericAliasDepth = 100
+
+// addGenericAliases adds the generic alias declarations of f to
+// [IR.GenericAliases].
+func (ir *IR) addGenericAliases(modNames UniqueModuleNames, file *File, f *ast.File) {
+	mod, ok := modNames[file.ModulePath]
+	if !ok {
+		panic("ir.Parse: expected modulePath to exist in modNames")
+	}
+	for _, decl := range f.Decls {
+		decl, ok := decl.(*ast.GenDecl)
+		if !ok || decl.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range decl.Specs {
+			typeSpec, ok := spec.(*ast.TypeSpec)
+			if !ok || !isGenericAlias(typeSpec) {
+				continue
+			}
+			var params []string
+			for _, field := range typeSpec.TypeParams.List {
+				for _, name := range field.Names {
+					params = append(params, name.Name)
+				}
+			}
+			name := mod + "." + typeSpec.Name.Name
+			ir.GenericAliases[name] = &GenericAlias{
+				Name:       name,
+				File:       file,
+				TypeParams: params,
+				Type:       typeSpec.Type,
+			}
+		}
+	}
+}
+
+// isGenericAlias reports whether typeSpec declares a generic alias.
+func isGenericAlias(typeSpec *ast.TypeSpec) bool {
+	return typeSpec.Assign.IsValid() && typeSpec.TypeParams != nil
+}
+
+// expandGenericAliases returns f with instantiations of generic aliases
+// in its declarations (e.g. Seq[int] for type Seq[T any] = List[T])
+// replaced by the aliased types with the type arguments substituted
+// (List[int]), so each type is known by a single name, like with
+// [IR.ResolveAlias]. Nodes are copied where they change, leaving f as
+// it is, since ASTs of dependencies may be shared. Instantiations which
+// can't be expanded, e.g. of aliases declared in packages which failed
+// to load, are left as they are.
+func (ir *IR) expandGenericAliases(modNames UniqueModuleNames, file *File, f *ast.File) *ast.File {
+	if len(ir.GenericAliases) == 0 {
+		return f
+	}
+
+	var expand func(expr ast.Expr, depth int) ast.Expr
+	expandFields := func(fields *ast.FieldList, depth int) *ast.FieldList {
+		if fields == nil {
+			return nil
+		}
+		res := fields
+		for i, field := range fields.List {
+			typ := expand(field.Type, depth)
+			if typ == field.Type {
+				continue
+			}
+			if res == fields {
+				res = &ast.FieldList{Opening: fields.Opening, List: slices.Clone(fields.List), Closing: fields.Closing}
+			}
+			newField := *field
+			newField.Type = typ
+			res.List[i] = &newField
+		}
+		return res
+	}
+	expandInst := func(inst, x ast.Expr, args []ast.Expr, depth int) ast.Expr {
+		if depth >= maxGenericAliasDepth {
+			return inst
+		}
+		alias, ok := ir.genericAliasOf(modNames, file, x)
+		if !ok {
+			return inst
+		}
+		res, ok := alias.instantiate(file, args)
+		if !ok {
+			return inst
+		}
+		return expand(res, depth+1)
+	}
+	expand = func(expr ast.Expr, depth int) ast.Expr {
+		switch expr := expr.(type) {
+		case *ast.StarExpr:
+			if x := expand(expr.X, depth); x != expr.X {
+				return &ast.StarExpr{Star: expr.Star, X: x}
+			}
+		case *ast.ParenExpr:
+			if x := expand(expr.X, depth); x != expr.X {
+				return &ast.ParenExpr{Lparen: expr.Lparen, X: x, Rparen: expr.Rparen}
+			}
+		case *ast.ArrayType:
+			if elt := expand(expr.Elt, depth); elt != expr.Elt {
+				return &ast.ArrayType{Lbrack: expr.Lbrack, Len: expr.Len, Elt: elt}
+			}
+		case *ast.Ellipsis:
+			if elt := expand(expr.Elt, depth); elt != expr.Elt {
+				return &ast.Ellipsis{Ellipsis: expr.Ellipsis, Elt: elt}
+			}
+		case *ast.MapType:
+			key, value := expand(expr.Key, depth), expand(expr.Value, depth)
+			if key != expr.Key || value != expr.Value {
+				return &ast.MapType{Map: expr.Map, Key: key, Value: value}
+			}
+		case *ast.ChanType:
+			if value := expand(expr.Value, depth); value != expr.Value {
+				return &ast.ChanType{Begin: expr.Begin, Arrow: expr.Arrow, Dir: expr.Dir, Value: value}
+			}
+		case *ast.FuncType:
+			params, results := expandFields(expr.Params, depth), expandFields(expr.Results, depth)
+			if params != expr.Params || results != expr.Results {
+				return &ast.FuncType{Func: expr.Func, TypeParams: expr.TypeParams, Params: params, Results: results}
+			}
+		case *ast.StructType:
+			if fields := expandFields(expr.Fields, depth); fields != expr.Fields {
+				return &ast.StructType{Struct: expr.Struct, Fields: fields, Incomplete: expr.Incomplete}
+			}
+		case *ast.InterfaceType:
+			if methods := expandFields(expr.Methods, depth); methods != expr.Methods {
+				return &ast.InterfaceType{Interface: expr.Interface, Methods: methods, Incomplete: expr.Incomplete}
+			}
+		case *ast.IndexExpr:
+			inst := expr
+			if index := expand(expr.Index, depth); index != expr.Index {
+				inst = &ast.IndexExpr{X: expr.X, Lbrack: expr.Lbrack, Index: index, Rbrack: expr.Rbrack}
+			}
+			return expandInst(inst, inst.X, []ast.Expr{inst.Index}, depth)
+		case *ast.IndexListExpr:
+			inst := expr
+			for i, index := range expr.Indices {
+				if newIndex := expand(index, depth); newIndex != index {
+					if inst == expr {
+						inst = &ast.IndexListExpr{X: expr.X, Lbrack: expr.Lbrack, Indices: slices.Clone(expr.Indices), Rbrack: expr.Rbrack}
+					}
+					inst.Indices[i] = newIndex
+				}
+			}
+			return expandInst(inst, inst.X, inst.Indices, depth)
+		}
+		return expr
+	}
+
+	var decls []ast.Decl
+	setDecl := func(i int, decl ast.Decl) {
+		if decls == nil {
+			decls = slices.Clone(f.Decls)
+		}
+		decls[i] = decl
+	}
+	for i, decl := range f.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			recv := expandFields(decl.Recv, 0)
+			typ := expand(decl.Type, 0).(*ast.FuncType)
+			if recv != decl.Recv || typ != decl.Type {
+				newDecl := *decl
+				newDecl.Recv, newDecl.Type = recv, typ
+				setDecl(i, &newDecl)
+			}
+		case *ast.GenDecl:
+			var specs []ast.Spec
+			for j, spec := range decl.Specs {
+				var newSpec ast.Spec
+				switch spec := spec.(type) {
+				case *ast.TypeSpec:
+					if typ := expand(spec.Type, 0); typ != spec.Type {
+						newTypeSpec := *spec
+						newTypeSpec.Type = typ
+						newSpec = &newTypeSpec
+					}
+				case *ast.ValueSpec:
+					if typ := expand(spec.Type, 0); typ != spec.Type {
+						newValueSpec := *spec
+						newValueSpec.Type = typ
+						newSpec = &newValueSpec
+					}
+				}
+				if newSpec != nil {
+					if specs == nil {
+						specs = slices.Clone(decl.Specs)
+					}
+					specs[j] = newSpec
+				}
+			}
+			if specs != nil {
+				newDecl := *decl
+				newDecl.Specs = specs
+				setDecl(i, &newDecl)
+			}
+		}
+	}
+	if decls == nil {
+		return f
+	}
+	newF := *f
+	newF.Decls = decls
+	return &newF
+}
+
+// instantiatedPkgs returns the paths of the packages whose types are
+// instantiated in the declarations of f (e.g. "pkg" for pkg.Seq[int]).
+// Their generic aliases must be known before f is expanded (see
+// [IR.expandGenericAliases]).
+func instantiatedPkgs(file *File, f *ast.File) map[string]struct{} {
+	res := make(map[string]struct{})
+	visit := func(node ast.Node) bool {
+		var x ast.Expr
+		switch node := node.(type) {
+		case *ast.IndexExpr:
+			x = node.X
+		case *ast.IndexListExpr:
+			x = node.X
+		default:
+			return true
+		}
+		if sel, ok := x.(*ast.SelectorExpr); ok {
+			if pkg, ok := sel.X.(*ast.Ident); ok {
+				if imp, ok := file.ImportsByName[pkg.Name]; ok {
+					res[imp.ModulePath] = struct{}{}
+				}
+			}
+		}
+		return true
+	}
+	for _, decl := range f.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			if decl.Recv != nil {
+				ast.Inspect(decl.Recv, visit)
+			}
+			ast.Inspect(decl.Type, visit)
+		case *ast.GenDecl:
+			for _, spec := range decl.Specs {
+				switch spec := spec.(type) {
+				case *ast.TypeSpec:
+					ast.Inspect(spec.Type, visit)
+				case *ast.ValueSpec:
+					if spec.Type != nil {
+						ast.Inspect(spec.Type, visit)
+					}
+				}
+			}
+		}
+	}
+	return res
+}
+
+// genericAliasOf returns the generic alias x (e.g. "Seq" or "pkg.Seq")
+// refers to in file.
+func (ir *IR) genericAliasOf(modNames UniqueModuleNames, file *File, x ast.Expr) (*GenericAlias, bool) {
+	var modulePath, name string
+	switch x := x.(type) {
+	case *ast.Ident:
+		modulePath, name = file.ModulePath, x.Name
+	case *ast.SelectorExpr:
+		pkg, ok := x.X.(*ast.Ident)
+		if !ok {
+			return nil, false
+		}
+		imp, ok := file.ImportsByName[pkg.Name]
+		if !ok {
+			return nil, false
+		}
+		modulePath, name = imp.ModulePath, x.Sel.Name
+	default:
+		return nil, false
+	}
+	mod, ok := modNames[modulePath]
+	if !ok {
+		return nil, false
+	}
+	alias, ok := ir.GenericAliases[mod+"."+name]
+	return alias, ok
+}
+
+// instantiate returns the aliased type of a with args substituted for
+// its type params, as a type expression in file (which may be in another
+// package than a). Reports false if the type can't be expressed in file,
+// e.g. if it refers to unexported types of a's package.
+func (a *GenericAlias) instantiate(file *File, args []ast.Expr) (ast.Expr, bool) {
+	if len(args) != len(a.TypeParams) {
+		return nil, false
+	}
+	params := make(map[string]ast.Expr, len(args))
+	for i, p := range a.TypeParams {
+		params[p] = args[i]
+	}
+
+	var subst func(expr ast.Expr) (ast.Expr, bool)
+	substFields := func(fields *ast.FieldList) (*ast.FieldList, bool) {
+		if fields == nil {
+			return nil, true
+		}
+		res := &ast.FieldList{List: make([]*ast.Field, len(fields.List))}
+		for i, field := range fields.List {
+			typ, ok := subst(field.Type)
+			if !ok {
+				return nil, false
+			}
+			res.List[i] = &ast.Field{Names: field.Names, Type: typ, Tag: field.Tag}
+		}
+		return res, true
+	}
+	substAll := func(exprs []ast.Expr) ([]ast.Expr, bool) {
+		res := make([]ast.Expr, len(exprs))
+		for i, expr := range exprs {
+			var ok bool
+			if res[i], ok = subst(expr); !ok {
+				return nil, false
+			}
+		}
+		return res, true
+	}
+	subst = func(expr ast.Expr) (ast.Expr, bool) {
+		switch expr := expr.(type) {
+		case nil:
+			return nil, true
+		case *ast.Ident:
+			if arg, ok := params[expr.Name]; ok {
+				return arg, true
+			}
+			if a.File.ModulePath == file.ModulePath || types.Universe.Lookup(expr.Name) != nil {
+				return &ast.Ident{Name: expr.Name}, true
+			}
+			imp, ok := file.ImportsByPath[a.File.ModulePath]
+			if !ok || !expr.IsExported() {
+				return nil, false
+			}
+			return &ast.SelectorExpr{X: &ast.Ident{Name: imp.ModuleName}, Sel: &ast.Ident{Name: expr.Name}}, true
+		case *ast.SelectorExpr:
+			pkg, ok := expr.X.(*ast.Ident)
+			if !ok {
+				return nil, false
+			}
+			imp, ok := a.File.ImportsByName[pkg.Name]
+			if !ok {
+				return nil, false
+			}
+			if imp.ModulePath == file.ModulePath {
+				return &ast.Ident{Name: expr.Sel.Name}, true
+			}
+			fileImp, ok := file.ImportsByPath[imp.ModulePath]
+			if !ok {
+				return nil, false
+			}
+			return &ast.SelectorExpr{X: &ast.Ident{Name: fileImp.ModuleName}, Sel: &ast.Ident{Name: expr.Sel.Name}}, true
+		case *ast.StarExpr:
+			x, ok := subst(expr.X)
+			return &ast.StarExpr{X: x}, ok
+		case *ast.ParenExpr:
+			x, ok := subst(expr.X)
+			return &ast.ParenExpr{X: x}, ok
+		case *ast.ArrayType:
+			if _, ok := expr.Len.(*ast.BasicLit); expr.Len != nil && !ok {
+				// Constant expressions refer to a's package.
+				return nil, false
+			}
+			elt, ok := subst(expr.Elt)
+			return &ast.ArrayType{Len: expr.Len, Elt: elt}, ok
+		case *ast.Ellipsis:
+			elt, ok := subst(expr.Elt)
+			return &ast.Ellipsis{Elt: elt}, ok
+		case *ast.MapType:
+			key, ok1 := subst(expr.Key)
+			value, ok2 := subst(expr.Value)
+			return &ast.MapType{Key: key, Value: value}, ok1 && ok2
+		case *ast.ChanType:
+			value, ok := subst(expr.Value)
+			return &ast.ChanType{Dir: expr.Dir, Value: value}, ok
+		case *ast.FuncType:
+			params, ok1 := substFields(expr.Params)
+			results, ok2 := substFields(expr.Results)
+			return &ast.FuncType{Params: params, Results: results}, ok1 && ok2
+		case *ast.StructType:
+			fields, ok := substFields(expr.Fields)
+			return &ast.StructType{Fields: fields}, ok
+		case *ast.InterfaceType:
+			methods, ok := substFields(expr.Methods)
+			return &ast.InterfaceType{Methods: methods}, ok
+		case *ast.IndexExpr:
+			x, ok1 := subst(expr.X)
+			index, ok2 := subst(expr.Index)
+			return &ast.IndexExpr{X: x, Index: index}, ok1 && ok2
+		case *ast.IndexListExpr:
+			x, ok1 := subst(expr.X)
+			indices, ok2 := substAll(expr.Indices)
+			return &ast.IndexListExpr{X: x, Indices: indices}, ok1 && ok2
+		}
+		return nil, false
+	}
+	return subst(a.Type)
+}
@@ -7,6 +7,7 @@ import (
 	"go/constant"
 	"go/format"
 	"go/token"
+	"go/types"
 	"maps"
 	"reflect"
 	"slices"
@@ -14,6 +15,7 @@ import (
 	"strings"
 
 	"github.com/hashicorp/go-multierror"
+	"github.com/iancoleman/strcase"
 )
 
 // Module path to globally unique name.
@@ -25,6 +27,15 @@ type File struct {
 	ModulePath    string
 	ImportsByName map[string]*File
 	ImportsByPath map[string]*File
+	// Names of the types declared in the package, shared by the files
+	// of the package, so const expressions can tell conversions from
+	// function calls (see [EvalConstExpr]).
+	TypeNames map[string]struct{}
+	// Build constraint the file is subject to (empty if unconstrained).
+	Constraint string
+	// File set the file was parsed with, for resolving positions (nil
+	// if unknown).
+	Fset *token.FileSet
 }
 
 func (f *File) AddImport(imp *File) {
@@ -82,6 +93,39 @@ type Ident struct {
 	UsedImports []*File
 }
 
+// SupportedTypeExprs are the go/ast expression node types (as in
+// reflect.TypeOf(expr).String()) which can be used as types in [NewIdent].
+var SupportedTypeExprs = []string{
+	"*ast.Ident",
+	"*ast.Ellipsis",
+	"*ast.StarExpr",
+	"*ast.SelectorExpr",
+	"*ast.ArrayType",
+	"*ast.FuncType",
+	"*ast.MapType",
+	"*ast.InterfaceType",
+	"*ast.StructType",
+	"*ast.ChanType",
+}
+
+// UnsupportedTypeExprs maps the other go/ast expression node types to
+// why [NewIdent] doesn't accept them as types.
+var UnsupportedTypeExprs = map[string]string{
+	"*ast.BadExpr":        "syntax error",
+	"*ast.BasicLit":       "not a type",
+	"*ast.FuncLit":        "not a type",
+	"*ast.CompositeLit":   "not a type",
+	"*ast.SliceExpr":      "not a type",
+	"*ast.TypeAssertExpr": "not a type",
+	"*ast.CallExpr":       "not a type",
+	"*ast.KeyValueExpr":   "not a type",
+	"*ast.ParenExpr":      "parenthesized types are unsupported",
+	"*ast.IndexExpr":      "generic type instantiations are unsupported",
+	"*ast.IndexListExpr":  "generic type instantiations are unsupported",
+	"*ast.UnaryExpr":      "type constraints (~T) are unsupported",
+	"*ast.BinaryExpr":     "type constraint unions (A | B) are unsupported",
+}
+
 func identExprToGoName(constValues map[string]ConstValue, modNames UniqueModuleNames, file *File, expr ast.Expr) (ident string, usedImports []*File, err error) {
 	switch expr := expr.(type) {
 	case *ast.Ident:
@@ -247,7 +291,11 @@ func identExprToGoName(constValues map[string]ConstValue, modNames UniqueModuleN
 		}
 		return ch + " " + val, imps, nil
 	default:
-		return "", nil, errors.New("invalid identifier expression type " + reflect.TypeOf(expr).String())
+		typ := reflect.TypeOf(expr).String()
+		if reason, ok := UnsupportedTypeExprs[typ]; ok {
+			return "", nil, fmt.Errorf("invalid type expression %v: %v", types.ExprString(expr), reason)
+		}
+		return "", nil, errors.New("invalid identifier expression type " + typ)
 	}
 }
 
@@ -293,6 +341,15 @@ func (id Ident) ParamName() string {
 	return "..." + id.Name[2:]
 }
 
+// Position returns the source position of id, or the zero position if
+// the file set of its file is unknown.
+func (id Ident) Position() token.Position {
+	if id.File == nil || id.File.Fset == nil || id.Expr == nil || !id.Expr.Pos().IsValid() {
+		return token.Position{}
+	}
+	return id.File.Fset.Position(id.Expr.Pos())
+}
+
 func (id Ident) RyeName() string {
 	return "Go(" + id.Name + ")"
 }
@@ -378,6 +435,8 @@ func (fn *Func) String() string {
 func ParamsToIdents(constValues map[string]ConstValue, modNames UniqueModuleNames, file *File, fl *ast.FieldList) (idents []NamedIdent, substImports []*File, err error) {
 	var res []NamedIdent
 	var substImps []*File
+	// Number of unnamed parameters by name derived from their type.
+	shorthands := make(map[string]int)
 	for i, v := range fl.List {
 		typID, err := NewIdent(constValues, modNames, file, v.Type)
 		if err != nil {
@@ -400,24 +459,112 @@ func ParamsToIdents(constValues map[string]ConstValue, modNames UniqueModuleName
 			if typID.Name == "error" && i == len(fl.List)-1 {
 				shorthand = "err"
 			} else {
-				shorthand = strconv.Itoa(i + 1)
+				shorthand = paramNameFromType(v.Type)
+			}
+			// e.g. (s, s2 string)
+			shorthands[shorthand]++
+			if n := shorthands[shorthand]; n > 1 {
+				shorthand += strconv.Itoa(n)
 			}
 			nameID, err := NewIdent(constValues, modNames, nil, &ast.Ident{Name: shorthand})
 			if err != nil {
 				return nil, nil, err
 			}
 			res = append(res, NamedIdent{
-				Name: nameID,
-				Type: typID,
+				Name:    nameID,
+				Type:    typID,
+				Unnamed: true,
 			})
 		}
 	}
 	return res, substImps, nil
 }
 
+// paramNameFromType returns a short name for an unnamed parameter of type
+// expr, e.g. "ctx" for context.Context, "reader" for io.Reader, "opts"
+// for *Options and "s" for string.
+func paramNameFromType(expr ast.Expr) string {
+	switch expr := expr.(type) {
+	case *ast.ParenExpr:
+		return paramNameFromType(expr.X)
+	case *ast.StarExpr:
+		return paramNameFromType(expr.X)
+	case *ast.IndexExpr:
+		return paramNameFromType(expr.X)
+	case *ast.IndexListExpr:
+		return paramNameFromType(expr.X)
+	case *ast.SelectorExpr:
+		return paramNameFromTypeName(expr.Sel.Name)
+	case *ast.Ident:
+		switch expr.Name {
+		case "string":
+			return "s"
+		case "bool":
+			return "ok"
+		case "int", "int8", "int16", "int32", "int64",
+			"uint", "uint8", "uint16", "uint32", "uint64", "uintptr":
+			return "n"
+		case "float32", "float64":
+			return "x"
+		case "complex64", "complex128":
+			return "c"
+		case "byte":
+			return "b"
+		case "rune":
+			return "r"
+		case "error":
+			return "err"
+		case "any":
+			return "v"
+		default:
+			return paramNameFromTypeName(expr.Name)
+		}
+	case *ast.ArrayType:
+		if id, ok := expr.Elt.(*ast.Ident); ok && id.Name == "byte" {
+			return "data"
+		}
+		return "items"
+	case *ast.Ellipsis:
+		return "args"
+	case *ast.MapType:
+		return "m"
+	case *ast.FuncType:
+		return "fn"
+	case *ast.ChanType:
+		return "ch"
+	default:
+		return "v"
+	}
+}
+
+// paramNameFromTypeName returns the parameter name for a value of the
+// named type typeName (see [paramNameFromType]).
+func paramNameFromTypeName(typeName string) string {
+	switch {
+	case typeName == "Context":
+		return "ctx"
+	case strings.HasSuffix(typeName, "Options"), strings.HasSuffix(typeName, "Opts"):
+		return "opts"
+	case strings.HasSuffix(typeName, "Config"):
+		return "cfg"
+	}
+	name := strcase.ToLowerCamel(typeName)
+	if name == "" {
+		return "v"
+	}
+	if token.IsKeyword(name) {
+		// e.g. "t" for reflect.Type
+		return name[:1]
+	}
+	return name
+}
+
 type NamedIdent struct {
 	Name Ident
 	Type Ident
+	// Unnamed reports whether the declaration left the parameter unnamed,
+	// in which case Name is derived from the type (see [ParamsToIdents]).
+	Unnamed bool
 }
 
 type Struct struct {
@@ -425,6 +572,9 @@ type Struct struct {
 	Fields   []NamedIdent
 	Methods  map[string]*Func
 	Inherits []Ident
+	// Names of the fields left out of Fields, since they are unexported
+	// or of internal types.
+	HiddenFields []string
 }
 
 func NewStruct(constValues map[string]ConstValue, modNames UniqueModuleNames, file *File, name *ast.Ident, structTyp *ast.StructType) (*Struct, error) {
@@ -440,10 +590,16 @@ func NewStruct(constValues map[string]ConstValue, modNames UniqueModuleNames, fi
 	}
 	for _, f := range structTyp.Fields.List {
 		if len(f.Names) > 0 {
+			hide := func(names []*ast.Ident) {
+				for _, name := range names {
+					res.HiddenFields = append(res.HiddenFields, name.Name)
+				}
+			}
 			if !slices.ContainsFunc(f.Names, func(name *ast.Ident) bool {
 				return name.IsExported()
 			}) {
 				// Don't even try to parse the field type if no name is exported
+				hide(f.Names)
 				continue
 			}
 
@@ -453,11 +609,13 @@ func NewStruct(constValues map[string]ConstValue, modNames UniqueModuleNames, fi
 			}
 			if IdentIsInternal(modNames, typID) {
 				// Ignore field with internal type
+				hide(f.Names)
 				continue
 			}
 
 			for _, name := range f.Names {
 				if !name.IsExported() {
+					hide([]*ast.Ident{name})
 					continue
 				}
 				nameID, err := NewIdent(constValues, modNames, nil, name)
@@ -475,6 +633,7 @@ func NewStruct(constValues map[string]ConstValue, modNames UniqueModuleNames, fi
 				structTyp = se.X
 			}
 			if !IdentExprIsExported(structTyp) {
+				res.HiddenFields = append(res.HiddenFields, types.ExprString(structTyp))
 				continue
 			}
 			structTypID, err := NewIdent(constValues, modNames, file, structTyp)
@@ -606,17 +765,21 @@ type ConstValue struct {
 	ast.Expr
 	File *File
 	Iota int64
+	// Declared type, nil if untyped. Carried over to following
+	// consts without values, like the value expression.
+	Type ast.Expr
 }
 
 func EvalConstExpr(constValues map[string]ConstValue, modNames UniqueModuleNames, file *File, expr ast.Expr) (constant.Value, error) {
 	makeVal := func(lit *ast.BasicLit) (constant.Value, error) {
 		switch lit.Kind {
 		case token.INT:
-			v, err := strconv.ParseInt(lit.Value, 10, 64)
-			if err != nil {
-				return nil, err
+			// Handles prefixes (e.g. 0x) and digit separators.
+			v := constant.MakeFromLiteral(lit.Value, lit.Kind, 0)
+			if v.Kind() == constant.Unknown {
+				return nil, fmt.Errorf("invalid integer literal %v", lit.Value)
 			}
-			return constant.MakeInt64(v), nil
+			return v, nil
 		case token.FLOAT:
 			v, err := strconv.ParseFloat(lit.Value, 64)
 			if err != nil {
@@ -693,11 +856,60 @@ func EvalConstExpr(constValues map[string]ConstValue, modNames UniqueModuleNames
 				return nil, err
 			}
 
-			return constant.BinaryOp(
-				x,
-				expr.Op,
-				y,
-			), nil
+			return constBinaryOp(x, expr.Op, y)
+		case *ast.ParenExpr:
+			return doEval(file, expr.X, iotaVal)
+		case *ast.UnaryExpr:
+			x, err := doEval(file, expr.X, iotaVal)
+			if err != nil {
+				return nil, err
+			}
+			return constUnaryOp(expr.Op, x)
+		case *ast.CallExpr:
+			args := make([]constant.Value, len(expr.Args))
+			for i, arg := range expr.Args {
+				v, err := doEval(file, arg, iotaVal)
+				if err != nil {
+					return nil, err
+				}
+				args[i] = v
+			}
+			switch fun := expr.Fun.(type) {
+			case *ast.Ident:
+				_, isBasic := constBasicTypes[fun.Name]
+				if !isBasic && !isDeclaredType(file, fun.Name) {
+					if _, ok := constBuiltinFuncs[fun.Name]; ok {
+						return constBuiltinCall(fun.Name, args)
+					}
+					return nil, fmt.Errorf("const call of %v, which isn't a type", fun.Name)
+				}
+			case *ast.SelectorExpr:
+				parent, ok := fun.X.(*ast.Ident)
+				if !ok {
+					return nil, fmt.Errorf("unexpected selector parent type %T", fun.X)
+				}
+				f, ok := file.ImportsByName[parent.Name]
+				if !ok {
+					return nil, fmt.Errorf("module %v imported by %v not found", parent.Name, file.Name)
+				}
+				if f.ModulePath == "unsafe" {
+					return nil, fmt.Errorf("unsupported const call of unsafe.%v", fun.Sel.Name)
+				}
+				// Only conversions can be called in const expressions
+				// besides predeclared functions, so any name is taken
+				// as a type if the package's types are unknown (e.g.
+				// it isn't loaded).
+				if len(f.TypeNames) > 0 && !isDeclaredType(f, fun.Sel.Name) {
+					return nil, fmt.Errorf("const call of %v.%v, which isn't a type", parent.Name, fun.Sel.Name)
+				}
+			default:
+				return nil, fmt.Errorf("unexpected const call of %T", expr.Fun)
+			}
+			// Conversion to a named or basic type, e.g. FileMode(1).
+			if len(args) != 1 {
+				return nil, fmt.Errorf("unexpected const conversion with %v arguments", len(args))
+			}
+			return args[0], nil
 		default:
 			return nil, fmt.Errorf("unexpected const expression type %T", expr)
 		}
@@ -706,24 +918,211 @@ func EvalConstExpr(constValues map[string]ConstValue, modNames UniqueModuleNames
 	return doEval(file, expr, -1)
 }
 
+// constBasicTypes are the predeclared types constants can be converted to.
+var constBasicTypes = map[string]struct{}{
+	"bool": {}, "string": {}, "byte": {}, "rune": {}, "uintptr": {},
+	"int": {}, "int8": {}, "int16": {}, "int32": {}, "int64": {},
+	"uint": {}, "uint8": {}, "uint16": {}, "uint32": {}, "uint64": {},
+	"float32": {}, "float64": {}, "complex64": {}, "complex128": {},
+}
+
+// constBuiltinFuncs are the predeclared functions which may be called in
+// constant expressions (see [constBuiltinCall]).
+var constBuiltinFuncs = map[string]struct{}{
+	"len": {}, "real": {}, "imag": {}, "complex": {}, "min": {}, "max": {},
+}
+
+// isDeclaredType reports whether name is a type declared in the package
+// of file (see [File.TypeNames]).
+func isDeclaredType(file *File, name string) bool {
+	_, ok := file.TypeNames[name]
+	return ok
+}
+
+// constBuiltinCall evaluates the call of the predeclared function fun
+// (see [constBuiltinFuncs]) with the constant args.
+func constBuiltinCall(fun string, args []constant.Value) (res constant.Value, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("invalid const call of %v: %v", fun, r)
+		}
+	}()
+	wantArgs := 1
+	switch fun {
+	case "complex":
+		wantArgs = 2
+	case "min", "max":
+		wantArgs = max(len(args), 1)
+	}
+	if len(args) != wantArgs {
+		return nil, fmt.Errorf("const call of %v with %v arguments", fun, len(args))
+	}
+	switch fun {
+	case "len":
+		if args[0].Kind() != constant.String {
+			return nil, fmt.Errorf("const call of len with %v argument", args[0].Kind())
+		}
+		return constant.MakeInt64(int64(len(constant.StringVal(args[0])))), nil
+	case "real":
+		return constant.Real(args[0]), nil
+	case "imag":
+		return constant.Imag(args[0]), nil
+	case "complex":
+		return constant.BinaryOp(constant.ToFloat(args[0]), token.ADD, constant.MakeImag(constant.ToFloat(args[1]))), nil
+	default:
+		op := token.LSS
+		if fun == "max" {
+			op = token.GTR
+		}
+		res = args[0]
+		for _, v := range args[1:] {
+			if constant.Compare(v, op, res) {
+				res = v
+			}
+		}
+		return res, nil
+	}
+}
+
+// constBinaryOp is like [constant.BinaryOp], but also handles shifts and
+// comparisons, and returns an error instead of panicking on invalid
+// operands.
+func constBinaryOp(x constant.Value, op token.Token, y constant.Value) (res constant.Value, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("invalid const operation %v %v %v: %v", x, op, y, r)
+		}
+	}()
+	switch op {
+	case token.SHL, token.SHR:
+		s, ok := constant.Uint64Val(y)
+		if !ok {
+			return nil, fmt.Errorf("invalid shift count %v", y)
+		}
+		return constant.Shift(x, op, uint(s)), nil
+	case token.EQL, token.NEQ, token.LSS, token.LEQ, token.GTR, token.GEQ:
+		return constant.MakeBool(constant.Compare(x, op, y)), nil
+	default:
+		return constant.BinaryOp(x, op, y), nil
+	}
+}
+
+// constUnaryOp is like [constant.UnaryOp], but returns an error instead
+// of panicking on invalid operands.
+func constUnaryOp(op token.Token, x constant.Value) (res constant.Value, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("invalid const operation %v%v: %v", op, x, r)
+		}
+	}()
+	return constant.UnaryOp(op, x, 0), nil
+}
+
 type IRInputFileInfo struct {
 	File       *ast.File
 	Name       string
 	ModulePath string
+	// build constraint the file is subject to (empty if unconstrained)
+	Constraint string
+	// file set the file was parsed with (optional)
+	Fset *token.FileSet
 	// only parse type declarations:
 	// needed in case of inheritance dependency
 	TypeDeclsOnly bool
 }
 
 type IR struct {
-	Funcs       map[string]*Func
-	Interfaces  map[string]*Interface
-	Structs     map[string]*Struct
-	Typedefs    map[string]Ident
-	Values      map[string]NamedIdent // consts and vars
-	Files       map[string]*File      // file by name
-	ConstValues map[string]ConstValue
-	TypeMethods map[string][]*Func // type to methods
+	Funcs      map[string]*Func
+	Interfaces map[string]*Interface
+	Structs    map[string]*Struct
+	Typedefs   map[string]Ident
+	Aliases    map[string]Ident // alias name to aliased named type
+	// Generic alias name to declaration.
+	GenericAliases map[string]*GenericAlias
+	Values         map[string]NamedIdent // consts and vars
+	Files          map[string]*File      // file by name
+	ConstValues    map[string]ConstValue
+	TypeMethods    map[string][]*Func // type to methods
+	PackageDocs    map[string]string  // module path to package doc comment
+	// Unexported marker method name (e.g. "mod.isMsg_Value") to the types
+	// implementing it. Code generators like protoc-gen-go name the marker
+	// method after the sealed interface a oneof field has, so this maps
+	// the field type to its possible concrete types.
+	MarkerImpls map[string][]Ident
+	// Go name to "//ryegen:" directives, where the Go name is [FuncGoIdent]
+	// for funcs, [Ident.Name] for types, vars and consts, and
+	// "<struct name>.<field name>" for struct fields.
+	Directives map[string][]Directive
+	// Go name ("<module>.<func>" or "<module>.<type>.<method>") to the
+	// code of Example functions in the package's tests. Filled in
+	// separately, since test files aren't parsed.
+	Examples map[string][]string
+
+	// Package path to the names of the types declared in it (see
+	// [File.TypeNames]).
+	typeNames map[string]map[string]struct{}
+}
+
+// Directive is a "//ryegen:<name> [args...]" comment attached to a
+// declaration, allowing library authors to ship binding hints.
+type Directive struct {
+	Name string
+	Args []string
+}
+
+const directivePrefix = "//ryegen:"
+
+// ParseDirectives returns all "//ryegen:" directives in the given comment groups.
+func ParseDirectives(cgs ...*ast.CommentGroup) []Directive {
+	var res []Directive
+	for _, cg := range cgs {
+		if cg == nil {
+			continue
+		}
+		for _, c := range cg.List {
+			text, ok := strings.CutPrefix(c.Text, directivePrefix)
+			if !ok {
+				continue
+			}
+			fields := strings.Fields(text)
+			if len(fields) == 0 {
+				continue
+			}
+			res = append(res, Directive{
+				Name: fields[0],
+				Args: fields[1:],
+			})
+		}
+	}
+	return res
+}
+
+func (ir *IR) addDirectives(name string, ds []Directive) {
+	if len(ds) == 0 {
+		return
+	}
+	ir.Directives[name] = append(ir.Directives[name], ds...)
+}
+
+// requireChain returns the chain of module paths requiring modulePath
+// (see requiredBy in [Parse]), starting at a bound package and ending
+// with modulePath.
+func requireChain(requiredBy map[string]string, modulePath string) []string {
+	chain := []string{modulePath}
+	seen := map[string]struct{}{modulePath: {}}
+	for {
+		parent, ok := requiredBy[chain[len(chain)-1]]
+		if !ok {
+			break
+		}
+		if _, ok := seen[parent]; ok {
+			break
+		}
+		seen[parent] = struct{}{}
+		chain = append(chain, parent)
+	}
+	slices.Reverse(chain)
+	return chain
 }
 
 // If a *multierror.Error is returned, that error is non-fatal and
@@ -737,19 +1136,97 @@ func Parse(
 	var resErr error
 
 	res := &IR{
-		Funcs:       make(map[string]*Func),
-		Interfaces:  make(map[string]*Interface),
-		Structs:     make(map[string]*Struct),
-		Typedefs:    make(map[string]Ident),
-		Values:      make(map[string]NamedIdent),
-		Files:       make(map[string]*File),
-		ConstValues: make(map[string]ConstValue),
-		TypeMethods: make(map[string][]*Func),
+		Funcs:          make(map[string]*Func),
+		Interfaces:     make(map[string]*Interface),
+		Structs:        make(map[string]*Struct),
+		Typedefs:       make(map[string]Ident),
+		Aliases:        make(map[string]Ident),
+		GenericAliases: make(map[string]*GenericAlias),
+		Values:         make(map[string]NamedIdent),
+		Files:          make(map[string]*File),
+		ConstValues:    make(map[string]ConstValue),
+		TypeMethods:    make(map[string][]*Func),
+		MarkerImpls:    make(map[string][]Ident),
+		PackageDocs:    make(map[string]string),
+		Examples:       make(map[string][]string),
+		Directives:     make(map[string][]Directive),
+		typeNames:      make(map[string]map[string]struct{}),
 	}
 
 	filesGoneThroughPrePass := make(map[string]struct{})
 	filesGoneThroughMainPass := make(map[string]struct{})
 
+	// Module path of the package which first required a dependency,
+	// for naming the import chain of dependencies failing to load.
+	requiredBy := make(map[string]string)
+	rootModules := make(map[string]struct{})
+	for _, in := range input {
+		rootModules[in.ModulePath] = struct{}{}
+	}
+	// withChain adds the chain of packages requiring modulePath to err,
+	// if modulePath is a dependency.
+	withChain := func(modulePath string, err error) error {
+		if _, ok := rootModules[modulePath]; ok {
+			return err
+		}
+		return fmt.Errorf("%w; required by: %v", err, strings.Join(requireChain(requiredBy, modulePath), " -> "))
+	}
+
+	// prePassInstantiated pre-passes the packages whose types are
+	// instantiated in the declarations of input, and recursively in
+	// theirs, so their generic aliases are known when the main pass
+	// expands the instantiations (see [IR.expandGenericAliases]).
+	// Packages failing to load are skipped, leaving their
+	// instantiations as they are.
+	instantiatedLoaded := make(map[string]struct{})
+	prePassInstantiated := func(input []IRInputFileInfo) error {
+		var resErr error
+		queue := slices.Clone(input)
+		for len(queue) > 0 {
+			in := queue[0]
+			queue = queue[1:]
+			file, ok := res.Files[in.Name]
+			if !ok {
+				continue
+			}
+			for req := range instantiatedPkgs(file, in.File) {
+				if _, ok := instantiatedLoaded[req]; ok {
+					continue
+				}
+				instantiatedLoaded[req] = struct{}{}
+				if _, ok := modNames[req]; !ok {
+					continue
+				}
+				if _, ok := requiredBy[req]; !ok && req != in.ModulePath {
+					if _, ok := rootModules[req]; !ok {
+						requiredBy[req] = in.ModulePath
+					}
+				}
+				files, err := getDependency(req)
+				if err != nil {
+					continue
+				}
+				for name, f := range files {
+					if _, ok := filesGoneThroughPrePass[name]; ok {
+						continue
+					}
+					if err := res.addFilePrePass(modNames, f, name, req, modDefaultNames); err != nil {
+						if multErr, ok := err.(*multierror.Error); ok {
+							for _, e := range multErr.Errors {
+								resErr = multierror.Append(resErr, withChain(req, e))
+							}
+						} else {
+							return withChain(req, err)
+						}
+					}
+					filesGoneThroughPrePass[name] = struct{}{}
+					queue = append(queue, IRInputFileInfo{File: f, Name: name, ModulePath: req})
+				}
+			}
+		}
+		return resErr
+	}
+
 	var addFiles func(input []IRInputFileInfo) error
 	addFiles = func(input []IRInputFileInfo) error {
 		var resErr error
@@ -763,13 +1240,26 @@ func Parse(
 			}
 			if err := res.addFilePrePass(modNames, in.File, in.Name, in.ModulePath, modDefaultNames); err != nil {
 				if multErr, ok := err.(*multierror.Error); ok {
-					resErr = multierror.Append(resErr, multErr.Errors...)
+					for _, e := range multErr.Errors {
+						resErr = multierror.Append(resErr, withChain(in.ModulePath, e))
+					}
 				} else {
-					return err
+					return withChain(in.ModulePath, err)
 				}
 			}
+			if f, ok := res.Files[in.Name]; ok {
+				f.Constraint = in.Constraint
+				f.Fset = in.Fset
+			}
 			filesGoneThroughPrePass[in.Name] = struct{}{}
 		}
+		if err := prePassInstantiated(input); err != nil {
+			if multErr, ok := err.(*multierror.Error); ok {
+				resErr = multierror.Append(resErr, multErr.Errors...)
+			} else {
+				return err
+			}
+		}
 		newlyRequiredFiles := make(map[string]IRInputFileInfo)
 		for _, in := range input {
 			if _, ok := filesGoneThroughMainPass[in.Name]; ok {
@@ -781,17 +1271,27 @@ func Parse(
 			)
 			if err != nil {
 				if multErr, ok := err.(*multierror.Error); ok {
-					resErr = multierror.Append(resErr, multErr.Errors...)
+					for _, e := range multErr.Errors {
+						resErr = multierror.Append(resErr, withChain(in.ModulePath, e))
+					}
 				} else {
-					return err
+					return withChain(in.ModulePath, err)
 				}
 			}
 			filesGoneThroughMainPass[in.Name] = struct{}{}
 
 			for req := range newlyRequired {
+				if _, ok := requiredBy[req]; !ok && req != in.ModulePath {
+					if _, ok := rootModules[req]; !ok {
+						requiredBy[req] = in.ModulePath
+					}
+				}
 				files, err := getDependency(req)
 				if err != nil {
-					return err
+					// Keep going to report every package failing
+					// to load.
+					resErr = multierror.Append(resErr, withChain(req, fmt.Errorf("load %v: %w", req, err)))
+					continue
 				}
 				for name, file := range files {
 					newlyRequiredFiles[name] = IRInputFileInfo{
@@ -821,9 +1321,15 @@ func Parse(
 		return resErr
 	}
 	if err := addFiles(input); err != nil {
-		return nil, err
+		if multErr, ok := err.(*multierror.Error); ok {
+			resErr = multierror.Append(resErr, multErr.Errors...)
+		} else {
+			return nil, err
+		}
 	}
 
+	res.resolveAliases(modNames)
+
 	if err := res.resolveInheritancesAndMethods(modNames); err != nil {
 		return nil, err
 	}
@@ -831,6 +1337,18 @@ func Parse(
 	return res, resErr
 }
 
+// packageTypeNames returns the names of the types declared in the
+// package modulePath (see [File.TypeNames]), filled in as its files are
+// pre-passed.
+func (ir *IR) packageTypeNames(modulePath string) map[string]struct{} {
+	names, ok := ir.typeNames[modulePath]
+	if !ok {
+		names = make(map[string]struct{})
+		ir.typeNames[modulePath] = names
+	}
+	return names
+}
+
 func (ir *IR) addFilePrePass(
 	modNames UniqueModuleNames,
 	f *ast.File,
@@ -846,6 +1364,16 @@ func (ir *IR) addFilePrePass(
 		ModulePath:    modulePath,
 		ImportsByName: make(map[string]*File),
 		ImportsByPath: make(map[string]*File),
+		TypeNames:     ir.packageTypeNames(modulePath),
+	}
+	for _, decl := range f.Decls {
+		if decl, ok := decl.(*ast.GenDecl); ok && decl.Tok == token.TYPE {
+			for _, spec := range decl.Specs {
+				if spec, ok := spec.(*ast.TypeSpec); ok {
+					file.TypeNames[spec.Name.Name] = struct{}{}
+				}
+			}
+		}
 	}
 
 	for _, imp := range f.Imports {
@@ -878,15 +1406,17 @@ func (ir *IR) addFilePrePass(
 			ModulePath:    path,
 			ImportsByName: make(map[string]*File),
 			ImportsByPath: make(map[string]*File),
+			TypeNames:     ir.packageTypeNames(path),
 		})
 	}
 	ir.Files[fName] = file
+	ir.addGenericAliases(modNames, file, f)
 
 	for _, decl := range f.Decls {
 		switch decl := decl.(type) {
 		case *ast.GenDecl:
 			if decl.Tok == token.CONST {
-				var prevValue ast.Expr
+				var prevValue, prevType ast.Expr
 				for specIdx, spec := range decl.Specs {
 					if valSpec, ok := spec.(*ast.ValueSpec); ok {
 						if len(valSpec.Names) != len(valSpec.Values) &&
@@ -900,11 +1430,11 @@ func (ir *IR) addFilePrePass(
 							}
 							name := mod + "." + valSpec.Names[i].Name
 
-							value := prevValue
+							value, typ := prevValue, prevType
 							if i < len(valSpec.Values) && valSpec.Values[i] != nil {
-								value = valSpec.Values[i]
+								value, typ = valSpec.Values[i], valSpec.Type
 							}
-							prevValue = value
+							prevValue, prevType = value, typ
 
 							if value == nil {
 								return fmt.Errorf("expected value for const %v in module %v", name, mod)
@@ -914,6 +1444,7 @@ func (ir *IR) addFilePrePass(
 								Expr: value,
 								File: file,
 								Iota: int64(specIdx),
+								Type: typ,
 							}
 						}
 					}
@@ -925,6 +1456,15 @@ func (ir *IR) addFilePrePass(
 	return resErr
 }
 
+// funcDeclName returns the name of a func or method declaration, e.g.
+// "Func" or "(*Type).Method", for error messages.
+func funcDeclName(decl *ast.FuncDecl) string {
+	if decl.Recv == nil || len(decl.Recv.List) != 1 {
+		return decl.Name.Name
+	}
+	return "(" + types.ExprString(decl.Recv.List[0].Type) + ")." + decl.Name.Name
+}
+
 func (ir *IR) addFileMainPass(
 	modNames UniqueModuleNames,
 	f *ast.File,
@@ -942,12 +1482,21 @@ func (ir *IR) addFileMainPass(
 	if !ok {
 		panic("main-pass: expected file " + fName + " to have been created in pre-pass")
 	}
+	f = ir.expandGenericAliases(modNames, file, f)
 
 	docComments := make(map[token.Pos]string) // decl pos to comment text
 	for _, comm := range f.Comments {
 		docComments[comm.End()+1] = comm.Text()
 	}
 
+	if !typeDeclsOnly && f.Doc != nil {
+		// If multiple files have a package doc comment, prefer
+		// the longest one (usually the one in doc.go).
+		if doc := f.Doc.Text(); len(doc) > len(ir.PackageDocs[file.ModulePath]) {
+			ir.PackageDocs[file.ModulePath] = doc
+		}
+	}
+
 declsLoop:
 	for _, decl := range f.Decls {
 		switch decl := decl.(type) {
@@ -956,6 +1505,18 @@ declsLoop:
 				continue
 			}
 			if !decl.Name.IsExported() {
+				if decl.Recv != nil && len(decl.Recv.List) == 1 && IdentExprIsExported(decl.Recv.List[0].Type) &&
+					decl.Type.Params.NumFields() == 0 && decl.Type.Results.NumFields() == 0 {
+					recv, err := NewIdent(ir.ConstValues, modNames, file, decl.Recv.List[0].Type)
+					if err != nil {
+						continue
+					}
+					marker, err := NewIdent(ir.ConstValues, modNames, file, decl.Name)
+					if err != nil {
+						continue
+					}
+					ir.MarkerImpls[marker.Name] = append(ir.MarkerImpls[marker.Name], recv)
+				}
 				continue
 			}
 			if decl.Recv != nil {
@@ -968,7 +1529,7 @@ declsLoop:
 			}
 			fn, err := NewFunc(ir.ConstValues, modNames, file, decl)
 			if err != nil {
-				resErr = multierror.Append(resErr, fmt.Errorf("parse %v: %w", file.ModuleName, err))
+				resErr = multierror.Append(resErr, fmt.Errorf("parse %v: %v: %w", file.ModuleName, funcDeclName(decl), err))
 				continue
 			}
 			if fn.Recv != nil {
@@ -976,6 +1537,7 @@ declsLoop:
 			}
 			fn.DocComment = docComments[decl.Pos()]
 			ir.Funcs[FuncGoIdent(fn)] = fn
+			ir.addDirectives(FuncGoIdent(fn), ParseDirectives(decl.Doc))
 		case *ast.GenDecl:
 			if decl.Tok == token.CONST || decl.Tok == token.VAR {
 				if typeDeclsOnly {
@@ -1052,12 +1614,15 @@ declsLoop:
 								Type: *typ,
 								Name: name,
 							}
+							ir.addDirectives(name.Name, ParseDirectives(decl.Doc, valSpec.Doc))
 						}
 					}
 				}
 			} else if decl.Tok == token.TYPE {
 				if typeSpec, ok := decl.Specs[0].(*ast.TypeSpec); ok {
-					if !typeSpec.Name.IsExported() {
+					if !typeSpec.Name.IsExported() || isGenericAlias(typeSpec) {
+						// Generic aliases are expanded where
+						// instantiated (see expandGenericAliases).
 						continue
 					}
 					switch typ := typeSpec.Type.(type) {
@@ -1067,6 +1632,7 @@ declsLoop:
 							return nil, err
 						}
 						ir.Interfaces[iface.Name.Name] = iface
+						ir.addDirectives(iface.Name.Name, ParseDirectives(decl.Doc, typeSpec.Doc))
 						for _, id := range iface.Inherits {
 							if refF, ok := id.GetReferencedPackage(modNames, iface.Name.File); ok {
 								requiredPkgs[refF.ModulePath] = struct{}{}
@@ -1079,6 +1645,12 @@ declsLoop:
 							continue
 						}
 						ir.Structs[struc.Name.Name] = struc
+						ir.addDirectives(struc.Name.Name, ParseDirectives(decl.Doc, typeSpec.Doc))
+						for _, f := range typ.Fields.List {
+							for _, name := range f.Names {
+								ir.addDirectives(struc.Name.Name+"."+name.Name, ParseDirectives(f.Doc))
+							}
+						}
 						for _, id := range struc.Inherits {
 							if refF, ok := id.GetReferencedPackage(modNames, struc.Name.File); ok {
 								requiredPkgs[refF.ModulePath] = struct{}{}
@@ -1094,7 +1666,19 @@ declsLoop:
 							resErr = multierror.Append(resErr, fmt.Errorf("typedef for %v: %w", name.Name, err))
 							continue
 						}
+						if typeSpec.Assign.IsValid() && isAliasableNamedType(typ) {
+							// Alias of a named type (type A = pkg.B): A and
+							// pkg.B are the same type, so all uses of A are
+							// replaced by pkg.B in resolveAliases.
+							ir.Aliases[name.Name] = id
+							ir.addDirectives(name.Name, ParseDirectives(decl.Doc, typeSpec.Doc))
+							if refF, ok := id.GetReferencedPackage(modNames, file); ok && refF != nil {
+								requiredPkgs[refF.ModulePath] = struct{}{}
+							}
+							continue
+						}
 						ir.Typedefs[name.Name] = id
+						ir.addDirectives(name.Name, ParseDirectives(decl.Doc, typeSpec.Doc))
 					}
 				}
 			}
@@ -1103,6 +1687,263 @@ declsLoop:
 	return
 }
 
+// isAliasableNamedType reports whether expr is an exported named type
+// (e.g. "B" or "pkg.B"), which an alias declaration can forward to.
+func isAliasableNamedType(expr ast.Expr) bool {
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		return expr.IsExported()
+	case *ast.SelectorExpr:
+		_, ok := expr.X.(*ast.Ident)
+		return ok && expr.Sel.IsExported()
+	}
+	return false
+}
+
+// ResolveAlias returns the type id refers to with all aliases (see
+// [IR.Aliases]) replaced by the named types they forward to, following
+// chains across packages (e.g. a.A = b.B = c.C gives c.C). Aliases
+// nested in composite types (e.g. map[string]a.A or func(a.A)) are
+// resolved as well.
+func (ir *IR) ResolveAlias(modNames UniqueModuleNames, id Ident) Ident {
+	switch id.Expr.(type) {
+	case *ast.Ident, *ast.SelectorExpr:
+		if target, ok := ir.aliasTarget(id.Name); ok {
+			return target
+		}
+		return id
+	}
+	if len(ir.Aliases) == 0 || id.Expr == nil || id.File == nil {
+		return id
+	}
+	// Targets may be declared in packages id.File doesn't import, so
+	// they are referred to through imports added to a copy of it.
+	var file *File
+	importName := func(pkg *File) string {
+		if file == nil {
+			file = &File{
+				Name:          id.File.Name,
+				ModuleName:    id.File.ModuleName,
+				ModulePath:    id.File.ModulePath,
+				ImportsByName: maps.Clone(id.File.ImportsByName),
+				ImportsByPath: maps.Clone(id.File.ImportsByPath),
+				TypeNames:     id.File.TypeNames,
+				Constraint:    id.File.Constraint,
+				Fset:          id.File.Fset,
+			}
+		}
+		name := pkg.ModuleName
+		for i := 2; ; i++ {
+			if imp, ok := file.ImportsByName[name]; !ok || imp.ModulePath == pkg.ModulePath {
+				break
+			}
+			name = fmt.Sprintf("%v%v", pkg.ModuleName, i)
+		}
+		file.ImportsByName[name] = pkg
+		return name
+	}
+	resolveNamed := func(expr ast.Expr) ast.Expr {
+		named, err := NewIdent(ir.ConstValues, modNames, id.File, expr)
+		if err != nil {
+			return expr
+		}
+		target, ok := ir.aliasTarget(named.Name)
+		if !ok || len(target.UsedImports) != 1 {
+			return expr
+		}
+		var typeName string
+		switch texpr := target.Expr.(type) {
+		case *ast.Ident:
+			typeName = texpr.Name
+		case *ast.SelectorExpr:
+			typeName = texpr.Sel.Name
+		default:
+			return expr
+		}
+		pkg := target.UsedImports[0]
+		if pkg.ModulePath == id.File.ModulePath {
+			return &ast.Ident{NamePos: expr.Pos(), Name: typeName}
+		}
+		return &ast.SelectorExpr{
+			X:   &ast.Ident{NamePos: expr.Pos(), Name: importName(pkg)},
+			Sel: &ast.Ident{Name: typeName},
+		}
+	}
+
+	var resolve func(expr ast.Expr) ast.Expr
+	resolveFields := func(fields *ast.FieldList) *ast.FieldList {
+		if fields == nil {
+			return nil
+		}
+		res := fields
+		for i, field := range fields.List {
+			typ := resolve(field.Type)
+			if typ == field.Type {
+				continue
+			}
+			if res == fields {
+				res = &ast.FieldList{Opening: fields.Opening, List: slices.Clone(fields.List), Closing: fields.Closing}
+			}
+			newField := *field
+			newField.Type = typ
+			res.List[i] = &newField
+		}
+		return res
+	}
+	resolve = func(expr ast.Expr) ast.Expr {
+		switch expr := expr.(type) {
+		case *ast.Ident, *ast.SelectorExpr:
+			return resolveNamed(expr)
+		case *ast.StarExpr:
+			if x := resolve(expr.X); x != expr.X {
+				return &ast.StarExpr{Star: expr.Star, X: x}
+			}
+		case *ast.ParenExpr:
+			if x := resolve(expr.X); x != expr.X {
+				return &ast.ParenExpr{Lparen: expr.Lparen, X: x, Rparen: expr.Rparen}
+			}
+		case *ast.ArrayType:
+			if elt := resolve(expr.Elt); elt != expr.Elt {
+				return &ast.ArrayType{Lbrack: expr.Lbrack, Len: expr.Len, Elt: elt}
+			}
+		case *ast.Ellipsis:
+			if elt := resolve(expr.Elt); elt != expr.Elt {
+				return &ast.Ellipsis{Ellipsis: expr.Ellipsis, Elt: elt}
+			}
+		case *ast.MapType:
+			key, value := resolve(expr.Key), resolve(expr.Value)
+			if key != expr.Key || value != expr.Value {
+				return &ast.MapType{Map: expr.Map, Key: key, Value: value}
+			}
+		case *ast.ChanType:
+			if value := resolve(expr.Value); value != expr.Value {
+				return &ast.ChanType{Begin: expr.Begin, Arrow: expr.Arrow, Dir: expr.Dir, Value: value}
+			}
+		case *ast.FuncType:
+			params, results := resolveFields(expr.Params), resolveFields(expr.Results)
+			if params != expr.Params || results != expr.Results {
+				return &ast.FuncType{Func: expr.Func, TypeParams: expr.TypeParams, Params: params, Results: results}
+			}
+		case *ast.StructType:
+			if fields := resolveFields(expr.Fields); fields != expr.Fields {
+				return &ast.StructType{Struct: expr.Struct, Fields: fields, Incomplete: expr.Incomplete}
+			}
+		case *ast.InterfaceType:
+			if methods := resolveFields(expr.Methods); methods != expr.Methods {
+				return &ast.InterfaceType{Interface: expr.Interface, Methods: methods, Incomplete: expr.Incomplete}
+			}
+		}
+		return expr
+	}
+
+	expr := resolve(id.Expr)
+	if expr == id.Expr {
+		return id
+	}
+	if file == nil {
+		file = id.File
+	}
+	res, err := NewIdent(ir.ConstValues, modNames, file, expr)
+	if err != nil {
+		return id
+	}
+	return res
+}
+
+// aliasTarget returns the named type the alias name forwards to,
+// following chains of aliases. Reports false if name isn't an alias.
+func (ir *IR) aliasTarget(name string) (Ident, bool) {
+	target, ok := ir.Aliases[name]
+	if !ok {
+		return Ident{}, false
+	}
+	// Chains are finite in valid Go; the limit only guards against
+	// cycles in broken input.
+	for range 100 {
+		next, ok := ir.Aliases[target.Name]
+		if !ok {
+			break
+		}
+		target = next
+	}
+	return target, true
+}
+
+// Replaces all uses of aliases by the types they forward to, so each
+// type is known by a single name (see [IR.ResolveAlias]).
+func (ir *IR) resolveAliases(modNames UniqueModuleNames) {
+	if len(ir.Aliases) == 0 {
+		return
+	}
+	resolve := func(id *Ident) {
+		*id = ir.ResolveAlias(modNames, *id)
+	}
+	resolveNamed := func(ids []NamedIdent) {
+		for i := range ids {
+			resolve(&ids[i].Type)
+		}
+	}
+	resolveFunc := func(fn *Func) {
+		if fn.Recv != nil {
+			recv := ir.ResolveAlias(modNames, *fn.Recv)
+			fn.Recv = &recv
+		}
+		resolveNamed(fn.Params)
+		resolveNamed(fn.Results)
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(ir.Funcs)) {
+		fn := ir.Funcs[key]
+		resolveFunc(fn)
+		if newKey := FuncGoIdent(fn); newKey != key {
+			// Method declared on an alias.
+			delete(ir.Funcs, key)
+			ir.Funcs[newKey] = fn
+			if ds, ok := ir.Directives[key]; ok {
+				delete(ir.Directives, key)
+				ir.addDirectives(newKey, ds)
+			}
+		}
+	}
+	// Methods declared on an alias belong to the aliased type.
+	typeMethods := make(map[string][]*Func, len(ir.TypeMethods))
+	for _, typ := range slices.Sorted(maps.Keys(ir.TypeMethods)) {
+		for _, fn := range ir.TypeMethods[typ] {
+			typeMethods[fn.Recv.Name] = append(typeMethods[fn.Recv.Name], fn)
+		}
+	}
+	ir.TypeMethods = typeMethods
+	for _, struc := range ir.Structs {
+		resolveNamed(struc.Fields)
+		for i := range struc.Inherits {
+			resolve(&struc.Inherits[i])
+		}
+	}
+	for _, iface := range ir.Interfaces {
+		for _, fn := range iface.Funcs {
+			resolveNamed(fn.Params)
+			resolveNamed(fn.Results)
+		}
+		for i := range iface.Inherits {
+			resolve(&iface.Inherits[i])
+		}
+	}
+	for name, v := range ir.Values {
+		resolve(&v.Type)
+		ir.Values[name] = v
+	}
+	for name, typ := range ir.Typedefs {
+		resolve(&typ)
+		ir.Typedefs[name] = typ
+	}
+	for name, impls := range ir.MarkerImpls {
+		for i := range impls {
+			resolve(&impls[i])
+		}
+		ir.MarkerImpls[name] = impls
+	}
+}
+
 // Resolves interface, struct, and method inheritance
 func (ir *IR) resolveInheritancesAndMethods(modNames UniqueModuleNames) (resErr error) {
 	var resolveInheritedIfaces func(iface *Interface) error
@@ -1208,6 +2049,14 @@ func (ir *IR) resolveInheritancesAndMethods(modNames UniqueModuleNames) (resErr
 					methods = append(methods, fn)
 					numMethodNameOccurrences[fn.Name.Name]++
 				}
+			} else if iface, exists := ir.Interfaces[inh.Name]; exists {
+				// Embedded interfaces are kept as a field of the
+				// interface type (e.g. Reader io.Reader), whose methods
+				// are promoted (inherited interfaces are resolved above).
+				for _, fn := range iface.Funcs {
+					methods = append(methods, fn)
+					numMethodNameOccurrences[fn.Name.Name]++
+				}
 			} else {
 				return errors.New("struct inheritance " + inh.Name + " from " + inh.File.ModulePath + " is unknown")
 			}
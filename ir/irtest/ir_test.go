@@ -1,10 +1,23 @@
 package irtest_test
 
 import (
+	"go/ast"
+	"go/constant"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/mod/module"
 
+	"github.com/refaktor/ryegen/ir"
 	"github.com/refaktor/ryegen/ir/irtest"
 )
 
@@ -36,3 +49,396 @@ func TestDocComments(t *testing.T) {
 Very useful.
 `)
 }
+
+func TestUnnamedParams(t *testing.T) {
+	assert := assert.New(t)
+
+	irData, _ := irtest.ParseSingleFile(t, "testdata/unnamed_params.go")
+	names := func(params []ir.NamedIdent) []string {
+		var res []string
+		for _, p := range params {
+			res = append(res, p.Name.Name)
+		}
+		return res
+	}
+	fn := irData.Funcs["testmodule.Process"]
+	assert.Equal([]string{"ctx", "reader", "opts", "s", "s2", "data", "t", "args"}, names(fn.Params))
+	assert.Equal([]string{"n", "err"}, names(fn.Results))
+	assert.True(fn.Params[0].Unnamed)
+	named := irData.Funcs["testmodule.Named"]
+	assert.Equal([]string{"ctx", "name"}, names(named.Params))
+	assert.False(named.Params[0].Unnamed)
+}
+
+func TestPositions(t *testing.T) {
+	assert := assert.New(t)
+
+	irData, _ := irtest.ParseSingleFile(t, "testdata/doc_comments.go")
+	pos := irData.Funcs["testmodule.AddTwoInts"].Name.Position()
+	assert.Equal("doc_comments.go", pos.Filename)
+	assert.Equal(5, pos.Line)
+	assert.Equal(6, pos.Column)
+}
+
+func TestPackageDoc(t *testing.T) {
+	assert := assert.New(t)
+
+	irData, _ := irtest.ParseSingleFile(t, "testdata/package_doc.go")
+	assert.Equal(irData.PackageDocs["test.module/tm"], `Package testfile does testing things.
+
+It is very useful.
+`)
+}
+
+func TestDirectives(t *testing.T) {
+	assert := assert.New(t)
+
+	irData, _ := irtest.ParseSingleFile(t, "testdata/directives.go")
+	assert.Equal(irData.Directives["testmodule.Hidden"], []ir.Directive{{Name: "exclude", Args: []string{}}})
+	assert.Equal(irData.Directives["testmodule.Renamed"], []ir.Directive{{Name: "rename", Args: []string{"do-things"}}})
+	assert.Equal(irData.Funcs["testmodule.Renamed"].DocComment, "Renamed does things.\n")
+	assert.Equal(irData.Directives["testmodule.Thing"], []ir.Directive{{Name: "exclude", Args: []string{}}})
+	assert.Equal(irData.Directives["testmodule.Thing.V"], []ir.Directive{{Name: "rename", Args: []string{"value"}}})
+}
+
+func TestAliases(t *testing.T) {
+	assert := assert.New(t)
+
+	irData, _ := irtest.ParseSingleFileWithDeps(t, "testdata/aliases.go", map[string]string{
+		"aliasdep":  "testdata/aliasdep.go",
+		"aliasbase": "testdata/aliasbase.go",
+	})
+	paramType := func(fn string, i int) string {
+		return irData.Funcs[fn].Params[i].Type.Name
+	}
+	resultType := func(fn string, i int) string {
+		return irData.Funcs[fn].Results[i].Type.Name
+	}
+	assert.Equal("testmodule.Thing", paramType("testmodule.UseDirect", 0))
+	assert.Equal("testmodule.Thing", resultType("testmodule.UseDirect", 0))
+	assert.Equal("*testmodule.Thing", paramType("testmodule.UsePtr", 0))
+	assert.Equal("[]testmodule.Thing", paramType("testmodule.UseSlice", 0))
+	assert.Equal("[]testmodule.Thing", paramType("testmodule.UseVariadic", 0))
+	assert.True(irData.Funcs["testmodule.UseVariadic"].Params[0].Type.IsEllipsis)
+	assert.Equal("aliasbase.Base", paramType("testmodule.UseForwarded", 0))
+	assert.Equal("*aliasbase.Base", resultType("testmodule.UseForwarded", 0))
+	// Aliases nested in composite types are resolved, including
+	// targets in packages the using file doesn't import.
+	assert.Equal("map[string]testmodule.Thing", paramType("testmodule.UseMap", 0))
+	assert.Equal("map[aliasbase.Base][2]*testmodule.Thing", paramType("testmodule.UseNested", 0))
+	assert.Equal("<-chan []aliasbase.Base", paramType("testmodule.UseNested", 1))
+	assert.Equal("func(testmodule.Thing) (aliasbase.Base, error)", paramType("testmodule.UseNested", 2))
+	assert.Equal("[]aliasbase.Base", irData.Structs["testmodule.Holder"].Fields[1].Type.Name)
+	assert.Equal("testmodule.Thing", irData.Values["testmodule.Default"].Type.Name)
+
+	// Methods declared on an alias belong to the aliased type.
+	assert.Nil(irData.Funcs["testmodule.Alias.Get"])
+	assert.NotNil(irData.Funcs["testmodule.Thing.Get"])
+	assert.Len(irData.TypeMethods["testmodule.Thing"], 1)
+	assert.Empty(irData.TypeMethods["testmodule.Alias"])
+	assert.Contains(irData.Structs["testmodule.Thing"].Methods, "Get")
+	assert.Contains(irData.Structs["testmodule.Holder"].Methods, "Get")
+	assert.Contains(irData.Structs["testmodule.Holder"].Methods, "Reset")
+
+	// Aliases aren't distinct types, so no uses of them remain.
+	assert.NotContains(irData.Typedefs, "testmodule.Alias")
+	assert.NotContains(irData.Typedefs, "testmodule.Forwarded")
+	aliasRe := regexp.MustCompile(`\b(testmodule\.(Alias|AliasAlias|Forwarded)|aliasdep\.Fwd)\b`)
+	for name, fn := range irData.Funcs {
+		for _, p := range slices.Concat(fn.Params, fn.Results) {
+			assert.NotRegexp(aliasRe, p.Type.Name, name)
+		}
+	}
+	for name, struc := range irData.Structs {
+		for _, f := range struc.Fields {
+			assert.NotRegexp(aliasRe, f.Type.Name, name)
+		}
+	}
+	assert.Equal("aliasbase.Base", irData.ResolveAlias(nil, irData.Aliases["testmodule.Forwarded"]).Name)
+}
+
+func TestEmbeddedInterfaces(t *testing.T) {
+	assert := assert.New(t)
+
+	irData, _ := irtest.ParseSingleFileWithDeps(t, "testdata/embedded_ifaces.go", map[string]string{
+		"ifacedep": "testdata/ifacedep.go",
+	})
+	struc := irData.Structs["testmodule.File"]
+	var fields []string
+	for _, f := range struc.Fields {
+		fields = append(fields, f.Name.Name+" "+f.Type.Name)
+	}
+	assert.Equal([]string{"Closer testmodule.Closer", "Reader ifacedep.Reader", "Name string"}, fields)
+	// Methods of embedded interfaces are promoted.
+	assert.Contains(struc.Methods, "Close")
+	assert.Contains(struc.Methods, "Read")
+	assert.Equal("testmodule.File", struc.Methods["Read"].Recv.Name)
+	assert.NotNil(irData.Funcs["testmodule.File.Read"])
+}
+
+func TestGenericAliases(t *testing.T) {
+	assert := assert.New(t)
+
+	irData, _, err := irtest.TryParseSingleFileWithDeps(t, "testdata/generic_aliases.go", nil)
+	paramType := func(fn string, i int) string {
+		return irData.Funcs[fn].Params[i].Type.Name
+	}
+	resultType := func(fn string, i int) string {
+		return irData.Funcs[fn].Results[i].Type.Name
+	}
+	// Instantiations are expanded to the aliased types.
+	assert.Equal("testmodule.Thing", paramType("testmodule.UseTagged", 0))
+	assert.Equal("[]testmodule.Thing", paramType("testmodule.UseChained", 0))
+	assert.Equal("func(string) (error)", paramType("testmodule.UseHandler", 0))
+	assert.Equal("func(*testmodule.Thing) (error)", resultType("testmodule.UseHandler", 0))
+	assert.Equal("struct{V testmodule.Thing}", paramType("testmodule.UseBox", 0))
+	assert.Equal("testmodule.Thing", paramType("testmodule.UseThings", 0))
+	assert.Equal("testmodule.Thing", paramType("testmodule.UseThing", 0))
+
+	// Generic aliases aren't types by themselves.
+	assert.Contains(irData.GenericAliases, "testmodule.Seq")
+	for _, name := range []string{"Seq", "StringPair", "Tagged", "Chained", "Handler", "Box", "Getter", "Num"} {
+		assert.NotContains(irData.Aliases, "testmodule."+name)
+		assert.NotContains(irData.Typedefs, "testmodule."+name)
+		assert.NotContains(irData.Structs, "testmodule."+name)
+		assert.NotContains(irData.Interfaces, "testmodule."+name)
+	}
+
+	// Instantiations of generic types stay unsupported, without
+	// affecting the rest of the package.
+	assert.Nil(irData.Funcs["testmodule.UseSeq"])
+	assert.EqualError(err, "1 error occurred:\n\t* parse testfile: UseSeq: invalid type expression List[int]: generic type instantiations are unsupported\n\n")
+}
+
+func TestGenericAliasesOfDependencies(t *testing.T) {
+	assert := assert.New(t)
+
+	// Parse by hand to check that the ASTs are left as they are.
+	fset := token.NewFileSet()
+	parse := func(path string) *ast.File {
+		f, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return f
+	}
+	root := parse("testdata/generic_aliases_dep.go")
+	modNames := ir.UniqueModuleNames{"test.module/tm": "testmodule", "genericaliasdep": "genericaliasdep"}
+	irData, err := ir.Parse(
+		modNames,
+		map[string]string(modNames),
+		[]ir.IRInputFileInfo{{File: root, Name: "testmodule", ModulePath: "test.module/tm", Fset: fset}},
+		func(modulePath string) (map[string]*ast.File, error) {
+			assert.Equal("genericaliasdep", modulePath)
+			return map[string]*ast.File{"genericaliasdep": parse("testdata/genericaliasdep.go")}, nil
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Generic aliases of dependencies are known before the declarations
+	// using them are expanded, even though nothing else requires the
+	// dependency.
+	assert.Contains(irData.GenericAliases, "genericaliasdep.Slice")
+	assert.Equal("[]int", irData.Funcs["testmodule.UseSlice"].Params[0].Type.Name)
+	assert.Equal("[]string", irData.Funcs["testmodule.UseThings"].Params[0].Type.Name)
+	assert.Equal("*genericaliasdep.Thing", irData.Funcs["testmodule.UsePtr"].Params[0].Type.Name)
+
+	var params []string
+	for _, decl := range root.Decls {
+		if decl, ok := decl.(*ast.FuncDecl); ok {
+			params = append(params, types.ExprString(decl.Type.Params.List[0].Type))
+		}
+	}
+	assert.Equal([]string{
+		"genericaliasdep.Slice[int]",
+		"genericaliasdep.Things[string]",
+		"genericaliasdep.Ptr[genericaliasdep.Thing]",
+	}, params)
+}
+
+// TestTypeExprsExhaustive checks that every go/ast expression node type
+// is either supported as a type by ir.NewIdent or documented as
+// unsupported, so new node types (e.g. in a future Go version) don't
+// only show up as errors deep inside generation.
+//
+// The node types are listed in testdata/ast_expr_nodes.txt, which is
+// checked against the go/ast source of the Go installation running the
+// test, if available.
+func TestTypeExprsExhaustive(t *testing.T) {
+	assert := assert.New(t)
+
+	data, err := os.ReadFile("testdata/ast_expr_nodes.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var exprNodes []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+			exprNodes = append(exprNodes, line)
+		}
+	}
+
+	astSrc := filepath.Join(runtime.GOROOT(), "src", "go", "ast", "ast.go")
+	if f, err := parser.ParseFile(token.NewFileSet(), astSrc, nil, parser.SkipObjectResolution); err == nil {
+		var srcExprNodes []string
+		for _, decl := range f.Decls {
+			fd, ok := decl.(*ast.FuncDecl)
+			if !ok || fd.Name.Name != "exprNode" || fd.Recv == nil {
+				continue
+			}
+			srcExprNodes = append(srcExprNodes, "*ast."+types.ExprString(fd.Recv.List[0].Type.(*ast.StarExpr).X))
+		}
+		if !assert.ElementsMatch(srcExprNodes, exprNodes, "go/ast of %v changed, update testdata/ast_expr_nodes.txt", runtime.Version()) {
+			exprNodes = srcExprNodes
+		}
+	} else {
+		t.Log("go/ast source unavailable, only using testdata/ast_expr_nodes.txt:", err)
+	}
+
+	assert.NotEmpty(exprNodes)
+	for _, node := range exprNodes {
+		_, unsupported := ir.UnsupportedTypeExprs[node]
+		supported := slices.Contains(ir.SupportedTypeExprs, node)
+		assert.Truef(supported != unsupported, "%v must be in exactly one of ir.SupportedTypeExprs and ir.UnsupportedTypeExprs", node)
+	}
+	for _, node := range ir.SupportedTypeExprs {
+		assert.Contains(exprNodes, node)
+	}
+	for node := range ir.UnsupportedTypeExprs {
+		assert.Contains(exprNodes, node)
+	}
+
+	_, err = ir.NewIdent(nil, nil, nil, &ast.IndexExpr{X: &ast.Ident{Name: "List"}, Index: &ast.Ident{Name: "int"}})
+	assert.EqualError(err, "invalid type expression List[int]: generic type instantiations are unsupported")
+}
+
+func TestUniqueModuleNames(t *testing.T) {
+	assert := assert.New(t)
+
+	names, err := ir.NewUniqueModuleNames(map[string]string{
+		"go/types":                          "types",
+		"example.com/a/types":               "types",
+		"example.com/b/types":               "types",
+		"example.com/b/v2/types":            "types",
+		"github.com/go-gl/glfw":             "glfw",
+		"github.com/other-org/glfw":         "glfw",
+		"example.com/env":                   "env",
+		"github.com/refaktor/rye/env":       "env",
+		"example.com/lib/string":            "string",
+		"example.com/prefer/internal/types": "types",
+	}, "example.com/prefer", map[string]string{
+		"env": "github.com/refaktor/rye/env",
+	})
+	assert.NoError(err)
+	assert.Equal("types", names["go/types"])
+	assert.Equal("internal_types", names["example.com/prefer/internal/types"])
+	assert.Equal("a_types", names["example.com/a/types"])
+	assert.Equal("b_types", names["example.com/b/types"])
+	assert.Equal("example_com_b_types", names["example.com/b/v2/types"])
+	assert.Equal("glfw", names["github.com/go-gl/glfw"])
+	assert.Equal("other_org_glfw", names["github.com/other-org/glfw"])
+	assert.Equal("env", names["github.com/refaktor/rye/env"])
+	assert.Equal("example_com_env", names["example.com/env"])
+	assert.Equal("lib_string", names["example.com/lib/string"])
+	for path, name := range names {
+		assert.True(token.IsIdentifier(name), "%v: %v", path, name)
+	}
+}
+
+func TestMajorVersionConflicts(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError(ir.MajorVersionConflicts([]module.Version{
+		{Path: "example.com/a", Version: "v1.2.0"},
+		{Path: "example.com/b/v2", Version: "v2.0.1"},
+	}, nil))
+
+	chains := ir.RequirementChains("example.com/root", map[string][]string{
+		"example.com/root": {"example.com/b", "example.com/a"},
+		"example.com/a":    {"example.com/x/v3", "example.com/b"},
+		"example.com/b":    {"example.com/x/v3", "example.com/c"},
+		"example.com/c":    {"example.com/root"},
+	})
+	assert.Equal(map[string][]string{
+		"example.com/root": {"example.com/root"},
+		"example.com/a":    {"example.com/root", "example.com/a"},
+		"example.com/b":    {"example.com/root", "example.com/b"},
+		// Shortest chain, ties broken by module path.
+		"example.com/x/v3": {"example.com/root", "example.com/a", "example.com/x/v3"},
+		"example.com/c":    {"example.com/root", "example.com/b", "example.com/c"},
+	}, chains)
+
+	err := ir.MajorVersionConflicts([]module.Version{
+		{Path: "example.com/root", Version: "v1.0.0"},
+		{Path: "example.com/x", Version: "v1.4.0"},
+		{Path: "example.com/x/v3", Version: "v3.1.0"},
+	}, chains)
+	if assert.Error(err) {
+		assert.Contains(err.Error(), "multiple major versions of example.com/x in build")
+		assert.Contains(err.Error(), "example.com/x/v3@v3.1.0 (required via example.com/root -> example.com/a -> example.com/x/v3)")
+		// Not reachable through direct requirements.
+		assert.Contains(err.Error(), "example.com/x@v1.4.0,")
+	}
+}
+
+func TestMissingDependencyChain(t *testing.T) {
+	assert := assert.New(t)
+
+	// aliasbase, required by aliasdep, fails to load.
+	irData, _, err := irtest.TryParseSingleFileWithDeps(t, "testdata/aliases.go", map[string]string{
+		"aliasdep":  "testdata/aliasdep.go",
+		"aliasbase": "",
+	})
+	if assert.Error(err) {
+		assert.Contains(err.Error(), "load aliasbase: getDependency: failed to load aliasbase; required by: test.module/tm -> aliasdep -> aliasbase")
+	}
+	// Declarations not depending on the package are still there.
+	if assert.NotNil(irData) {
+		assert.NotNil(irData.Funcs["testmodule.UseDirect"])
+	}
+}
+
+func TestConstCalls(t *testing.T) {
+	assert := assert.New(t)
+
+	irData, modNames := irtest.ParseSingleFileWithDeps(t, "testdata/const_calls.go", map[string]string{
+		"constcalldep": "testdata/constcalldep.go",
+	})
+	file := irData.ConstValues["testmodule.Length"].File
+	eval := func(src string) (constant.Value, error) {
+		t.Helper()
+		expr, err := parser.ParseExpr(src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ir.EvalConstExpr(irData.ConstValues, modNames, file, expr)
+	}
+	for name, want := range map[string]constant.Value{
+		"Length":    constant.MakeInt64(3),
+		"Converted": constant.MakeInt64(5),
+		"Basic":     constant.MakeInt64(3),
+		"Biggest":   constant.MakeInt64(5),
+		"Smallest":  constant.MakeInt64(2),
+		"Real":      constant.MakeFloat64(1),
+		"Imag":      constant.MakeFloat64(2),
+		"DepFlags":  constant.MakeInt64(3),
+	} {
+		v, err := eval(name)
+		if assert.NoError(err, name) {
+			assert.True(constant.Compare(want, token.EQL, v), "%v: expected %v, got %v", name, want, v)
+		}
+	}
+
+	for src, wantErr := range map[string]string{
+		"Length(1)":              "const call of Length, which isn't a type",
+		"len(1)":                 "const call of len with Int argument",
+		"complex(1)":             "const call of complex with 1 arguments",
+		"constcalldep.Double(3)": "const call of constcalldep.Double, which isn't a type",
+		"Mode(1, 2)":             "unexpected const conversion with 2 arguments",
+	} {
+		_, err := eval(src)
+		assert.EqualError(err, wantErr, src)
+	}
+}
@@ -0,0 +1,5 @@
+package aliasbase
+
+type Base struct {
+	V string
+}
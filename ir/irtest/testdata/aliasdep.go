@@ -0,0 +1,5 @@
+package aliasdep
+
+import "aliasbase"
+
+type Fwd = aliasbase.Base
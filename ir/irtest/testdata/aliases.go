@@ -0,0 +1,42 @@
+package testfile
+
+import "aliasdep"
+
+type Thing struct {
+	N int
+}
+
+func (t *Thing) Reset() {}
+
+// Same-package alias.
+type Alias = Thing
+
+// Alias of an alias.
+type AliasAlias = Alias
+
+// Alias of an alias in another package, which forwards to a third one.
+type Forwarded = aliasdep.Fwd
+
+// Method declared on an alias.
+func (a Alias) Get() int { return a.N }
+
+func UseDirect(a Alias) AliasAlias { return a }
+
+func UsePtr(a *Alias) {}
+
+func UseSlice(as []AliasAlias) {}
+
+func UseVariadic(as ...Alias) {}
+
+func UseForwarded(f Forwarded) *aliasdep.Fwd { return nil }
+
+func UseMap(m map[string]Alias) {}
+
+func UseNested(m map[Forwarded][2]*AliasAlias, ch <-chan []Forwarded, fn func(Alias) (Forwarded, error)) {}
+
+type Holder struct {
+	Alias
+	Items []Forwarded
+}
+
+var Default Alias
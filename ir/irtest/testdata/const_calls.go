@@ -0,0 +1,21 @@
+package testfile
+
+import "constcalldep"
+
+type Mode uint32
+
+const (
+	Length    = len("abc")
+	Converted = Mode(5)
+	Basic     = int64(len("ab") + 1)
+	Biggest   = max(1, 5, 3)
+	Smallest  = min(4, 2)
+	Real      = real(complex(1, 2))
+	Imag      = imag(complex(1, 2))
+	DepFlags  = constcalldep.Flags(3)
+)
+
+// Embeds a type of the dependency, so it's loaded.
+type Embedding struct {
+	constcalldep.Base
+}
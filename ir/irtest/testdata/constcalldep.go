@@ -0,0 +1,7 @@
+package constcalldep
+
+type Flags int
+
+type Base struct{}
+
+func Double(x int) int { return 2 * x }
@@ -0,0 +1,15 @@
+package testfile
+
+//ryegen:exclude
+func Hidden() {}
+
+// Renamed does things.
+//
+//ryegen:rename do-things
+func Renamed() {}
+
+//ryegen:exclude
+type Thing struct {
+	//ryegen:rename value
+	V int
+}
@@ -0,0 +1,14 @@
+package testfile
+
+import "ifacedep"
+
+type Closer interface {
+	Close() error
+}
+
+// Embeds an interface of the same and of another package.
+type File struct {
+	Closer
+	ifacedep.Reader
+	Name string
+}
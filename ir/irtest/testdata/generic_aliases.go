@@ -0,0 +1,65 @@
+package testfile
+
+type List[T any] struct {
+	Items []T
+}
+
+type Pair[K comparable, V any] struct {
+	Key K
+	Val V
+}
+
+type Thing struct {
+	N int
+}
+
+// Generic alias of a generic type.
+type Seq[T any] = List[T]
+
+// Generic alias with several type params.
+type StringPair[V any] = Pair[string, V]
+
+// Generic alias of a non-generic type.
+type Tagged[T any] = Thing
+
+// Generic alias of a generic alias.
+type Chained[T any] = Tagged[T]
+
+// Generic alias of a func type.
+type Handler[T any] = func(T) error
+
+// Generic alias of a struct type.
+type Box[T any] = struct {
+	V T
+}
+
+// Generic alias of an interface.
+type Getter[T any] = interface {
+	Get() T
+}
+
+// Generic alias of a constraint.
+type Num[T any] = interface {
+	~int | ~float64
+	Add(T) T
+}
+
+// Alias of an instantiation.
+type Things = Tagged[int]
+
+// Plain alias next to generic ones.
+type ThingAlias = Thing
+
+func UseSeq(s Seq[int]) {}
+
+func UseTagged(t Tagged[string]) {}
+
+func UseChained(c []Chained[int]) {}
+
+func UseHandler(h Handler[string]) Handler[*Thing] { return nil }
+
+func UseBox(b Box[Thing]) {}
+
+func UseThings(t Things) {}
+
+func UseThing(t ThingAlias) {}
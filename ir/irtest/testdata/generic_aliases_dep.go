@@ -0,0 +1,9 @@
+package testfile
+
+import "genericaliasdep"
+
+func UseSlice(s genericaliasdep.Slice[int]) {}
+
+func UseThings(s genericaliasdep.Things[string]) {}
+
+func UsePtr(p genericaliasdep.Ptr[genericaliasdep.Thing]) {}
@@ -0,0 +1,14 @@
+package genericaliasdep
+
+type Thing struct {
+	N int
+}
+
+// Generic alias of a slice.
+type Slice[T any] = []T
+
+// Generic alias of a generic alias.
+type Things[T any] = Slice[T]
+
+// Generic alias of a pointer.
+type Ptr[T any] = *T
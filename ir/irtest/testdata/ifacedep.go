@@ -0,0 +1,5 @@
+package ifacedep
+
+type Reader interface {
+	Read(p []byte) (n int, err error)
+}
@@ -0,0 +1,6 @@
+// Package testfile does testing things.
+//
+// It is very useful.
+package testfile
+
+func DoNothing() {}
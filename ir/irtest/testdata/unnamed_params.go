@@ -0,0 +1,15 @@
+package testmodule
+
+import (
+	"context"
+	"io"
+	"reflect"
+)
+
+type Options struct{}
+
+func Process(context.Context, io.Reader, *Options, string, string, []byte, reflect.Type, ...any) (int, error) {
+	return 0, nil
+}
+
+func Named(ctx context.Context, name string) {}
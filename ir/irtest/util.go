@@ -7,20 +7,63 @@ import (
 	"go/token"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/go-multierror"
 	"github.com/refaktor/ryegen/ir"
 )
 
 func ParseSingleFile(t *testing.T, path string) (*ir.IR, ir.UniqueModuleNames) {
 	t.Helper()
+	return ParseSingleFileInModule(t, path, "test.module/tm")
+}
+
+// ParseSingleFileInModule is like [ParseSingleFile], but the file is
+// part of the package modulePath (still named "testmodule"), e.g. to
+// test internal packages.
+func ParseSingleFileInModule(t *testing.T, path, modulePath string) (*ir.IR, ir.UniqueModuleNames) {
+	t.Helper()
+	return mustParse(t, path, modulePath, nil)
+}
+
+// ParseSingleFileWithDeps is like [ParseSingleFile], but the file may
+// import the packages in deps (package path to file path), e.g. "dep"
+// for a file declaring package dep. Each package consists of a single
+// file, and its path must be its name.
+func ParseSingleFileWithDeps(t *testing.T, path string, deps map[string]string) (*ir.IR, ir.UniqueModuleNames) {
+	t.Helper()
+	return mustParse(t, path, "test.module/tm", deps)
+}
+
+// TryParseSingleFileWithDeps is like [ParseSingleFileWithDeps], but
+// returns the non-fatal errors of [ir.Parse] instead of failing the test.
+// Packages in deps with an empty file path fail to load.
+func TryParseSingleFileWithDeps(t *testing.T, path string, deps map[string]string) (*ir.IR, ir.UniqueModuleNames, error) {
+	t.Helper()
+	return parseSingleFile(t, path, "test.module/tm", deps)
+}
+
+func mustParse(t *testing.T, path, modulePath string, deps map[string]string) (*ir.IR, ir.UniqueModuleNames) {
+	t.Helper()
+	irData, modNames, err := parseSingleFile(t, path, modulePath, deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return irData, modNames
+}
+
+func parseFile(t *testing.T, fset *token.FileSet, path string) *ast.File {
+	t.Helper()
 
 	fileRd, err := os.Open(path)
 	if err != nil {
 		t.Fatal(err)
 	}
+	defer fileRd.Close()
 	file, err := parser.ParseFile(
-		token.NewFileSet(),
+		fset,
 		filepath.Base(path),
 		fileRd,
 		parser.SkipObjectResolution|parser.ParseComments,
@@ -28,13 +71,40 @@ func ParseSingleFile(t *testing.T, path string) (*ir.IR, ir.UniqueModuleNames) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	modNames := ir.UniqueModuleNames{"test.module/tm": "testmodule"}
-	modDefaultNames := map[string]string{"test.module/tm": "testmodule"}
+	return file
+}
+
+// parseSingleFile fails the test on fatal errors of [ir.Parse] and
+// returns non-fatal ones.
+func parseSingleFile(t *testing.T, path, modulePath string, deps map[string]string) (*ir.IR, ir.UniqueModuleNames, error) {
+	t.Helper()
+
+	fset := token.NewFileSet()
+	file := parseFile(t, fset, path)
+	modNames := ir.UniqueModuleNames{modulePath: "testmodule"}
+	modDefaultNames := map[string]string{modulePath: "testmodule"}
+	for depPath := range deps {
+		modNames[depPath] = depPath
+		modDefaultNames[depPath] = depPath
+	}
+	for _, imp := range file.Imports {
+		// Only imports of standard library packages (e.g. "unsafe" or
+		// "math/big") and deps are supported, since their names are
+		// known without parsing them.
+		impPath, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		name := impPath[strings.LastIndex(impPath, "/")+1:]
+		modNames[impPath] = name
+		modDefaultNames[impPath] = name
+	}
 	input := []ir.IRInputFileInfo{
 		{
 			File:       file,
 			Name:       "testmodule",
-			ModulePath: "test.module/tm",
+			ModulePath: modulePath,
+			Fset:       fset,
 		},
 	}
 	irData, err := ir.Parse(
@@ -42,12 +112,19 @@ func ParseSingleFile(t *testing.T, path string) (*ir.IR, ir.UniqueModuleNames) {
 		modDefaultNames,
 		input,
 		func(modulePath string) (map[string]*ast.File, error) {
-			return nil, fmt.Errorf("getDependency not implemented")
+			depPath, ok := deps[modulePath]
+			if !ok {
+				return nil, fmt.Errorf("getDependency: unknown package %v", modulePath)
+			}
+			if depPath == "" {
+				return nil, fmt.Errorf("getDependency: failed to load %v", modulePath)
+			}
+			return map[string]*ast.File{depPath: parseFile(t, fset, depPath)}, nil
 		},
 	)
-	if err != nil {
+	if _, ok := err.(*multierror.Error); err != nil && !ok {
 		t.Fatal(err)
 	}
 
-	return irData, modNames
+	return irData, modNames, err
 }
@@ -0,0 +1,252 @@
+package ir
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/iancoleman/strcase"
+	"golang.org/x/mod/module"
+)
+
+// modulePathElementVersion parses strings like "v2", "v3" etc.
+func modulePathElementVersion(s string) int {
+	if strings.HasPrefix(s, "v") {
+		ver, err := strconv.Atoi(s[1:])
+		if err == nil && ver >= 1 {
+			return ver
+		}
+	}
+	return -1
+}
+
+// removeModulePathVersionElements removes all "v2", "v3" etc. parts.
+func removeModulePathVersionElements(s string) string {
+	sp := strings.Split(s, "/")
+	spOut := []string{}
+	for _, v := range sp {
+		if modulePathElementVersion(v) == -1 {
+			spOut = append(spOut, v)
+		}
+	}
+	return strings.Join(spOut, "/")
+}
+
+// MajorVersionConflicts returns an error for each module of which more
+// than one major version is part of the build (e.g. example.com/x and
+// example.com/x/v2). Types from different major versions are distinct,
+// so mixing them leads to confusing conversion errors in bindings.
+//
+// chains maps a module path to the chain of module paths requiring it,
+// starting at the bound module (see [RequirementChains]), and is used to
+// name the origin of each version in the diagnostic. Versions without a
+// chain are listed without their origin.
+func MajorVersionConflicts(mods []module.Version, chains map[string][]string) error {
+	byPrefix := make(map[string][]module.Version)
+	for _, m := range mods {
+		prefix, _, ok := module.SplitPathVersion(m.Path)
+		if !ok {
+			prefix = m.Path
+		}
+		if !slices.Contains(byPrefix[prefix], m) {
+			byPrefix[prefix] = append(byPrefix[prefix], m)
+		}
+	}
+
+	var resErr error
+	for _, prefix := range slices.Sorted(maps.Keys(byPrefix)) {
+		vers := byPrefix[prefix]
+		if len(vers) < 2 {
+			continue
+		}
+		slices.SortFunc(vers, func(a, b module.Version) int {
+			return strings.Compare(a.Path, b.Path)
+		})
+		var desc []string
+		for _, v := range vers {
+			s := v.Path
+			if v.Version != "" {
+				s += "@" + v.Version
+			}
+			if chain := chains[v.Path]; len(chain) > 1 {
+				s += " (required via " + strings.Join(chain, " -> ") + ")"
+			}
+			desc = append(desc, s)
+		}
+		resErr = multierror.Append(resErr, fmt.Errorf(
+			"multiple major versions of %v in build: %v; their types are distinct, so bindings mixing them will fail to convert; update dependencies to require a single major version",
+			prefix, strings.Join(desc, ", "),
+		))
+	}
+	return resErr
+}
+
+// RequirementChains returns the shortest chain of module paths by which
+// root requires each module reachable from it, starting at root.
+// requires maps a module path to the paths of the modules its go.mod
+// requires. Ties are broken by module path.
+func RequirementChains(root string, requires map[string][]string) map[string][]string {
+	chains := map[string][]string{root: {root}}
+	queue := []string{root}
+	for len(queue) > 0 {
+		mod := queue[0]
+		queue = queue[1:]
+		for _, req := range slices.Sorted(slices.Values(requires[mod])) {
+			if _, ok := chains[req]; ok {
+				continue
+			}
+			chains[req] = append(slices.Clip(chains[mod]), req)
+			queue = append(queue, req)
+		}
+	}
+	return chains
+}
+
+// Order of importance (descending):
+// - Part of stdlib
+// - Prefix of preferPkg
+// - Shorter path (ignoring version numbers)
+// - Smaller string according to strings.Compare (ignoring version numbers)
+// - Larger version number (e.g. v2, v3)
+func makeCompareModulePaths(preferPkg string) func(a, b string) int {
+	return func(a, b string) int {
+		aOrig, bOrig := a, b
+		a, b = removeModulePathVersionElements(a), removeModulePathVersionElements(b)
+		{
+			aSp := strings.SplitN(a, "/", 2)
+			bSp := strings.SplitN(b, "/", 2)
+			if len(aSp) > 0 && len(bSp) > 0 {
+				aStd := !strings.Contains(aSp[0], ".")
+				bStd := !strings.Contains(bSp[0], ".")
+				if aStd && !bStd {
+					return -1
+				} else if !aStd && bStd {
+					return 1
+				}
+			}
+		}
+		if preferPkg != "" {
+			aPfx := strings.HasPrefix(aOrig, preferPkg)
+			bPfx := strings.HasPrefix(bOrig, preferPkg)
+			if aPfx && !bPfx {
+				return -1
+			} else if !aPfx && bPfx {
+				return 1
+			}
+		}
+		if len(a) < len(b) {
+			return -1
+		} else if len(a) > len(b) {
+			return 1
+		}
+		if a > b {
+			return -1
+		} else if a < b {
+			return 1
+		}
+		{
+			aSp := strings.Split(aOrig, "/")
+			bSp := strings.Split(bOrig, "/")
+			if len(aSp) >= 1 && len(bSp) >= 1 {
+				if len(aSp) == len(bSp) &&
+					modulePathElementVersion(aSp[len(aSp)-1]) > modulePathElementVersion(bSp[len(bSp)-1]) {
+					return -1
+				}
+				if len(aSp) == len(bSp)+1 &&
+					modulePathElementVersion(aSp[len(aSp)-1]) > 1 {
+					return -1
+				}
+				if len(aSp) == len(bSp) &&
+					modulePathElementVersion(aSp[len(aSp)-1]) < modulePathElementVersion(bSp[len(bSp)-1]) {
+					return 1
+				}
+				if len(aSp)+1 == len(bSp) &&
+					modulePathElementVersion(bSp[len(bSp)-1]) > 1 {
+					return 1
+				}
+			}
+		}
+		return strings.Compare(aOrig, bOrig)
+	}
+}
+
+// identFromPathElem turns a module path element into a valid
+// Go identifier part (e.g. "go-gl" => "go_gl", "fyne.io" => "fyne_io").
+func identFromPathElem(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return r
+		}
+		return '_'
+	}, strcase.ToSnake(s))
+	return s
+}
+
+// NewUniqueModuleNames assigns each module path in modDefaultNames (module path
+// to name declared in the "package <name>" line) a unique name usable as an
+// import qualifier.
+//
+// If the default name is already taken, previous path elements are prepended
+// (e.g. github.com/username/reponame/resources/audio => "resources_audio",
+// then "reponame_resources_audio" etc.). If that doesn't suffice, a
+// numeric discriminator is appended.
+//
+// Modules prefixed by preferPkg get first pick. Go keywords and predeclared
+// identifiers are never used. reserved maps identifiers used by generated
+// code to the only module path allowed to use them as a qualifier
+// ("" if none).
+func NewUniqueModuleNames(modDefaultNames map[string]string, preferPkg string, reserved map[string]string) (UniqueModuleNames, error) {
+	res := make(UniqueModuleNames)
+	res["C"] = "C"
+
+	modPaths := make([]string, 0, len(modDefaultNames))
+	for k := range modDefaultNames {
+		modPaths = append(modPaths, k)
+	}
+	slices.SortFunc(modPaths, makeCompareModulePaths(preferPkg))
+
+	existing := make(map[string]struct{})
+	isTaken := func(modPath, name string) bool {
+		if _, exists := existing[name]; exists {
+			return true
+		}
+		if token.IsKeyword(name) || types.Universe.Lookup(name) != nil {
+			return true
+		}
+		if allowed, ok := reserved[name]; ok && allowed != modPath {
+			return true
+		}
+		return false
+	}
+	for _, modPath := range modPaths {
+		modPathElems := strings.Split(removeModulePathVersionElements(modPath), "/")
+		nameComponents := []string{modDefaultNames[modPath]}
+		for isTaken(modPath, strings.Join(nameComponents, "_")) && len(modPathElems) > 0 {
+			lastElem := identFromPathElem(modPathElems[len(modPathElems)-1])
+			modPathElems = modPathElems[:len(modPathElems)-1]
+			if lastElem == "" || slices.Contains(nameComponents, lastElem) {
+				continue
+			}
+			if unicode.IsDigit(rune(lastElem[0])) {
+				lastElem = "_" + lastElem
+			}
+			nameComponents = append([]string{lastElem}, nameComponents...)
+		}
+		name := strings.Join(nameComponents, "_")
+		for i := 2; isTaken(modPath, name); i++ {
+			name = strings.Join(nameComponents, "_") + strconv.Itoa(i)
+		}
+		if !token.IsIdentifier(name) {
+			return nil, fmt.Errorf("cannot create unique module name for %v", modPath)
+		}
+		res[modPath] = name
+		existing[name] = struct{}{}
+	}
+	return res, nil
+}
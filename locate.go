@@ -0,0 +1,95 @@
+package ryegen
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/refaktor/ryegen/binder"
+)
+
+// sourceCommentPrefix starts the comment at the top of every generated
+// builtin's body, naming the binding (as in bindings.txt) and the
+// converters used by it.
+const sourceCommentPrefix = "//ryegen:source "
+
+var (
+	builtinEntryRe = regexp.MustCompile(`^\s*m\["(.*)"\] = &env\.Builtin\{`)
+	topLevelDeclRe = regexp.MustCompile(`^(?:func|type|var|const) (?:\([^)]*\) )?(\w+)`)
+)
+
+// sourceComment returns the source comment of a builtin.
+func sourceComment(bindingName string, convUsage map[binder.ConvID]int) string {
+	convs := make([]string, 0, len(convUsage))
+	for id := range convUsage {
+		convs = append(convs, id.String())
+	}
+	slices.Sort(convs)
+	if len(convs) == 0 {
+		return sourceCommentPrefix + bindingName
+	}
+	return sourceCommentPrefix + bindingName + " converters=" + strings.Join(convs, ",")
+}
+
+// declAtLine describes what produced the 1-based line in generated
+// code: the binding (and its converters) if the line is in a builtin,
+// otherwise the enclosing top-level declaration (e.g. a converter
+// helper like ctxTo_...), or "unknown" if the line is between
+// declarations.
+func declAtLine(lines []string, line int) string {
+	target := min(line, len(lines)) - 1
+	decl := "unknown"
+	for i := 0; i <= target; i++ {
+		if m := builtinEntryRe.FindStringSubmatch(lines[i]); m != nil {
+			end := builtinEnd(lines, i)
+			if target <= end {
+				for _, l := range lines[i : end+1] {
+					if s, ok := strings.CutPrefix(strings.TrimSpace(l), sourceCommentPrefix); ok {
+						return "binding " + s
+					}
+				}
+				return "binding " + m[1]
+			}
+			i = end
+			continue
+		}
+		if m := topLevelDeclRe.FindStringSubmatch(lines[i]); m != nil {
+			decl = m[1]
+			if !strings.HasSuffix(lines[i], "{") && !strings.HasSuffix(lines[i], "(") && i < target {
+				// Single-line declaration.
+				decl = "unknown"
+			}
+		} else if (strings.HasPrefix(lines[i], "}") || strings.HasPrefix(lines[i], ")")) && i < target {
+			decl = "unknown"
+		}
+	}
+	return decl
+}
+
+// builtinEnd returns the index of the line closing the builtin entry
+// starting at lines[start], or the last line if there is none.
+func builtinEnd(lines []string, start int) int {
+	indent := lines[start][:len(lines[start])-len(strings.TrimLeft(lines[start], " \t"))]
+	for i := start + 1; i < len(lines); i++ {
+		if lines[i] == indent+"}" {
+			return i
+		}
+	}
+	return len(lines) - 1
+}
+
+// Locate describes which binding or declaration produced the 1-based
+// line in a file generated by ryegen.
+func Locate(path string, line int) (string, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	lines := strings.Split(string(src), "\n")
+	if line < 1 || line > len(lines) {
+		return "", fmt.Errorf("%v: line %v out of range (file has %v lines)", path, line, len(lines))
+	}
+	return declAtLine(lines, line), nil
+}
@@ -0,0 +1,64 @@
+package ryegen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const locateTestSrc = `package greet
+
+var builtinsGenerated = makeBuiltinsGenerated()
+
+func makeBuiltinsGeneratedChunk0(m map[string]*env.Builtin) {
+	// Returns a greeting.
+	m["greet-hello"] = &env.Builtin{
+		Doc:   "Returns a greeting.",
+		Argsn: 1,
+		Fn: func(ps *env.ProgramState, arg0, arg1, arg2, arg3, arg4 env.Object) env.Object {
+			//ryegen:source greet.Hello converters=ctxTo_string
+			res := greet.Hello(ctxTo_string(arg0))
+			return *env.NewString(res)
+		},
+	}
+
+	m["greet-bye"] = &env.Builtin{
+		Fn: func(ps *env.ProgramState, arg0, arg1, arg2, arg3, arg4 env.Object) env.Object {
+			return nil
+		},
+	}
+}
+
+func ctxTo_string(v env.Object) string {
+	return v.(env.String).Value
+}
+`
+
+func TestDeclAtLine(t *testing.T) {
+	lines := strings.Split(locateTestSrc, "\n")
+	for _, tt := range []struct {
+		name   string
+		line   string // first line containing this
+		offset int    // added to the line number
+		want   string
+	}{
+		{"package clause", "package greet", 0, "unknown"},
+		{"single-line declaration", "var builtinsGenerated", 0, "builtinsGenerated"},
+		{"between declarations", "var builtinsGenerated", 1, "unknown"},
+		{"builtin entry", `m["greet-hello"]`, 0, "binding greet.Hello converters=ctxTo_string"},
+		{"builtin field before source comment", `Doc:   "Returns a greeting."`, 0, "binding greet.Hello converters=ctxTo_string"},
+		{"source comment", "//ryegen:source", 0, "binding greet.Hello converters=ctxTo_string"},
+		{"builtin body", "res := greet.Hello", 0, "binding greet.Hello converters=ctxTo_string"},
+		{"builtin closing brace", "return *env.NewString(res)", 2, "binding greet.Hello converters=ctxTo_string"},
+		{"line after builtin", "return *env.NewString(res)", 3, "makeBuiltinsGeneratedChunk0"},
+		{"builtin without source comment", "return nil", 0, "binding greet-bye"},
+		{"shared helper", "return v.(env.String).Value", 0, "ctxTo_string"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			// Line numbers are 1-based.
+			line := strings.Count(locateTestSrc[:strings.Index(locateTestSrc, tt.line)], "\n") + 1 + tt.offset
+			assert.Equal(t, tt.want, declAtLine(lines, line), "line %v: %q", line, lines[line-1])
+		})
+	}
+}
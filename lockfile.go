@@ -0,0 +1,94 @@
+package ryegen
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+	"strings"
+
+	"golang.org/x/mod/module"
+)
+
+// lockFilePath is the file recording the exact module versions bindings
+// were generated from, relative to [RunOptions.Dir].
+const lockFilePath = "ryegen.lock"
+
+// lockUpdateEnv sets [RunOptions.UpdateLock] in [Run], like "--update".
+const lockUpdateEnv = "RYEGEN_UPDATE"
+
+// readLockFile reads the module versions in the lock file at path by
+// module path ("std" for the standard library). Returns nil if the file
+// doesn't exist.
+func readLockFile(path string) (map[string]string, error) {
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string]string)
+	sc := bufio.NewScanner(bytes.NewReader(b))
+	for lineNum := 1; sc.Scan(); lineNum++ {
+		ln := strings.TrimSpace(sc.Text())
+		if ln == "" || strings.HasPrefix(ln, "#") {
+			continue
+		}
+		fields := strings.Fields(ln)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("%v:%v: expected module path and version", path, lineNum)
+		}
+		res[fields[0]] = fields[1]
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// marshalLockFile returns the contents of a lock file for mods, sorted
+// by module path.
+func marshalLockFile(mods []module.Version) []byte {
+	mods = slices.Clone(mods)
+	slices.SortFunc(mods, func(a, b module.Version) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+	var b strings.Builder
+	b.WriteString("# Generated by ryegen. Module versions used to generate the bindings.\n")
+	b.WriteString("# Refresh with \"go run ./gen.go --update\" (or " + lockUpdateEnv + "=1).\n")
+	for _, m := range mods {
+		fmt.Fprintf(&b, "%v %v\n", m.Path, m.Version)
+	}
+	return []byte(b.String())
+}
+
+// checkLockFile returns an error listing the differences between the
+// locked versions and the resolved mods.
+func checkLockFile(locked map[string]string, mods []module.Version) error {
+	var diffs []string
+	resolved := make(map[string]struct{}, len(mods))
+	for _, m := range mods {
+		resolved[m.Path] = struct{}{}
+		if v, ok := locked[m.Path]; !ok {
+			diffs = append(diffs, fmt.Sprintf("%v %v is not locked", m.Path, m.Version))
+		} else if v != m.Version {
+			diffs = append(diffs, fmt.Sprintf("%v is locked at %v, but resolved to %v", m.Path, v, m.Version))
+		}
+	}
+	for path, v := range sortedMapAll(locked) {
+		if _, ok := resolved[path]; !ok {
+			diffs = append(diffs, fmt.Sprintf("%v %v is locked, but no longer required", path, v))
+		}
+	}
+	if len(diffs) == 0 {
+		return nil
+	}
+	return fmt.Errorf(
+		"%v doesn't match the resolved module versions (refresh it with \"go run ./gen.go --update\" or %v=1):\n  * %v",
+		lockFilePath, lockUpdateEnv, strings.Join(diffs, "\n  * "),
+	)
+}
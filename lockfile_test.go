@@ -0,0 +1,61 @@
+package ryegen
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/mod/module"
+)
+
+func TestLockFile(t *testing.T) {
+	assert := assert.New(t)
+
+	path := filepath.Join(t.TempDir(), "ryegen.lock")
+	locked, err := readLockFile(path)
+	assert.NoError(err)
+	assert.Nil(locked, "missing lock file")
+
+	mods := []module.Version{
+		{Path: "std", Version: "go1.21.0"},
+		{Path: "example.com/b", Version: "v1.2.0"},
+		{Path: "example.com/a", Version: "v0.1.0"},
+	}
+	data := marshalLockFile(mods)
+	assert.Equal("# Generated by ryegen. Module versions used to generate the bindings.\n"+
+		"# Refresh with \"go run ./gen.go --update\" (or RYEGEN_UPDATE=1).\n"+
+		"example.com/a v0.1.0\n"+
+		"example.com/b v1.2.0\n"+
+		"std go1.21.0\n", string(data))
+	assert.Equal("std", mods[0].Path, "mods aren't sorted in place")
+
+	if err := os.WriteFile(path, append(data, "\n  # comment\n"...), 0666); err != nil {
+		t.Fatal(err)
+	}
+	locked, err = readLockFile(path)
+	assert.NoError(err)
+	assert.Equal(map[string]string{
+		"example.com/a": "v0.1.0",
+		"example.com/b": "v1.2.0",
+		"std":           "go1.21.0",
+	}, locked)
+	assert.NoError(checkLockFile(locked, mods))
+
+	err = checkLockFile(locked, []module.Version{
+		{Path: "std", Version: "go1.21.0"},
+		{Path: "example.com/a", Version: "v0.2.0"},
+		{Path: "example.com/c", Version: "v1.0.0"},
+	})
+	if assert.Error(err) {
+		assert.Contains(err.Error(), "example.com/a is locked at v0.1.0, but resolved to v0.2.0")
+		assert.Contains(err.Error(), "example.com/c v1.0.0 is not locked")
+		assert.Contains(err.Error(), "example.com/b v1.2.0 is locked, but no longer required")
+	}
+
+	if err := os.WriteFile(path, []byte("# comment\nexample.com/a\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	_, err = readLockFile(path)
+	assert.ErrorContains(err, "ryegen.lock:2: expected module path and version")
+}
@@ -2,6 +2,7 @@ package ryegen
 
 import (
 	"cmp"
+	"errors"
 	"fmt"
 	"go/ast"
 	"go/token"
@@ -10,12 +11,16 @@ import (
 	"math"
 	"os"
 	"path/filepath"
+	"runtime"
+	"runtime/debug"
 	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 	"unicode"
 
+	"golang.org/x/mod/modfile"
 	"golang.org/x/mod/module"
 
 	"github.com/hashicorp/go-multierror"
@@ -29,6 +34,10 @@ import (
 	"github.com/refaktor/ryegen/repo"
 )
 
+// GC percentage (see [debug.SetGCPercent]) used if
+// [config.Config.LowMemory] is set.
+const lowMemoryGCPercent = 25
+
 func isEnvEnabled(name string) bool {
 	return !slices.Contains(
 		[]string{"", "0", "false", "no", "off", "disabled"},
@@ -36,95 +45,69 @@ func isEnvEnabled(name string) bool {
 	)
 }
 
-// modulePathElementVersion parses strings like "v2", "v3" etc.
-func modulePathElementVersion(s string) int {
-	if strings.HasPrefix(s, "v") {
-		ver, err := strconv.Atoi(s[1:])
-		if err == nil && ver >= 1 {
-			return ver
-		}
-	}
-	return -1
+// Identifiers used in generated code which must not be used
+// as import qualifiers, mapped to the only module path allowed
+// to use it ("" if none).
+var generatedCodeIdents = map[string]string{
+	"env":               "github.com/refaktor/rye/env",
+	"evaldo":            "github.com/refaktor/rye/evaldo",
+	"loader":            "github.com/refaktor/rye/loader",
+	"debug":             "runtime/debug",
+	"os":                "os",
+	"Aliases":           "",
+	"deprecatedAlias":   "",
+	"semver":            "golang.org/x/mod/semver",
+	"MinRyeVersion":     "",
+	"CheckRyeVersion":   "",
+	"ConvOptions":       "",
+	"SetConvOptions":    "",
+	"convOptionsWord":   "",
+	"convOptionsOf":     "",
+	"convCtx":           "",
+	"newConvCtx":        "",
+	"convTrace":         "",
+	"Assets":            "",
+	"extractAssets":     "",
+	"setupEnv":          "",
+	"Prelude":           "",
+	"LoadPrelude":       "",
+	"Builtins":          "",
+	"boolToInt64":       "",
+	"objectDebugString": "",
+	"ifaceToNative":     "",
+	"errorToRye":        "",
+	"nativeError":       "",
+	"globalsMu":         "",
+	"ps":                "",
+	"self":              "",
+	"fn":                "",
+	"ch":                "",
+	"v":                 "",
+	"val":               "",
+	"vc":                "",
+	"ok":                "",
+	"res":               "",
+	"arg0":              "",
+	"arg1":              "",
+	"arg2":              "",
+	"arg3":              "",
+	"arg4":              "",
 }
 
-// removeModulePathVersionElements removes all "v2", "v3" etc. parts.
-func removeModulePathVersionElements(s string) string {
-	sp := strings.Split(s, "/")
-	spOut := []string{}
-	for _, v := range sp {
-		if modulePathElementVersion(v) == -1 {
-			spOut = append(spOut, v)
-		}
-	}
-	return strings.Join(spOut, "/")
-}
-
-// Order of importance (descending):
-// - Part of stdlib
-// - Prefix of preferPkg
-// - Shorter path (ignoring version numbers)
-// - Smaller string according to strings.Compare (ignoring version numbers)
-// - Larger version number (e.g. v2, v3)
-func makeCompareModulePaths(preferPkg string) func(a, b string) int {
-	return func(a, b string) int {
-		aOrig, bOrig := a, b
-		a, b = removeModulePathVersionElements(a), removeModulePathVersionElements(b)
-		{
-			aSp := strings.SplitN(a, "/", 2)
-			bSp := strings.SplitN(b, "/", 2)
-			if len(aSp) > 0 && len(bSp) > 0 {
-				aStd := !strings.Contains(aSp[0], ".")
-				bStd := !strings.Contains(bSp[0], ".")
-				if aStd && !bStd {
-					return -1
-				} else if !aStd && bStd {
-					return 1
-				}
-			}
-		}
-		if preferPkg != "" {
-			aPfx := strings.HasPrefix(aOrig, preferPkg)
-			bPfx := strings.HasPrefix(bOrig, preferPkg)
-			if aPfx && !bPfx {
-				return -1
-			} else if !aPfx && bPfx {
-				return 1
-			}
-		}
-		if len(a) < len(b) {
-			return -1
-		} else if len(a) > len(b) {
-			return 1
-		}
-		if a > b {
-			return -1
-		} else if a < b {
-			return 1
-		}
-		{
-			aSp := strings.Split(aOrig, "/")
-			bSp := strings.Split(bOrig, "/")
-			if len(aSp) >= 1 && len(bSp) >= 1 {
-				if len(aSp) == len(bSp) &&
-					modulePathElementVersion(aSp[len(aSp)-1]) > modulePathElementVersion(bSp[len(bSp)-1]) {
-					return -1
-				}
-				if len(aSp) == len(bSp)+1 &&
-					modulePathElementVersion(aSp[len(aSp)-1]) > 1 {
-					return -1
-				}
-				if len(aSp) == len(bSp) &&
-					modulePathElementVersion(aSp[len(aSp)-1]) < modulePathElementVersion(bSp[len(bSp)-1]) {
-					return 1
-				}
-				if len(aSp)+1 == len(bSp) &&
-					modulePathElementVersion(bSp[len(bSp)-1]) > 1 {
-					return 1
-				}
-			}
-		}
-		return strings.Compare(aOrig, bOrig)
+// snakeModulePath returns path in lowercase with all characters other
+// than letters and digits replaced by underscores, e.g. "fyne_io_fyne_v2"
+// for "fyne.io/fyne/v2".
+func snakeModulePath(path string) string {
+	var b strings.Builder
+	for _, r := range path {
+		r = unicode.ToLower(r)
+		if (r < 'a' || r > 'z') &&
+			(r < '0' || r > '9') {
+			r = '_'
+		}
+		b.WriteRune(r)
 	}
+	return b.String()
 }
 
 func sortedMapAll[Map ~map[K]V, K cmp.Ordered, V any](m Map) iter.Seq2[K, V] {
@@ -142,8 +125,35 @@ func sortedMapAll[Map ~map[K]V, K cmp.Ordered, V any](m Map) iter.Seq2[K, V] {
 	}
 }
 
+// directRequirements returns the paths of the modules the go.mod in dir
+// requires directly (not marked "// indirect"), or nil if it can't be
+// read.
+func directRequirements(dir string) []string {
+	path := filepath.Join(dir, "go.mod")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	f, err := modfile.ParseLax(path, data, nil)
+	if err != nil {
+		return nil
+	}
+	var res []string
+	for _, r := range f.Require {
+		if !r.Indirect {
+			res = append(res, r.Mod.Path)
+		}
+	}
+	return res
+}
+
 func recursivelyGetRepo(
-	dstPath, pkg, ver string,
+	dstPath, cachePath, proxy, pkg, ver string,
+	// whether ver is pinned in the lock file, so notices aren't checked
+	pinned bool,
+	buildTags []string,
+	// receives the module cache (see [moduleCache])
+	sink OutputSink,
 	onInfo func(msg string),
 ) (
 	// module path to unique (short) module name
@@ -152,38 +162,59 @@ func recursivelyGetRepo(
 	modDirPaths map[string]string,
 	// module path to name (declared in "package <name>" line)
 	modDefaultNames map[string]string,
+	// exact versions of pkg, its requirements and std
+	resolved []module.Version,
+	// deprecation and retraction notices for pkg
+	notices []string,
+	// non-fatal diagnostics
+	warn error,
 	err error,
 ) {
-	modUniqueNames = make(ir.UniqueModuleNames)
 	modDirPaths = make(map[string]string)
 	modDefaultNames = make(map[string]string)
 
 	getRepo := func(pkg, version string) (string, error) {
-		have, dir, _, err := repo.Have(dstPath, pkg, version)
+		have, dir, exactVersion, err := repo.Have(proxy, dstPath, pkg, version)
 		if err != nil {
 			return "", err
 		}
 		if !have {
-			onInfo(fmt.Sprintf("downloading %v %v", pkg, version))
-			_, err := repo.Get(dstPath, pkg, version)
+			onInfo(fmt.Sprintf("downloading %v %v", pkg, exactVersion))
+			_, err := repo.Get(proxy, dstPath, pkg, exactVersion)
 			if err != nil {
 				return "", err
 			}
 		}
+		resolved = append(resolved, module.Version{Path: pkg, Version: exactVersion})
 		return dir, nil
 	}
 
 	srcDir, err := getRepo(pkg, ver)
 	if err != nil {
-		return nil, nil, nil, fmt.Errorf("get repo: %w", err)
+		return nil, nil, nil, nil, nil, nil, fmt.Errorf("get repo: %w", err)
 	}
 
 	{
+		cache := loadModuleCache(cachePath)
+		defer func() {
+			if err := cache.Save(sink, cachePath); err != nil {
+				warn = multierror.Append(warn, err)
+			}
+		}()
+
 		addPkgNames := func(dir, modulePath string) (string, []module.Version, error) {
-			goVer, pkgNms, req, err := parser.ParseDirModules(token.NewFileSet(), dir, modulePath)
-			if err != nil {
-				return "", nil, err
+			cached, ok := cache.Get(dir, modulePath, buildTags)
+			if !ok {
+				var err error
+				cached.GoVer, cached.Modules, cached.Require, err = parser.ParseDirModules(token.NewFileSet(), dir, modulePath, buildTags)
+				if err != nil {
+					return "", nil, err
+				}
+				if err := cache.Put(dir, modulePath, buildTags, cached); err != nil {
+					return "", nil, err
+				}
 			}
+			goVer, pkgNms, req := cached.GoVer, cached.Modules, slices.Clone(cached.Require)
 			for mod, name := range pkgNms {
 				if name != "" {
 					modDefaultNames[mod] = name
@@ -194,62 +225,44 @@ func recursivelyGetRepo(
 		}
 		goVer, req, err := addPkgNames(srcDir, pkg)
 		if err != nil {
-			return nil, nil, nil, fmt.Errorf("parse modules: %w", err)
+			return nil, nil, nil, nil, nil, nil, fmt.Errorf("parse modules: %w", err)
+		}
+		mods := append([]module.Version{{Path: pkg, Version: ver}}, req...)
+		// Direct requirements by module path, to name the chain by
+		// which each module is required in diagnostics.
+		requires := map[string][]string{pkg: directRequirements(srcDir)}
+		// Only pkg's version is chosen here, its requirements'
+		// versions are fixed by its go.mod.
+		// Pinned versions were checked when they were locked.
+		if !pinned {
+			if n, ok := cache.GetNotices(resolved[0]); ok {
+				notices = n
+			} else if n, err := repo.Notices(proxy, pkg, resolved[0].Version); err != nil {
+				warn = multierror.Append(warn, fmt.Errorf("check %v for deprecation and retractions: %w", pkg, err))
+			} else {
+				notices = n
+				cache.PutNotices(resolved[0], n)
+			}
 		}
 		req = append(req, module.Version{Path: "std", Version: goVer})
 		for _, v := range req {
 			dir, err := getRepo(v.Path, v.Version)
 			if err != nil {
-				return nil, nil, nil, fmt.Errorf("get repo: %w", err)
+				return nil, nil, nil, nil, nil, nil, fmt.Errorf("get repo: %w", err)
 			}
 			if _, _, err := addPkgNames(dir, v.Path); err != nil {
-				return nil, nil, nil, fmt.Errorf("parse modules: %w", err)
+				return nil, nil, nil, nil, nil, nil, fmt.Errorf("parse modules: %w", err)
 			}
+			requires[v.Path] = directRequirements(dir)
 		}
-	}
-	modUniqueNames["C"] = "C"
-	{
-		moduleNameKeys := make([]string, 0, len(modDefaultNames))
-		for k := range modDefaultNames {
-			moduleNameKeys = append(moduleNameKeys, k)
-		}
-		slices.SortFunc(moduleNameKeys, makeCompareModulePaths(pkg))
-
-		existingModuleNames := make(map[string]struct{})
-		for _, modPath := range moduleNameKeys {
-			// Create a unique module path. If the default name as declared in the
-			// "package <name>" directive doesn't work, try prepending the previous
-			// element of the path.
-			// Does not repeat name components, or include version numbers like "v2".
-			// Example:
-			// 	modPath = github.com/username/reponame/resources/audio
-			//  "audio" is already taken.
-			//  Try "resources_audio".
-			//  If that's already taken, try "reponame_resources_audio" etc.
-
-			modPathElems := strings.Split(removeModulePathVersionElements(modPath), "/")
-			nameComponents := []string{modDefaultNames[modPath]}
-			for ; func() bool {
-				_, exists := existingModuleNames[strings.Join(nameComponents, "_")]
-				return exists
-			}(); modPathElems = modPathElems[:len(modPathElems)-1] {
-				if len(modPathElems) == 0 {
-					return nil, nil, nil, fmt.Errorf("cannot create unique module name for %v", modPath)
-				}
-
-				lastElem := modPathElems[len(modPathElems)-1]
-				lastElemSnakeCase := strcase.ToSnake(lastElem)
-				if slices.Contains(nameComponents, lastElemSnakeCase) {
-					continue
-				}
-
-				nameComponents = append([]string{lastElemSnakeCase}, nameComponents...)
-			}
-			name := strings.Join(nameComponents, "_")
-			modUniqueNames[modPath] = name
-			existingModuleNames[name] = struct{}{}
+		if err := ir.MajorVersionConflicts(mods, ir.RequirementChains(pkg, requires)); err != nil {
+			warn = multierror.Append(warn, err)
 		}
 	}
+	modUniqueNames, err = ir.NewUniqueModuleNames(modDefaultNames, pkg, generatedCodeIdents)
+	if err != nil {
+		return nil, nil, nil, nil, nil, nil, err
+	}
 
 	return
 }
@@ -262,29 +275,42 @@ func parsePkgs(
 	modUniqueNames ir.UniqueModuleNames,
 	modDirPaths map[string]string,
 	modDefaultNames map[string]string,
+	buildTags []string,
+	// intern identifier names and drop function bodies to reduce
+	// memory usage
+	lowMemory bool,
 ) (
 	irData *ir.IR,
 	genBindingsForPkgs []string,
+	// non-fatal errors, e.g. examples failing to parse
+	warn error,
 	err error,
 ) {
 	var resErr error
 
 	var fileInfo []ir.IRInputFileInfo
 	genBindPkgs := make(map[string]struct{}) // mod paths
+	internedStrs := make(map[string]string)
 
 	parseDirGo := func(dirPath string, modulePath string) error {
-		pkgs, err := parser.ParseDir(token.NewFileSet(), dirPath, modulePath, -1)
+		fset := token.NewFileSet()
+		pkgs, err := parser.ParseDir(fset, dirPath, modulePath, -1, buildTags, lowMemory)
 		if err != nil {
 			return err
 		}
 
 		for _, pkg := range pkgs {
-			for name, f := range pkg.Files {
-				name := strings.TrimPrefix(name, pkgDlPath+string(filepath.Separator))
+			for path, f := range pkg.Files {
+				if lowMemory {
+					parser.InternIdents(f, internedStrs)
+				}
+				name := strings.TrimPrefix(path, pkgDlPath+string(filepath.Separator))
 				fileInfo = append(fileInfo, ir.IRInputFileInfo{
 					File:       f,
 					Name:       name,
 					ModulePath: pkg.Path,
+					Constraint: pkg.Constraints[path],
+					Fset:       fset,
 				})
 			}
 			genBindPkgs[pkg.Path] = struct{}{}
@@ -299,10 +325,10 @@ func parsePkgs(
 	for _, pkg := range pkgs {
 		dirPath, ok := modDirPaths[pkg]
 		if !ok {
-			return nil, nil, fmt.Errorf("unknown package: %v", pkg)
+			return nil, nil, nil, fmt.Errorf("unknown package: %v", pkg)
 		}
 		if err := parseDirGo(dirPath, pkg); err != nil {
-			return nil, nil, err
+			return nil, nil, nil, err
 		}
 	}
 
@@ -315,7 +341,7 @@ func parsePkgs(
 			if !ok {
 				return nil, fmt.Errorf("unknown package: %v", modulePath)
 			}
-			pkgs, err := parser.ParseDir(token.NewFileSet(), dirPath, modulePath, 1)
+			pkgs, err := parser.ParseDir(token.NewFileSet(), dirPath, modulePath, 1, buildTags, lowMemory)
 			if err != nil {
 				return nil, err
 			}
@@ -327,6 +353,9 @@ func parsePkgs(
 					if _, ok := res[name]; ok {
 						return nil, fmt.Errorf("getDependency: duplicate file name %v in package %v", name, pkg.Name)
 					}
+					if lowMemory {
+						parser.InternIdents(f, internedStrs)
+					}
 					res[name] = f
 				}
 			}
@@ -337,11 +366,81 @@ func parsePkgs(
 		if multErr, ok := err.(*multierror.Error); ok {
 			resErr = multierror.Append(resErr, multErr.Errors...)
 		} else {
-			return nil, nil, err
+			return nil, nil, nil, err
 		}
 	}
 
-	return irData, slices.Sorted(maps.Keys(genBindPkgs)), resErr
+	for pkg := range genBindPkgs {
+		dirPath, ok := modDirPaths[pkg]
+		if !ok {
+			continue
+		}
+		examples, err := parser.ParseExamples(token.NewFileSet(), dirPath)
+		if err != nil {
+			// Examples are optional, so bind the package without them.
+			warn = multierror.Append(warn, fmt.Errorf("%v: examples: %w", pkg, err))
+			continue
+		}
+		for name, codes := range examples {
+			// e.g. Button_SetText => widget.Button.SetText
+			key := modUniqueNames[pkg] + "." + strings.ReplaceAll(name, "_", ".")
+			irData.Examples[key] = append(irData.Examples[key], codes...)
+		}
+	}
+
+	return irData, slices.Sorted(maps.Keys(genBindPkgs)), warn, resErr
+}
+
+type funcBindingResult struct {
+	bind *binder.BindingFunc
+	deps *binder.Dependencies
+	err  error
+}
+
+// genFuncBindings generates the bindings of funcs, one goroutine per
+// package (at most GOMAXPROCS at a time, or one in low memory mode).
+// The results are in the order of funcs, regardless of scheduling.
+func genFuncBindings(ctx *binder.Context, funcs []*ir.Func) []funcBindingResult {
+	type indexedResult struct {
+		i int
+		funcBindingResult
+	}
+
+	pkgFuncs := make(map[string][]int) // package to indices into funcs
+	for i, fn := range funcs {
+		pkgFuncs[fn.File.ModulePath] = append(pkgFuncs[fn.File.ModulePath], i)
+	}
+
+	numWorkers := runtime.GOMAXPROCS(0)
+	if ctx.Config.LowMemory {
+		numWorkers = 1
+	}
+	sem := make(chan struct{}, numWorkers)
+	resCh := make(chan indexedResult)
+	var wg sync.WaitGroup
+	for _, indices := range pkgFuncs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			for _, i := range indices {
+				deps := binder.NewDependencies()
+				bind, err := binder.GenerateBinding(deps, ctx, funcs[i])
+				resCh <- indexedResult{i, funcBindingResult{bind, deps, err}}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(resCh)
+	}()
+
+	res := make([]funcBindingResult, len(funcs))
+	for r := range resCh {
+		res[r.i] = r.funcBindingResult
+	}
+	return res
 }
 
 // May return a *multierror.Error in resErr, in which case the error
@@ -349,6 +448,7 @@ func parsePkgs(
 func genBindings(
 	targetPkgs []string,
 	ctx *binder.Context,
+	imports *importGraph,
 ) (
 	bindings []*binder.BindingFunc,
 	genericInterfaceImpls []string,
@@ -357,6 +457,52 @@ func genBindings(
 ) {
 	deps = binder.NewDependencies()
 
+	// Merges the dependencies of a single binding generated with
+	// bindDeps and records its converter usage.
+	// Also drops bindings importing packages in "exclude-imports".
+	addBinding := func(bind *binder.BindingFunc, bindDeps *binder.Dependencies, err error) (*binder.BindingFunc, error) {
+		if err != nil {
+			return nil, err
+		}
+		if len(ctx.Config.ExcludeImports) > 0 {
+			for _, imp := range slices.Sorted(maps.Keys(bindDeps.Imports)) {
+				excl, ok, err := imports.ExcludedImport(imp)
+				if err != nil {
+					return nil, err
+				}
+				if ok {
+					return nil, fmt.Errorf("imports %v, whose init is excluded (via %v)", excl, imp)
+				}
+			}
+		}
+		if err := deps.Merge(bindDeps); err != nil {
+			return nil, err
+		}
+		bind.ConvUsage = bindDeps.ConvUsage
+		bind.ConvDeps = bindDeps.ConvDeps
+		return bind, nil
+	}
+	trackConvUsage := func(gen func(deps *binder.Dependencies) (*binder.BindingFunc, error)) (*binder.BindingFunc, error) {
+		bindDeps := binder.NewDependencies()
+		bind, err := gen(bindDeps)
+		return addBinding(bind, bindDeps, err)
+	}
+
+	// First binding by unique name, to tell which names are taken
+	// without scanning all bindings.
+	boundNames := make(map[string]*binder.BindingFunc)
+	appendBinding := func(bind *binder.BindingFunc) {
+		bindings = append(bindings, bind)
+		name := bind.UniqueName(ctx)
+		if _, ok := boundNames[name]; !ok {
+			boundNames[name] = bind
+		}
+	}
+	isBound := func(bind *binder.BindingFunc) bool {
+		_, ok := boundNames[bind.UniqueName(ctx)]
+		return ok
+	}
+
 	for _, iface := range sortedMapAll(ctx.IR.Interfaces) {
 		if iface.Name.File == nil || ir.IdentIsInternal(ctx.ModNames, iface.Name) {
 			continue
@@ -365,28 +511,76 @@ func genBindings(
 			continue
 		}
 		for _, fn := range iface.Funcs {
-			bind, err := binder.GenerateBinding(deps, ctx, fn)
+			bind, err := trackConvUsage(func(deps *binder.Dependencies) (*binder.BindingFunc, error) {
+				return binder.GenerateBinding(deps, ctx, fn)
+			})
 			if err != nil {
-				resErr = multierror.Append(resErr, fmt.Errorf("%v: %w", fn.String(), err))
+				resErr = multierror.Append(resErr, newBindingError(fn.File.ModulePath, fn.String(), err).at(fn.Name))
 				continue
 			}
-			bindings = append(bindings, bind)
+			appendBinding(bind)
 		}
 	}
 
+	argsBound := make(map[string]struct{})
+	var funcs []*ir.Func
 	for _, fn := range sortedMapAll(ctx.IR.Funcs) {
 		if ir.ModulePathIsInternal(ctx.ModNames, fn.File.ModulePath) || (fn.Recv != nil && ir.IdentIsInternal(ctx.ModNames, *fn.Recv)) {
-			continue
+			// Methods of types in allowed internal packages are bound
+			// opaquely.
+			if fn.Recv == nil || !binder.InternalAllowed(ctx, fn.File.ModulePath) {
+				continue
+			}
 		}
 		if !slices.Contains(targetPkgs, fn.File.ModulePath) {
 			continue
 		}
-		bind, err := binder.GenerateBinding(deps, ctx, fn)
+		if fn.Recv != nil && binder.IsCodegenInternal(ctx, fn.Name.Expr.(*ast.Ident).Name) {
+			continue
+		}
+		if _, ok := ctx.Config.BindArgs[binder.BindArgsKey(fn)]; ok {
+			argsBound[binder.BindArgsKey(fn)] = struct{}{}
+		}
+		funcs = append(funcs, fn)
+	}
+	for i, res := range genFuncBindings(ctx, funcs) {
+		fn := funcs[i]
+		bind, err := addBinding(res.bind, res.deps, res.err)
 		if err != nil {
-			resErr = multierror.Append(resErr, fmt.Errorf("%v: %w", fn.String(), err))
+			resErr = multierror.Append(resErr, newBindingError(fn.File.ModulePath, fn.String(), err).at(fn.Name))
 			continue
 		}
-		bindings = append(bindings, bind)
+		appendBinding(bind)
+	}
+	for name := range sortedMapAll(ctx.Config.BindArgs) {
+		if _, ok := argsBound[name]; !ok {
+			resErr = multierror.Append(resErr, fmt.Errorf("bind-args: %v is not a bound function or method", name))
+		}
+	}
+
+	if ctx.Config.MethodValues {
+		for _, fn := range sortedMapAll(ctx.IR.Funcs) {
+			if fn.Recv == nil || ir.ModulePathIsInternal(ctx.ModNames, fn.File.ModulePath) || ir.IdentIsInternal(ctx.ModNames, *fn.Recv) {
+				continue
+			}
+			if !slices.Contains(targetPkgs, fn.File.ModulePath) {
+				continue
+			}
+			if binder.IsCodegenInternal(ctx, fn.Name.Expr.(*ast.Ident).Name) {
+				continue
+			}
+			bind, err := trackConvUsage(func(deps *binder.Dependencies) (*binder.BindingFunc, error) {
+				return binder.GenerateMethodValue(deps, ctx, fn)
+			})
+			if err != nil {
+				resErr = multierror.Append(resErr, newBindingError(fn.File.ModulePath, fn.String()+" (method value)", err).at(fn.Name))
+				continue
+			}
+			if isBound(bind) {
+				continue
+			}
+			appendBinding(bind)
+		}
 	}
 
 	for _, struc := range sortedMapAll(ctx.IR.Structs) {
@@ -397,8 +591,16 @@ func genBindings(
 			continue
 		}
 		for _, f := range struc.Fields {
+			if binder.IsCodegenInternal(ctx, f.Name.Name) {
+				continue
+			}
 			for _, setter := range []bool{false, true} {
-				bind, err := binder.GenerateGetterOrSetter(deps, ctx, f, struc.Name, setter)
+				if setter && !ctx.Config.SettersEnabled(struc.Name.File.ModulePath) {
+					continue
+				}
+				bind, err := trackConvUsage(func(deps *binder.Dependencies) (*binder.BindingFunc, error) {
+					return binder.GenerateGetterOrSetter(deps, ctx, f, struc.Name, setter)
+				})
 				if err != nil {
 					s := struc.Name.Name + "//" + f.Name.Name
 					if setter {
@@ -406,14 +608,26 @@ func genBindings(
 					} else {
 						s += "?"
 					}
-					resErr = multierror.Append(resErr, fmt.Errorf("%v: %w", s, err))
+					resErr = multierror.Append(resErr, newBindingError(struc.Name.File.ModulePath, s, err).at(f.Name))
+					continue
+				}
+				appendBinding(bind)
+			}
+			if chTyp, ok := f.Type.Expr.(*ast.ChanType); ok && chTyp.Dir != ast.SEND && !slices.Contains(ctx.Config.DisableConverters, config.DisableChan) {
+				bind, err := trackConvUsage(func(deps *binder.Dependencies) (*binder.BindingFunc, error) {
+					return binder.GenerateChannelWatch(deps, ctx, f, struc)
+				})
+				if err != nil {
+					s := struc.Name.Name + "//" + f.Name.Name + "Watch"
+					resErr = multierror.Append(resErr, newBindingError(struc.Name.File.ModulePath, s, err).at(f.Name))
 					continue
 				}
-				bindings = append(bindings, bind)
+				appendBinding(bind)
 			}
 		}
 	}
 
+	synchronized := make(map[string]struct{})
 	for _, value := range sortedMapAll(ctx.IR.Values) {
 		if value.Name.File == nil || ir.IdentIsInternal(ctx.ModNames, value.Name) {
 			continue
@@ -421,15 +635,45 @@ func genBindings(
 		if !slices.Contains(targetPkgs, value.Name.File.ModulePath) {
 			continue
 		}
-		bind, err := binder.GenerateValue(deps, ctx, value)
+		bind, err := trackConvUsage(func(deps *binder.Dependencies) (*binder.BindingFunc, error) {
+			return binder.GenerateValue(deps, ctx, value)
+		})
 		if err != nil {
-			s := value.Name.Name
-			resErr = multierror.Append(resErr, fmt.Errorf("%v: %w", s, err))
+			resErr = multierror.Append(resErr, newBindingError(value.Name.File.ModulePath, value.Name.Name, err).at(value.Name))
 			continue
 		}
-		bindings = append(bindings, bind)
+		appendBinding(bind)
+		if ctx.Config.TypedConsts && binder.IsTypedConst(ctx, value) {
+			bind, err := trackConvUsage(func(deps *binder.Dependencies) (*binder.BindingFunc, error) {
+				return binder.GenerateTypedConst(deps, ctx, value)
+			})
+			if err != nil {
+				resErr = multierror.Append(resErr, newBindingError(value.Name.File.ModulePath, value.Name.Name+" (native)", err).at(value.Name))
+				continue
+			}
+			if !isBound(bind) {
+				appendBinding(bind)
+			}
+		}
+		if binder.IsSynchronizedValue(ctx, value) {
+			synchronized[value.Name.File.ModulePath+"."+value.Name.Expr.(*ast.Ident).Name] = struct{}{}
+			bind, err := trackConvUsage(func(deps *binder.Dependencies) (*binder.BindingFunc, error) {
+				return binder.GenerateValueSetter(deps, ctx, value)
+			})
+			if err != nil {
+				resErr = multierror.Append(resErr, newBindingError(value.Name.File.ModulePath, value.Name.Name+" (setter)", err).at(value.Name))
+				continue
+			}
+			appendBinding(bind)
+		}
+	}
+	for _, name := range ctx.Config.Synchronize {
+		if _, ok := synchronized[name]; !ok {
+			resErr = multierror.Append(resErr, fmt.Errorf("synchronize: %v is not a bound global variable", name))
+		}
 	}
 
+	defaultsUsed := make(map[string]struct{}) // keys of StructDefaults
 	for _, struc := range sortedMapAll(ctx.IR.Structs) {
 		if struc.Name.File == nil || ir.IdentIsInternal(ctx.ModNames, struc.Name) {
 			continue
@@ -437,20 +681,187 @@ func genBindings(
 		if !slices.Contains(targetPkgs, struc.Name.File.ModulePath) {
 			continue
 		}
-		bind, err := binder.GenerateNewStruct(deps, ctx, struc.Name)
+		bind, err := trackConvUsage(func(deps *binder.Dependencies) (*binder.BindingFunc, error) {
+			return binder.GenerateNewStruct(deps, ctx, struc.Name)
+		})
 		if err != nil {
-			s := struc.Name.Name
-			resErr = multierror.Append(resErr, fmt.Errorf("%v: %w", s, err))
+			resErr = multierror.Append(resErr, newBindingError(struc.Name.File.ModulePath, struc.Name.Name, err).at(struc.Name))
 			continue
 		}
-		if !slices.ContainsFunc(bindings, func(b *binder.BindingFunc) bool {
-			return b.UniqueName(ctx) == bind.UniqueName(ctx)
-		}) {
+		if !isBound(bind) {
 			// Only generate NewMyStruct if the function doesn't already exist.
-			bindings = append(bindings, bind)
+			appendBinding(bind)
+			defaultsUsed[binder.StructDefaultsKey(struc.Name)] = struct{}{}
+		}
+	}
+	for name := range sortedMapAll(ctx.Config.StructDefaults) {
+		if _, ok := defaultsUsed[name]; !ok {
+			resErr = multierror.Append(resErr, fmt.Errorf("struct-defaults: %v has no generated struct initializer (e.g. due to an existing New function)", name))
 		}
 	}
 
+	for name := range sortedMapAll(ctx.IR.Typedefs) {
+		// Only types with methods stay natives (see
+		// [binder.ContainerHelperNames]), so get the named type from
+		// a receiver.
+		methods := append(slices.Clone(ctx.IR.TypeMethods[name]), ctx.IR.TypeMethods["*"+name]...)
+		if len(methods) == 0 {
+			continue
+		}
+		typ := *methods[0].Recv
+		if se, ok := typ.Expr.(*ast.StarExpr); ok {
+			var err error
+			typ, err = ir.NewIdent(ctx.IR.ConstValues, ctx.ModNames, typ.File, se.X)
+			if err != nil {
+				resErr = multierror.Append(resErr, err)
+				continue
+			}
+		}
+		if typ.File == nil || !slices.Contains(targetPkgs, typ.File.ModulePath) {
+			continue
+		}
+		for _, name := range binder.ContainerHelperNames(ctx, typ) {
+			bind, err := trackConvUsage(func(deps *binder.Dependencies) (*binder.BindingFunc, error) {
+				return binder.GenerateContainerHelper(deps, ctx, typ, name)
+			})
+			if err != nil {
+				resErr = multierror.Append(resErr, newBindingError(typ.File.ModulePath, typ.Name+" (container helper "+name+")", err).at(typ))
+				continue
+			}
+			if !isBound(bind) {
+				appendBinding(bind)
+			}
+		}
+	}
+
+	if ctx.Config.TypeAssertions {
+		addIfUnbound := func(bind *binder.BindingFunc) {
+			if !isBound(bind) {
+				appendBinding(bind)
+			}
+		}
+		for _, iface := range sortedMapAll(ctx.IR.Interfaces) {
+			if iface.Name.File == nil || ir.IdentIsInternal(ctx.ModNames, iface.Name) {
+				continue
+			}
+			if !slices.Contains(targetPkgs, iface.Name.File.ModulePath) {
+				continue
+			}
+			bind, err := trackConvUsage(func(deps *binder.Dependencies) (*binder.BindingFunc, error) {
+				return binder.GenerateKindOf(deps, ctx, iface)
+			})
+			if err != nil {
+				resErr = multierror.Append(resErr, newBindingError(iface.Name.File.ModulePath, iface.Name.Name+" (kind-of)", err).at(iface.Name))
+				continue
+			}
+			addIfUnbound(bind)
+			bind, err = trackConvUsage(func(deps *binder.Dependencies) (*binder.BindingFunc, error) {
+				return binder.GenerateUpcast(deps, ctx, iface)
+			})
+			if err != nil {
+				resErr = multierror.Append(resErr, newBindingError(iface.Name.File.ModulePath, iface.Name.Name+" (as)", err).at(iface.Name))
+				continue
+			}
+			addIfUnbound(bind)
+		}
+		for _, struc := range sortedMapAll(ctx.IR.Structs) {
+			if struc.Name.File == nil || ir.IdentIsInternal(ctx.ModNames, struc.Name) {
+				continue
+			}
+			if !slices.Contains(targetPkgs, struc.Name.File.ModulePath) {
+				continue
+			}
+			bind, err := trackConvUsage(func(deps *binder.Dependencies) (*binder.BindingFunc, error) {
+				return binder.GenerateDowncast(deps, ctx, struc.Name)
+			})
+			if err != nil {
+				resErr = multierror.Append(resErr, newBindingError(struc.Name.File.ModulePath, struc.Name.Name+" (as)", err).at(struc.Name))
+				continue
+			}
+			addIfUnbound(bind)
+		}
+	}
+
+	for _, pkg := range targetPkgs {
+		for _, gen := range []struct {
+			name string
+			fn   func(*binder.Context, string) (*binder.BindingFunc, error)
+		}{
+			{"help", binder.GenerateHelp},
+			{"list", binder.GenerateList},
+		} {
+			bind, err := gen.fn(ctx, pkg)
+			if err != nil {
+				resErr = multierror.Append(resErr, newBindingError(pkg, pkg+": "+gen.name, err))
+				continue
+			}
+			// Only generate help/list if a function with the same name doesn't already exist.
+			if b, ok := boundNames[bind.UniqueName(ctx)]; ok {
+				taken := cmp.Or(b.GoSymbol, "another builtin")
+				resErr = multierror.Append(resErr, newBindingError(pkg, pkg+": "+gen.name, fmt.Errorf("name %v is taken by %v", bind.UniqueName(ctx), taken)))
+				continue
+			}
+			appendBinding(bind)
+		}
+	}
+
+	if slices.Contains(targetPkgs, "time") {
+		for _, name := range binder.TimeHelperNames {
+			bind, err := trackConvUsage(func(deps *binder.Dependencies) (*binder.BindingFunc, error) {
+				return binder.GenerateTimeHelper(deps, ctx, name)
+			})
+			if err != nil {
+				resErr = multierror.Append(resErr, newBindingError("time", "time helper "+name, err))
+				continue
+			}
+			if !isBound(bind) {
+				// Go's own functions (e.g. time.Now) take precedence.
+				appendBinding(bind)
+			}
+		}
+	}
+
+	if slices.Contains(ctx.Config.Presets, config.PresetBinary) {
+		for _, name := range binder.BinaryHelperNames {
+			bind, err := trackConvUsage(func(deps *binder.Dependencies) (*binder.BindingFunc, error) {
+				return binder.GenerateBinaryHelper(deps, ctx, name)
+			})
+			if err != nil {
+				resErr = multierror.Append(resErr, newBindingError("encoding/binary", "binary helper "+name, err))
+				continue
+			}
+			if !isBound(bind) {
+				appendBinding(bind)
+			}
+		}
+	}
+
+	if slices.Contains(targetPkgs, "reflect") || slices.ContainsFunc(bindings, binder.UsesReflectConverters) {
+		for _, name := range binder.ReflectHelperNames {
+			bind, err := trackConvUsage(func(deps *binder.Dependencies) (*binder.BindingFunc, error) {
+				return binder.GenerateReflectHelper(deps, ctx, name)
+			})
+			if err != nil {
+				resErr = multierror.Append(resErr, newBindingError("reflect", "reflect helper "+name, err))
+				continue
+			}
+			if !isBound(bind) {
+				// Go's own functions (e.g. reflect.TypeOf) take precedence.
+				appendBinding(bind)
+			}
+		}
+	}
+
+	for _, bind := range bindings {
+		if bind.File == nil || bind.File.Constraint == "" {
+			continue
+		}
+		// Platform-specific, so make it visible to scripts (via help
+		// and bindings.txt) which bindings aren't always available.
+		bind.Doc += fmt.Sprintf(" (build: %v)", bind.File.Constraint)
+		bind.DocComment += fmt.Sprintf("Build constraint:\n * %v\n", bind.File.Constraint)
+	}
+
 	genericIfaceImpls := make(map[string]string)
 	for {
 		// Generate interface impls recursively until all are implemented,
@@ -477,89 +888,466 @@ func genBindings(
 	return
 }
 
-func TryRun(
-	onInfo func(msg string),
+// Applies "//ryegen:" directives to bindings not already configured
+// in the binding list.
+// May return a *multierror.Error containing non-fatal errors.
+func applySourceDirectives(ctx *binder.Context, bindings []*binder.BindingFunc, bindingList *config.BindingList, tr *ruleTracer) error {
+	var resErr error
+	for _, bind := range bindings {
+		name := bind.UniqueName(ctx)
+		renamed := false
+		for _, d := range bind.Directives {
+			switch d.Name {
+			case "exclude":
+				if len(d.Args) != 0 {
+					resErr = multierror.Append(resErr, fmt.Errorf("%v: directive %v: expected no arguments", name, d.Name))
+					continue
+				}
+				ok := bindingList.SetDefaultEnabled(name, false)
+				tr.Trace(name, "directive exclude", ok, "disabled")
+			case "rename":
+				if len(d.Args) != 1 {
+					resErr = multierror.Append(resErr, fmt.Errorf("%v: directive %v: expected exactly one argument", name, d.Name))
+					continue
+				}
+				if renamed {
+					resErr = multierror.Append(resErr, fmt.Errorf("%v: directive %v: binding already renamed by a directive", name, d.Name))
+					continue
+				}
+				renamed = true
+				ok := bindingList.SetDefaultRename(name, d.Args[0])
+				tr.Trace(name, "directive rename", ok, "rename candidate "+d.Args[0])
+			default:
+				resErr = multierror.Append(resErr, fmt.Errorf("%v: unknown directive %v", name, d.Name))
+			}
+		}
+	}
+	return resErr
+}
+
+// writeConvOptions writes ConvOptions, SetConvOptions and convCtx to cb,
+// with numericChecks as the default NumericChecks. The options of an
+// interpreter are stored in its context, so they're collected with it.
+func writeConvOptions(cb *binderio.CodeBuilder, numericChecks string) {
+	cb.Linef(`// ConvOptions configures conversions between Rye and Go values of`)
+	cb.Linef(`// an interpreter at runtime (see [SetConvOptions]). Zero values`)
+	cb.Linef(`// keep the generated defaults.`)
+	cb.Linef(`type ConvOptions struct {`)
+	cb.Indent++
+	cb.Linef(`// NumericChecks is how Rye integers are range checked when`)
+	cb.Linef(`// converted to sized Go integers: "strict", "wrap" or "off"`)
+	cb.Linef(`// (default: %v, see the numeric-checks config option).`, strconv.Quote(numericChecks))
+	cb.Linef(`NumericChecks string`)
+	cb.Indent--
+	cb.Linef(`}`)
+	cb.Linef(``)
+	cb.Linef(`// convOptionsWord is the word the ConvOptions of an interpreter are`)
+	cb.Linef(`// bound to in its context, as a native.`)
+	cb.Linef(`const convOptionsWord = "ryegen-conv-options"`)
+	cb.Linef(``)
+	cb.Linef(`// SetConvOptions sets the conversion options of the interpreter ps.`)
+	cb.Linef(`// They're stored in its current context (normally the root context),`)
+	cb.Linef(`// so they apply to code evaluated in it and its child contexts. Call`)
+	cb.Linef(`// it before evaluating code in ps. Setting zero options resets them.`)
+	cb.Linef(`func SetConvOptions(ps *env.ProgramState, opts ConvOptions) {`)
+	cb.Indent++
+	cb.Linef(`if cur, ok := convOptionsOf(ps); ok {`)
+	cb.Indent++
+	cb.Linef(`*cur = opts`)
+	cb.Linef(`return`)
+	cb.Indent--
+	cb.Linef(`}`)
+	cb.Linef(`ps.Ctx.Set(ps.Idx.IndexWord(convOptionsWord), *env.NewNative(ps.Idx, &opts, convOptionsWord))`)
+	cb.Indent--
+	cb.Linef(`}`)
+	cb.Linef(``)
+	cb.Linef(`// convOptionsOf returns the conversion options set for ps, if any.`)
+	cb.Linef(`func convOptionsOf(ps *env.ProgramState) (*ConvOptions, bool) {`)
+	cb.Indent++
+	cb.Linef(`idx, ok := ps.Idx.GetIndex(convOptionsWord)`)
+	cb.Linef(`if !ok || ps.Ctx == nil {`)
+	cb.Indent++
+	cb.Linef(`return nil, false`)
+	cb.Indent--
+	cb.Linef(`}`)
+	cb.Linef(`obj, ok := ps.Ctx.Get(idx)`)
+	cb.Linef(`if !ok {`)
+	cb.Indent++
+	cb.Linef(`return nil, false`)
+	cb.Indent--
+	cb.Linef(`}`)
+	cb.Linef(`nat, ok := obj.(env.Native)`)
+	cb.Linef(`if !ok {`)
+	cb.Indent++
+	cb.Linef(`return nil, false`)
+	cb.Indent--
+	cb.Linef(`}`)
+	cb.Linef(`opts, ok := nat.Value.(*ConvOptions)`)
+	cb.Linef(`return opts, ok`)
+	cb.Indent--
+	cb.Linef(`}`)
+	cb.Linef(``)
+	cb.Linef(`// convCtx is the context of conversions between Rye and Go values,`)
+	cb.Linef(`// carrying the program state and its conversion options.`)
+	cb.Linef(`type convCtx struct {`)
+	cb.Indent++
+	cb.Linef(`ps   *env.ProgramState`)
+	cb.Linef(`opts ConvOptions`)
+	cb.Indent--
+	cb.Linef(`}`)
+	cb.Linef(``)
+	cb.Linef(`func newConvCtx(ps *env.ProgramState) convCtx {`)
+	cb.Indent++
+	cb.Linef(`cc := convCtx{ps: ps}`)
+	cb.Linef(`if opts, ok := convOptionsOf(ps); ok {`)
+	cb.Indent++
+	cb.Linef(`cc.opts = *opts`)
+	cb.Indent--
+	cb.Linef(`}`)
+	cb.Linef(`return cc`)
+	cb.Indent--
+	cb.Linef(`}`)
+	cb.Linef(``)
+	cb.Linef(`func (cc convCtx) numericChecks() string {`)
+	cb.Indent++
+	cb.Linef(`if cc.opts.NumericChecks != "" {`)
+	cb.Indent++
+	cb.Linef(`return cc.opts.NumericChecks`)
+	cb.Indent--
+	cb.Linef(`}`)
+	cb.Linef(`return %v`, strconv.Quote(numericChecks))
+	cb.Indent--
+	cb.Linef(`}`)
+	cb.Linef(``)
+}
+
+// RunResult is the outcome of [TryRun].
+type RunResult struct {
+	// Path of the generated bindings file.
+	OutFile string
+	// Source directory of the bound package in the module cache
+	// (_srcrepos), "" if only the default config was created.
+	SrcDir string
+	// What was generated, with suggested config changes (nil if only
+	// the default config was created).
+	Summary *Summary
+	// More detailed statistics, printed with RYEGEN_STATS=1.
+	Stats string
+	// Non-fatal errors, e.g. bindings dropped due to unhandled type
+	// conversions.
+	Warn error
+}
+
+// RunOptions configures [TryRunWithOptions].
+type RunOptions struct {
+	// Directory the inputs (config.toml, bindings.txt, ...) are read
+	// from and the outputs are written to, "" for the working directory.
+	// Relative paths in the config are relative to it as well, and
+	// output file names (see [OutputSink]) include it.
+	Dir string
+	// Path of the config file, config.toml in Dir if empty. A default
+	// config is created if it doesn't exist.
+	ConfigPath string
+	// Receives all output files, [FileSink] if nil.
+	Sink OutputSink
+	// Called with progress messages, if not nil.
+	OnInfo func(msg string)
+	// Also write an API reference of each bound package to
+	// [docsDirPath] (see the "docs" command of [Run]).
+	Docs bool
+	// Replace the versions in [lockFilePath] with the currently
+	// resolved ones instead of checking them (see "--update" in [Run]).
+	UpdateLock bool
+}
+
+// TryRun generates bindings as configured in config.toml, writing them
+// to the filesystem (see [TryRunWithOptions]).
+func TryRun(onInfo func(msg string)) (*RunResult, error) {
+	return TryRunWithOptions(RunOptions{OnInfo: onInfo})
+}
+
+// TryRunWithSink is like [TryRun], but writes all output files
+// (bindings, bindings.txt, reports) to sink, e.g. to capture them in
+// memory or in a zip archive. Inputs are still read from the working
+// directory. Nothing is written to sink if generation fails.
+func TryRunWithSink(onInfo func(msg string), sink OutputSink) (*RunResult, error) {
+	return TryRunWithOptions(RunOptions{OnInfo: onInfo, Sink: sink})
+}
+
+// TryRunWithOptions generates bindings as configured in opts.
+func TryRunWithOptions(opts RunOptions) (*RunResult, error) {
+	if opts.Sink == nil {
+		opts.Sink = FileSink{}
+	}
+	if opts.OnInfo == nil {
+		opts.OnInfo = func(string) {}
+	}
+	outFile, srcDir, summary, stats, warn, err := tryRun(opts)
+	if err != nil {
+		return nil, err
+	}
+	return &RunResult{
+		OutFile: outFile,
+		SrcDir:  srcDir,
+		Summary: summary,
+		Stats:   stats,
+		Warn:    warn,
+	}, nil
+}
+
+func tryRun(
+	opts RunOptions,
 ) (
 	outFile string,
+	srcDir string,
+	summary *Summary,
 	stats string,
 	warn error,
 	err error,
 ) {
+	onInfo, sink := opts.OnInfo, opts.Sink
+	// inDir returns path relative to opts.Dir.
+	inDir := func(path string) string {
+		if opts.Dir == "" || filepath.IsAbs(path) {
+			return path
+		}
+		return filepath.Join(opts.Dir, path)
+	}
+	timeRunStart := time.Now()
+
 	var cfg *config.Config
 	{
-		const configPath = "config.toml"
+		configPath := opts.ConfigPath
+		if configPath == "" {
+			configPath = inDir("config.toml")
+		}
 		var createdDefault bool
 		var err error
 		cfg, createdDefault, err = config.ReadConfigFromFileOrCreateDefault(configPath)
 		if err != nil {
-			return "", "", nil, fmt.Errorf("open config: %w", err)
+			return "", "", nil, "", nil, fmt.Errorf("open config: %w", err)
 		}
 		if createdDefault {
-			return "", "", fmt.Errorf("created default config at %v", configPath), nil
+			return "", "", nil, "", fmt.Errorf("created default config at %v", configPath), nil
 		}
 	}
 
-	const pkgDlPath = "_srcrepos"
+	// Nothing is written until all outputs are generated (see
+	// staged.commit below).
+	staged := newStagingSink(sink)
+	outputs := newSizeRecordingSink(staged)
+	sink = outputs
+
+	var prelude string
+	if cfg.Prelude != "" {
+		b, err := os.ReadFile(inDir(cfg.Prelude))
+		if err != nil {
+			return "", "", nil, "", nil, fmt.Errorf("read prelude: %w", err)
+		}
+		prelude = string(b)
+	}
+	fullBindingName := snakeModulePath(cfg.Package)
+	if cfg.Library != "" {
+		fullBindingName = cfg.Library
+	}
+	outDir := filepath.Join(inDir(cfg.OutDir), fullBindingName)
+	pkgDlPath := inDir("_srcrepos")
+
+	assets, err := readAssets(opts.Dir, cfg.Assets, []string{
+		outDir,
+		pkgDlPath,
+		filepath.Dir(inDir(moduleCachePath)),
+		inDir(docsDirPath),
+	})
+	if err != nil {
+		return "", "", nil, "", nil, err
+	}
+	hasAssetsFile := len(assets) > 0 || len(cfg.Env) > 0
+
+	lockFilePath := inDir(lockFilePath)
+
+	if cfg.LowMemory {
+		// Collect garbage more often, trading speed for lower peak memory usage.
+		defer debug.SetGCPercent(debug.SetGCPercent(lowMemoryGCPercent))
+	}
+
+	locked, err := readLockFile(lockFilePath)
+	if err != nil {
+		return "", "", nil, "", nil, fmt.Errorf("read lock file: %w", err)
+	}
+	updateLock := opts.UpdateLock
+	version := cfg.Version
+	if v, ok := locked[cfg.Package]; ok && !updateLock && (version == "" || version == "latest") {
+		// Don't move on to a newer latest version until asked to.
+		version = v
+	}
+	pinned := !updateLock && locked[cfg.Package] != "" && locked[cfg.Package] == version
 
 	timeStart := time.Now()
 
 	modUniqueNames,
 		modDirPaths,
 		modDefaultNames,
-		err := recursivelyGetRepo(pkgDlPath, cfg.Package, cfg.Version, onInfo)
+		resolved,
+		repoNotices,
+		repoWarn,
+		err := recursivelyGetRepo(pkgDlPath, inDir(moduleCachePath), cfg.Proxy, cfg.Package, version, pinned, cfg.BuildTags, staged, onInfo)
 	if err != nil {
-		return "", "", nil, fmt.Errorf("get repo: %w", err)
+		return "", "", nil, "", nil, fmt.Errorf("get repo: %w", err)
+	}
+	if repoWarn != nil {
+		warn = multierror.Append(warn, repoWarn)
+	}
+	if locked != nil && !updateLock {
+		if err := checkLockFile(locked, resolved); err != nil {
+			return "", "", nil, "", nil, err
+		}
+	} else if err := sink.WriteFile(lockFilePath, marshalLockFile(resolved)); err != nil {
+		return "", "", nil, "", nil, fmt.Errorf("write lock file: %w", err)
 	}
 
 	timeGetRepos := time.Since(timeStart)
 	timeStart = time.Now()
 
-	irData, genBindingsForPkgs, err := parsePkgs(
+	irData, genBindingsForPkgs, parseWarn, err := parsePkgs(
 		pkgDlPath,
 		append([]string{cfg.Package}, cfg.IncludeStdLibs...),
 		modUniqueNames,
 		modDirPaths,
 		modDefaultNames,
+		cfg.BuildTags,
+		cfg.LowMemory,
 	)
 	if err != nil {
-		return "", "", nil, fmt.Errorf("parse packages: %w", err)
+		return "", "", nil, "", nil, fmt.Errorf("parse packages: %w", err)
+	}
+	if parseWarn != nil {
+		warn = multierror.Append(warn, parseWarn)
+	}
+	if cfg.LowMemory {
+		// Return memory of the parsed but unneeded declarations.
+		debug.FreeOSMemory()
 	}
 
 	timeParse := time.Since(timeStart)
 	timeStart = time.Now()
 
 	ctx := binder.NewContext(cfg, irData, modUniqueNames)
+	if cfg.ReceiverNaming == config.ReceiverNamingShort {
+		var conflicts []string
+		ctx.Kinds, conflicts = binder.ShortKinds(ctx)
+		for _, conflict := range conflicts {
+			warn = multierror.Append(warn, fmt.Errorf("receiver-naming: ambiguous short kind %v, keeping Go names", conflict))
+		}
+	}
+	frozenDirPath := inDir(frozenDirPath)
+	ctx.FrozenConvs, err = readFrozenConvs(frozenDirPath)
+	if err != nil {
+		return "", "", nil, "", nil, fmt.Errorf("read frozen conversions: %w", err)
+	}
 
-	bindings, genericInterfaceImpls, dependencies, err := genBindings(genBindingsForPkgs, ctx)
+	imports := newImportGraph(modDirPaths, cfg.BuildTags, cfg.ExcludeImports)
+	bindings, genericInterfaceImpls, dependencies, err := genBindings(genBindingsForPkgs, ctx, imports)
+	// Bindings excluded by default for passing unsafe pointers, which
+	// aren't warned about.
+	var unsafeExcluded []string
+	// Other non-fatal errors of bindings, which were dropped.
+	var errs []error
 	if err != nil {
 		if multErr, ok := err.(*multierror.Error); ok {
-			warn = multierror.Append(warn, multErr.Errors...)
+			for _, e := range multErr.Errors {
+				var bErr *bindingError
+				if errors.Is(e, binder.ErrUnsafePointers) && errors.As(e, &bErr) {
+					unsafeExcluded = append(unsafeExcluded, bErr.Binding)
+				} else {
+					errs = append(errs, e)
+				}
+			}
+			if cfg.Strict {
+				// Only dropped bindings fail, not configuration problems
+				// (e.g. of bind-args or synchronize), which are warned
+				// about.
+				var dropped []error
+				for _, e := range errs {
+					if _, ok := e.(*bindingError); ok {
+						dropped = append(dropped, e)
+					}
+				}
+				if len(dropped) > 0 {
+					var b strings.Builder
+					for _, e := range dropped {
+						fmt.Fprintf(&b, "\n  * %v", e)
+					}
+					return "", "", nil, "", nil, fmt.Errorf("strict mode: %v bindings dropped:%v", len(dropped), b.String())
+				}
+			}
+			if len(errs) > 0 {
+				warn = multierror.Append(warn, errs...)
+			}
 		} else {
-			return "", "", nil, fmt.Errorf("generate bindings: %w", err)
+			return "", "", nil, "", nil, fmt.Errorf("generate bindings: %w", err)
+		}
+	}
+	for _, key := range unusedFrozenConvs(ctx.FrozenConvs, dependencies.FrozenConvUsage) {
+		warn = multierror.Append(warn, fmt.Errorf("%v: frozen conversion %v is unused", frozenDirPath, key))
+	}
+	for _, name := range slices.Sorted(maps.Keys(dependencies.DictStructs)) {
+		if hidden := ctx.IR.Structs[name].HiddenFields; len(hidden) > 0 {
+			warn = multierror.Append(warn, fmt.Errorf("dict-structs: %v has unexported fields (%v), which are left out of dicts and zero in structs converted from dicts", name, strings.Join(hidden, ", ")))
+		}
+	}
+
+	var kindSpecs string
+	if cfg.KindSpecs {
+		var specs []string
+		for _, struc := range sortedMapAll(ctx.IR.Structs) {
+			if struc.Name.File == nil || ir.IdentIsInternal(ctx.ModNames, struc.Name) {
+				continue
+			}
+			if !slices.Contains(genBindingsForPkgs, struc.Name.File.ModulePath) {
+				continue
+			}
+			spec, err := binder.GenerateKindSpec(ctx, struc)
+			if err != nil {
+				// Not every struct has a meaningful spec (e.g. no
+				// validatable fields), so don't warn.
+				continue
+			}
+			specs = append(specs, spec)
 		}
+		kindSpecs = strings.Join(specs, "\n")
 	}
 
 	timeGenBindings := time.Since(timeStart)
 	timeStart = time.Now()
 
-	const bindingListPath = "bindings.txt"
+	bindingListPath := inDir("bindings.txt")
 	var bindingList *config.BindingList
 	if _, err := os.Stat(bindingListPath); err == nil {
 		var err error
 		bindingList, err = config.LoadBindingListFromFile(bindingListPath)
 		if err != nil {
-			return "", "", nil, err
+			return "", "", nil, "", nil, err
 		}
 	} else {
 		bindingList = config.NewBindingList()
 	}
+	tracer, err := newRuleTracer(os.Getenv("RYEGEN_TRACE_RULES"), onInfo)
+	if err != nil {
+		return "", "", nil, "", nil, err
+	}
+	if cfg.SourceDirectives {
+		if err := applySourceDirectives(ctx, bindings, bindingList, tracer); err != nil {
+			warn = multierror.Append(warn, err)
+		}
+	}
 	{
 		bindingFuncsToDocstrs := make(map[string]string, len(bindings))
 		for _, bind := range bindings {
 			bindingFuncsToDocstrs[bind.UniqueName(ctx)] = bind.Doc
 		}
-		if err := bindingList.SaveToFile(bindingListPath, bindingFuncsToDocstrs); err != nil {
-			return "", "", nil, err
+		if err := sink.WriteFile(bindingListPath, bindingList.Marshal(bindingFuncsToDocstrs)); err != nil {
+			return "", "", nil, "", nil, err
 		}
 	}
 
@@ -568,29 +1356,44 @@ func TryRun(
 
 	dependencies.Imports["github.com/refaktor/rye/env"] = struct{}{}
 	dependencies.Imports["github.com/refaktor/rye/evaldo"] = struct{}{}
+	dependencies.Imports["github.com/refaktor/rye/loader"] = struct{}{}
 	dependencies.Imports["reflect"] = struct{}{}
-
-	var fullBindingName string
-	{
-		var b strings.Builder
-		for _, r := range cfg.Package {
-			r = unicode.ToLower(r)
-			if (r < 'a' || r > 'z') &&
-				(r < '0' || r > '9') {
-				r = '_'
-			}
-			b.WriteRune(r)
-		}
-		fullBindingName = b.String()
+	dependencies.Imports["fmt"] = struct{}{}
+	dependencies.Imports["sort"] = struct{}{}
+	dependencies.Imports["strings"] = struct{}{}
+	dependencies.Imports["sync"] = struct{}{}
+	dependencies.Imports["runtime/debug"] = struct{}{}
+	if cfg.RenameAliases {
+		dependencies.Imports["os"] = struct{}{}
 	}
-
-	outDir := filepath.Join(cfg.OutDir, fullBindingName)
-	if err := os.MkdirAll(outDir, os.ModePerm); err != nil {
-		return "", "", nil, err
+	if cfg.GoAPI {
+		dependencies.Imports["errors"] = struct{}{}
+	}
+	minRyeVersion, hasSemver, err := ryeRequirement(inDir(cfg.OutDir))
+	if err != nil {
+		return "", "", nil, "", nil, fmt.Errorf("read Rye version: %w", err)
+	}
+	if msg := ryeVersionInfo(minRyeVersion, hasSemver); msg != "" {
+		onInfo(msg)
+	} else {
+		dependencies.Imports[semverModulePath+"/semver"] = struct{}{}
 	}
+
 	outFileCustom := filepath.Join(outDir, "custom.go")
 	outFileNot := filepath.Join(outDir, "generated.not.go")
 	outFile = filepath.Join(outDir, "generated.go")
+	outFileData := filepath.Join(outDir, "generated_data.go")
+	outFileDataPkg := filepath.Join(outDir, bindingDataDir, "generated.go")
+
+	// The data tables are split into a package of their own if the
+	// bindings are in a module, so they're compiled separately from the
+	// builtins. Otherwise they can't be imported and stay in the binding
+	// package.
+	dataImportPath, err := packageImportPath(filepath.Dir(outFileDataPkg))
+	if err != nil {
+		return "", "", nil, "", nil, fmt.Errorf("find import path of binding data: %w", err)
+	}
+	splitData := dataImportPath != ""
 
 	if _, err := os.Stat(outFileCustom); os.IsNotExist(err) {
 		var cb binderio.CodeBuilder
@@ -632,7 +1435,7 @@ func TryRun(
 		cb.Indent--
 		cb.Linef(`}`)
 		cb.Linef(`s := ps.Idx.GetWord(nat.Kind.Index)`)
-		cb.Linef(`s = s[3:len(s)-1] // remove surrounding "Go()"`)
+		cb.Linef(`s = strings.TrimSuffix(strings.TrimPrefix(s, "Go("), ")") // remove potential surrounding "Go()"`)
 		cb.Linef(`s = strings.TrimPrefix(s, "*") // remove potential pointer "*"`)
 		cb.Linef(`return *env.NewString(s)`)
 		cb.Indent--
@@ -643,18 +1446,16 @@ func TryRun(
 		cb.Linef(`// Add your custom builtins here:`)
 		cb.Linef(`}`)
 
-		if fmtErr, err := cb.SaveToFile(outFileCustom); err != nil || fmtErr != nil {
-			return "", "", nil, fmt.Errorf("save custom.go: general=%w, fmt=%v", err, fmtErr)
+		if fmtErr, err := saveCode(sink, &cb, outFileCustom); err != nil || fmtErr != nil {
+			return "", "", nil, "", nil, fmt.Errorf("save custom.go: general=%w, fmt=%v", err, fmtErr)
 		}
 	} else if err != nil {
-		return "", "", nil, fmt.Errorf("stat custom.go: %w", err)
+		return "", "", nil, "", nil, fmt.Errorf("stat custom.go: %w", err)
 	}
 
 	if cfg.DontBuildFlag == "" {
-		if _, err := os.Stat(outFileNot); err == nil {
-			if err := os.Remove(outFileNot); err != nil {
-				return "", "", nil, fmt.Errorf("remove %v: %w", outFileNot, err)
-			}
+		if err := removeOutput(sink, outFileNot); err != nil {
+			return "", "", nil, "", nil, err
 		}
 	} else {
 		var cb binderio.CodeBuilder
@@ -665,15 +1466,69 @@ func TryRun(
 		cb.Linef(``)
 		cb.Linef(`package %v`, fullBindingName)
 		cb.Linef(``)
-		cb.Linef(`import "github.com/refaktor/rye/env"`)
+		if len(assets) > 0 {
+			cb.Linef(`import (`)
+			cb.Indent++
+			cb.Linef(`"embed"`)
+			cb.Linef(``)
+			cb.Linef(`"github.com/refaktor/rye/env"`)
+			cb.Indent--
+			cb.Linef(`)`)
+		} else {
+			cb.Linef(`import "github.com/refaktor/rye/env"`)
+		}
 		cb.Linef(``)
 		cb.Linef(`var Builtins = map[string]*env.Builtin{}`)
+		if len(assets) > 0 {
+			cb.Linef(``)
+			cb.Linef(`var Assets embed.FS`)
+		}
+		cb.Linef(``)
+		cb.Linef(`var BuiltinNames []string`)
+		cb.Linef(``)
+		cb.Linef(`var Categories = map[string]map[string][]string{}`)
+		cb.Linef(``)
+		cb.Linef(`var Prefixes = map[string]string{}`)
+		cb.Linef(``)
+		if cfg.RenameAliases {
+			cb.Linef(`var Aliases = map[string]string{}`)
+			cb.Linef(``)
+		}
+		cb.Linef(`type ConvOptions struct {`)
+		cb.Indent++
+		cb.Linef(`NumericChecks string`)
+		cb.Indent--
+		cb.Linef(`}`)
+		cb.Linef(``)
+		cb.Linef(`func SetConvOptions(ps *env.ProgramState, opts ConvOptions) {}`)
+		cb.Linef(``)
+		cb.Linef(`func RegisterBuiltins(ps *env.ProgramState) {}`)
+		cb.Linef(``)
+		cb.Linef(`func LoadPrelude(ps *env.ProgramState) error { return nil }`)
+		cb.Linef(``)
+		cb.Linef(`const MinRyeVersion = %v`, strconv.Quote(minRyeVersion))
+		cb.Linef(``)
+		cb.Linef(`func CheckRyeVersion() error { return nil }`)
+		if cfg.Library != "" {
+			cb.Linef(``)
+			cb.Linef(`func RegisterInto(ps *env.ProgramState) error { return nil }`)
+		}
 
-		if fmtErr, err := cb.SaveToFile(outFileNot); err != nil || fmtErr != nil {
-			return "", "", nil, fmt.Errorf("save binding dummy: general=%w, fmt=%v", err, fmtErr)
+		if fmtErr, err := saveCode(sink, &cb, outFileNot); err != nil || fmtErr != nil {
+			return "", "", nil, "", nil, fmt.Errorf("save binding dummy: general=%w, fmt=%v", err, fmtErr)
 		}
 	}
 
+	if err := writeUsageFiles(sink, outDir, fullBindingName, cfg.UsageTag, cfg.DontBuildFlag); err != nil {
+		return "", "", nil, "", nil, err
+	}
+	if err := writeConvTraceFiles(sink, outDir, fullBindingName, cfg.TraceTag, cfg.DontBuildFlag); err != nil {
+		return "", "", nil, "", nil, err
+	}
+	if err := writeAssetsFiles(sink, outDir, fullBindingName, cfg.DontBuildFlag, assets, cfg.Env); err != nil {
+		return "", "", nil, "", nil, err
+	}
+
 	var cb binderio.CodeBuilder
 
 	cb.Linef(`// Code generated by ryegen. DO NOT EDIT.`)
@@ -686,6 +1541,26 @@ func TryRun(
 	}
 	cb.Linef(`package %v`, fullBindingName)
 	cb.Linef(``)
+
+	// Lookup tables and name lists go into their own package (or file),
+	// so changes to them don't recompile the (much larger) builtins and
+	// vice versa.
+	var dataCb binderio.CodeBuilder
+
+	dataCb.Linef(`// Code generated by ryegen. DO NOT EDIT.`)
+	dataCb.Linef(``)
+	if splitData {
+		dataCb.Linef(`// Package %v holds the data tables of the %v bindings.`, bindingDataDir, fullBindingName)
+		dataCb.Linef(`package %v`, bindingDataDir)
+	} else {
+		if cfg.DontBuildFlag != "" {
+			dataCb.Linef(`//go:build !%v`, cfg.DontBuildFlag)
+			dataCb.Linef(``)
+		}
+		dataCb.Linef(`package %v`, fullBindingName)
+	}
+	dataCb.Linef(``)
+
 	cb.Linef(`import (`)
 	cb.Indent++
 	for _, mod := range slices.Sorted(maps.Keys(dependencies.Imports)) {
@@ -697,13 +1572,30 @@ func TryRun(
 			cb.Linef(`%v "%v"`, uniqueName, mod)
 		}
 	}
+	for _, mod := range cfg.BlankImports {
+		if _, ok := dependencies.Imports[mod]; !ok {
+			cb.Linef(`_ "%v"`, mod)
+		}
+	}
+	if splitData {
+		cb.Linef(``)
+		cb.Linef(`%v "%v"`, bindingDataImportName, dataImportPath)
+	}
 	cb.Indent--
 	cb.Linef(`)`)
 	cb.Linef(``)
+	if splitData {
+		writeBindingDataVars(&cb, bindingDataImportName, cfg.RenameAliases)
+	}
 
 	cb.Linef(``)
 	cb.Linef(`var Builtins map[string]*env.Builtin`)
 	cb.Linef(``)
+	cb.Linef(`// BuiltinNames lists the keys of Builtins in sorted order, so they can`)
+	cb.Linef(`// be registered in the same order every time instead of iterating`)
+	cb.Linef(`// Builtins in random order.`)
+	cb.Linef(`var BuiltinNames []string`)
+	cb.Linef(``)
 	cb.Linef(`func init() {`)
 	cb.Indent++
 	cb.Linef(`Builtins = make(map[string]*env.Builtin, len(builtinsGenerated) + len(builtinsCustom))`)
@@ -717,13 +1609,74 @@ func TryRun(
 	cb.Linef(`Builtins[k] = v`)
 	cb.Indent--
 	cb.Linef(`}`)
+	cb.Linef(``)
+	cb.Linef(`// Generated names are sorted already, so only custom builtins`)
+	cb.Linef(`// require sorting at startup.`)
+	cb.Linef(`BuiltinNames = make([]string, 0, len(Builtins))`)
+	cb.Linef(`BuiltinNames = append(BuiltinNames, builtinNamesGenerated[:]...)`)
+	cb.Linef(`for k := range builtinsCustom {`)
+	cb.Indent++
+	cb.Linef(`if _, ok := builtinsGenerated[k]; !ok {`)
+	cb.Indent++
+	cb.Linef(`BuiltinNames = append(BuiltinNames, k)`)
+	cb.Indent--
+	cb.Linef(`}`)
+	cb.Indent--
+	cb.Linef(`}`)
+	if cfg.TraceTag != "" {
+		cb.Linef(`if _, ok := Builtins[%q]; !ok {`, convTraceBuiltinName)
+		cb.Indent++
+		cb.Linef(`Builtins[%q] = convTraceBuiltin`, convTraceBuiltinName)
+		cb.Linef(`BuiltinNames = append(BuiltinNames, %q)`, convTraceBuiltinName)
+		cb.Indent--
+		cb.Linef(`}`)
+	}
+	if cfg.RenameAliases {
+		writeAliasRegistration(&cb)
+	}
+	cb.Linef(`if len(BuiltinNames) > len(builtinNamesGenerated) {`)
+	cb.Indent++
+	cb.Linef(`sort.Strings(BuiltinNames)`)
+	cb.Indent--
+	cb.Linef(`}`)
 	cb.Indent--
 	cb.Linef(`}`)
+	cb.Linef(``)
 
+	writeRegisterBuiltins(&cb)
+
+	if cfg.RenameAliases {
+		writeDeprecatedAlias(&cb)
+	}
 	cb.Linef(`// Force-use evaldo and env packages since tracking them would be too complicated`)
 	cb.Linef(`var _ = evaldo.BuiltinNames`)
 	cb.Linef(`var _ = env.Object(nil)`)
+	if cfg.GoAPI {
+		cb.Linef(`var _ = errors.New`)
+	}
+	cb.Linef(``)
+
+	writeRyeVersionCheck(&cb, fullBindingName, minRyeVersion, hasSemver)
+
+	if len(cfg.Synchronize) > 0 {
+		cb.Linef(`var %v sync.RWMutex`, binder.GlobalsMutexName)
+		cb.Linef(``)
+	}
+
+	cb.Linef(`// Prelude is Rye code shipped with the bindings (see "prelude" in config.toml).`)
+	cb.Linef(`const Prelude = %v`, strconv.Quote(prelude))
 	cb.Linef(``)
+	if cfg.KindSpecs {
+		cb.Linef(`// KindSpecs is Rye code defining kinds (validation specs) for the`)
+		cb.Linef(`// bound structs (see "kind-specs" in config.toml).`)
+		cb.Linef(`const KindSpecs = %v`, strconv.Quote(kindSpecs))
+		cb.Linef(``)
+	}
+	writeLoadPrelude(&cb, fullBindingName, cfg.KindSpecs, hasAssetsFile)
+
+	if cfg.Library != "" {
+		writeRegisterInto(&cb, cfg.Library)
+	}
 
 	cb.Linef(`func boolToInt64(x bool) int64 {`)
 	cb.Indent++
@@ -738,6 +1691,12 @@ func TryRun(
 	cb.Linef(`}`)
 	cb.Linef(``)
 
+	numericChecks := cfg.NumericChecks
+	if numericChecks == "" {
+		numericChecks = config.NumericChecksStrict
+	}
+	writeConvOptions(&cb, numericChecks)
+
 	cb.Linef(`func objectDebugString(idx *env.Idxs, v any) string {`)
 	cb.Indent++
 	cb.Linef(`if v, ok := v.(env.Object); ok {`)
@@ -753,6 +1712,37 @@ func TryRun(
 	cb.Linef(`}`)
 	cb.Linef(``)
 
+	cb.Linef(`// errorToRye returns a Rye error with the message of err, which wraps`)
+	cb.Linef(`// err itself as a native under the "native" key (see nativeError).`)
+	cb.Linef(`func errorToRye(idx *env.Idxs, err error) *env.Error {`)
+	cb.Indent++
+	cb.Linef(`res := env.NewError(err.Error())`)
+	cb.Linef(`res.Values = map[string]env.Object{`)
+	cb.Indent++
+	cb.Linef(`"native": *env.NewNative(idx, err, "Go(error)"),`)
+	cb.Indent--
+	cb.Linef(`}`)
+	cb.Linef(`return res`)
+	cb.Indent--
+	cb.Linef(`}`)
+	cb.Linef(``)
+
+	cb.Linef(`// nativeError returns the Go error wrapped by a Rye error made by`)
+	cb.Linef(`// errorToRye.`)
+	cb.Linef(`func nativeError(e *env.Error) (error, bool) {`)
+	cb.Indent++
+	cb.Linef(`nat, ok := e.Values["native"].(env.Native)`)
+	cb.Linef(`if !ok {`)
+	cb.Indent++
+	cb.Linef(`return nil, false`)
+	cb.Indent--
+	cb.Linef(`}`)
+	cb.Linef(`err, ok := nat.Value.(error)`)
+	cb.Linef(`return err, ok`)
+	cb.Indent--
+	cb.Linef(`}`)
+	cb.Linef(``)
+
 	cb.Linef(`func ifaceToNative(idx *env.Idxs, v any, ifaceName string) env.Native {`)
 	cb.Indent++
 	cb.Linef(`rV := reflect.ValueOf(v)`)
@@ -791,13 +1781,16 @@ func TryRun(
 	cb.Linef(`}`)
 	cb.Linef(``)
 
-	cb.Linef(`var ryeStructNameLookup = map[string]string{`)
-	cb.Indent++
+	dataCb.Linef(`var %v = map[string]string{`, bindingDataName("ryeStructNameLookup", splitData))
+	dataCb.Indent++
 	{
 		typNames := make(map[string]string, len(irData.Structs)*2)
 		for _, struc := range irData.Structs {
 			id := struc.Name
-			if !ir.IdentExprIsExported(id.Expr) || ir.IdentIsInternal(ctx.ModNames, id) {
+			if !ir.IdentExprIsExported(id.Expr) {
+				continue
+			}
+			if ir.IdentIsInternal(ctx.ModNames, id) && (id.File == nil || !binder.InternalAllowed(ctx, id.File.ModulePath)) {
 				continue
 			}
 			var nameNoMod string
@@ -822,15 +1815,15 @@ func TryRun(
 				panic(err)
 			}
 
-			typNames[id.File.ModulePath+".*"+nameNoMod] = id.RyeName()
+			typNames[id.File.ModulePath+".*"+nameNoMod] = binder.KindName(ctx, id)
 		}
 		for k, v := range sortedMapAll(typNames) {
-			cb.Linef(`"%v": "%v",`, k, v)
+			dataCb.Linef(`"%v": "%v",`, k, v)
 		}
 	}
-	cb.Indent--
-	cb.Linef(`}`)
-	cb.Linef(``)
+	dataCb.Indent--
+	dataCb.Linef(`}`)
+	dataCb.Linef(``)
 
 	for _, ifaceImpl := range slices.Sorted(slices.Values(genericInterfaceImpls)) {
 		cb.Append(ifaceImpl)
@@ -859,7 +1852,7 @@ func TryRun(
 			topNames := make(map[string]int) // current top candidate to index into sortedBindings
 			for i, bind := range sortedBindings {
 				if len(nameCandidates[i]) == 0 {
-					return "", "", nil, fmt.Errorf("unable to resolve naming conflict for %v", bind.UniqueName(ctx))
+					return "", "", nil, "", nil, fmt.Errorf("unable to resolve naming conflict for %v", bind.UniqueName(ctx))
 				}
 				topName := nameCandidates[i][0]
 				if otherI, exists := topNames[topName]; exists {
@@ -871,6 +1864,13 @@ func TryRun(
 						nameCandidates[otherI] = nameCandidates[otherI][1:]
 						topNames[topName] = i
 						foundConflict = true
+					} else if cfg.Naming == config.NamingShort && len(nameCandidates[i]) > 1 && len(nameCandidates[otherI]) > 1 {
+						// Same priority: fall back to the (usually prefixed)
+						// next candidates of both.
+						nameCandidates[i] = nameCandidates[i][1:]
+						nameCandidates[otherI] = nameCandidates[otherI][1:]
+						delete(topNames, topName)
+						foundConflict = true
 					} else {
 						// TODO: Find a better way to do this.
 						warn = multierror.Append(warn,
@@ -897,33 +1897,202 @@ func TryRun(
 		for i := range sortedBindings {
 			bindingNames[i] = nameCandidates[i][0]
 		}
+
+		for i, bind := range sortedBindings {
+			name := bind.UniqueName(ctx)
+			tracer.Trace(name, "disable", bindingList.Disabled(name), "disabled")
+			rename, ok := bindingList.Rename(name)
+			tracer.Trace(name, "rename", ok, "rename candidate "+rename)
+			tracer.Trace(name, "export", bindingList.Exported(name), "exported")
+			tracer.Trace(name, "no-prefix", namePrios[i] != math.MaxInt, fmt.Sprintf("unprefixed name candidates allowed (priority %v)", namePrios[i]))
+			tracer.Trace(name, "cut-new", cfg.CutNew && strings.HasPrefix(bind.Name, "New"), `"New" removed from name`)
+			tracer.Log(name, fmt.Sprintf("name candidates %v, resolved to %v", strings.Join(nameCandidates[i], ", "), bindingNames[i]))
+		}
+	}
+
+	// Names renamed builtins would have without their rename, to their
+	// current names (see [config.Config.RenameAliases]).
+	renameAliases := make(map[string]string)
+	if cfg.RenameAliases {
+		taken := make(map[string]struct{}, len(bindingNames))
+		for _, name := range bindingNames {
+			taken[name] = struct{}{}
+		}
+		for i, bind := range sortedBindings {
+			name := bind.UniqueName(ctx)
+			if _, ok := bindingList.Rename(name); !ok || bindingList.Disabled(name) {
+				continue
+			}
+			noPrefix := slices.Contains(cfg.NoPrefix, bind.File.ModulePath)
+			old := bind.RyeifiedNameCandidates(ctx, noPrefix, cfg.CutNew, "")[0]
+			if _, ok := taken[old]; ok {
+				// Unchanged by the rename or used by another builtin.
+				continue
+			}
+			renameAliases[old] = bindingNames[i]
+			taken[old] = struct{}{}
+			tracer.Trace(name, "rename-aliases", true, "deprecated alias "+old)
+		}
+	}
+
+	helpTexts := make(map[string]string) // module path to help text
+	listItems := make(map[string]string) // module path to list items expression
+	// module path to category to sorted binding names
+	pkgCategoryNames := make(map[string]map[string][]string)
+	// module path to sorted list entries ("name signature")
+	pkgListEntries := make(map[string][]string)
+	{
+		for i, bind := range sortedBindings {
+			if bindingList.Disabled(bind.UniqueName(ctx)) {
+				continue
+			}
+			if bind.Category == "Help" {
+				continue
+			}
+			names, ok := pkgCategoryNames[bind.File.ModulePath]
+			if !ok {
+				names = make(map[string][]string)
+				pkgCategoryNames[bind.File.ModulePath] = names
+			}
+			names[bind.Category] = append(names[bind.Category], bindingNames[i])
+			entry := bindingNames[i]
+			if sig := bind.Signature.String(); sig != "" {
+				entry += " " + sig
+			}
+			pkgListEntries[bind.File.ModulePath] = append(pkgListEntries[bind.File.ModulePath], entry)
+		}
+		for _, names := range pkgCategoryNames {
+			for _, category := range names {
+				slices.Sort(category)
+			}
+		}
+		for _, entries := range pkgListEntries {
+			slices.Sort(entries)
+		}
+		for _, bind := range sortedBindings {
+			if bind.Category != "Help" {
+				continue
+			}
+			helpTexts[bind.File.ModulePath] = strconv.Quote(binder.HelpText(ctx, bind.File.ModulePath, pkgCategoryNames[bind.File.ModulePath]))
+			listItems[bind.File.ModulePath] = binder.ListItems(pkgListEntries[bind.File.ModulePath])
+		}
+	}
+
+	dataCb.Linef(`// Categories maps each Go package path to the categories of its`)
+	dataCb.Linef(`// builtins (e.g. "Functions", "Methods", "Getters") to their names,`)
+	dataCb.Linef(`// for presenting grouped listings.`)
+	dataCb.Linef(`var Categories = map[string]map[string][]string{`)
+	dataCb.Indent++
+	for pkg, names := range sortedMapAll(pkgCategoryNames) {
+		dataCb.Linef(`%v: {`, strconv.Quote(pkg))
+		dataCb.Indent++
+		categories := slices.Collect(maps.Keys(names))
+		binder.SortHelpCategories(categories)
+		for _, category := range categories {
+			dataCb.Linef(`%v: {`, strconv.Quote(category))
+			dataCb.Indent++
+			for _, name := range names[category] {
+				dataCb.Linef(`%v,`, strconv.Quote(name))
+			}
+			dataCb.Indent--
+			dataCb.Linef(`},`)
+		}
+		dataCb.Indent--
+		dataCb.Linef(`},`)
+	}
+	dataCb.Indent--
+	dataCb.Linef(`}`)
+	dataCb.Linef(``)
+
+	appliedPrefixes := make(map[string]string)
+	for _, bind := range sortedBindings {
+		if prefix, ok := cfg.CustomPrefix(bind.File.ModulePath); ok && bind.Recv == "" {
+			appliedPrefixes[bind.File.ModulePath] = prefix
+		}
+	}
+	dataCb.Linef(`// Prefixes maps Go package paths to the custom prefix of their`)
+	dataCb.Linef(`// builtins' names (see "custom-prefixes" in config.toml).`)
+	dataCb.Linef(`var Prefixes = map[string]string{`)
+	dataCb.Indent++
+	for pkg, prefix := range sortedMapAll(appliedPrefixes) {
+		dataCb.Linef(`%v: %v,`, strconv.Quote(pkg), strconv.Quote(prefix))
+	}
+	dataCb.Indent--
+	dataCb.Linef(`}`)
+	dataCb.Linef(``)
+
+	if cfg.RenameAliases {
+		dataCb.Linef(`// Aliases maps the previous names of renamed builtins to their current`)
+		dataCb.Linef(`// names. The previous names stay registered as deprecated builtins`)
+		dataCb.Linef(`// (see "rename-aliases" in config.toml).`)
+		dataCb.Linef(`var Aliases = map[string]string{`)
+		dataCb.Indent++
+		for old, name := range sortedMapAll(renameAliases) {
+			dataCb.Linef(`%v: %v,`, strconv.Quote(old), strconv.Quote(name))
+		}
+		dataCb.Indent--
+		dataCb.Linef(`}`)
+		dataCb.Linef(``)
 	}
 
 	for i, bind := range sortedBindings {
-		if _, ok := bindingList.Export[bind.UniqueName(ctx)]; !ok {
+		if !bindingList.Exported(bind.UniqueName(ctx)) {
 			continue
 		}
 		funcName := strcase.ToSnake(bindingNames[i])
 		cb.Linef(`func ExportedFunc_%v(funcName string, ps *env.ProgramState, arg0, arg1, arg2, arg3, arg4 env.Object) env.Object {`, funcName)
 		cb.Indent++
-		rep := strings.NewReplacer(`((RYEGEN:FUNCNAME))`, `" + funcName + "`)
+		rep := strings.NewReplacer(
+			`((RYEGEN:FUNCNAME))`, `" + funcName + "`,
+			binder.HelpListingPlaceholder, helpTexts[bind.File.ModulePath],
+			binder.ListPlaceholder, listItems[bind.File.ModulePath],
+		)
 		cb.Append(rep.Replace(bind.Body))
 		cb.Indent--
 		cb.Linef(`}`)
 		cb.Linef(``)
 	}
 
-	cb.Linef(`var builtinsGenerated = map[string]*env.Builtin{`)
-	cb.Indent++
+	goAPINames := make(map[string]string) // Go API name to binding name
+	for i, bind := range sortedBindings {
+		if bind.GoAPI == "" {
+			continue
+		}
+		if bindingList.Disabled(bind.UniqueName(ctx)) {
+			continue
+		}
+		name := binder.GoAPIName(bindingNames[i])
+		if other, exists := goAPINames[name]; exists {
+			warn = multierror.Append(warn, fmt.Errorf("go-api: %v and %v both map to %v, skipping %v", other, bindingNames[i], name, bindingNames[i]))
+			continue
+		}
+		goAPINames[name] = bindingNames[i]
+		rep := strings.NewReplacer(
+			`((RYEGEN:FUNCNAME))`, bindingNames[i],
+			binder.GoAPINamePlaceholder, name,
+		)
+		cb.Append(rep.Replace(bind.GoAPI))
+		cb.Linef(``)
+	}
+
+	// Number of builtins registered per generated function, avoiding
+	// compiler limits with huge packages.
+	const builtinsPerChunk = 500
 
 	numWrittenBindings := 0
 	numBindingsByCategory := make(map[string]int)
 	numWrittenBindingsByCategory := make(map[string]int)
+	var builtinEntries []string
+	var writtenNames []string                         // names of builtinEntries
+	builtinSizes := make(map[string][]SummaryBuiltin) // module path to written builtins
+	writeDocsEnabled := opts.Docs
+	docEntries := make(map[string][]docEntry) // module path to API reference entries
 	for i, bind := range sortedBindings {
 		numBindingsByCategory[bind.Category]++
-		if enabled, ok := bindingList.Enabled[bind.UniqueName(ctx)]; ok && !enabled {
+		if bindingList.Disabled(bind.UniqueName(ctx)) {
 			continue
 		}
+		var entry binderio.CodeBuilder
 		if bind.DocComment != "" {
 			lines := strings.Split(bind.DocComment, "\n")
 			if lines[len(lines)-1] == "" {
@@ -935,44 +2104,199 @@ func TryRun(
 					name = s
 				}
 				line = strings.ReplaceAll(line, bind.Name, name)
-				cb.Linef(`// %v`, line)
+				entry.Linef(`// %v`, line)
 			}
 		}
-		cb.Linef(`"%v": {`, bindingNames[i])
-		cb.Indent++
-		cb.Linef(`Doc: "%v",`, bind.Doc)
-		cb.Linef(`Argsn: %v,`, bind.Argsn)
-		cb.Linef(`Fn: func(ps *env.ProgramState, arg0, arg1, arg2, arg3, arg4 env.Object) env.Object {`)
-		cb.Indent++
-		rep := strings.NewReplacer(`((RYEGEN:FUNCNAME))`, bindingNames[i])
-		cb.Append(rep.Replace(bind.Body))
-		cb.Indent--
-		cb.Linef(`},`)
-		cb.Indent--
-		cb.Linef(`},`)
+		entry.Linef(`m["%v"] = &env.Builtin{`, bindingNames[i])
+		entry.Indent++
+		entry.Linef(`Doc: "%v",`, bind.Doc)
+		entry.Linef(`Argsn: %v,`, bind.Argsn)
+		entry.Linef(`Fn: func(ps *env.ProgramState, arg0, arg1, arg2, arg3, arg4 env.Object) env.Object {`)
+		entry.Indent++
+		entry.Linef(`%v`, sourceComment(bind.UniqueName(ctx), bind.ConvUsage))
+		if cfg.UsageTag != "" {
+			entry.Linef(`%v`, usageRecordCall(bind.File.ModulePath, bind.UniqueName(ctx)))
+		}
+		rep := strings.NewReplacer(
+			`((RYEGEN:FUNCNAME))`, bindingNames[i],
+			binder.HelpListingPlaceholder, helpTexts[bind.File.ModulePath],
+			binder.ListPlaceholder, listItems[bind.File.ModulePath],
+		)
+		entry.Append(rep.Replace(bind.Body))
+		entry.Indent--
+		entry.Linef(`},`)
+		entry.Indent--
+		entry.Linef(`}`)
+		builtinEntries = append(builtinEntries, entry.String())
+		writtenNames = append(writtenNames, bindingNames[i])
+		if writeDocsEnabled && bind.Category != "Help" {
+			docEntries[bind.File.ModulePath] = append(docEntries[bind.File.ModulePath], docEntry{
+				Word:      bindingNames[i],
+				GoSymbol:  bind.GoSymbol,
+				Signature: bind.Signature.String(),
+				Summary:   docSummary(bind.DocComment),
+			})
+		}
+		builtinSizes[bind.File.ModulePath] = append(builtinSizes[bind.File.ModulePath], SummaryBuiltin{
+			Name: bind.UniqueName(ctx),
+			Size: len(entry.String()),
+		})
 		numWrittenBindingsByCategory[bind.Category]++
 		numWrittenBindings++
 	}
+	cb.ChunkedMapVar("builtinsGenerated", "map[string]*env.Builtin", builtinsPerChunk, builtinEntries)
+	cb.Linef(``)
+	slices.Sort(writtenNames)
+	dataCb.Linef(`var %v = [...]string{`, bindingDataName("builtinNamesGenerated", splitData))
+	dataCb.Indent++
+	for _, name := range writtenNames {
+		dataCb.Linef(`%v,`, strconv.Quote(name))
+	}
+	dataCb.Indent--
+	dataCb.Linef(`}`)
 
-	cb.Indent--
-	cb.Linef(`}`)
+	if graphPath := os.Getenv("RYEGEN_CONV_GRAPH"); isEnvEnabled("RYEGEN_CONV_GRAPH") {
+		if !strings.HasSuffix(graphPath, ".dot") {
+			graphPath = "conv_graph.dot"
+		}
+		graphPath = inDir(graphPath)
+		var graphBindings []convGraphBinding
+		for i, bind := range sortedBindings {
+			if bindingList.Disabled(bind.UniqueName(ctx)) {
+				continue
+			}
+			var pkg string
+			if bind.File != nil {
+				pkg = bind.File.ModulePath
+			}
+			graphBindings = append(graphBindings, convGraphBinding{
+				Pkg:       pkg,
+				Name:      bindingNames[i],
+				ConvUsage: bind.ConvUsage,
+				ConvDeps:  bind.ConvDeps,
+			})
+		}
+		if err := sink.WriteFile(graphPath, []byte(convGraphDOT(graphBindings))); err != nil {
+			return "", "", nil, "", nil, fmt.Errorf("write converter graph: %w", err)
+		}
+		onInfo("wrote converter graph to " + graphPath)
+	}
+
+	convUsageWritten := make(map[binder.ConvID]int)
+	convUsageTotal := make(map[binder.ConvID]int)
+	convUsageDisabledExamples := make(map[binder.ConvID][]string) // up to 3 disabled bindings using a converter
+	for i, bind := range sortedBindings {
+		written := !bindingList.Disabled(bind.UniqueName(ctx))
+		for id, n := range bind.ConvUsage {
+			convUsageTotal[id] += n
+			if written {
+				convUsageWritten[id] += n
+			} else if len(convUsageDisabledExamples[id]) < 3 {
+				convUsageDisabledExamples[id] = append(convUsageDisabledExamples[id], bindingNames[i])
+			}
+		}
+	}
 
 	{
-		fmtErr, err := cb.SaveToFile(outFile)
+		fmtErr, err := saveCode(sink, &cb, outFile)
 		if err != nil {
-			return "", "", nil, fmt.Errorf("save bindings: %w", err)
+			return "", "", nil, "", nil, fmt.Errorf("save bindings: %w", err)
 		}
 		if fmtErr != nil {
 			warn = multierror.Append(warn, fmt.Errorf("cannot format bindings: %w, saved as unformatted go code instead", fmtErr))
 		}
 	}
+	{
+		dataFile, staleDataFile := outFileData, outFileDataPkg
+		if splitData {
+			dataFile, staleDataFile = staleDataFile, dataFile
+		}
+		if err := removeOutput(sink, staleDataFile); err != nil {
+			return "", "", nil, "", nil, err
+		}
+		fmtErr, err := saveCode(sink, &dataCb, dataFile)
+		if err != nil {
+			return "", "", nil, "", nil, fmt.Errorf("save binding data: %w", err)
+		}
+		if fmtErr != nil {
+			warn = multierror.Append(warn, fmt.Errorf("cannot format binding data: %w, saved as unformatted go code instead", fmtErr))
+		}
+	}
+
+	if writeDocsEnabled {
+		if err := writeDocs(sink, inDir(docsDirPath), docEntries, ctx.IR.PackageDocs); err != nil {
+			return "", "", nil, "", nil, fmt.Errorf("write docs: %w", err)
+		}
+		onInfo(fmt.Sprintf("wrote API references of %v packages to %v", len(docEntries), inDir(docsDirPath)))
+	}
 
 	timeWriteCode := time.Since(timeStart)
 
+	if cfg.InitReport {
+		roots := slices.Sorted(maps.Keys(dependencies.Imports))
+		roots = append(roots, cfg.BlankImports...)
+		chains, err := imports.InitChains(roots)
+		if err != nil {
+			return "", "", nil, "", nil, fmt.Errorf("init report: %w", err)
+		}
+		if err := writeInitReport(sink, inDir(initReportPath), chains); err != nil {
+			return "", "", nil, "", nil, fmt.Errorf("init report: %w", err)
+		}
+		onInfo(fmt.Sprintf("wrote %v (%v packages with init funcs)", inDir(initReportPath), len(chains)))
+	}
+
+	if cfg.TargetReport {
+		variants := make(map[string]map[string][]targetVariant)
+		numDiffer := 0
+		for _, pkg := range genBindingsForPkgs {
+			dir, ok := modDirPaths[pkg]
+			if !ok {
+				continue
+			}
+			pkgVariants, err := targetVariants(dir, pkg)
+			if err != nil {
+				return "", "", nil, "", nil, fmt.Errorf("target report: %w", err)
+			}
+			if len(pkgVariants) == 0 {
+				continue
+			}
+			variants[pkg] = pkgVariants
+			for name, vs := range sortedMapAll(pkgVariants) {
+				if targetSignaturesDiffer(vs) {
+					numDiffer++
+					warn = multierror.Append(warn, fmt.Errorf("%v.%v: signature differs between build targets (%v)", pkg, name, strings.Join(formatTargetVariants(vs), "; ")))
+				}
+			}
+		}
+		if err := writeTargetReport(sink, inDir(targetReportPath), variants); err != nil {
+			return "", "", nil, "", nil, fmt.Errorf("target report: %w", err)
+		}
+		onInfo(fmt.Sprintf("wrote %v (%v symbols with differing signatures)", inDir(targetReportPath), numDiffer))
+	}
+
+	if cfg.Verify {
+		onInfo("verifying generated code")
+		if verified, err := verifyStaged(staged, outDir, outFile); err != nil {
+			return "", "", nil, "", nil, err
+		} else if !verified {
+			onInfo("skipped verification: outputs aren't written to files")
+		}
+	}
+
+	if err := staged.commit(); err != nil {
+		return "", "", nil, "", nil, err
+	}
+
 	{
 		var sw strings.Builder
 		fmt.Fprintf(&sw, "==Binding stats==\n")
 		fmt.Fprintf(&sw, "Generated %v generic interface implementations.\n", len(genericInterfaceImpls))
+		if len(unsafeExcluded) > 0 {
+			fmt.Fprintf(&sw, "Excluded bindings passing unsafe pointers (see \"allow-unsafe\" in config.toml):\n")
+			for _, name := range unsafeExcluded {
+				fmt.Fprintf(&sw, "  * %v\n", name)
+			}
+		}
 		fmt.Fprintf(&sw, "Number of generated builtins (excludes generic interface impls):\n")
 		{
 			tbl := tablewriter.NewWriter(&sw)
@@ -987,6 +2311,52 @@ func TryRun(
 			tbl.Render()
 		}
 		fmt.Fprintln(&sw)
+		fmt.Fprintf(&sw, "==Converter stats==\n")
+		{
+			var numRyeToGo, numGoToRye int
+			for id, n := range convUsageWritten {
+				if id.GoToRye {
+					numGoToRye += n
+				} else {
+					numRyeToGo += n
+				}
+			}
+			fmt.Fprintf(&sw, "Generated %v Rye to Go and %v Go to Rye conversions in written builtins.\n", numRyeToGo, numGoToRye)
+			fmt.Fprintf(&sw, "Number of generated conversions by converter:\n")
+
+			tbl := tablewriter.NewWriter(&sw)
+			tbl.SetHeader([]string{"Converter", "Written/Total"})
+			var unused []string
+			for _, id := range binder.AllConvIDs() {
+				if convUsageWritten[id] == 0 {
+					s := id.String()
+					if examples := convUsageDisabledExamples[id]; len(examples) > 0 {
+						s += " (only used by disabled builtins, e.g. " + strings.Join(examples, ", ") + ")"
+					}
+					unused = append(unused, s)
+				}
+				if convUsageTotal[id] == 0 {
+					continue
+				}
+				tbl.Append([]string{id.String(), fmt.Sprintf("%v/%v", convUsageWritten[id], convUsageTotal[id])})
+			}
+			tbl.SetColumnAlignment([]int{tablewriter.ALIGN_LEFT, tablewriter.ALIGN_CENTER})
+			tbl.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+			tbl.SetCenterSeparator("|")
+			tbl.Render()
+
+			if len(unused) > 0 {
+				fmt.Fprintf(&sw, "Converters unused by written builtins:\n")
+				for _, s := range unused {
+					fmt.Fprintf(&sw, "  * %v\n", s)
+				}
+			}
+		}
+		fmt.Fprintln(&sw)
+		fmt.Fprintf(&sw, "==Rule stats==\n")
+		fmt.Fprintf(&sw, "Number of bindings matched by rule:\n")
+		sw.WriteString(tracer.Stats())
+		fmt.Fprintln(&sw)
 		fmt.Fprintf(&sw, "==Timing stats==\n")
 		fmt.Fprintf(&sw, "Fetched/checked source repos in %v.\n", timeGetRepos)
 		fmt.Fprintf(&sw, "Binding generation tasks (excludes fetching/checking source repos):\n")
@@ -1016,17 +2386,52 @@ func TryRun(
 		stats = sw.String()
 	}
 
-	return outFile, stats, warn, nil
+	summary = &Summary{
+		OutFile:   outFile,
+		Duration:  time.Since(timeRunStart),
+		Generated: numWrittenBindings,
+		Excluded:  len(bindings) - numWrittenBindings,
+		Unsafe:    unsafeExcluded,
+		Outputs:   outputs.Sizes(),
+		Notices:   repoNotices,
+	}
+	summary.setDrops(errs)
+	summary.setPackages(builtinSizes, cfg.MaxSize, summary.Outputs[outFile])
+	summary.suggest(errs, cfg.IncludeStdLibs)
+
+	return outFile, modDirPaths[cfg.Package], summary, stats, warn, nil
 }
 
+// Run generates bindings as configured in config.toml, printing warnings
+// and exiting on fatal errors. If the first command line argument is
+// "watch", it calls [Watch] instead. If it is "docs" (or [docsEnv] is
+// set), it also writes the API reference of each bound package. The
+// "--update" flag (or [lockUpdateEnv]) updates the lock file (see
+// [RunOptions]).
 func Run() {
-	outFile, stats, warn, err := TryRun(func(msg string) {
-		fmt.Println("Ryegen:", msg)
+	if len(os.Args) > 1 && os.Args[1] == "watch" {
+		Watch()
+		return
+	}
+	res, err := TryRunWithOptions(RunOptions{
+		OnInfo: func(msg string) {
+			fmt.Println("Ryegen:", msg)
+		},
+		Docs:       (len(os.Args) > 1 && os.Args[1] == "docs") || isEnvEnabled(docsEnv),
+		UpdateLock: slices.Contains(os.Args[1:], "--update") || isEnvEnabled(lockUpdateEnv),
 	})
 	if err != nil {
 		fmt.Println("Ryegen: fatal:", err)
 		os.Exit(1)
 	}
+	printResult(res.Stats, res.Warn)
+	if res.Summary != nil {
+		fmt.Print(res.Summary)
+	}
+}
+
+// printResult prints the stats (if enabled) and warnings of [TryRun].
+func printResult(stats string, warn error) {
 	if isEnvEnabled("RYEGEN_STATS") {
 		fmt.Println()
 		fmt.Println("====== BEGIN RYEGEN STATS ======")
@@ -1037,13 +2442,13 @@ func Run() {
 	}
 	if warn != nil {
 		if multErr, ok := warn.(*multierror.Error); ok {
-			fmt.Println("Ryegen:", len(multErr.Errors), "warnings:")
-			for _, e := range multErr.Errors {
-				fmt.Println("  *", e)
+			mode := os.Getenv("RYEGEN_ERRORS")
+			if mode == "" {
+				mode = warningsGrouped
 			}
+			fmt.Print(formatWarnings(multErr.Errors, mode))
 		} else {
 			fmt.Println("Ryegen: warning:", warn)
 		}
 	}
-	fmt.Println("Ryegen: Wrote bindings to", outFile)
 }
@@ -0,0 +1,199 @@
+package ryegen
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"golang.org/x/mod/module"
+)
+
+// moduleCachePath is the file scanned module info is persisted to between
+// runs, relative to the working directory.
+var moduleCachePath = filepath.Join(".ryegen-cache", "packages.gob")
+
+// Increment when the cached data changes meaning.
+const moduleCacheVersion = 2
+
+// Entries unused for longer are evicted, e.g. those of module versions
+// no longer required.
+const moduleCacheMaxAge = 30 * 24 * time.Hour
+
+// Notices are checked again after this long, since modules may be
+// deprecated or retracted at any time.
+const moduleNoticesMaxAge = 24 * time.Hour
+
+// moduleCacheEntry is the result of scanning a module directory with
+// parser.ParseDirModules.
+type moduleCacheEntry struct {
+	// go.mod size and modification time when scanned (zero if
+	// there is no go.mod).
+	GoModSize    int64
+	GoModModTime int64
+	// Unix time the entry was last used, updated at most daily so
+	// the cache isn't rewritten on every run.
+	Used int64
+
+	GoVer   string
+	Modules map[string]string
+	Require []module.Version
+}
+
+// moduleNoticesEntry is the result of checking a module version for
+// deprecation and retractions with repo.Notices.
+type moduleNoticesEntry struct {
+	// Unix time of the check.
+	Checked int64
+	Notices []string
+}
+
+// moduleCache caches the results of scanning module directories, which
+// otherwise requires reading every Go file of the module and its
+// dependencies (including std) on each run. Downloaded modules don't
+// change, but the go.mod stamp is checked anyway to catch local edits.
+// Entries of removed directories and entries unused for
+// [moduleCacheMaxAge] are evicted when saving. It also caches the
+// notices of module versions for [moduleNoticesMaxAge], so they aren't
+// fetched from the proxy on every run.
+type moduleCache struct {
+	Version int
+	Entries map[string]moduleCacheEntry
+	// Module version ("<path>@<version>") to notices.
+	Notices map[string]moduleNoticesEntry
+	dirty   bool
+}
+
+// loadModuleCache reads the cache from path. A missing, unreadable or
+// outdated cache yields an empty one.
+func loadModuleCache(path string) *moduleCache {
+	c := &moduleCache{
+		Version: moduleCacheVersion,
+		Entries: make(map[string]moduleCacheEntry),
+		Notices: make(map[string]moduleNoticesEntry),
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return c
+	}
+	defer f.Close()
+	var loaded moduleCache
+	if err := gob.NewDecoder(f).Decode(&loaded); err != nil || loaded.Version != moduleCacheVersion || loaded.Entries == nil {
+		return c
+	}
+	if loaded.Notices == nil {
+		loaded.Notices = make(map[string]moduleNoticesEntry)
+	}
+	return &loaded
+}
+
+func moduleCacheKey(dir, modulePath string, buildTags []string) string {
+	tags := slices.Clone(buildTags)
+	slices.Sort(tags)
+	return dir + "\x00" + modulePath + "\x00" + strings.Join(tags, ",")
+}
+
+func goModStamp(dir string) (size, modTime int64, err error) {
+	info, err := os.Stat(filepath.Join(dir, "go.mod"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, 0, nil
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+	return info.Size(), info.ModTime().UnixNano(), nil
+}
+
+// Get returns the cached scan result for the module directory, if it is
+// still valid.
+func (c *moduleCache) Get(dir, modulePath string, buildTags []string) (moduleCacheEntry, bool) {
+	e, ok := c.Entries[moduleCacheKey(dir, modulePath, buildTags)]
+	if !ok {
+		return moduleCacheEntry{}, false
+	}
+	size, modTime, err := goModStamp(dir)
+	if err != nil || size != e.GoModSize || modTime != e.GoModModTime {
+		return moduleCacheEntry{}, false
+	}
+	if now := time.Now(); now.Sub(time.Unix(e.Used, 0)) > 24*time.Hour {
+		e.Used = now.Unix()
+		c.Entries[moduleCacheKey(dir, modulePath, buildTags)] = e
+		c.dirty = true
+	}
+	return e, true
+}
+
+// Put stores a scan result for the module directory.
+func (c *moduleCache) Put(dir, modulePath string, buildTags []string, e moduleCacheEntry) error {
+	var err error
+	e.GoModSize, e.GoModModTime, err = goModStamp(dir)
+	if err != nil {
+		return err
+	}
+	e.Used = time.Now().Unix()
+	c.Entries[moduleCacheKey(dir, modulePath, buildTags)] = e
+	c.dirty = true
+	return nil
+}
+
+// GetNotices returns the cached notices of the module version, if they
+// were checked within [moduleNoticesMaxAge].
+func (c *moduleCache) GetNotices(mod module.Version) ([]string, bool) {
+	e, ok := c.Notices[mod.String()]
+	if !ok || time.Since(time.Unix(e.Checked, 0)) > moduleNoticesMaxAge {
+		return nil, false
+	}
+	return e.Notices, true
+}
+
+// PutNotices stores the notices of the module version.
+func (c *moduleCache) PutNotices(mod module.Version, notices []string) {
+	c.Notices[mod.String()] = moduleNoticesEntry{
+		Checked: time.Now().Unix(),
+		Notices: notices,
+	}
+	c.dirty = true
+}
+
+// evict removes the entries of directories which no longer exist,
+// entries unused for [moduleCacheMaxAge] and outdated notices.
+func (c *moduleCache) evict() {
+	for key, e := range c.Entries {
+		dir, _, _ := strings.Cut(key, "\x00")
+		_, err := os.Stat(dir)
+		if errors.Is(err, fs.ErrNotExist) || time.Since(time.Unix(e.Used, 0)) > moduleCacheMaxAge {
+			delete(c.Entries, key)
+			c.dirty = true
+		}
+	}
+	for key, e := range c.Notices {
+		if time.Since(time.Unix(e.Checked, 0)) > moduleNoticesMaxAge {
+			delete(c.Notices, key)
+			c.dirty = true
+		}
+	}
+}
+
+// Save evicts outdated entries (see [moduleCache.evict]) and writes the
+// cache to path in sink if it changed.
+func (c *moduleCache) Save(sink OutputSink, path string) error {
+	c.evict()
+	if !c.dirty {
+		return nil
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(c); err != nil {
+		return fmt.Errorf("save module cache: %w", err)
+	}
+	if err := sink.WriteFile(path, buf.Bytes()); err != nil {
+		return fmt.Errorf("save module cache: %w", err)
+	}
+	c.dirty = false
+	return nil
+}
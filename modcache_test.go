@@ -0,0 +1,111 @@
+package ryegen
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/mod/module"
+)
+
+func TestModuleCache(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"go.mod": "module example.com/m\n"})
+	path := filepath.Join(t.TempDir(), "packages.gob")
+
+	c := loadModuleCache(path)
+	_, ok := c.Get(dir, "example.com/m", nil)
+	assert.False(ok)
+	entry := moduleCacheEntry{
+		GoVer:   "1.21",
+		Modules: map[string]string{"example.com/m": "m"},
+		Require: []module.Version{{Path: "example.com/dep", Version: "v1.0.0"}},
+	}
+	if err := c.Put(dir, "example.com/m", []string{"b", "a"}, entry); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Save(FileSink{}, path); err != nil {
+		t.Fatal(err)
+	}
+
+	c = loadModuleCache(path)
+	got, ok := c.Get(dir, "example.com/m", []string{"a", "b"})
+	if assert.True(ok) {
+		assert.Equal(entry.Modules, got.Modules)
+		assert.Equal(entry.Require, got.Require)
+	}
+	_, ok = c.Get(dir, "example.com/m", []string{"a"})
+	assert.False(ok, "other build tags")
+
+	// Unchanged caches aren't written.
+	var sink MemorySink
+	assert.NoError(c.Save(&sink, path))
+	assert.Empty(sink.Files())
+
+	// Edited go.mod files invalidate the entry.
+	writeFiles(t, dir, map[string]string{"go.mod": "module example.com/m\n\ngo 1.22\n"})
+	_, ok = c.Get(dir, "example.com/m", []string{"a", "b"})
+	assert.False(ok)
+}
+
+func TestModuleCacheEvict(t *testing.T) {
+	assert := assert.New(t)
+
+	kept, removed, stale := t.TempDir(), t.TempDir(), t.TempDir()
+	c := loadModuleCache(filepath.Join(t.TempDir(), "packages.gob"))
+	for _, dir := range []string{kept, removed, stale} {
+		if err := c.Put(dir, "example.com/m", nil, moduleCacheEntry{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Remove(removed); err != nil {
+		t.Fatal(err)
+	}
+	key := moduleCacheKey(stale, "example.com/m", nil)
+	e := c.Entries[key]
+	e.Used = time.Now().Add(-moduleCacheMaxAge - time.Hour).Unix()
+	c.Entries[key] = e
+
+	var sink MemorySink
+	if err := c.Save(&sink, "packages.gob"); err != nil {
+		t.Fatal(err)
+	}
+	assert.Contains(sink.Files(), "packages.gob")
+	assert.Equal([]string{moduleCacheKey(kept, "example.com/m", nil)}, slices.Collect(maps.Keys(c.Entries)))
+}
+
+func TestModuleCacheNotices(t *testing.T) {
+	assert := assert.New(t)
+
+	path := filepath.Join(t.TempDir(), "packages.gob")
+	mod := module.Version{Path: "example.com/m", Version: "v1.0.0"}
+	stale := module.Version{Path: "example.com/m", Version: "v0.9.0"}
+
+	c := loadModuleCache(path)
+	_, ok := c.GetNotices(mod)
+	assert.False(ok)
+	c.PutNotices(mod, []string{"example.com/m is deprecated"})
+	c.PutNotices(stale, nil)
+	e := c.Notices[stale.String()]
+	e.Checked = time.Now().Add(-moduleNoticesMaxAge - time.Hour).Unix()
+	c.Notices[stale.String()] = e
+	_, ok = c.GetNotices(stale)
+	assert.False(ok, "outdated")
+	if err := c.Save(FileSink{}, path); err != nil {
+		t.Fatal(err)
+	}
+
+	c = loadModuleCache(path)
+	notices, ok := c.GetNotices(mod)
+	assert.True(ok)
+	assert.Equal([]string{"example.com/m is deprecated"}, notices)
+	_, ok = c.GetNotices(module.Version{Path: "example.com/m", Version: "v1.1.0"})
+	assert.False(ok, "other version")
+	assert.Equal([]string{mod.String()}, slices.Collect(maps.Keys(c.Notices)), "outdated notices evicted")
+}
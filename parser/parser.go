@@ -1,15 +1,20 @@
 package parser
 
 import (
+	"bytes"
 	"fmt"
 	"go/ast"
 	"go/build/constraint"
+	"go/doc"
+	"go/format"
 	"go/parser"
 	"go/token"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
+	"unicode"
 
 	"golang.org/x/mod/modfile"
 	"golang.org/x/mod/module"
@@ -19,6 +24,9 @@ type Package struct {
 	Name  string
 	Path  string
 	Files map[string]*ast.File
+	// Maps file name to the build constraint the file is
+	// subject to (empty if unconstrained).
+	Constraints map[string]string
 }
 
 func visitDir(
@@ -28,10 +36,12 @@ func visitDir(
 	depth int,
 	mode parser.Mode,
 	modulePathHint string,
+	// build tags (including GOOS and GOARCH) considered satisfied
+	tags []string,
 	// Called when entering a directory BEFORE onFile is called for every go file
 	onDir func(dirname, module string) error,
 	// Called on every go file included in the build
-	onFile func(f *ast.File, filename, module string) error,
+	onFile func(f *ast.File, filename, module, constraint string) error,
 ) (goVer string, require []module.Version, err error) {
 	noGoMod := false
 
@@ -96,35 +106,26 @@ func visitDir(
 				if strings.HasSuffix(ent.Name(), "_test.go") {
 					continue
 				}
-				if goos, goarch := filenameSuffixConstraints(ent.Name()); goos != "" || goarch != "" {
+				if goos, goarch := filenameSuffixConstraints(ent.Name()); (goos != "" && !slices.Contains(tags, goos)) ||
+					(goarch != "" && !slices.Contains(tags, goarch)) {
 					continue
 				}
 				f, err := parser.ParseFile(fset, fsPath, nil, mode)
 				if err != nil {
 					return err
 				}
-				skip, err := func() (bool, error) {
-					for _, c := range f.Comments {
-						for _, c := range c.List {
-							if !constraint.IsGoBuild(c.Text) {
-								continue
-							}
-							expr, err := constraint.Parse(c.Text)
-							if err != nil {
-								return false, err
-							}
-							return !expr.Eval(func(tag string) bool {
-								return false
-							}), nil
-						}
-					}
-					return false, nil
-				}()
+				expr, err := fileConstraint(ent.Name(), f)
 				if err != nil {
 					return err
 				}
-				if skip {
-					continue
+				var constr string
+				if expr != nil {
+					if !expr.Eval(func(tag string) bool {
+						return slices.Contains(tags, tag)
+					}) {
+						continue
+					}
+					constr = expr.String()
 				}
 				if noGoMod {
 					for _, imp := range f.Imports {
@@ -142,7 +143,7 @@ func visitDir(
 				if strings.HasSuffix(modName, "_test") || modName == "main" {
 					continue
 				}
-				if err := onFile(f, fsPath, modPath); err != nil {
+				if err := onFile(f, fsPath, modPath, constr); err != nil {
 					return err
 				}
 			}
@@ -172,9 +173,10 @@ func visitDir(
 //
 // modulePathHint is the full package path (required if no go.mod is present).
 // goVer is the semantic version of the module.
+// tags are the build tags (including GOOS and GOARCH) considered satisfied.
 // modules maps package path to package name.
 // require lists all dependencies of the parsed package.
-func ParseDirModules(fset *token.FileSet, dirPath, modulePathHint string) (goVer string, modules map[string]string, require []module.Version, err error) {
+func ParseDirModules(fset *token.FileSet, dirPath, modulePathHint string, tags []string) (goVer string, modules map[string]string, require []module.Version, err error) {
 	modules = make(map[string]string)
 	goVer, require, err = visitDir(
 		fset,
@@ -182,13 +184,14 @@ func ParseDirModules(fset *token.FileSet, dirPath, modulePathHint string) (goVer
 		-1,
 		parser.PackageClauseOnly|parser.ImportsOnly|parser.ParseComments,
 		modulePathHint,
+		tags,
 		func(dirname, module string) error {
 			if _, ok := modules[module]; !ok {
 				modules[module] = ""
 			}
 			return nil
 		},
-		func(f *ast.File, filename, module string) error {
+		func(f *ast.File, filename, module, constraint string) error {
 			if name, ok := modules[module]; ok && name != "" && name != f.Name.Name {
 				return fmt.Errorf("module %v has conflicting names: %v and %v", module, name, f.Name.Name)
 			}
@@ -207,8 +210,11 @@ func ParseDirModules(fset *token.FileSet, dirPath, modulePathHint string) (goVer
 //
 // modulePathHint is the full package path (required if no go.mod is present).
 // depth is the maximum depth (-1 for infinite), 1 for only current dir etc.
+// tags are the build tags (including GOOS and GOARCH) considered satisfied.
+// trimBodies drops function bodies and the comments inside them, which
+// make up most of the AST, e.g. to save memory in low-memory mode.
 // pkgs maps package path to [Package].
-func ParseDir(fset *token.FileSet, dirPath string, modulePathHint string, depth int) (pkgs map[string]*Package, err error) {
+func ParseDir(fset *token.FileSet, dirPath string, modulePathHint string, depth int, tags []string, trimBodies bool) (pkgs map[string]*Package, err error) {
 	pkgs = make(map[string]*Package)
 	_, _, err = visitDir(
 		fset,
@@ -216,24 +222,32 @@ func ParseDir(fset *token.FileSet, dirPath string, modulePathHint string, depth
 		depth,
 		parser.SkipObjectResolution|parser.ParseComments,
 		modulePathHint,
+		tags,
 		func(dirname, module string) error {
 			if _, ok := pkgs[module]; ok {
 				return fmt.Errorf("duplicate module %v", module)
 			}
 			pkgs[module] = &Package{
-				Name:  "",
-				Path:  module,
-				Files: make(map[string]*ast.File),
+				Name:        "",
+				Path:        module,
+				Files:       make(map[string]*ast.File),
+				Constraints: make(map[string]string),
 			}
 			return nil
 		},
-		func(f *ast.File, filename, module string) error {
+		func(f *ast.File, filename, module, constraint string) error {
 			pkg, ok := pkgs[module]
 			if !ok {
 				return fmt.Errorf("expected module %v to exist", module)
 			}
 			pkg.Name = f.Name.Name
+			if trimBodies {
+				trimFuncBodies(f)
+			}
 			pkg.Files[filename] = f
+			if constraint != "" {
+				pkg.Constraints[filename] = constraint
+			}
 			return nil
 		},
 	)
@@ -244,6 +258,143 @@ func ParseDir(fset *token.FileSet, dirPath string, modulePathHint string, depth
 	return pkgs, nil
 }
 
+// ParseDirAllTargets parses the package in dirPath (not recursively)
+// from source code for all build targets, i.e. including files with
+// unsatisfied build constraints, e.g. both foo_linux.go and
+// foo_windows.go. Files constrained by the "ignore" tag are skipped.
+func ParseDirAllTargets(fset *token.FileSet, dirPath, modulePath string) (*Package, error) {
+	ents, err := os.ReadDir(dirPath)
+	if err != nil {
+		return nil, err
+	}
+	pkg := &Package{
+		Path:        modulePath,
+		Files:       make(map[string]*ast.File),
+		Constraints: make(map[string]string),
+	}
+	for _, ent := range ents {
+		if ent.IsDir() || !strings.HasSuffix(ent.Name(), ".go") || strings.HasSuffix(ent.Name(), "_test.go") {
+			continue
+		}
+		fsPath := filepath.Join(dirPath, ent.Name())
+		f, err := parser.ParseFile(fset, fsPath, nil, parser.SkipObjectResolution|parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		if strings.HasSuffix(f.Name.Name, "_test") || f.Name.Name == "main" {
+			continue
+		}
+		expr, err := fileConstraint(ent.Name(), f)
+		if err != nil {
+			return nil, err
+		}
+		if expr != nil && expr.String() == "ignore" {
+			continue
+		}
+		pkg.Name = f.Name.Name
+		pkg.Files[fsPath] = f
+		if expr != nil {
+			pkg.Constraints[fsPath] = expr.String()
+		}
+	}
+	return pkg, nil
+}
+
+// trimFuncBodies removes function bodies and the comments inside them,
+// which aren't needed for bindings, but make up most of the AST.
+func trimFuncBodies(f *ast.File) {
+	var bodies []*ast.BlockStmt
+	for _, decl := range f.Decls {
+		if fd, ok := decl.(*ast.FuncDecl); ok && fd.Body != nil {
+			bodies = append(bodies, fd.Body)
+			fd.Body = nil
+		}
+	}
+	if len(bodies) == 0 {
+		return
+	}
+	// Both the bodies and the comments are in source order.
+	i := 0
+	f.Comments = slices.DeleteFunc(f.Comments, func(cg *ast.CommentGroup) bool {
+		for i < len(bodies) && bodies[i].Rbrace < cg.Pos() {
+			i++
+		}
+		return i < len(bodies) && cg.Pos() >= bodies[i].Lbrace && cg.End() <= bodies[i].Rbrace
+	})
+}
+
+// InternIdents replaces the names of all identifiers in f with
+// equal strings from strs (adding them if missing), so repeated names
+// share memory across files.
+func InternIdents(f *ast.File, strs map[string]string) {
+	ast.Inspect(f, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok {
+			if s, ok := strs[id.Name]; ok {
+				id.Name = s
+			} else {
+				strs[id.Name] = id.Name
+			}
+		}
+		return true
+	})
+}
+
+// ParseExamples parses the Example functions from the test files of a single
+// package directory (non-recursively).
+//
+// examples maps the name of the exemplified item without the example
+// suffix (e.g. "Button_SetText" for ExampleButton_SetText_second) to
+// the formatted example code, including the expected output, if any.
+func ParseExamples(fset *token.FileSet, dirPath string) (examples map[string][]string, err error) {
+	ents, err := os.ReadDir(dirPath)
+	if err != nil {
+		return nil, err
+	}
+	var files []*ast.File
+	for _, ent := range ents {
+		if ent.IsDir() || !strings.HasSuffix(ent.Name(), "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, filepath.Join(dirPath, ent.Name()), nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+
+	examples = make(map[string][]string)
+	for _, ex := range doc.Examples(files...) {
+		name := ex.Name
+		if i := strings.LastIndexByte(name, '_'); i != -1 && i+1 < len(name) && unicode.IsLower(rune(name[i+1])) {
+			// Remove suffix
+			name = name[:i]
+		}
+
+		var buf bytes.Buffer
+		if err := format.Node(&buf, fset, ex.Code); err != nil {
+			return nil, err
+		}
+		code := buf.String()
+		if _, ok := ex.Code.(*ast.BlockStmt); ok {
+			// Remove braces and indentation
+			code = strings.TrimSuffix(strings.TrimPrefix(code, "{\n"), "\n}")
+			lines := strings.Split(code, "\n")
+			for i := range lines {
+				lines[i] = strings.TrimPrefix(lines[i], "\t")
+			}
+			code = strings.TrimSpace(strings.Join(lines, "\n"))
+		}
+		if ex.Output != "" {
+			code += "\n// Output:"
+			for _, line := range strings.Split(strings.TrimSuffix(ex.Output, "\n"), "\n") {
+				code += "\n// " + line
+			}
+		}
+		examples[name] = append(examples[name], code)
+	}
+	return examples, nil
+}
+
 var (
 	goosSuffixes   = []string{"aix", "android", "darwin", "dragonfly", "freebsd", "hurd", "illumos", "ios", "js", "linux", "nacl", "netbsd", "openbsd", "plan9", "solaris", "windows", "zos"}
 	goarchSuffixes = []string{"386", "amd64", "amd64p32", "arm", "arm64", "arm64be", "armbe", "loong64", "mips", "mips64", "mips64le", "mips64p32", "mips64p32le", "mipsle", "ppc", "ppc64", "ppc64le", "riscv", "riscv64", "s390", "s390x", "sparc", "sparc64", "wasm"}
@@ -267,3 +418,46 @@ func filenameSuffixConstraints(filename string) (goosConstraint, goarchConstrain
 	}
 	return "", ""
 }
+
+// fileConstraint returns the build constraint implied by the file name
+// suffix and //go:build line, or nil if the file is unconstrained.
+// f must be parsed with comments.
+func fileConstraint(filename string, f *ast.File) (constraint.Expr, error) {
+	var res constraint.Expr
+	and := func(x constraint.Expr) {
+		if res == nil {
+			res = x
+		} else {
+			res = &constraint.AndExpr{X: res, Y: x}
+		}
+	}
+	goos, goarch := filenameSuffixConstraints(filename)
+	if goos != "" {
+		and(&constraint.TagExpr{Tag: goos})
+	}
+	if goarch != "" {
+		and(&constraint.TagExpr{Tag: goarch})
+	}
+	for _, cg := range f.Comments {
+		// Like the go command, only look at the file header, without the
+		// package doc.
+		if cg.Pos() >= f.Package {
+			break
+		}
+		if cg == f.Doc {
+			continue
+		}
+		for _, c := range cg.List {
+			if !constraint.IsGoBuild(c.Text) {
+				continue
+			}
+			expr, err := constraint.Parse(c.Text)
+			if err != nil {
+				return nil, err
+			}
+			and(expr)
+			return res, nil
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,148 @@
+package parser
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const trimSrc = `package p
+
+// A is kept.
+func A() {
+	// Inside A.
+	_ = 1 /* also inside A */
+}
+
+// Between A and B.
+
+type T struct{}
+
+// B is kept.
+func (T) B() int {
+	// Inside B.
+	return 1
+}
+
+func Decl()
+
+// Trailing.
+`
+
+func commentTexts(f *ast.File) []string {
+	var res []string
+	for _, cg := range f.Comments {
+		for _, c := range cg.List {
+			res = append(res, c.Text)
+		}
+	}
+	return res
+}
+
+func TestTrimFuncBodies(t *testing.T) {
+	assert := assert.New(t)
+
+	f, err := parser.ParseFile(token.NewFileSet(), "p.go", trimSrc, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	trimFuncBodies(f)
+	for _, decl := range f.Decls {
+		if fd, ok := decl.(*ast.FuncDecl); ok {
+			assert.Nil(fd.Body, fd.Name.Name)
+		}
+	}
+	assert.Equal([]string{"// A is kept.", "// Between A and B.", "// B is kept.", "// Trailing."}, commentTexts(f))
+	assert.Equal("B is kept.\n", f.Decls[2].(*ast.FuncDecl).Doc.Text())
+}
+
+func TestParseDirTrimBodies(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "p.go"), []byte(trimSrc), 0666); err != nil {
+		t.Fatal(err)
+	}
+	for _, trim := range []bool{false, true} {
+		pkgs, err := ParseDir(token.NewFileSet(), dir, "example.com/p", 1, nil, trim)
+		if !assert.NoError(err) {
+			continue
+		}
+		f := pkgs["example.com/p"].Files[filepath.Join(dir, "p.go")]
+		if !assert.NotNil(f) {
+			continue
+		}
+		assert.Equal(!trim, f.Decls[0].(*ast.FuncDecl).Body != nil)
+		assert.Equal(!trim, len(commentTexts(f)) == 7)
+	}
+}
+
+func TestFileConstraint(t *testing.T) {
+	for _, tt := range []struct {
+		filename string
+		src      string
+		want     string
+	}{
+		{"p.go", "package p\n", ""},
+		{"p_linux.go", "package p\n", "linux"},
+		{"p_linux_amd64.go", "package p\n", "linux && amd64"},
+		{"p_amd64.go", "package p\n", "amd64"},
+		{"p.go", "//go:build cgo || windows\n\npackage p\n", "cgo || windows"},
+		{"p_linux.go", "//go:build cgo\n\npackage p\n", "linux && cgo"},
+		// Not a build constraint, since it's part of the package doc.
+		{"p.go", "// Package p.\n//go:build cgo\npackage p\n", ""},
+		{"p.go", "package p\n\n//go:build cgo\n", ""},
+	} {
+		f, err := parser.ParseFile(token.NewFileSet(), tt.filename, tt.src, parser.ParseComments)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expr, err := fileConstraint(tt.filename, f)
+		if !assert.NoError(t, err, tt.src) {
+			continue
+		}
+		var got string
+		if expr != nil {
+			got = expr.String()
+		}
+		assert.Equal(t, tt.want, got, "%v: %q", tt.filename, tt.src)
+	}
+}
+
+func TestParseDirBuildTags(t *testing.T) {
+	dir := t.TempDir()
+	for name, src := range map[string]string{
+		"p.go":         "package p\n",
+		"p_linux.go":   "package p\n",
+		"p_windows.go": "package p\n",
+		"cgo.go":       "//go:build cgo\n\npackage p\n",
+		"nocgo.go":     "//go:build !cgo\n\npackage p\n",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, tt := range []struct {
+		tags []string
+		want map[string]string
+	}{
+		{nil, map[string]string{"p.go": "", "nocgo.go": "!cgo"}},
+		{[]string{"linux", "cgo"}, map[string]string{"p.go": "", "p_linux.go": "linux", "cgo.go": "cgo"}},
+	} {
+		pkgs, err := ParseDir(token.NewFileSet(), dir, "example.com/p", 1, tt.tags, false)
+		if !assert.NoError(t, err) {
+			continue
+		}
+		pkg := pkgs["example.com/p"]
+		got := make(map[string]string)
+		for path := range pkg.Files {
+			got[filepath.Base(path)] = pkg.Constraints[path]
+		}
+		assert.Equal(t, tt.want, got, "tags: %v", tt.tags)
+	}
+}
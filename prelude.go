@@ -0,0 +1,60 @@
+package ryegen
+
+import "github.com/refaktor/ryegen/binder/binderio"
+
+// writeLoadPrelude writes LoadPrelude to cb, which checks the Rye
+// version, sets up the environment of the assets file if hasAssetsFile
+// and evaluates the KindSpecs (if kindSpecs) and Prelude constants.
+func writeLoadPrelude(cb *binderio.CodeBuilder, fullBindingName string, kindSpecs, hasAssetsFile bool) {
+	if kindSpecs {
+		cb.Linef(`// LoadPrelude evaluates KindSpecs and Prelude in the current context of ps.`)
+	} else {
+		cb.Linef(`// LoadPrelude evaluates Prelude in the current context of ps.`)
+	}
+	cb.Linef(`// Call it after registering Builtins. Fails if CheckRyeVersion does.`)
+	cb.Linef(`func LoadPrelude(ps *env.ProgramState) error {`)
+	cb.Indent++
+	cb.Linef(`if err := CheckRyeVersion(); err != nil {`)
+	cb.Indent++
+	cb.Linef(`return err`)
+	cb.Indent--
+	cb.Linef(`}`)
+	if hasAssetsFile {
+		cb.Linef(`if err := setupEnv(); err != nil {`)
+		cb.Indent++
+		cb.Linef(`return fmt.Errorf("%v env: %%w", err)`, fullBindingName)
+		cb.Indent--
+		cb.Linef(`}`)
+	}
+	// Evaluates the Rye code in the const codeName.
+	evalCode := func(codeName, desc string) {
+		cb.Linef(`if %v != "" {`, codeName)
+		cb.Indent++
+		cb.Linef(`loaded := loader.LoadStringNEW(%v, false, ps)`, codeName)
+		cb.Linef(`block, ok := loaded.(env.Block)`)
+		cb.Linef(`if !ok {`)
+		cb.Indent++
+		cb.Linef(`return fmt.Errorf("%v %v: parse error: %%v", loaded.Inspect(*ps.Idx))`, fullBindingName, desc)
+		cb.Indent--
+		cb.Linef(`}`)
+		cb.Linef(`ser := ps.Ser`)
+		cb.Linef(`ps.Ser = block.Series`)
+		cb.Linef(`evaldo.EvalBlock(ps)`)
+		cb.Linef(`ps.Ser = ser`)
+		cb.Linef(`if ps.ErrorFlag || ps.FailureFlag {`)
+		cb.Indent++
+		cb.Linef(`return fmt.Errorf("%v %v: %%v", ps.Res.Inspect(*ps.Idx))`, fullBindingName, desc)
+		cb.Indent--
+		cb.Linef(`}`)
+		cb.Indent--
+		cb.Linef(`}`)
+	}
+	if kindSpecs {
+		evalCode("KindSpecs", "kind specs")
+	}
+	evalCode("Prelude", "prelude")
+	cb.Linef(`return nil`)
+	cb.Indent--
+	cb.Linef(`}`)
+	cb.Linef(``)
+}
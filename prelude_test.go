@@ -0,0 +1,99 @@
+package ryegen
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/refaktor/ryegen/binder/binderio"
+)
+
+const preludeTestSrc = `package check
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/refaktor/rye/env"
+
+	"example.com/check/failure"
+	"example.com/check/ok"
+	"example.com/check/parseerror"
+)
+
+func TestLoadPrelude(t *testing.T) {
+	for _, c := range []struct {
+		load func(ps *env.ProgramState) error
+		want string
+	}{
+		{ok.LoadPrelude, ""},
+		{parseerror.LoadPrelude, "check prelude: parse error: unbalanced braces"},
+		{failure.LoadPrelude, "check prelude: failed"},
+	} {
+		// Results of earlier evaluations aren't reported.
+		ps := &env.ProgramState{Idx: &env.Idxs{}, Res: env.NewString("stale")}
+		err := c.load(ps)
+		if got := fmt.Sprint(err); (err != nil || c.want != "") && got != c.want {
+			t.Errorf("expected %q, got %q", c.want, got)
+		}
+	}
+}
+`
+
+func TestLoadPrelude(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go test")
+	}
+
+	mod := t.TempDir()
+	write := func(name string, data []byte) {
+		t.Helper()
+		path := filepath.Join(mod, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0777); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, data, 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, name := range []string{"env/env.go", "evaldo/evaldo.go", "loader/loader.go"} {
+		stub, err := os.ReadFile("binder/bindertest/testdata/ryestub/" + name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		write("rye/"+name, stub)
+	}
+	for pkg, prelude := range map[string]string{
+		"ok":         "print { 1 }",
+		"parseerror": "print { 1",
+		"failure":    "fail",
+	} {
+		var cb binderio.CodeBuilder
+		cb.Linef(`package %v`, pkg)
+		cb.Linef(``)
+		cb.Linef(`import (`)
+		cb.Linef(`	"fmt"`)
+		cb.Linef(``)
+		cb.Linef(`	"github.com/refaktor/rye/env"`)
+		cb.Linef(`	"github.com/refaktor/rye/evaldo"`)
+		cb.Linef(`	"github.com/refaktor/rye/loader"`)
+		cb.Linef(`)`)
+		cb.Linef(``)
+		cb.Linef(`const Prelude = %q`, prelude)
+		cb.Linef(``)
+		writeRyeVersionCheck(&cb, "check", "", false)
+		writeLoadPrelude(&cb, "check", false, false)
+		write(pkg+"/"+pkg+".go", []byte(cb.String()))
+	}
+	write("go.mod", []byte("module example.com/check\n\ngo 1.22\n\nrequire github.com/refaktor/rye v0.0.0\n\nreplace github.com/refaktor/rye => ./rye\n"))
+	write("rye/go.mod", []byte("module github.com/refaktor/rye\n\ngo 1.22\n"))
+	write("check_test.go", []byte(preludeTestSrc))
+
+	cmd := exec.Command("go", "test", ".")
+	cmd.Dir = mod
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOPROXY=off", "GOWORK=off")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("go test: %v\n%s", err, out)
+	}
+}
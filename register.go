@@ -0,0 +1,52 @@
+package ryegen
+
+import (
+	"strconv"
+
+	"github.com/refaktor/ryegen/binder/binderio"
+)
+
+// writeRegisterBuiltins writes RegisterBuiltins to cb, which registers
+// Builtins in the order of the pre-sorted BuiltinNames. Requires the
+// strings import.
+func writeRegisterBuiltins(cb *binderio.CodeBuilder) {
+	cb.Linef(`// RegisterBuiltins registers Builtins in the current context of ps`)
+	cb.Linef(`// like evaldo.RegisterBuiltins2, but in the order of BuiltinNames`)
+	cb.Linef(`// instead of iterating Builtins in random order, so words are always`)
+	cb.Linef(`// indexed the same way. Builtins named "<kind>//<word>" are`)
+	cb.Linef(`// registered as generic builtins of the kind.`)
+	cb.Linef(`func RegisterBuiltins(ps *env.ProgramState) {`)
+	cb.Indent++
+	cb.Linef(`for _, name := range BuiltinNames {`)
+	cb.Indent++
+	cb.Linef(`if kind, word, ok := strings.Cut(name, "//"); ok {`)
+	cb.Indent++
+	cb.Linef(`ps.Gen.Set(ps.Idx.IndexWord(kind), ps.Idx.IndexWord(word), *Builtins[name])`)
+	cb.Indent--
+	cb.Linef(`} else {`)
+	cb.Indent++
+	cb.Linef(`ps.Ctx.Set(ps.Idx.IndexWord(name), *Builtins[name])`)
+	cb.Indent--
+	cb.Linef(`}`)
+	cb.Indent--
+	cb.Linef(`}`)
+	cb.Indent--
+	cb.Linef(`}`)
+	cb.Linef(``)
+}
+
+// writeRegisterInto writes RegisterInto to cb, which registers Builtins
+// in the context named library and loads the prelude (see "library" in
+// config.toml). Requires the evaldo import and LoadPrelude.
+func writeRegisterInto(cb *binderio.CodeBuilder, library string) {
+	cb.Linef(`// RegisterInto registers Builtins in the %v context of ps and loads`, library)
+	cb.Linef(`// the prelude, like hand-written Rye extensions do. Returns the error`)
+	cb.Linef(`// of LoadPrelude.`)
+	cb.Linef(`func RegisterInto(ps *env.ProgramState) error {`)
+	cb.Indent++
+	cb.Linef(`evaldo.RegisterBuiltinsInContext(Builtins, ps, %v)`, strconv.Quote(library))
+	cb.Linef(`return LoadPrelude(ps)`)
+	cb.Indent--
+	cb.Linef(`}`)
+	cb.Linef(``)
+}
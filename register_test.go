@@ -0,0 +1,189 @@
+package ryegen
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/refaktor/ryegen/binder/binderio"
+)
+
+const registerTestSrc = `package check
+
+import (
+	"testing"
+
+	"github.com/refaktor/rye/env"
+)
+
+func TestRegisterBuiltins(t *testing.T) {
+	ps := &env.ProgramState{Idx: &env.Idxs{}, Ctx: env.NewEnv(nil), Gen: env.NewGen()}
+	RegisterBuiltins(ps)
+
+	for _, word := range []string{"greet-hello", "greet-new-greeter"} {
+		idx, ok := ps.Idx.GetIndex(word)
+		if !ok {
+			t.Fatalf("word %v isn't indexed", word)
+		}
+		if obj, ok := ps.Ctx.Get(idx); !ok || obj.Inspect(*ps.Idx) != word {
+			t.Errorf("expected builtin %v in context, got %v", word, obj)
+		}
+	}
+
+	kind, _ := ps.Idx.GetIndex("Go(*greet.Greeter)")
+	word, _ := ps.Idx.GetIndex("greet")
+	if obj, ok := ps.Gen.Get(kind, word); !ok || obj.Inspect(*ps.Idx) != "Go(*greet.Greeter)//greet" {
+		t.Errorf("expected generic builtin, got %v", obj)
+	}
+	if _, ok := ps.Idx.GetIndex("Go(*greet.Greeter)//greet"); ok {
+		t.Error("generic builtin registered under its full name")
+	}
+}
+`
+
+func TestRegisterBuiltins(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go test")
+	}
+
+	mod := t.TempDir()
+	write := func(name string, data []byte) {
+		t.Helper()
+		path := filepath.Join(mod, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0777); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, data, 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	stub, err := os.ReadFile("binder/bindertest/testdata/ryestub/env/env.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cb binderio.CodeBuilder
+	cb.Linef(`package check`)
+	cb.Linef(``)
+	cb.Linef(`import (`)
+	cb.Linef(`	"strings"`)
+	cb.Linef(``)
+	cb.Linef(`	"github.com/refaktor/rye/env"`)
+	cb.Linef(`)`)
+	cb.Linef(``)
+	cb.Linef(`var Builtins = map[string]*env.Builtin{`)
+	for _, name := range []string{"Go(*greet.Greeter)//greet", "greet-hello", "greet-new-greeter"} {
+		cb.Linef(`	%q: {Doc: %q},`, name, name)
+	}
+	cb.Linef(`}`)
+	cb.Linef(``)
+	cb.Linef(`var BuiltinNames = []string{"Go(*greet.Greeter)//greet", "greet-hello", "greet-new-greeter"}`)
+	cb.Linef(``)
+	writeRegisterBuiltins(&cb)
+	write("go.mod", []byte("module example.com/check\n\ngo 1.22\n\nrequire github.com/refaktor/rye v0.0.0\n\nreplace github.com/refaktor/rye => ./rye\n"))
+	write("rye/go.mod", []byte("module github.com/refaktor/rye\n\ngo 1.22\n"))
+	write("rye/env/env.go", stub)
+	write("check.go", []byte(cb.String()))
+	write("check_test.go", []byte(registerTestSrc))
+
+	cmd := exec.Command("go", "test", ".")
+	cmd.Dir = mod
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOPROXY=off", "GOWORK=off")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("go test: %v\n%s", err, out)
+	}
+}
+
+const registerIntoTestSrc = `package check
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/refaktor/rye/env"
+
+	"example.com/check/failure"
+	"example.com/check/ok"
+)
+
+func TestRegisterInto(t *testing.T) {
+	ps := &env.ProgramState{Idx: &env.Idxs{}, Ctx: env.NewEnv(nil), Res: env.Void{}}
+	if err := ok.RegisterInto(ps); err != nil {
+		t.Fatal(err)
+	}
+	idx, _ := ps.Idx.GetIndex("mylib")
+	obj, found := ps.Ctx.Get(idx)
+	ctx, isCtx := obj.(env.RyeCtx)
+	if !found || !isCtx {
+		t.Fatalf("expected mylib context, got %v", obj)
+	}
+	idx, _ = ps.Idx.GetIndex("hello")
+	if obj, found := ctx.Get(idx); !found || obj.Inspect(*ps.Idx) != "hello" {
+		t.Errorf("expected builtin in mylib context, got %v", obj)
+	}
+
+	ps = &env.ProgramState{Idx: &env.Idxs{}, Ctx: env.NewEnv(nil), Res: env.Void{}}
+	if err := failure.RegisterInto(ps); fmt.Sprint(err) != "check prelude: failed" {
+		t.Errorf("expected prelude error, got %v", err)
+	}
+}
+`
+
+func TestRegisterInto(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go test")
+	}
+
+	mod := t.TempDir()
+	write := func(name string, data []byte) {
+		t.Helper()
+		path := filepath.Join(mod, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0777); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, data, 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, name := range []string{"env/env.go", "evaldo/evaldo.go", "loader/loader.go"} {
+		stub, err := os.ReadFile("binder/bindertest/testdata/ryestub/" + name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		write("rye/"+name, stub)
+	}
+	for pkg, prelude := range map[string]string{
+		"ok":      "print { 1 }",
+		"failure": "fail",
+	} {
+		var cb binderio.CodeBuilder
+		cb.Linef(`package %v`, pkg)
+		cb.Linef(``)
+		cb.Linef(`import (`)
+		cb.Linef(`	"fmt"`)
+		cb.Linef(``)
+		cb.Linef(`	"github.com/refaktor/rye/env"`)
+		cb.Linef(`	"github.com/refaktor/rye/evaldo"`)
+		cb.Linef(`	"github.com/refaktor/rye/loader"`)
+		cb.Linef(`)`)
+		cb.Linef(``)
+		cb.Linef(`var Builtins = map[string]*env.Builtin{"hello": {Doc: "hello"}}`)
+		cb.Linef(``)
+		cb.Linef(`const Prelude = %q`, prelude)
+		cb.Linef(``)
+		writeRyeVersionCheck(&cb, "check", "", false)
+		writeLoadPrelude(&cb, "check", false, false)
+		writeRegisterInto(&cb, "mylib")
+		write(pkg+"/"+pkg+".go", []byte(cb.String()))
+	}
+	write("go.mod", []byte("module example.com/check\n\ngo 1.22\n\nrequire github.com/refaktor/rye v0.0.0\n\nreplace github.com/refaktor/rye => ./rye\n"))
+	write("rye/go.mod", []byte("module github.com/refaktor/rye\n\ngo 1.22\n"))
+	write("check_test.go", []byte(registerIntoTestSrc))
+
+	cmd := exec.Command("go", "test", ".")
+	cmd.Dir = mod
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOPROXY=off", "GOWORK=off")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("go test: %v\n%s", err, out)
+	}
+}
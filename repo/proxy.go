@@ -0,0 +1,226 @@
+package repo
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"golang.org/x/mod/module"
+)
+
+const defaultGoProxy = "https://proxy.golang.org,direct"
+
+type proxyEntry struct {
+	URL string
+	// Try the next proxy on any error, not just 404 and 410.
+	FallThroughOnError bool
+}
+
+// proxyList returns the proxies to try for pkg in order, respecting
+// GOPROXY, GOPRIVATE and GONOPROXY. proxy overrides them if not empty,
+// using the same syntax as GOPROXY.
+//
+// Entries after "off" are ignored, since lookups stop there.
+// Direct VCS access is not supported, so "direct" entries are skipped.
+// Checksums aren't verified, so GONOSUMDB and GONOSUMCHECK are irrelevant.
+func proxyList(proxy, pkg string) ([]proxyEntry, error) {
+	goProxy := proxy
+	if goProxy == "" {
+		goProxy = os.Getenv("GOPROXY")
+	}
+	if goProxy == "" {
+		goProxy = defaultGoProxy
+	}
+	if proxy == "" {
+		noProxy := os.Getenv("GONOPROXY")
+		if noProxy == "" {
+			noProxy = os.Getenv("GOPRIVATE")
+		}
+		if noProxy != "" && module.MatchPrefixPatterns(noProxy, pkg) {
+			return nil, fmt.Errorf("%v is private (matches GONOPROXY/GOPRIVATE), but direct VCS access is unsupported; set \"proxy\" in config.toml to a proxy serving it", pkg)
+		}
+	}
+
+	var res []proxyEntry
+entries:
+	for goProxy != "" {
+		var entry string
+		var fallThroughOnError bool
+		if i := strings.IndexAny(goProxy, ",|"); i != -1 {
+			entry = goProxy[:i]
+			fallThroughOnError = goProxy[i] == '|'
+			goProxy = goProxy[i+1:]
+		} else {
+			entry = goProxy
+			goProxy = ""
+		}
+		entry = strings.TrimSpace(entry)
+		switch entry {
+		case "", "direct":
+			continue
+		case "off":
+			res = append(res, proxyEntry{URL: "off"})
+			break entries
+		default:
+			res = append(res, proxyEntry{URL: entry, FallThroughOnError: fallThroughOnError})
+		}
+	}
+	if len(res) == 0 {
+		return nil, errors.New("no usable module proxy configured (direct VCS access is unsupported)")
+	}
+	return res, nil
+}
+
+// fetchFromProxies requests the given path for pkg from each proxy in
+// order (see [proxyList]) until one succeeds, like the go command does.
+func fetchFromProxies(proxy, pkg string, path ...string) ([]byte, error) {
+	proxies, err := proxyList(proxy, pkg)
+	if err != nil {
+		return nil, err
+	}
+	var resErr error
+	for _, p := range proxies {
+		if p.URL == "off" {
+			return nil, fmt.Errorf("module lookup disabled by GOPROXY=off: %v", pkg)
+		}
+		u, err := proxyRequestURL(p.URL, pkg, path...)
+		if err != nil {
+			return nil, err
+		}
+		data, notFound, err := httpGet(u)
+		if err == nil {
+			return data, nil
+		}
+		resErr = err
+		if !notFound && !p.FallThroughOnError {
+			break
+		}
+	}
+	return nil, resErr
+}
+
+// httpGet gets the URL, authenticating with credentials from the URL
+// or netrc, if any. notFound is true if the server responded with 404
+// or 410.
+func httpGet(u string) (data []byte, notFound bool, err error) {
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	if req.URL.User == nil {
+		if login, password, ok := netrcLookup(req.URL.Hostname()); ok {
+			req.SetBasicAuth(login, password)
+		}
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, false, err
+	}
+	defer resp.Body.Close()
+
+	data, err = io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone {
+			return nil, true, errors.New(string(data))
+		}
+		return nil, false, fmt.Errorf("get %v: %v (%v)", redactURL(u), resp.Status, resp.StatusCode)
+	}
+	return data, false, nil
+}
+
+func redactURL(u string) string {
+	pu, err := url.Parse(u)
+	if err != nil {
+		return u
+	}
+	return pu.Redacted()
+}
+
+// netrcLookup looks up the credentials for host in the netrc file
+// ($NETRC, or .netrc/_netrc in the home directory).
+func netrcLookup(host string) (login, password string, ok bool) {
+	path := os.Getenv("NETRC")
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", "", false
+		}
+		name := ".netrc"
+		if runtime.GOOS == "windows" {
+			name = "_netrc"
+		}
+		path = filepath.Join(home, name)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return "", "", false
+	}
+	defer f.Close()
+	return parseNetrc(f, host)
+}
+
+func parseNetrc(r io.Reader, host string) (login, password string, ok bool) {
+	sc := bufio.NewScanner(r)
+	sc.Split(bufio.ScanWords)
+	var inMachine, isDefault bool
+	var defLogin, defPassword string
+	var haveDefault bool
+	for sc.Scan() {
+		switch sc.Text() {
+		case "machine":
+			if inMachine && login != "" {
+				return login, password, true
+			}
+			if !sc.Scan() {
+				break
+			}
+			inMachine = sc.Text() == host
+			isDefault = false
+			login, password = "", ""
+		case "default":
+			if inMachine && login != "" {
+				return login, password, true
+			}
+			inMachine = false
+			isDefault = true
+			haveDefault = true
+		case "login":
+			if !sc.Scan() {
+				break
+			}
+			if inMachine {
+				login = sc.Text()
+			} else if isDefault {
+				defLogin = sc.Text()
+			}
+		case "password":
+			if !sc.Scan() {
+				break
+			}
+			if inMachine {
+				password = sc.Text()
+			} else if isDefault {
+				defPassword = sc.Text()
+			}
+		}
+	}
+	if inMachine && login != "" {
+		return login, password, true
+	}
+	if haveDefault && defLogin != "" {
+		return defLogin, defPassword, true
+	}
+	return "", "", false
+}
@@ -0,0 +1,84 @@
+package repo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProxyList(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Setenv("GOPROXY", "https://a.example|https://b.example,direct,off,https://c.example")
+	t.Setenv("GONOPROXY", "")
+	t.Setenv("GOPRIVATE", "example.com/private")
+
+	res, err := proxyList("", "example.com/m")
+	assert.NoError(err)
+	// Lookups stop at "off".
+	assert.Equal([]proxyEntry{
+		{URL: "https://a.example", FallThroughOnError: true},
+		{URL: "https://b.example"},
+		{URL: "off"},
+	}, res)
+
+	// The explicit proxy overrides GOPROXY and GOPRIVATE.
+	_, err = proxyList("", "example.com/private/m")
+	assert.ErrorContains(err, "example.com/private/m is private")
+	res, err = proxyList("https://d.example", "example.com/private/m")
+	assert.NoError(err)
+	assert.Equal([]proxyEntry{{URL: "https://d.example"}}, res)
+
+	_, err = proxyList("direct", "example.com/m")
+	assert.ErrorContains(err, "no usable module proxy configured")
+
+	t.Setenv("GOPROXY", "")
+	res, err = proxyList("", "example.com/m")
+	assert.NoError(err)
+	assert.Equal([]proxyEntry{{URL: "https://proxy.golang.org"}}, res)
+}
+
+func TestFetchFromProxies(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Setenv("NETRC", filepath.Join(t.TempDir(), "netrc"))
+	t.Setenv("GONOPROXY", "")
+	t.Setenv("GOPRIVATE", "")
+	serve := func(status int, body string) string {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/example.com/m/@v/list" {
+				http.NotFound(w, r)
+				return
+			}
+			w.WriteHeader(status)
+			w.Write([]byte(body))
+		}))
+		t.Cleanup(srv.Close)
+		return srv.URL
+	}
+	notFound := serve(http.StatusNotFound, "not found")
+	failing := serve(http.StatusInternalServerError, "internal error")
+	ok := serve(http.StatusOK, "v1.0.0\n")
+
+	fetch := func(proxy string) (string, error) {
+		data, err := fetchFromProxies(proxy, "example.com/m", "@v", "list")
+		return string(data), err
+	}
+
+	data, err := fetch(notFound + "," + ok)
+	assert.NoError(err)
+	assert.Equal("v1.0.0\n", data)
+
+	// Other errors only fall through after "|".
+	_, err = fetch(failing + "," + ok)
+	assert.ErrorContains(err, "500 Internal Server Error")
+	data, err = fetch(failing + "|" + ok)
+	assert.NoError(err)
+	assert.Equal("v1.0.0\n", data)
+
+	_, err = fetch(notFound + ",off," + ok)
+	assert.ErrorContains(err, "module lookup disabled by GOPROXY=off: example.com/m")
+}
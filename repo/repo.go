@@ -7,7 +7,6 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -15,7 +14,6 @@ import (
 	"strings"
 )
 
-const proxyURL = "https://proxy.golang.org/"
 const goZipURL = "https://github.com/golang/go/archive/refs/tags/"
 
 func proxyRequestURL(proxyURL, pkg string, path ...string) (string, error) {
@@ -64,24 +62,22 @@ func pkgPath(pkg, version string) string {
 	}
 }
 
-// GetLatestVersion tries to retrieve the latest version given a package path.
-func GetLatestVersion(pkg string) (string, error) {
+// GetLatestVersion tries to retrieve the latest version given a package
+// path, skipping versions retracted in the go.mod of the latest version.
+//
+// proxy overrides the module proxy list from the GOPROXY environment
+// variable if not empty. It uses the same syntax as GOPROXY.
+func GetLatestVersion(proxy, pkg string) (string, error) {
 	if pkg == "std" {
 		return "", errors.New("cannot get latest version for pkg std")
 	}
+	return latestUnretracted(proxy, pkg)
+}
 
-	url, err := proxyRequestURL(proxyURL, pkg, "@latest")
-	if err != nil {
-		return "", err
-	}
-
-	resp, err := http.Get(url)
-	if err != nil {
-		return "", err
-	}
-	defer resp.Body.Close()
-
-	b, err := io.ReadAll(resp.Body)
+// getProxyLatestVersion retrieves the latest version of pkg as reported
+// by the proxy, which may be retracted.
+func getProxyLatestVersion(proxy, pkg string) (string, error) {
+	b, err := fetchFromProxies(proxy, pkg, "@latest")
 	if err != nil {
 		return "", err
 	}
@@ -107,9 +103,9 @@ func GetLatestVersion(pkg string) (string, error) {
 //
 // Params are the same as for [Get].
 // Always returns a valid outPath and exactVersion if err == nil.
-func Have(dstPath, pkg, version string) (have bool, outPath string, exactVersion string, err error) {
+func Have(proxy, dstPath, pkg, version string) (have bool, outPath string, exactVersion string, err error) {
 	if version == "" || version == "latest" {
-		v, err := GetLatestVersion(pkg)
+		v, err := GetLatestVersion(proxy, pkg)
 		if err != nil {
 			return false, "", "", err
 		}
@@ -130,44 +126,28 @@ func Have(dstPath, pkg, version string) (have bool, outPath string, exactVersion
 
 // Get downloads a Go package.
 //
+// proxy overrides GOPROXY if not empty (see [GetLatestVersion]).
 // pkg is the go package name, or "std" for the go std library.
 // version is the semantic version (e.g. v1.0.0), "latest" for the latest version, or the go version (e.g. 1.21.5) if pkg == "std".
 // Returns the file path of the downloaded package.
 // To check if a package is already downloaded, see [Have].
-func Get(dstPath, pkg, version string) (string, error) {
-	have, outPath, version, err := Have(dstPath, pkg, version)
+func Get(proxy, dstPath, pkg, version string) (string, error) {
+	have, outPath, version, err := Have(proxy, dstPath, pkg, version)
 	if have {
 		return outPath, nil
 	}
 
-	var zipURL string
+	var data []byte
 	if pkg == "std" {
-		zipURL = goZipURL + "go" + makeGoStdlibVersionValid(version) + ".zip"
+		zipURL := goZipURL + "go" + makeGoStdlibVersionValid(version) + ".zip"
+		data, _, err = httpGet(zipURL)
 	} else {
-		zipURL, err = proxyRequestURL(proxyURL, pkg, "@v", version+".zip")
-		if err != nil {
-			return "", err
-		}
-	}
-
-	resp, err := http.Get(zipURL)
-	if err != nil {
-		return "", err
+		data, err = fetchFromProxies(proxy, pkg, "@v", version+".zip")
 	}
-	defer resp.Body.Close()
-
-	data, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return "", err
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		if resp.StatusCode == http.StatusNotFound {
-			return "", errors.New(string(data))
-		}
-		return "", fmt.Errorf("get %v: %v (%v)", zipURL, resp.Status, resp.StatusCode)
-	}
-
 	if err := unzip(dstPath, data); err != nil {
 		return "", err
 	}
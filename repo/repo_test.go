@@ -13,7 +13,7 @@ import (
 func testRepo(t *testing.T, dir, pkg, version, wantFile string) {
 	t.Log("Downloading", pkg, version)
 
-	path, err := repo.Get(dir, pkg, version)
+	path, err := repo.Get("", dir, pkg, version)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -58,3 +58,49 @@ func TestRepo(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestModuleStatus(t *testing.T) {
+	s, err := repo.ParseModuleStatus([]byte(`// Deprecated: use example.com/new instead.
+module example.com/old
+
+go 1.21
+
+retract (
+	v1.2.0 // Published accidentally.
+	[v1.3.0, v1.3.5]
+	v2.0.0-beta.1
+)
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "use example.com/new instead."; s.Deprecated != want {
+		t.Fatalf("expected deprecation %q, but got %q", want, s.Deprecated)
+	}
+	if rationale, ok := s.Retracted("v1.2.0"); !ok || rationale != "Published accidentally." {
+		t.Fatalf("expected v1.2.0 to be retracted with rationale, but got %q, %v", rationale, ok)
+	}
+	if _, ok := s.Retracted("v1.3.2"); !ok {
+		t.Fatal("expected v1.3.2 to be retracted")
+	}
+	if _, ok := s.Retracted("v1.3.6"); ok {
+		t.Fatal("expected v1.3.6 not to be retracted")
+	}
+
+	for _, tc := range []struct {
+		versions []string
+		want     string
+	}{
+		{[]string{"v1.1.0", "v1.2.0", "v1.3.5"}, "v1.1.0"},
+		{[]string{"v1.1.0", "v1.3.6", "v1.2.0"}, "v1.3.6"},
+		// Releases are preferred over pre-releases.
+		{[]string{"v1.1.0", "v2.0.0-beta.2"}, "v1.1.0"},
+		{[]string{"v2.0.0-beta.1", "v2.0.0-beta.2"}, "v2.0.0-beta.2"},
+		{[]string{"v1.2.0", "v2.0.0-beta.1"}, ""},
+	} {
+		got, ok := s.Latest(tc.versions)
+		if got != tc.want || ok != (tc.want != "") {
+			t.Errorf("latest of %v: expected %q, but got %q", tc.versions, tc.want, got)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package repo
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/semver"
+)
+
+// ModuleStatus is the deprecation and retractions of a module, as
+// declared in the go.mod of its latest version.
+type ModuleStatus struct {
+	// Deprecation notice ("// Deprecated:" comment of the module
+	// directive), empty if not deprecated.
+	Deprecated string
+	retract    []*modfile.Retract
+	// Latest version as reported by the proxy.
+	latest string
+}
+
+// ParseModuleStatus parses the status of a module from the go.mod of its
+// latest version.
+func ParseModuleStatus(goMod []byte) (*ModuleStatus, error) {
+	f, err := modfile.ParseLax("go.mod", goMod, nil)
+	if err != nil {
+		return nil, err
+	}
+	res := &ModuleStatus{retract: f.Retract}
+	if f.Module != nil {
+		res.Deprecated = f.Module.Deprecated
+	}
+	return res, nil
+}
+
+// Retracted reports whether version is retracted, and the rationale
+// given for it (may be empty).
+func (s *ModuleStatus) Retracted(version string) (rationale string, ok bool) {
+	for _, r := range s.retract {
+		if semver.Compare(r.Low, version) <= 0 && semver.Compare(version, r.High) <= 0 {
+			return r.Rationale, true
+		}
+	}
+	return "", false
+}
+
+// Latest returns the latest of versions which isn't retracted,
+// preferring releases over pre-releases like the go command does.
+func (s *ModuleStatus) Latest(versions []string) (string, bool) {
+	versions = slices.Clone(versions)
+	semver.Sort(versions)
+	for _, pre := range []bool{false, true} {
+		for _, v := range slices.Backward(versions) {
+			if !semver.IsValid(v) || (semver.Prerelease(v) != "") != pre {
+				continue
+			}
+			if _, ok := s.Retracted(v); !ok {
+				return v, true
+			}
+		}
+	}
+	return "", false
+}
+
+var (
+	statusCacheMu sync.Mutex
+	// Proxy and module path to status, for not fetching it repeatedly.
+	statusCache = make(map[[2]string]*ModuleStatus)
+)
+
+// GetModuleStatus retrieves the status of pkg from the go.mod of its
+// latest version (as reported by the proxy, ignoring retractions).
+// proxy overrides GOPROXY if not empty (see [GetLatestVersion]).
+func GetModuleStatus(proxy, pkg string) (*ModuleStatus, error) {
+	if pkg == "std" {
+		return &ModuleStatus{}, nil
+	}
+	key := [2]string{proxy, pkg}
+	statusCacheMu.Lock()
+	s, ok := statusCache[key]
+	statusCacheMu.Unlock()
+	if ok {
+		return s, nil
+	}
+	// Fetched without holding the lock, so lookups of other modules
+	// don't wait for it. Concurrent lookups of the same module may both
+	// fetch it, the first one stored wins.
+	latest, err := getProxyLatestVersion(proxy, pkg)
+	if err != nil {
+		return nil, err
+	}
+	goMod, err := fetchFromProxies(proxy, pkg, "@v", latest+".mod")
+	if err != nil {
+		return nil, err
+	}
+	s, err = ParseModuleStatus(goMod)
+	if err != nil {
+		return nil, fmt.Errorf("go.mod of %v %v: %w", pkg, latest, err)
+	}
+	s.latest = latest
+	statusCacheMu.Lock()
+	defer statusCacheMu.Unlock()
+	if cached, ok := statusCache[key]; ok {
+		return cached, nil
+	}
+	statusCache[key] = s
+	return s, nil
+}
+
+// Notices returns notices about pkg at version for the user: whether
+// pkg is deprecated or version is retracted.
+func Notices(proxy, pkg, version string) ([]string, error) {
+	s, err := GetModuleStatus(proxy, pkg)
+	if err != nil {
+		return nil, err
+	}
+	var res []string
+	if s.Deprecated != "" {
+		res = append(res, fmt.Sprintf("%v is deprecated: %v", pkg, s.Deprecated))
+	}
+	if rationale, ok := s.Retracted(version); ok {
+		msg := fmt.Sprintf("%v %v is retracted", pkg, version)
+		if rationale != "" {
+			msg += ": " + rationale
+		}
+		res = append(res, msg)
+	}
+	return res, nil
+}
+
+// latestUnretracted returns the latest version of pkg which isn't
+// retracted.
+func latestUnretracted(proxy, pkg string) (string, error) {
+	s, err := GetModuleStatus(proxy, pkg)
+	if err != nil {
+		return "", err
+	}
+	if _, ok := s.Retracted(s.latest); !ok {
+		return s.latest, nil
+	}
+	list, err := fetchFromProxies(proxy, pkg, "@v", "list")
+	if err != nil {
+		return "", err
+	}
+	if v, ok := s.Latest(strings.Fields(string(list))); ok {
+		return v, nil
+	}
+	return "", errors.New("all versions of " + pkg + " are retracted")
+}
@@ -0,0 +1,72 @@
+package repo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotices(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Setenv("NETRC", filepath.Join(t.TempDir(), "netrc"))
+	t.Setenv("GONOPROXY", "")
+	t.Setenv("GOPRIVATE", "")
+	release := make(chan struct{})
+	requests := make(map[string]int)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/example.com/slow/@latest":
+			<-release
+			w.Write([]byte(`{"Version":"v1.0.0"}`))
+		case "/example.com/m/@latest":
+			requests[r.URL.Path]++
+			w.Write([]byte(`{"Version":"v1.1.0"}`))
+		case "/example.com/m/@v/v1.1.0.mod":
+			requests[r.URL.Path]++
+			w.Write([]byte("// Deprecated: use example.com/n.\nmodule example.com/m\n\nretract v1.0.0 // Broken.\n"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	t.Cleanup(func() {
+		select {
+		case <-release:
+		default:
+			close(release)
+		}
+	})
+
+	// Lookups of other modules don't wait for the slow one.
+	go GetModuleStatus(srv.URL, "example.com/slow")
+	time.Sleep(10 * time.Millisecond)
+	done := make(chan struct{})
+	var notices []string
+	var err error
+	go func() {
+		notices, err = Notices(srv.URL, "example.com/m", "v1.0.0")
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("blocked by the lookup of another module")
+	}
+	close(release)
+	assert.NoError(err)
+	assert.Equal([]string{
+		"example.com/m is deprecated: use example.com/n.",
+		"example.com/m v1.0.0 is retracted: Broken.",
+	}, notices)
+
+	// The status is fetched once.
+	notices, err = Notices(srv.URL, "example.com/m", "v1.1.0")
+	assert.NoError(err)
+	assert.Equal([]string{"example.com/m is deprecated: use example.com/n."}, notices)
+	assert.Equal(map[string]int{"/example.com/m/@latest": 1, "/example.com/m/@v/v1.1.0.mod": 1}, requests)
+}
@@ -0,0 +1,461 @@
+package ryegen
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/mod/module"
+)
+
+const greetSrc = "package greet\n\n// Hello greets name.\nfunc Hello(name string) string { return \"Hello, \" + name }\n"
+
+// writeSrcRepos writes a module example.com/greet v1.0.0 with the source
+// src to dir/_srcrepos, where it's found without downloading (see
+// [repo.Have]), along with an empty std library.
+func writeSrcRepos(t *testing.T, dir, src string) {
+	t.Helper()
+	write := func(name, content string) {
+		t.Helper()
+		path := filepath.Join(dir, "_srcrepos", filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0777); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("example.com/greet@v1.0.0/go.mod", "module example.com/greet\n\ngo 1.21\n")
+	write("example.com/greet@v1.0.0/greet.go", src)
+	write("go-go1.21.0/src/go.mod", "module std\n\ngo 1.21\n")
+}
+
+func TestTryRunWithOptionsDir(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	writeSrcRepos(t, dir, greetSrc)
+	// Not used, since ConfigPath is set.
+	const dirConfig = "package = \"example.com/other\"\n"
+	if err := os.WriteFile(filepath.Join(dir, "config.toml"), []byte(dirConfig), 0666); err != nil {
+		t.Fatal(err)
+	}
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(configPath, []byte("out-dir = \"out\"\npackage = \"example.com/greet\"\nversion = \"v1.0.0\"\nproxy = \"off\"\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var sink MemorySink
+	res, err := TryRunWithOptions(RunOptions{Dir: dir, ConfigPath: configPath, Sink: &sink})
+	if !assert.NoError(err) {
+		return
+	}
+	assert.Equal(filepath.Join(dir, "out", "example_com_greet", "generated.go"), res.OutFile)
+	assert.Equal(filepath.Join(dir, "_srcrepos", "example.com", "greet@v1.0.0"), res.SrcDir)
+	files := sink.Files()
+	assert.Contains(string(files[filepath.ToSlash(res.OutFile)]), "greet.Hello(")
+	for name := range files {
+		assert.True(strings.HasPrefix(name, filepath.ToSlash(dir)+"/"), name)
+	}
+	assert.Contains(files, filepath.ToSlash(filepath.Join(dir, "bindings.txt")))
+	// Without a go.mod, the data tables can't be imported from a package
+	// of their own.
+	assert.Contains(files, filepath.ToSlash(filepath.Join(dir, "out", "example_com_greet", "generated_data.go")))
+	// Everything in generated.go is either part of a package's builtins
+	// or shared.
+	if assert.Len(res.Summary.Packages, 1) {
+		assert.Equal("example.com/greet", res.Summary.Packages[0].Pkg)
+		assert.Positive(res.Summary.SharedSize)
+		assert.Equal(res.Summary.Outputs[res.OutFile], res.Summary.Packages[0].Size+res.Summary.SharedSize)
+	}
+
+	// Neither the working directory nor dir/config.toml changed.
+	newWd, err := os.Getwd()
+	assert.NoError(err)
+	assert.Equal(wd, newWd)
+	data, err := os.ReadFile(filepath.Join(dir, "config.toml"))
+	assert.NoError(err)
+	assert.Equal(dirConfig, string(data))
+}
+
+func TestListConflict(t *testing.T) {
+	dir := t.TempDir()
+	writeSrcRepos(t, dir, greetSrc+"\nfunc List() []string { return nil }\n")
+	configPath := filepath.Join(dir, "config.toml")
+	if err := os.WriteFile(configPath, []byte("out-dir = \"out\"\npackage = \"example.com/greet\"\nversion = \"v1.0.0\"\nproxy = \"off\"\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	var sink MemorySink
+	res, err := TryRunWithOptions(RunOptions{Dir: dir, Sink: &sink})
+	if !assert.NoError(t, err) {
+		return
+	}
+	// The package's List is bound, the list builtin is reported.
+	assert.Contains(t, string(sink.Files()[filepath.ToSlash(res.OutFile)]), "greet.List()")
+	assert.Contains(t, res.Summary.DropCauses, SummaryCause{Cause: "name greet-list is taken by greet.List", Count: 1})
+}
+
+func TestReceiverNamingShort(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	writeSrcRepos(t, dir, greetSrc+`
+type Greeter struct{}
+
+// NewGreeter returns a Go(*greet.Greeter).
+func NewGreeter() *Greeter { return nil }
+
+func (g *Greeter) Greet() string { return "" }
+`)
+	configPath := filepath.Join(dir, "config.toml")
+	if err := os.WriteFile(configPath, []byte("out-dir = \"out\"\npackage = \"example.com/greet\"\nversion = \"v1.0.0\"\nproxy = \"off\"\nreceiver-naming = \"short\"\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	var sink MemorySink
+	res, err := TryRunWithOptions(RunOptions{Dir: dir, Sink: &sink})
+	if !assert.NoError(err) {
+		return
+	}
+	code := string(sink.Files()[filepath.ToSlash(res.OutFile)])
+	assert.Contains(code, `"greet-greeter//greet"`)
+	assert.Contains(code, `"greet-greeter")`)
+	// Doc text isn't rewritten.
+	assert.Contains(code, "returns a Go(*greet.Greeter).")
+	assert.Contains(string(sink.Files()[filepath.ToSlash(filepath.Join(dir, "bindings.txt"))]), "Go(*greet.Greeter)//greet")
+}
+
+func TestRunOptionsDocsAndUpdateLock(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	writeSrcRepos(t, dir, greetSrc)
+	if err := os.WriteFile(filepath.Join(dir, "config.toml"), []byte("out-dir = \"out\"\npackage = \"example.com/greet\"\nversion = \"v1.0.0\"\nproxy = \"off\"\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	// Outdated lock file.
+	lockPath := filepath.Join(dir, "ryegen.lock")
+	if err := os.WriteFile(lockPath, []byte("example.com/greet v0.9.0\nstd go1.21.0\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := TryRunWithOptions(RunOptions{Dir: dir, Sink: &MemorySink{}})
+	assert.ErrorContains(err, "example.com/greet is locked at v0.9.0, but resolved to v1.0.0")
+
+	var sink MemorySink
+	_, err = TryRunWithOptions(RunOptions{Dir: dir, Sink: &sink, Docs: true, UpdateLock: true})
+	if !assert.NoError(err) {
+		return
+	}
+	files := sink.Files()
+	assert.Contains(string(files[filepath.ToSlash(lockPath)]), "example.com/greet v1.0.0\n")
+	assert.Contains(string(files[filepath.ToSlash(filepath.Join(dir, "docs", "example_com_greet.md"))]),
+		"| `greet-hello` | `greet.Hello` | `name:string -> string` | Hello greets name. |")
+}
+
+func TestDictStructHiddenFieldsWarning(t *testing.T) {
+	dir := t.TempDir()
+	writeSrcRepos(t, dir, greetSrc+`
+type Server struct {
+	Host  string
+	token string
+}
+
+func Servers() map[string]Server { return nil }
+`)
+	configPath := filepath.Join(dir, "config.toml")
+	if err := os.WriteFile(configPath, []byte("out-dir = \"out\"\npackage = \"example.com/greet\"\nversion = \"v1.0.0\"\nproxy = \"off\"\ndict-structs = [\"*\"]\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := TryRunWithOptions(RunOptions{Dir: dir, Sink: &MemorySink{}})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.ErrorContains(t, res.Warn, "dict-structs: greet.Server has unexported fields (token)")
+}
+
+func TestBuildTags(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	writeSrcRepos(t, dir, greetSrc)
+	for name, src := range map[string]string{
+		"greet_linux.go":   "package greet\n\n// Tux greets penguins.\nfunc Tux() string { return \"\" }\n",
+		"greet_windows.go": "package greet\n\nfunc Windows() string { return \"\" }\n",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, "_srcrepos", "example.com", "greet@v1.0.0", name), []byte(src), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	configPath := filepath.Join(dir, "config.toml")
+	if err := os.WriteFile(configPath, []byte("out-dir = \"out\"\npackage = \"example.com/greet\"\nversion = \"v1.0.0\"\nproxy = \"off\"\nbuild-tags = [\"linux\"]\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	var sink MemorySink
+	res, err := TryRunWithOptions(RunOptions{Dir: dir, Sink: &sink})
+	if !assert.NoError(err) {
+		return
+	}
+	code := string(sink.Files()[filepath.ToSlash(res.OutFile)])
+	assert.Contains(code, `Doc:   "greet.Tux (build: linux)",`)
+	assert.Contains(code, "\t// Build constraint:\n\t//  * linux\n")
+	assert.Contains(code, `Doc:   "greet.Hello",`)
+	assert.NotContains(code, "greet.Windows")
+}
+
+func TestNoticesCached(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	writeSrcRepos(t, dir, greetSrc)
+	if err := os.WriteFile(filepath.Join(dir, "config.toml"), []byte("out-dir = \"out\"\npackage = \"example.com/greet\"\nversion = \"v1.0.0\"\nproxy = \"off\"\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	const checkErr = "check example.com/greet for deprecation and retractions"
+
+	// Checked with the proxy, which is off.
+	res, err := TryRunWithOptions(RunOptions{Dir: dir, Sink: &MemorySink{}})
+	if !assert.NoError(err) {
+		return
+	}
+	assert.ErrorContains(res.Warn, checkErr)
+
+	// Cached notices.
+	cachePath := filepath.Join(dir, moduleCachePath)
+	c := loadModuleCache(cachePath)
+	c.PutNotices(module.Version{Path: "example.com/greet", Version: "v1.0.0"}, []string{"example.com/greet is deprecated: use example.com/hello"})
+	if err := c.Save(FileSink{}, cachePath); err != nil {
+		t.Fatal(err)
+	}
+	res, err = TryRunWithOptions(RunOptions{Dir: dir, Sink: &MemorySink{}})
+	if !assert.NoError(err) {
+		return
+	}
+	assert.Equal([]string{"example.com/greet is deprecated: use example.com/hello"}, res.Summary.Notices)
+	if res.Warn != nil {
+		assert.NotContains(res.Warn.Error(), checkErr)
+	}
+
+	// Versions pinned in the lock file aren't checked.
+	if err := os.Remove(cachePath); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "ryegen.lock"), []byte("example.com/greet v1.0.0\nstd 1.21\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	res, err = TryRunWithOptions(RunOptions{Dir: dir, Sink: &MemorySink{}})
+	if !assert.NoError(err) {
+		return
+	}
+	assert.Empty(res.Summary.Notices)
+	if res.Warn != nil {
+		assert.NotContains(res.Warn.Error(), checkErr)
+	}
+}
+
+func TestBrokenExamples(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	writeSrcRepos(t, dir, greetSrc)
+	if err := os.WriteFile(filepath.Join(dir, "_srcrepos", "example.com", "greet@v1.0.0", "example_test.go"), []byte("package greet_test\n\nfunc ExampleHello() {\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "config.toml"), []byte("out-dir = \"out\"\npackage = \"example.com/greet\"\nversion = \"v1.0.0\"\nproxy = \"off\"\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	var sink MemorySink
+	res, err := TryRunWithOptions(RunOptions{Dir: dir, Sink: &sink})
+	if !assert.NoError(err) {
+		return
+	}
+	// The package is bound without its examples.
+	assert.ErrorContains(res.Warn, "example.com/greet: examples: ")
+	assert.Contains(string(sink.Files()[filepath.ToSlash(res.OutFile)]), "greet.Hello(")
+}
+
+func TestStrict(t *testing.T) {
+	assert := assert.New(t)
+
+	const config = "out-dir = \"out\"\npackage = \"example.com/greet\"\nversion = \"v1.0.0\"\nproxy = \"off\"\nstrict = true\n" +
+		// A configuration problem, not a dropped binding.
+		"[bind-args]\n\"example.com/greet.Missing\" = { x = \"1\" }\n"
+	for _, tt := range []struct {
+		src     string
+		wantErr string
+	}{
+		{greetSrc, ""},
+		{greetSrc + "\nfunc Listen(a, b, c, d, e, f int) {}\n", "strict mode: 1 bindings dropped:\n  * "},
+	} {
+		dir := t.TempDir()
+		writeSrcRepos(t, dir, tt.src)
+		if err := os.WriteFile(filepath.Join(dir, "config.toml"), []byte(config), 0666); err != nil {
+			t.Fatal(err)
+		}
+
+		res, err := TryRunWithOptions(RunOptions{Dir: dir, Sink: &MemorySink{}})
+		if tt.wantErr != "" {
+			if assert.ErrorContains(err, tt.wantErr) {
+				assert.Contains(err.Error(), "greet.Listen")
+				assert.NotContains(err.Error(), "bind-args")
+			}
+			continue
+		}
+		if assert.NoError(err) {
+			assert.ErrorContains(res.Warn, "bind-args: example.com/greet.Missing is not a bound function or method")
+		}
+	}
+}
+
+func TestSetters(t *testing.T) {
+	assert := assert.New(t)
+
+	const src = greetSrc + "\ntype Greeter struct {\n\tName string\n}\n"
+	for _, tt := range []struct {
+		config     string
+		wantSetter bool
+	}{
+		{"", true},
+		{"[setters]\n\"*\" = false\n", false},
+		{"[setters]\n\"*\" = false\n\"example.com/greet\" = true\n", true},
+	} {
+		dir := t.TempDir()
+		writeSrcRepos(t, dir, src)
+		config := "out-dir = \"out\"\npackage = \"example.com/greet\"\nversion = \"v1.0.0\"\nproxy = \"off\"\n" + tt.config
+		if err := os.WriteFile(filepath.Join(dir, "config.toml"), []byte(config), 0666); err != nil {
+			t.Fatal(err)
+		}
+
+		var sink MemorySink
+		res, err := TryRunWithOptions(RunOptions{Dir: dir, Sink: &sink})
+		if !assert.NoError(err) {
+			continue
+		}
+		out := string(sink.Files()[filepath.ToSlash(res.OutFile)])
+		bindingsTxt := string(sink.Files()[filepath.ToSlash(filepath.Join(dir, "bindings.txt"))])
+		assert.Contains(out, `m["Go(*greet.Greeter)//name?"] = `, tt.config)
+		if tt.wantSetter {
+			assert.Contains(out, `m["Go(*greet.Greeter)//name!"] = `, tt.config)
+		} else {
+			assert.NotContains(out, `m["Go(*greet.Greeter)//name!"] = `, tt.config)
+			assert.NotContains(bindingsTxt, "Go(*greet.Greeter)//name!", tt.config)
+		}
+	}
+}
+
+func TestInits(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	writeSrcRepos(t, dir, greetSrc+"\nfunc init() {}\n")
+	for name, src := range map[string]string{
+		"driver/driver.go":   "package driver\n\nfunc init() {}\n",
+		"devices/devices.go": "package devices\n\ntype Device int\n\nfunc init() {}\n",
+		"hw/hw.go":           "package hw\n\nimport \"example.com/greet/devices\"\n\nfunc Use(d devices.Device) {}\n",
+	} {
+		path := filepath.Join(dir, "_srcrepos", "example.com", "greet@v1.0.0", filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0777); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(src), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	const config = "out-dir = \"out\"\npackage = \"example.com/greet\"\nversion = \"v1.0.0\"\nproxy = \"off\"\n" +
+		"blank-imports = [\"example.com/greet/driver\"]\nexclude-imports = [\"example.com/greet/devices\"]\ninit-report = true\n"
+	if err := os.WriteFile(filepath.Join(dir, "config.toml"), []byte(config), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	var sink MemorySink
+	res, err := TryRunWithOptions(RunOptions{Dir: dir, Sink: &sink})
+	if !assert.NoError(err) {
+		return
+	}
+	out := string(sink.Files()[filepath.ToSlash(res.OutFile)])
+	_, imports, _ := strings.Cut(out, "\nimport (\n")
+	imports, _, _ = strings.Cut(imports, "\n)\n")
+	assert.Contains(imports, "\t\"example.com/greet\"\n\t_ \"example.com/greet/driver\"\n")
+	assert.NotContains(imports, "example.com/greet/hw")
+	assert.NotContains(imports, "example.com/greet/devices")
+	assert.ErrorContains(res.Warn, "hw.Use (devices.Device) -> (): imports example.com/greet/devices, whose init is excluded")
+
+	report := string(sink.Files()[filepath.ToSlash(filepath.Join(dir, "inits.txt"))])
+	assert.Contains(report, "\nexample.com/greet (example.com/greet)\nexample.com/greet/driver (example.com/greet/driver)\n")
+	assert.NotContains(report, "devices")
+}
+
+func TestParallelDeterministic(t *testing.T) {
+	assert := assert.New(t)
+
+	var src strings.Builder
+	src.WriteString(greetSrc + "\ntype Greeter struct {\n\tName  string\n\tNames []string\n}\n")
+	for i := range 50 {
+		fmt.Fprintf(&src, "\nfunc F%v(a []int, b map[string]*Greeter) (Greeter, error) { return Greeter{}, nil }\n", i)
+		fmt.Fprintf(&src, "\nfunc (g *Greeter) M%v(f func(x []string) int) []*Greeter { return nil }\n", i)
+	}
+	dir := t.TempDir()
+	writeSrcRepos(t, dir, src.String())
+	if err := os.WriteFile(filepath.Join(dir, "config.toml"), []byte("out-dir = \"out\"\npackage = \"example.com/greet\"\nversion = \"v1.0.0\"\nproxy = \"off\"\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	gen := func(procs int) map[string][]byte {
+		defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(procs))
+		var sink MemorySink
+		if _, err := TryRunWithOptions(RunOptions{Dir: dir, Sink: &sink}); err != nil {
+			t.Fatal(err)
+		}
+		files := sink.Files()
+		// Not generated code, and gob doesn't encode maps in order.
+		delete(files, filepath.ToSlash(filepath.Join(dir, moduleCachePath)))
+		return files
+	}
+	want := gen(1)
+	got := gen(8)
+	assert.Contains(string(want[filepath.ToSlash(filepath.Join(dir, "bindings.txt"))]), "greet.F49")
+	assert.Equal(slices.Sorted(maps.Keys(want)), slices.Sorted(maps.Keys(got)))
+	for name, data := range want {
+		assert.True(bytes.Equal(data, got[name]), "%v differs", name)
+	}
+}
+
+func TestConverterStats(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	writeSrcRepos(t, dir, greetSrc+"\nfunc Sum(xs []int) int { return 0 }\n")
+	if err := os.WriteFile(filepath.Join(dir, "config.toml"), []byte("out-dir = \"out\"\npackage = \"example.com/greet\"\nversion = \"v1.0.0\"\nproxy = \"off\"\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "bindings.txt"), []byte("[disabled]\ngreet-sum\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := TryRunWithOptions(RunOptions{Dir: dir, Sink: &MemorySink{}})
+	if !assert.NoError(err) {
+		return
+	}
+	// The disabled binding's conversions only count in the totals.
+	assert.Contains(res.Stats, "Generated 1 Rye to Go and 1 Go to Rye conversions in written builtins.\n")
+	assert.Regexp(`\| rye-to-go/array +\| +0/1 +\|`, res.Stats)
+	assert.Regexp(`\| rye-to-go/builtin +\| +1/2 +\|`, res.Stats)
+	assert.Regexp(`\| go-to-rye/builtin +\| +1/2 +\|`, res.Stats)
+	assert.Contains(res.Stats, "Converters unused by written builtins:\n  * rye-to-go/array (only used by disabled builtins, e.g. greet-sum)\n")
+	assert.Contains(res.Stats, "\n  * go-to-rye/array\n")
+}
@@ -0,0 +1,81 @@
+// Package ryegentest helps binding repositories check in their tests
+// that bindings still generate and compile.
+package ryegentest
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/refaktor/ryegen"
+	"github.com/refaktor/ryegen/config"
+)
+
+// GenerateInto runs binding generation in dir and builds the resulting
+// package with "go build", failing t if either fails. dir must be inside
+// a Go module which requires Rye and the bound package.
+//
+// If cfg is not nil, it is used instead of dir/config.toml (which is
+// left as it is), otherwise dir/config.toml is used. An existing
+// dir/bindings.txt is respected. The working directory isn't changed,
+// so tests generating into different directories can run in parallel.
+//
+// Returns the path of the generated file.
+//
+// Example:
+//
+//	func TestBindings(t *testing.T) {
+//		ryegentest.GenerateInto(t, nil, ".")
+//	}
+func GenerateInto(t testing.TB, cfg *config.Config, dir string) string {
+	t.Helper()
+
+	opts := ryegen.RunOptions{
+		Dir: dir,
+		OnInfo: func(msg string) {
+			t.Log("ryegen:", msg)
+		},
+	}
+	if cfg != nil {
+		opts.ConfigPath = filepath.Join(t.TempDir(), "config.toml")
+		f, err := os.Create(opts.ConfigPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := toml.NewEncoder(f).Encode(cfg); err != nil {
+			f.Close()
+			t.Fatal(err)
+		}
+		if err := f.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	res, err := ryegen.TryRunWithOptions(opts)
+	if err != nil {
+		t.Fatal("ryegen:", err)
+	}
+	if res.OutFile == "" {
+		// e.g. created default config
+		t.Fatal("ryegen:", res.Warn)
+	}
+	if warn := res.Warn; warn != nil {
+		if multErr, ok := warn.(*multierror.Error); ok {
+			t.Logf("ryegen: %v warnings", len(multErr.Errors))
+		} else {
+			t.Log("ryegen: warning:", warn)
+		}
+	}
+
+	cmd := exec.Command("go", "build", ".")
+	cmd.Dir = filepath.Dir(res.OutFile)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("go build %v: %v\n%s", cmd.Dir, err, out)
+	}
+
+	return res.OutFile
+}
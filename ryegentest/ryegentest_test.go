@@ -0,0 +1,101 @@
+package ryegentest_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/refaktor/ryegen/config"
+	"github.com/refaktor/ryegen/ryegentest"
+)
+
+// fatalTB records the first failure of a helper instead of failing the
+// test running it.
+type fatalTB struct {
+	testing.TB
+	fatal string
+}
+
+func (tb *fatalTB) Helper()                      {}
+func (tb *fatalTB) Log(args ...any)              {}
+func (tb *fatalTB) Logf(format string, a ...any) {}
+
+func (tb *fatalTB) Fatal(args ...any) {
+	tb.fatal = fmt.Sprint(args...)
+	runtime.Goexit()
+}
+
+func (tb *fatalTB) Fatalf(format string, args ...any) {
+	tb.fatal = fmt.Sprintf(format, args...)
+	runtime.Goexit()
+}
+
+// writeSrcRepos writes a module example.com/greet v1.0.0 to
+// dir/_srcrepos, where it's found without downloading, along with an
+// empty std library.
+func writeSrcRepos(t *testing.T, dir string) {
+	t.Helper()
+	for name, content := range map[string]string{
+		"example.com/greet@v1.0.0/go.mod":   "module example.com/greet\n\ngo 1.21\n",
+		"example.com/greet@v1.0.0/greet.go": "package greet\n\nfunc Hello(name string) string { return \"Hello, \" + name }\n",
+		"go-go1.21.0/src/go.mod":            "module std\n\ngo 1.21\n",
+	} {
+		path := filepath.Join(dir, "_srcrepos", filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0777); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestGenerateInto(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range 2 {
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			t.Parallel()
+			assert := assert.New(t)
+
+			dir := t.TempDir()
+			writeSrcRepos(t, dir)
+			const dirConfig = "package = \"example.com/other\"\n"
+			if err := os.WriteFile(filepath.Join(dir, "config.toml"), []byte(dirConfig), 0666); err != nil {
+				t.Fatal(err)
+			}
+
+			tb := &fatalTB{TB: t}
+			var outFile string
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				outFile = ryegentest.GenerateInto(tb, &config.Config{
+					OutDir:  "out",
+					Package: "example.com/greet",
+					Version: "v1.0.0",
+					Proxy:   "off",
+				}, dir)
+			}()
+			<-done
+
+			// dir isn't in a module requiring Rye, so only building
+			// fails.
+			assert.Contains(tb.fatal, "go build")
+			assert.Empty(outFile)
+			assert.FileExists(filepath.Join(dir, "out", "example_com_greet", "generated.go"))
+			data, err := os.ReadFile(filepath.Join(dir, "config.toml"))
+			assert.NoError(err)
+			assert.Equal(dirConfig, string(data), "config.toml was overwritten")
+			newWd, err := os.Getwd()
+			assert.NoError(err)
+			assert.Equal(wd, newWd)
+		})
+	}
+}
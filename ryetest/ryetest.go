@@ -0,0 +1,135 @@
+// Package ryetest runs Rye scripts with an interpreter built from
+// generated bindings and compares their output with golden files, for
+// end-to-end tests of bindings.
+package ryetest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// DefaultScriptTimeout is the time a single script run by [RunScripts] may
+// take if no timeout is given.
+const DefaultScriptTimeout = 30 * time.Second
+
+// BuildInterpreter builds the Go main package in dir (usually a
+// directory set up by ryegen-init) into a temporary executable and
+// returns its path, failing t if the build fails.
+func BuildInterpreter(t testing.TB, dir string) string {
+	t.Helper()
+
+	exe := filepath.Join(t.TempDir(), "rye-interpreter")
+	cmd := exec.Command("go", "build", "-o", exe, ".")
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("go build %v: %v\n%s", dir, err, out)
+	}
+	return exe
+}
+
+// RunScripts runs every .rye script in corpusDir with the interpreter
+// executable, each as a parallel subtest named after the script, and
+// compares the combined stdout and stderr with the golden file of the
+// same name ending in .out.
+//
+// Corpus layout:
+//
+//	corpusDir/
+//		hello.rye  # script, run with the corpus dir as working directory
+//		hello.out  # expected output
+//
+// If a golden file doesn't exist, it is written and the subtest fails,
+// so new cases can be added by creating a script and running the tests
+// twice. A script failing with a non-zero exit status is not an error by
+// itself, since the expected output may contain the failure.
+//
+// Each script is killed after timeout (or [DefaultScriptTimeout] if
+// timeout is 0).
+//
+// Example:
+//
+//	func TestScripts(t *testing.T) {
+//		ryegentest.GenerateInto(t, nil, ".")
+//		exe := ryetest.BuildInterpreter(t, ".")
+//		ryetest.RunScripts(t, exe, "testdata", 0)
+//	}
+func RunScripts(t *testing.T, interpreter, corpusDir string, timeout time.Duration) {
+	t.Helper()
+
+	if timeout == 0 {
+		timeout = DefaultScriptTimeout
+	}
+	interpreter, err := filepath.Abs(interpreter)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scripts, err := filepath.Glob(filepath.Join(corpusDir, "*.rye"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(scripts) == 0 {
+		t.Fatalf("no .rye scripts found in %v", corpusDir)
+	}
+
+	for _, script := range scripts {
+		name := strings.TrimSuffix(filepath.Base(script), ".rye")
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			out, err := runScript(interpreter, script, timeout)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := checkGolden(filepath.Join(corpusDir, name+".out"), out); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+// runScript runs script with the interpreter in the script's directory
+// and returns its combined stdout and stderr. It fails if the script
+// doesn't finish within timeout, but not if it exits with a non-zero
+// status.
+func runScript(interpreter, script string, timeout time.Duration) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, interpreter, filepath.Base(script))
+	cmd.Dir = filepath.Dir(script)
+	out, err := cmd.CombinedOutput()
+	if ctx.Err() != nil {
+		return nil, fmt.Errorf("timed out after %v\n%s", timeout, out)
+	}
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		return nil, err
+	}
+	return out, nil
+}
+
+// checkGolden compares out with the contents of goldenFile. If
+// goldenFile doesn't exist, it writes out to it and fails anyway.
+func checkGolden(goldenFile string, out []byte) error {
+	expect, err := os.ReadFile(goldenFile)
+	if errors.Is(err, os.ErrNotExist) {
+		if err := os.WriteFile(goldenFile, out, 0666); err != nil {
+			return err
+		}
+		return fmt.Errorf("no output comparison file found, wrote %v", goldenFile)
+	} else if err != nil {
+		return err
+	}
+	if string(out) != string(expect) {
+		return fmt.Errorf("output differs from %v\n--- expected:\n%s\n--- got:\n%s", goldenFile, expect, out)
+	}
+	return nil
+}
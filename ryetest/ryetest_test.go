@@ -0,0 +1,118 @@
+package ryetest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeInterpreterSrc prints the script it's given, exits with status 3
+// for scripts containing "exit" and hangs for scripts containing
+// "hang".
+const fakeInterpreterSrc = `package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+func main() {
+	data, err := os.ReadFile(os.Args[1])
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	fmt.Print(string(data))
+	if strings.Contains(string(data), "hang") {
+		time.Sleep(time.Minute)
+	}
+	if strings.Contains(string(data), "exit") {
+		os.Exit(3)
+	}
+}
+`
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0777); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func buildFakeInterpreter(t *testing.T) string {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("runs go build")
+	}
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"go.mod":  "module example.com/interp\n\ngo 1.22\n",
+		"main.go": fakeInterpreterSrc,
+	})
+	t.Setenv("GOFLAGS", "-mod=mod")
+	t.Setenv("GOWORK", "off")
+	return BuildInterpreter(t, dir)
+}
+
+func TestRunScripts(t *testing.T) {
+	exe := buildFakeInterpreter(t)
+
+	corpus := t.TempDir()
+	writeFiles(t, corpus, map[string]string{
+		"hello.rye": "print \"hello\"\n",
+		"hello.out": "print \"hello\"\n",
+		// Failing scripts are compared like the others.
+		"fail.rye":  "exit\n",
+		"fail.out":  "exit\n",
+		"notes.txt": "not a script",
+	})
+	RunScripts(t, exe, corpus, 0)
+}
+
+func TestRunScript(t *testing.T) {
+	assert := assert.New(t)
+	exe := buildFakeInterpreter(t)
+
+	corpus := t.TempDir()
+	writeFiles(t, corpus, map[string]string{
+		"ok.rye":   "ok\n",
+		"exit.rye": "exit\n",
+		"hang.rye": "hang\n",
+	})
+
+	out, err := runScript(exe, filepath.Join(corpus, "ok.rye"), time.Minute)
+	assert.NoError(err)
+	assert.Equal("ok\n", string(out))
+
+	out, err = runScript(exe, filepath.Join(corpus, "exit.rye"), time.Minute)
+	assert.NoError(err, "non-zero exit status")
+	assert.Equal("exit\n", string(out))
+
+	_, err = runScript(exe, filepath.Join(corpus, "hang.rye"), 100*time.Millisecond)
+	assert.ErrorContains(err, "timed out after 100ms")
+}
+
+func TestCheckGolden(t *testing.T) {
+	assert := assert.New(t)
+
+	golden := filepath.Join(t.TempDir(), "case.out")
+	err := checkGolden(golden, []byte("first\n"))
+	assert.ErrorContains(err, "no output comparison file found")
+	data, readErr := os.ReadFile(golden)
+	assert.NoError(readErr)
+	assert.Equal("first\n", string(data))
+
+	assert.NoError(checkGolden(golden, []byte("first\n")))
+	assert.ErrorContains(checkGolden(golden, []byte("second\n")), "--- expected:\nfirst\n\n--- got:\nsecond\n")
+}
@@ -0,0 +1,128 @@
+package ryegen
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"golang.org/x/mod/modfile"
+
+	"github.com/refaktor/ryegen/binder/binderio"
+)
+
+const (
+	ryeModulePath    = "github.com/refaktor/rye"
+	semverModulePath = "golang.org/x/mod"
+)
+
+// findGoMod returns the directory and parsed go.mod of the module
+// containing dir, found by searching dir and its parents. Returns a nil
+// file if there is no go.mod.
+func findGoMod(dir string) (modDir string, f *modfile.File, err error) {
+	dir, err = filepath.Abs(dir)
+	if err != nil {
+		return "", nil, err
+	}
+	for {
+		path := filepath.Join(dir, "go.mod")
+		data, err := os.ReadFile(path)
+		if err == nil {
+			f, err := modfile.ParseLax(path, data, nil)
+			if err != nil {
+				return "", nil, err
+			}
+			return dir, f, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return "", nil, err
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil, nil
+		}
+		dir = parent
+	}
+}
+
+// ryeRequirement returns the github.com/refaktor/rye version required by
+// the go.mod of the module containing dir (see [findGoMod]), which the
+// bindings are generated and verified against. hasSemver reports whether
+// the module also requires golang.org/x/mod, which the generated code
+// needs to compare versions. Returns "" if there is no go.mod or it
+// doesn't require Rye.
+func ryeRequirement(dir string) (version string, hasSemver bool, err error) {
+	_, f, err := findGoMod(dir)
+	if err != nil || f == nil {
+		return "", false, err
+	}
+	for _, req := range f.Require {
+		switch req.Mod.Path {
+		case ryeModulePath:
+			version = req.Mod.Version
+		case semverModulePath:
+			hasSemver = true
+		}
+	}
+	return version, hasSemver, nil
+}
+
+// writeRyeVersionCheck writes MinRyeVersion (minVersion, see
+// [ryeRequirement]) and CheckRyeVersion to cb. If minVersion is empty
+// or hasSemver is false, CheckRyeVersion accepts any version (see
+// [ryeVersionInfo]). Otherwise the check imports fmt, runtime/debug and
+// golang.org/x/mod/semver.
+func writeRyeVersionCheck(cb *binderio.CodeBuilder, fullBindingName, minVersion string, hasSemver bool) {
+	cb.Linef(`// MinRyeVersion is the github.com/refaktor/rye version required by`)
+	cb.Linef(`// go.mod when the bindings were generated ("" if unknown).`)
+	cb.Linef(`const MinRyeVersion = %v`, strconv.Quote(minVersion))
+	cb.Linef(``)
+	cb.Linef(`// CheckRyeVersion returns an error if the github.com/refaktor/rye`)
+	cb.Linef(`// version the program is built with is older than MinRyeVersion.`)
+	cb.Linef(`// Replaced and unknown versions are accepted. Called by LoadPrelude.`)
+	if minVersion == "" || !hasSemver {
+		cb.Linef(`func CheckRyeVersion() error { return nil }`)
+		cb.Linef(``)
+		return
+	}
+	cb.Linef(`func CheckRyeVersion() error {`)
+	cb.Indent++
+	cb.Linef(`info, ok := debug.ReadBuildInfo()`)
+	cb.Linef(`if !ok {`)
+	cb.Indent++
+	cb.Linef(`return nil`)
+	cb.Indent--
+	cb.Linef(`}`)
+	cb.Linef(`for _, dep := range info.Deps {`)
+	cb.Indent++
+	cb.Linef(`if dep.Path != %q || dep.Replace != nil || !semver.IsValid(dep.Version) {`, ryeModulePath)
+	cb.Indent++
+	cb.Linef(`continue`)
+	cb.Indent--
+	cb.Linef(`}`)
+	cb.Linef(`if semver.Compare(dep.Version, MinRyeVersion) < 0 {`)
+	cb.Indent++
+	cb.Linef(`return fmt.Errorf("%v: github.com/refaktor/rye %%v is older than %%v required by the bindings, update it with \"go get github.com/refaktor/rye@%%v\"", dep.Version, MinRyeVersion, MinRyeVersion)`, fullBindingName)
+	cb.Indent--
+	cb.Linef(`}`)
+	cb.Indent--
+	cb.Linef(`}`)
+	cb.Linef(`return nil`)
+	cb.Indent--
+	cb.Linef(`}`)
+	cb.Linef(``)
+}
+
+// ryeVersionInfo describes why CheckRyeVersion of the generated code
+// accepts any version, or returns "" if it doesn't.
+func ryeVersionInfo(minVersion string, hasSemver bool) string {
+	switch {
+	case minVersion == "":
+		return "skipped Rye version check: go.mod doesn't require " + ryeModulePath
+	case !hasSemver:
+		return fmt.Sprintf("skipped Rye version check: go.mod doesn't require %v, which is needed to compare versions", semverModulePath)
+	}
+	return ""
+}
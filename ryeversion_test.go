@@ -0,0 +1,86 @@
+package ryegen
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/refaktor/ryegen/binder/binderio"
+)
+
+func TestRyeRequirement(t *testing.T) {
+	assert := assert.New(t)
+
+	mod := t.TempDir()
+	outDir := filepath.Join(mod, "ryegen_bindings")
+	if err := os.MkdirAll(outDir, 0777); err != nil {
+		t.Fatal(err)
+	}
+	writeGoMod := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(mod, "go.mod"), []byte(content), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writeGoMod("module example.com/m\n\ngo 1.22\n\nrequire (\n\tgithub.com/refaktor/rye v0.0.90\n\tgolang.org/x/mod v0.21.0 // indirect\n)\n")
+	version, hasSemver, err := ryeRequirement(outDir)
+	assert.NoError(err)
+	assert.Equal("v0.0.90", version)
+	assert.True(hasSemver)
+	assert.Empty(ryeVersionInfo(version, hasSemver))
+
+	writeGoMod("module example.com/m\n\ngo 1.22\n\nrequire github.com/refaktor/rye v0.0.90\n")
+	version, hasSemver, err = ryeRequirement(outDir)
+	assert.NoError(err)
+	assert.Equal("v0.0.90", version)
+	assert.False(hasSemver)
+	assert.Equal("skipped Rye version check: go.mod doesn't require golang.org/x/mod, which is needed to compare versions", ryeVersionInfo(version, hasSemver))
+
+	writeGoMod("module example.com/m\n\ngo 1.22\n")
+	version, _, err = ryeRequirement(outDir)
+	assert.NoError(err)
+	assert.Empty(version)
+	assert.Equal("skipped Rye version check: go.mod doesn't require github.com/refaktor/rye", ryeVersionInfo(version, hasSemver))
+
+	writeGoMod("module example.com/m\n\nrequire (\n")
+	_, _, err = ryeRequirement(outDir)
+	assert.Error(err)
+}
+
+func TestRyeVersionCheckCompiles(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go vet")
+	}
+
+	mod := t.TempDir()
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(mod, name), []byte(content), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var cb binderio.CodeBuilder
+	cb.Linef(`package check`)
+	cb.Linef(``)
+	cb.Linef(`import (`)
+	cb.Linef(`"fmt"`)
+	cb.Linef(`"runtime/debug"`)
+	cb.Linef(``)
+	cb.Linef(`"golang.org/x/mod/semver"`)
+	cb.Linef(`)`)
+	cb.Linef(``)
+	writeRyeVersionCheck(&cb, "check", "v0.0.90", true)
+	write("check.go", cb.String())
+	write("go.mod", "module example.com/check\n\ngo 1.22\n\nrequire golang.org/x/mod v0.21.0\n")
+
+	cmd := exec.Command("go", "vet", ".")
+	cmd.Dir = mod
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOPROXY=off", "GOWORK=off", "GOSUMDB=off")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("go vet: %v\n%s", err, out)
+	}
+}
@@ -0,0 +1,199 @@
+package ryegen
+
+import (
+	"archive/zip"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/refaktor/ryegen/binder/binderio"
+)
+
+// OutputSink receives the files written by [TryRunWithSink]. Names are
+// paths relative to the working directory (including [RunOptions.Dir]),
+// e.g. "ryegen_bindings/fyne_io_fyne_v2/generated.go".
+type OutputSink interface {
+	// WriteFile writes (or overwrites) the file name.
+	WriteFile(name string, data []byte) error
+}
+
+// OutputRemover is implemented by sinks which can remove files written
+// by previous runs, e.g. generated.not.go after "dont-build-flag" is
+// unset. Sinks which don't implement it only ever receive new files.
+type OutputRemover interface {
+	// RemoveFile removes the file name. It isn't an error if it
+	// doesn't exist.
+	RemoveFile(name string) error
+}
+
+// FileSink writes files to the filesystem, creating parent directories
+// as needed. It is the sink used by [TryRun].
+type FileSink struct{}
+
+// WriteFile replaces name atomically: data is written to a temporary
+// file next to it, which is then renamed, so name is never left partially
+// written (e.g. when the disk is full).
+func (fs FileSink) WriteFile(name string, data []byte) error {
+	tmp, err := fs.writeTemp(name, data)
+	if err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, name); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// writeTemp writes data to a new temporary file in the directory of name
+// and returns its path.
+func (FileSink) writeTemp(name string, data []byte) (string, error) {
+	if err := os.MkdirAll(filepath.Dir(name), os.ModePerm); err != nil {
+		return "", err
+	}
+	f, err := os.CreateTemp(filepath.Dir(name), "."+filepath.Base(name)+".tmp*")
+	if err != nil {
+		return "", err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	// CreateTemp uses 0600, so keep the mode of the file replaced, or
+	// use what os.WriteFile with 0666 typically yields.
+	mode := os.FileMode(0644)
+	if fi, err := os.Stat(name); err == nil {
+		mode = fi.Mode().Perm()
+	}
+	if err := os.Chmod(f.Name(), mode); err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+// writeFiles writes all files to temporary files first and only then
+// renames them into place, so failing to write any of them (e.g. when
+// the disk is full) leaves all previous files as they were.
+func (fs FileSink) writeFiles(files []stagedFile) error {
+	tmps := make([]string, len(files))
+	removeTemps := func() {
+		for _, tmp := range tmps {
+			if tmp != "" {
+				os.Remove(tmp)
+			}
+		}
+	}
+	for i, f := range files {
+		if f.Data == nil {
+			continue
+		}
+		tmp, err := fs.writeTemp(f.Name, f.Data)
+		if err != nil {
+			removeTemps()
+			return fmt.Errorf("write %v: %w", f.Name, err)
+		}
+		tmps[i] = tmp
+	}
+	for i, f := range files {
+		if f.Data == nil {
+			if err := fs.RemoveFile(f.Name); err != nil {
+				removeTemps()
+				return fmt.Errorf("remove %v: %w", f.Name, err)
+			}
+			continue
+		}
+		if err := os.Rename(tmps[i], f.Name); err != nil {
+			removeTemps()
+			return fmt.Errorf("write %v: %w", f.Name, err)
+		}
+		tmps[i] = ""
+	}
+	return nil
+}
+
+func (FileSink) RemoveFile(name string) error {
+	if err := os.Remove(name); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// MemorySink keeps written files in memory, e.g. for tests. It is safe
+// for concurrent use.
+type MemorySink struct {
+	mu    sync.Mutex
+	files map[string][]byte
+}
+
+func (s *MemorySink) WriteFile(name string, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.files == nil {
+		s.files = make(map[string][]byte)
+	}
+	s.files[filepath.ToSlash(name)] = data
+	return nil
+}
+
+func (s *MemorySink) RemoveFile(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.files, filepath.ToSlash(name))
+	return nil
+}
+
+// Files returns the written files by slash-separated name.
+func (s *MemorySink) Files() map[string][]byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	res := make(map[string][]byte, len(s.files))
+	for name, data := range s.files {
+		res[name] = data
+	}
+	return res
+}
+
+// ZipSink writes files into a zip archive. The caller closes W after
+// generation.
+type ZipSink struct {
+	W *zip.Writer
+}
+
+func (s ZipSink) WriteFile(name string, data []byte) error {
+	w, err := s.W.Create(filepath.ToSlash(name))
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// removeOutput removes the file name from sink, if supported.
+func removeOutput(sink OutputSink, name string) error {
+	if r, ok := sink.(OutputRemover); ok {
+		if err := r.RemoveFile(name); err != nil {
+			return fmt.Errorf("remove %v: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// saveCode is like [binderio.CodeBuilder.SaveToFile], but writes to sink.
+func saveCode(sink OutputSink, cb *binderio.CodeBuilder, name string) (fmtErr error, err error) {
+	code, err := cb.FmtString()
+	if err != nil {
+		fmtErr = err
+		code = cb.String()
+	}
+	if err := sink.WriteFile(name, []byte(code)); err != nil {
+		return nil, err
+	}
+	return fmtErr, nil
+}
@@ -0,0 +1,167 @@
+package ryegen
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/refaktor/ryegen/binder/binderio"
+)
+
+// dirNames returns the names of the files in dir.
+func dirNames(t *testing.T, dir string) []string {
+	t.Helper()
+	ents, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var res []string
+	for _, ent := range ents {
+		res = append(res, ent.Name())
+	}
+	return res
+}
+
+func TestFileSink(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	name := filepath.Join(dir, "out", "a.go")
+	var sink FileSink
+	if !assert.NoError(sink.WriteFile(name, []byte("a"))) {
+		return
+	}
+	data, err := os.ReadFile(name)
+	assert.NoError(err)
+	assert.Equal("a", string(data))
+	if runtime.GOOS != "windows" {
+		fi, err := os.Stat(name)
+		if assert.NoError(err) {
+			assert.Equal(os.FileMode(0644), fi.Mode().Perm())
+		}
+		// The mode of replaced files is kept.
+		assert.NoError(os.Chmod(name, 0600))
+		assert.NoError(sink.WriteFile(name, []byte("b")))
+		fi, err = os.Stat(name)
+		if assert.NoError(err) {
+			assert.Equal(os.FileMode(0600), fi.Mode().Perm())
+		}
+	}
+	// No temporary files are left behind.
+	assert.Equal([]string{"a.go"}, dirNames(t, filepath.Dir(name)))
+
+	assert.NoError(sink.RemoveFile(name))
+	assert.NoFileExists(name)
+	assert.NoError(sink.RemoveFile(name), "removing a missing file")
+}
+
+func TestFileSinkWriteFiles(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"a": "old a", "b": "old b", "file": ""})
+	a, b, c := filepath.Join(dir, "a"), filepath.Join(dir, "b"), filepath.Join(dir, "c")
+	read := func(name string) string {
+		t.Helper()
+		data, err := os.ReadFile(name)
+		if err != nil {
+			return ""
+		}
+		return string(data)
+	}
+
+	var sink FileSink
+	// The parent of the last file is a file, so writing it fails and
+	// nothing is changed.
+	err := sink.writeFiles([]stagedFile{
+		{Name: a, Data: []byte("new a")},
+		{Name: b},
+		{Name: filepath.Join(dir, "file", "x"), Data: []byte("x")},
+	})
+	assert.Error(err)
+	assert.Equal("old a", read(a))
+	assert.Equal("old b", read(b))
+	assert.ElementsMatch([]string{"a", "b", "file"}, dirNames(t, dir))
+
+	assert.NoError(sink.writeFiles([]stagedFile{
+		{Name: a, Data: []byte("new a")},
+		{Name: b},
+		{Name: c, Data: []byte("c")},
+	}))
+	assert.Equal("new a", read(a))
+	assert.NoFileExists(b)
+	assert.Equal("c", read(c))
+	assert.ElementsMatch([]string{"a", "c", "file"}, dirNames(t, dir))
+}
+
+func TestMemorySink(t *testing.T) {
+	assert := assert.New(t)
+
+	var sink MemorySink
+	assert.Empty(sink.Files())
+	assert.NoError(sink.RemoveFile("missing"))
+	assert.NoError(sink.WriteFile(filepath.Join("out", "a.go"), []byte("a")))
+	assert.NoError(sink.WriteFile("b.txt", []byte("b")))
+	assert.NoError(sink.WriteFile("b.txt", []byte("bb")))
+	files := sink.Files()
+	assert.Equal(map[string][]byte{"out/a.go": []byte("a"), "b.txt": []byte("bb")}, files)
+
+	// Files returns a copy.
+	delete(files, "b.txt")
+	assert.Len(sink.Files(), 2)
+
+	assert.NoError(sink.RemoveFile(filepath.Join("out", "a.go")))
+	assert.Equal(map[string][]byte{"b.txt": []byte("bb")}, sink.Files())
+}
+
+func TestZipSink(t *testing.T) {
+	assert := assert.New(t)
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	sink := ZipSink{W: zw}
+	assert.NoError(sink.WriteFile(filepath.Join("out", "a.go"), []byte("a")))
+	// Can't remove files, so removing is skipped.
+	assert.NoError(removeOutput(sink, "a.go"))
+	assert.NoError(zw.Close())
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if !assert.NoError(err) || !assert.Len(zr.File, 1) {
+		return
+	}
+	assert.Equal("out/a.go", zr.File[0].Name)
+	rc, err := zr.File[0].Open()
+	if assert.NoError(err) {
+		data, _ := io.ReadAll(rc)
+		rc.Close()
+		assert.Equal("a", string(data))
+	}
+}
+
+func TestSaveCode(t *testing.T) {
+	assert := assert.New(t)
+
+	var sink MemorySink
+	var cb binderio.CodeBuilder
+	cb.Linef(`package a`)
+	cb.Linef(`func  F() {}`)
+	fmtErr, err := saveCode(&sink, &cb, "a.go")
+	assert.NoError(err)
+	assert.NoError(fmtErr)
+	assert.Equal("package a\n\nfunc F() {}\n", string(sink.Files()["a.go"]))
+
+	// Unformattable code is written as is.
+	cb = binderio.CodeBuilder{}
+	cb.Linef(`package a`)
+	cb.Linef(`func {`)
+	fmtErr, err = saveCode(&sink, &cb, "a.go")
+	assert.NoError(err)
+	assert.Error(fmtErr)
+	assert.Equal(cb.String(), string(sink.Files()["a.go"]))
+}
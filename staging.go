@@ -0,0 +1,422 @@
+package ryegen
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/build/constraint"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// stagingSink holds back the files written to it until [stagingSink.commit],
+// so a run failing midway (or interrupted) leaves the outputs of the
+// previous run as they were instead of a mix of old and new files which
+// don't compile together. It is safe for concurrent use.
+type stagingSink struct {
+	sink OutputSink
+
+	mu sync.Mutex
+	// Staged file contents by name; nil for removed files.
+	files map[string][]byte
+	// Names in the order they were first staged.
+	order []string
+}
+
+func newStagingSink(sink OutputSink) *stagingSink {
+	return &stagingSink{
+		sink:  sink,
+		files: make(map[string][]byte),
+	}
+}
+
+func (s *stagingSink) stage(name string, data []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.files[name]; !ok {
+		s.order = append(s.order, name)
+	}
+	s.files[name] = data
+}
+
+func (s *stagingSink) WriteFile(name string, data []byte) error {
+	if data == nil {
+		data = []byte{}
+	}
+	s.stage(name, data)
+	return nil
+}
+
+func (s *stagingSink) RemoveFile(name string) error {
+	s.stage(name, nil)
+	return nil
+}
+
+// commit checks the staged Go files for consistency (see
+// [checkOutputPackages]) and passes the staged files on to the
+// underlying sink. If the sink implements [outputBatchWriter], all
+// files are written at once.
+func (s *stagingSink) commit() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := checkOutputPackages(s.files); err != nil {
+		return err
+	}
+
+	if bw, ok := s.sink.(outputBatchWriter); ok {
+		files := make([]stagedFile, len(s.order))
+		for i, name := range s.order {
+			files[i] = stagedFile{Name: name, Data: s.files[name]}
+		}
+		return bw.writeFiles(files)
+	}
+	for _, name := range s.order {
+		data := s.files[name]
+		if data == nil {
+			if err := removeOutput(s.sink, name); err != nil {
+				return err
+			}
+		} else if err := s.sink.WriteFile(name, data); err != nil {
+			return fmt.Errorf("write %v: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// filesIn returns the files in dir (recursively, by path relative to
+// dir) as they will be once the staged files are committed: the files on
+// disk, replaced or removed by the staged ones.
+func (s *stagingSink) filesIn(dir string) (map[string][]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	res := make(map[string][]byte)
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if errors.Is(err, os.ErrNotExist) && path == dir {
+			return filepath.SkipDir
+		}
+		if err != nil || d.IsDir() {
+			return err
+		}
+		if _, ok := s.files[path]; ok {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, path)
+		res[rel] = data
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for name, data := range s.files {
+		rel, err := filepath.Rel(dir, name)
+		if err != nil || data == nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		res[rel] = data
+	}
+	return res, nil
+}
+
+// stagedFile is a file to be written by an [outputBatchWriter]. Data is
+// nil if the file is to be removed.
+type stagedFile struct {
+	Name string
+	Data []byte
+}
+
+// outputBatchWriter is implemented by sinks which write several files
+// better all at once than one by one.
+type outputBatchWriter interface {
+	writeFiles(files []stagedFile) error
+}
+
+// checkOutputPackages checks that the identifiers used by the Go files in
+// files are declared in their package, i.e. in one of the files or in a
+// Go file already in the same directory (e.g. custom.go), so a generated
+// file never refers to declarations of another generated file which is
+// missing or outdated. Packages are checked once for each combination of
+// the build tags in their files' build constraints (see
+// [buildTagSets]). files maps file names to contents; nil contents are
+// removed files.
+func checkOutputPackages(files map[string][]byte) error {
+	dirs := make(map[string][]string) // directory to staged Go files
+	for name, data := range files {
+		if data != nil && strings.HasSuffix(name, ".go") {
+			dir := filepath.Dir(name)
+			dirs[dir] = append(dirs[dir], name)
+		}
+	}
+
+	var errs []error
+	for _, dir := range slices.Sorted(maps.Keys(dirs)) {
+		fset := token.NewFileSet()
+		srcs := make(map[string][]byte)
+		parsed := make(map[string]*ast.File)
+		addFile := func(name string, src []byte) {
+			f, err := parser.ParseFile(fset, name, src, parser.SkipObjectResolution|parser.ParseComments)
+			if err != nil {
+				return
+			}
+			srcs[name] = src
+			parsed[name] = f
+		}
+
+		staged := dirs[dir]
+		for _, name := range staged {
+			// Unparsable output is still written (unformatted) and
+			// reported as such elsewhere.
+			addFile(name, files[name])
+		}
+		if ents, err := os.ReadDir(dir); err == nil {
+			for _, ent := range ents {
+				name := filepath.Join(dir, ent.Name())
+				if _, ok := files[name]; ok || ent.IsDir() || !strings.HasSuffix(name, ".go") {
+					continue
+				}
+				src, err := os.ReadFile(name)
+				if err != nil {
+					return err
+				}
+				// Files which aren't ours are left to the Go compiler
+				// to complain about.
+				addFile(name, src)
+			}
+		}
+
+		names := slices.Sorted(maps.Keys(parsed))
+		reported := make(map[string]struct{}) // file and name
+		for _, tags := range buildTagSets(parsed) {
+			bctx := build.Default
+			bctx.BuildTags = tags
+			bctx.OpenFile = func(path string) (io.ReadCloser, error) {
+				return io.NopCloser(bytes.NewReader(srcs[path])), nil
+			}
+			decls := make(map[string]struct{})
+			var included []string
+			for _, name := range names {
+				if ok, err := bctx.MatchFile(dir, filepath.Base(name)); err != nil || !ok {
+					continue
+				}
+				maps.Copy(decls, packageDecls(parsed[name]))
+				included = append(included, name)
+			}
+			for _, name := range included {
+				if _, ok := files[name]; !ok {
+					continue
+				}
+				f := parsed[name]
+				for _, undecl := range undeclaredNames(f, decls) {
+					key := name + "\x00" + undecl
+					if _, ok := reported[key]; ok {
+						continue
+					}
+					reported[key] = struct{}{}
+					err := fmt.Errorf("%v: %v is not declared in package %v", name, undecl, f.Name.Name)
+					if len(tags) > 0 {
+						err = fmt.Errorf("%w (build tags %v)", err, strings.Join(tags, ","))
+					}
+					errs = append(errs, err)
+				}
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("inconsistent output, not written: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// maxCombinedBuildTags is the maximum number of build tags whose
+// combinations are all checked by [checkOutputPackages].
+const maxCombinedBuildTags = 6
+
+// buildTagSets returns the sets of build tags to check the package of
+// files with: every combination of the tags in the files' "//go:build"
+// constraints, sorted, starting with none. If there are more than
+// maxCombinedBuildTags tags, only no tags and each tag alone are
+// returned. Tags of the target platform and Go version, which are
+// always satisfied (see [build.Context.MatchFile]), are left out.
+func buildTagSets(files map[string]*ast.File) [][]string {
+	tagSet := make(map[string]struct{})
+	for _, f := range files {
+		for _, cg := range f.Comments {
+			if cg.Pos() > f.Package {
+				break
+			}
+			for _, c := range cg.List {
+				if !constraint.IsGoBuild(c.Text) {
+					continue
+				}
+				expr, err := constraint.Parse(c.Text)
+				if err != nil {
+					continue
+				}
+				expr.Eval(func(tag string) bool {
+					if !implicitBuildTag(tag) {
+						tagSet[tag] = struct{}{}
+					}
+					return false
+				})
+			}
+		}
+	}
+	tags := slices.Sorted(maps.Keys(tagSet))
+
+	res := [][]string{nil}
+	if len(tags) > maxCombinedBuildTags {
+		for _, tag := range tags {
+			res = append(res, []string{tag})
+		}
+		return res
+	}
+	for mask := 1; mask < 1<<len(tags); mask++ {
+		var set []string
+		for i, tag := range tags {
+			if mask&(1<<i) != 0 {
+				set = append(set, tag)
+			}
+		}
+		res = append(res, set)
+	}
+	return res
+}
+
+// implicitBuildTag reports whether tag is satisfied without setting it,
+// e.g. "linux" or "go1.21".
+func implicitBuildTag(tag string) bool {
+	bctx := build.Default
+	return tag == bctx.GOOS || tag == bctx.GOARCH || tag == bctx.Compiler ||
+		(tag == "cgo" && bctx.CgoEnabled) || slices.Contains(bctx.ReleaseTags, tag)
+}
+
+// packageDecls returns the names of the package level declarations of f.
+func packageDecls(f *ast.File) map[string]struct{} {
+	res := make(map[string]struct{})
+	for _, decl := range f.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			if decl.Recv == nil {
+				res[decl.Name.Name] = struct{}{}
+			}
+		case *ast.GenDecl:
+			for _, spec := range decl.Specs {
+				switch spec := spec.(type) {
+				case *ast.TypeSpec:
+					res[spec.Name.Name] = struct{}{}
+				case *ast.ValueSpec:
+					for _, name := range spec.Names {
+						res[name.Name] = struct{}{}
+					}
+				}
+			}
+		}
+	}
+	return res
+}
+
+// undeclaredNames returns the names f refers to which are neither in
+// decls, nor imports, nor predeclared. To keep it simple, scopes aren't
+// tracked: a name declared anywhere in f (e.g. a local variable) counts
+// as declared everywhere in f.
+func undeclaredNames(f *ast.File, decls map[string]struct{}) []string {
+	known := make(map[string]struct{})
+	for _, imp := range f.Imports {
+		if imp.Name != nil {
+			known[imp.Name.Name] = struct{}{}
+		}
+	}
+	// Local declarations, struct fields, composite literal keys and
+	// selected names aren't package level references.
+	notRefs := make(map[*ast.Ident]struct{})
+	ast.Inspect(f, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.SelectorExpr:
+			notRefs[n.Sel] = struct{}{}
+			if x, ok := n.X.(*ast.Ident); ok {
+				// Possibly an import, whose default name may differ
+				// from the last element of its path.
+				notRefs[x] = struct{}{}
+			}
+		case *ast.KeyValueExpr:
+			if key, ok := n.Key.(*ast.Ident); ok {
+				notRefs[key] = struct{}{}
+			}
+		case *ast.Field:
+			for _, name := range n.Names {
+				known[name.Name] = struct{}{}
+			}
+		case *ast.AssignStmt:
+			if n.Tok == token.DEFINE {
+				for _, lhs := range n.Lhs {
+					if id, ok := lhs.(*ast.Ident); ok {
+						known[id.Name] = struct{}{}
+					}
+				}
+			}
+		case *ast.RangeStmt:
+			if n.Tok == token.DEFINE {
+				for _, e := range []ast.Expr{n.Key, n.Value} {
+					if id, ok := e.(*ast.Ident); ok {
+						known[id.Name] = struct{}{}
+					}
+				}
+			}
+		case *ast.ValueSpec:
+			for _, name := range n.Names {
+				known[name.Name] = struct{}{}
+			}
+		case *ast.TypeSpec:
+			known[n.Name.Name] = struct{}{}
+		case *ast.LabeledStmt:
+			known[n.Label.Name] = struct{}{}
+		case *ast.BranchStmt:
+			if n.Label != nil {
+				notRefs[n.Label] = struct{}{}
+			}
+		case *ast.FuncDecl:
+			notRefs[n.Name] = struct{}{}
+		}
+		return true
+	})
+
+	res := make(map[string]struct{})
+	for _, decl := range f.Decls {
+		ast.Inspect(decl, func(n ast.Node) bool {
+			id, ok := n.(*ast.Ident)
+			if !ok || id.Name == "_" {
+				return true
+			}
+			if _, ok := notRefs[id]; ok {
+				return true
+			}
+			if _, ok := known[id.Name]; ok {
+				return true
+			}
+			if _, ok := decls[id.Name]; ok {
+				return true
+			}
+			if types.Universe.Lookup(id.Name) != nil {
+				return true
+			}
+			res[id.Name] = struct{}{}
+			return true
+		})
+	}
+	return slices.Sorted(maps.Keys(res))
+}
@@ -0,0 +1,159 @@
+package ryegen
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUndeclaredNames(t *testing.T) {
+	f, err := parser.ParseFile(token.NewFileSet(), "a.go", `package a
+
+import (
+	"fmt"
+	str "strings"
+)
+
+type T struct{ Field int }
+
+func (t T) M(param int) (res int) {
+	local := param
+	for i, v := range []int{local} {
+		_ = i + v
+	}
+	var v2 = T{Field: 1}
+loop:
+	for {
+		break loop
+	}
+	fmt.Println(str.ToUpper("a"), v2.Field, len("a"), nil, true)
+	return Declared + Missing(param) + missingVar
+}
+`, parser.SkipObjectResolution)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"Missing", "missingVar"}, undeclaredNames(f, map[string]struct{}{"Declared": {}}))
+}
+
+func TestBuildTagSets(t *testing.T) {
+	assert := assert.New(t)
+
+	parse := func(srcs ...string) map[string]*ast.File {
+		t.Helper()
+		res := make(map[string]*ast.File)
+		for i, src := range srcs {
+			f, err := parser.ParseFile(token.NewFileSet(), "", src, parser.ParseComments)
+			if err != nil {
+				t.Fatal(err)
+			}
+			res[string(rune('a'+i))+".go"] = f
+		}
+		return res
+	}
+
+	assert.Equal([][]string{nil}, buildTagSets(parse("package a\n")))
+	assert.Equal([][]string{nil, {"a"}, {"b"}, {"a", "b"}}, buildTagSets(parse(
+		"//go:build a && !b\n\npackage a\n",
+		// Release tags are always satisfied.
+		"//go:build b || go1.1\n\npackage a\n",
+		// Not a build constraint.
+		"package a\n\n//go:build c\n",
+	)))
+
+	var many []string
+	for i := range maxCombinedBuildTags + 1 {
+		many = append(many, "//go:build t"+string(rune('0'+i))+"\n\npackage a\n")
+	}
+	assert.Len(buildTagSets(parse(many...)), 1+maxCombinedBuildTags+1)
+}
+
+func TestCheckOutputPackages(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "custom.go"), []byte("package out\n\nfunc Custom() int { return 1 }\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	path := func(name string) string { return filepath.Join(dir, name) }
+
+	for _, c := range []struct {
+		name  string
+		files map[string]string
+		errs  []string
+	}{
+		{
+			name: "consistent",
+			files: map[string]string{
+				"a.go": "package out\n\nvar A = B + Custom()\n",
+				"b.go": "package out\n\nconst B = 1\n",
+			},
+		},
+		{
+			name: "missing",
+			files: map[string]string{
+				"a.go": "package out\n\nvar A = B + Custom()\n",
+			},
+			errs: []string{"a.go: B is not declared in package out"},
+		},
+		{
+			name: "removed",
+			files: map[string]string{
+				"a.go":      "package out\n\nvar A = Custom()\n",
+				"custom.go": "",
+			},
+			errs: []string{"a.go: Custom is not declared in package out"},
+		},
+		{
+			name: "tagged declaration",
+			files: map[string]string{
+				"a.go":     "package out\n\nvar A = Traced\n",
+				"trace.go": "//go:build trace\n\npackage out\n\nconst Traced = true\n",
+			},
+			errs: []string{"a.go: Traced is not declared in package out"},
+		},
+		{
+			name: "declared in each variant",
+			files: map[string]string{
+				"a.go":         "package out\n\nvar A = Traced\n",
+				"trace.go":     "//go:build trace\n\npackage out\n\nconst Traced = true\n",
+				"notrace.go":   "//go:build !trace\n\npackage out\n\nconst Traced = false\n",
+				"tracehelp.go": "//go:build trace\n\npackage out\n\nvar H = Traced\n",
+			},
+		},
+		{
+			name: "missing in one variant",
+			files: map[string]string{
+				"a.go":       "//go:build !a\n\npackage out\n\nvar A = Shared\n",
+				"shared.go":  "//go:build b\n\npackage out\n\nconst Shared = 1\n",
+				"shared2.go": "//go:build !b\n\npackage out\n\nconst Shared = 2\n",
+				"b.go":       "//go:build b\n\npackage out\n\nvar B = OnlyA\n",
+				"onlya.go":   "//go:build a\n\npackage out\n\nconst OnlyA = 1\n",
+			},
+			errs: []string{"b.go: OnlyA is not declared in package out (build tags b)"},
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			files := make(map[string][]byte)
+			for name, src := range c.files {
+				files[path(name)] = []byte(src)
+				if src == "" {
+					files[path(name)] = nil
+				}
+			}
+			err := checkOutputPackages(files)
+			if len(c.errs) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			if assert.Error(t, err) {
+				for _, msg := range c.errs {
+					assert.Contains(t, err.Error(), path(msg))
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,299 @@
+package ryegen
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Summary describes the outcome of a [TryRun].
+type Summary struct {
+	OutFile  string
+	Duration time.Duration
+	// Number of builtins written to OutFile.
+	Generated int
+	// Number of builtins disabled in bindings.txt (including by
+	// source directives).
+	Excluded int
+	// Bindings excluded for passing unsafe pointers.
+	Unsafe []string
+	// Number of bindings dropped due to errors (e.g. unhandled type
+	// conversions), and their most common causes.
+	Dropped    int
+	DropCauses []SummaryCause
+	// Files written, by name.
+	Outputs map[string]int
+	// Size of the Go source of the written builtins per package,
+	// largest first. This is the size of the generated code, not of the
+	// compiled binary.
+	Packages []SummaryPackage
+	// Size of the Go source in OutFile not belonging to any builtin,
+	// e.g. conversion helpers and interface implementations shared by
+	// the builtins of all packages.
+	SharedSize int
+	// Suggested config.toml snippets, each with a comment line
+	// explaining it.
+	Suggestions []string
+	// Deprecation and retraction notices of the bound module.
+	Notices []string
+}
+
+// SummaryCause is a root cause of dropped bindings.
+type SummaryCause struct {
+	Cause string
+	Count int
+}
+
+// SummaryPackage is the size of the Go source of the builtins written
+// for a package.
+type SummaryPackage struct {
+	Pkg  string
+	Size int
+	// If Size exceeds "max-size" in config.toml, the builtins to disable
+	// to get under it, largest first.
+	Exclude []SummaryBuiltin
+}
+
+// SummaryBuiltin is the size of the Go source of a builtin, including
+// the conversions inlined into it.
+type SummaryBuiltin struct {
+	// Name as in bindings.txt.
+	Name string
+	Size int
+}
+
+// maxSummaryDropCauses is the number of causes listed in [Summary.DropCauses].
+const maxSummaryDropCauses = 5
+
+// setDrops sets the dropped bindings of s from the (non-fatal) errors
+// of generating bindings.
+func (s *Summary) setDrops(errs []error) {
+	var bErrs []error
+	for _, err := range errs {
+		if _, ok := err.(*bindingError); ok {
+			bErrs = append(bErrs, err)
+		}
+	}
+	s.Dropped = len(bErrs)
+	groups := groupWarnings(bErrs, false)
+	for _, g := range groups[:min(len(groups), maxSummaryDropCauses)] {
+		s.DropCauses = append(s.DropCauses, SummaryCause{Cause: g.cause, Count: g.count})
+	}
+}
+
+// setPackages sets the package sizes of s from the sizes of the written
+// builtins by package, proposing builtins to exclude from packages
+// exceeding maxSize (if not 0). codeSize is the size of OutFile, the
+// rest of which is shared code.
+func (s *Summary) setPackages(builtins map[string][]SummaryBuiltin, maxSize, codeSize int) {
+	s.SharedSize = codeSize
+	for pkg, bs := range builtins {
+		p := SummaryPackage{Pkg: pkg}
+		for _, b := range bs {
+			p.Size += b.Size
+		}
+		s.SharedSize -= p.Size
+		if maxSize > 0 && p.Size > maxSize {
+			bs = slices.Clone(bs)
+			slices.SortStableFunc(bs, func(a, b SummaryBuiltin) int {
+				return cmp.Or(-cmp.Compare(a.Size, b.Size), strings.Compare(a.Name, b.Name))
+			})
+			size := p.Size
+			for _, b := range bs {
+				if size <= maxSize {
+					break
+				}
+				p.Exclude = append(p.Exclude, b)
+				size -= b.Size
+			}
+		}
+		s.Packages = append(s.Packages, p)
+	}
+	slices.SortFunc(s.Packages, func(a, b SummaryPackage) int {
+		return cmp.Or(-cmp.Compare(a.Size, b.Size), strings.Compare(a.Pkg, b.Pkg))
+	})
+	// Builtins are measured before formatting, which may make them
+	// slightly larger.
+	s.SharedSize = max(s.SharedSize, 0)
+}
+
+// suggest adds config.toml suggestions for the errors of generating
+// bindings. stdLibs is the value of "include-std-libs".
+func (s *Summary) suggest(errs []error, stdLibs []string) {
+	if len(s.Unsafe) > 0 {
+		s.Suggestions = append(s.Suggestions, fmt.Sprintf(
+			"# Bind functions and fields with unsafe pointers after reviewing them (%v excluded,\n"+
+				"# listed with RYEGEN_STATS=1), e.g. %v:\n"+
+				"unsafe-acknowledged = true\n"+
+				"allow-unsafe = [\"<package path>.<Func>\", \"<package path>.<Type>.<Method>\", \"<package path>.<Type>.<Field>\"]",
+			len(s.Unsafe), s.Unsafe[0],
+		))
+	}
+
+	// Std libs with dropped bindings may not be needed at all.
+	dropsByPkg := make(map[string]int)
+	for _, err := range errs {
+		if bErr, ok := err.(*bindingError); ok {
+			dropsByPkg[bErr.Pkg]++
+		}
+	}
+	var offending []string
+	for _, pkg := range stdLibs {
+		if dropsByPkg[pkg] > 0 {
+			offending = append(offending, pkg)
+		}
+	}
+	slices.SortStableFunc(offending, func(a, b string) int {
+		return -cmp.Compare(dropsByPkg[a], dropsByPkg[b])
+	})
+	if len(offending) > 0 {
+		top := offending[:min(len(offending), 3)]
+		remaining := slices.DeleteFunc(slices.Clone(stdLibs), func(pkg string) bool {
+			return slices.Contains(top, pkg)
+		})
+		var b strings.Builder
+		b.WriteString("# Stop binding std libs with the most dropped bindings (")
+		for i, pkg := range top {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			fmt.Fprintf(&b, "%v: %v", pkg, dropsByPkg[pkg])
+		}
+		b.WriteString("):\n")
+		b.WriteString("include-std-libs = [")
+		for i, pkg := range remaining {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString(strconv.Quote(pkg))
+		}
+		b.WriteString("]")
+		s.Suggestions = append(s.Suggestions, b.String())
+	}
+}
+
+// formatSize formats a number of bytes, e.g. "12.3 KiB".
+func formatSize(n int) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%v B", n)
+	}
+	f := float64(n)
+	for _, suffix := range []string{"KiB", "MiB"} {
+		f /= unit
+		if f < unit || suffix == "MiB" {
+			return strconv.FormatFloat(f, 'f', 1, 64) + " " + suffix
+		}
+	}
+	panic("unreachable")
+}
+
+// String formats s for console output.
+func (s *Summary) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Ryegen: Wrote %v builtins to %v in %v\n", s.Generated, s.OutFile, s.Duration.Round(time.Millisecond))
+	if s.Excluded > 0 {
+		fmt.Fprintf(&b, "  %v excluded in bindings.txt (or by source directives)\n", s.Excluded)
+	}
+	if len(s.Unsafe) > 0 {
+		fmt.Fprintf(&b, "  %v excluded for passing unsafe pointers\n", len(s.Unsafe))
+	}
+	if s.Dropped > 0 {
+		fmt.Fprintf(&b, "  %v dropped due to errors, most commonly:\n", s.Dropped)
+		for _, c := range s.DropCauses {
+			fmt.Fprintf(&b, "    * %vx %v\n", c.Count, c.Cause)
+		}
+	}
+	if len(s.Outputs) > 0 {
+		b.WriteString("  Output files:\n")
+		for _, name := range slices.Sorted(maps.Keys(s.Outputs)) {
+			fmt.Fprintf(&b, "    %v (%v)\n", name, formatSize(s.Outputs[name]))
+		}
+	}
+	if len(s.Packages) > 0 {
+		b.WriteString("  Generated Go source by package (not binary size):\n")
+		for _, p := range s.Packages {
+			fmt.Fprintf(&b, "    %v (%v)\n", p.Pkg, formatSize(p.Size))
+		}
+		if s.SharedSize > 0 {
+			fmt.Fprintf(&b, "    shared by all packages (%v)\n", formatSize(s.SharedSize))
+		}
+	}
+	for _, p := range s.Packages {
+		if len(p.Exclude) == 0 {
+			continue
+		}
+		excluded := 0
+		for _, e := range p.Exclude {
+			excluded += e.Size
+		}
+		fmt.Fprintf(&b, "  %v exceeds max-size; disable in bindings.txt to save %v:\n", p.Pkg, formatSize(excluded))
+		for _, e := range p.Exclude {
+			fmt.Fprintf(&b, "    * %v (%v)\n", e.Name, formatSize(e.Size))
+		}
+	}
+	if len(s.Notices) > 0 {
+		b.WriteString("  Module notices:\n")
+		for _, n := range s.Notices {
+			fmt.Fprintf(&b, "    * %v\n", n)
+		}
+	}
+	if len(s.Suggestions) > 0 {
+		b.WriteString("  Suggested config.toml changes:\n")
+		for _, sugg := range s.Suggestions {
+			for _, line := range strings.Split(sugg, "\n") {
+				fmt.Fprintf(&b, "    %v\n", line)
+			}
+		}
+	}
+	return b.String()
+}
+
+// sizeRecordingSink wraps an [OutputSink], recording the size of each
+// file written, for [Summary.Outputs].
+type sizeRecordingSink struct {
+	sink  OutputSink
+	mu    sync.Mutex
+	sizes map[string]int
+}
+
+func newSizeRecordingSink(sink OutputSink) *sizeRecordingSink {
+	return &sizeRecordingSink{
+		sink:  sink,
+		sizes: make(map[string]int),
+	}
+}
+
+func (s *sizeRecordingSink) WriteFile(name string, data []byte) error {
+	if err := s.sink.WriteFile(name, data); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sizes[name] = len(data)
+	return nil
+}
+
+func (s *sizeRecordingSink) RemoveFile(name string) error {
+	if r, ok := s.sink.(OutputRemover); ok {
+		if err := r.RemoveFile(name); err != nil {
+			return err
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sizes, name)
+	return nil
+}
+
+// Sizes returns the size of each file written by name.
+func (s *sizeRecordingSink) Sizes() map[string]int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return maps.Clone(s.sizes)
+}
@@ -0,0 +1,150 @@
+package ryegen
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummarySetDrops(t *testing.T) {
+	assert := assert.New(t)
+
+	errUnhandled := errors.New("unhandled type")
+	var errs []error
+	for i := range 3 {
+		errs = append(errs, newBindingError("example.com/a", fmt.Sprintf("A%v", i), fmt.Errorf("convert: %w", errUnhandled)))
+	}
+	errs = append(errs,
+		newBindingError("example.com/b", "B", errors.New("unsupported generic")),
+		errors.New("not a binding error"),
+	)
+	for i := range maxSummaryDropCauses {
+		errs = append(errs, newBindingError("example.com/c", "C", fmt.Errorf("rare cause %v", i)))
+	}
+
+	var s Summary
+	s.setDrops(errs)
+	assert.Equal(4+maxSummaryDropCauses, s.Dropped)
+	assert.Len(s.DropCauses, maxSummaryDropCauses)
+	assert.Equal(SummaryCause{Cause: "unhandled type", Count: 3}, s.DropCauses[0])
+}
+
+func TestSummarySetPackages(t *testing.T) {
+	assert := assert.New(t)
+
+	var s Summary
+	s.setPackages(map[string][]SummaryBuiltin{
+		"example.com/small": {{Name: "small-fn", Size: 10}},
+		"example.com/big": {
+			{Name: "a", Size: 30},
+			{Name: "b", Size: 50},
+			{Name: "c", Size: 50},
+			{Name: "d", Size: 20},
+		},
+	}, 100, 1000)
+	assert.Equal([]SummaryPackage{
+		{
+			Pkg:  "example.com/big",
+			Size: 150,
+			// Largest first, ties by name, until under max size.
+			Exclude: []SummaryBuiltin{{Name: "b", Size: 50}},
+		},
+		{Pkg: "example.com/small", Size: 10},
+	}, s.Packages)
+	assert.Equal(1000-150-10, s.SharedSize)
+
+	s = Summary{}
+	s.setPackages(map[string][]SummaryBuiltin{
+		"example.com/big": {{Name: "a", Size: 1000}},
+	}, 0, 990)
+	assert.Empty(s.Packages[0].Exclude, "no max size")
+	assert.Zero(s.SharedSize, "builtins larger after formatting")
+}
+
+func TestSummarySuggest(t *testing.T) {
+	assert := assert.New(t)
+
+	var s Summary
+	s.suggest(nil, []string{"fmt"})
+	assert.Empty(s.Suggestions)
+
+	errs := []error{
+		newBindingError("net/http", "A", errors.New("x")),
+		newBindingError("net/http", "B", errors.New("x")),
+		newBindingError("os", "C", errors.New("x")),
+		newBindingError("example.com/m", "D", errors.New("x")),
+	}
+	s = Summary{Unsafe: []string{"unsafe.Fn"}}
+	s.suggest(errs, []string{"fmt", "os", "net/http"})
+	if assert.Len(s.Suggestions, 2) {
+		assert.Contains(s.Suggestions[0], "unsafe-acknowledged = true")
+		assert.Contains(s.Suggestions[0], "e.g. unsafe.Fn")
+		assert.Equal(
+			"# Stop binding std libs with the most dropped bindings (net/http: 2, os: 1):\n"+
+				`include-std-libs = ["fmt"]`,
+			s.Suggestions[1],
+		)
+	}
+}
+
+func TestFormatSize(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal("0 B", formatSize(0))
+	assert.Equal("1023 B", formatSize(1023))
+	assert.Equal("1.0 KiB", formatSize(1024))
+	assert.Equal("12.3 KiB", formatSize(12595))
+	assert.Equal("2.0 MiB", formatSize(2<<20))
+	assert.Equal("4096.0 MiB", formatSize(4<<30))
+}
+
+func TestSummaryString(t *testing.T) {
+	s := &Summary{
+		OutFile:    "bindings/generated.go",
+		Duration:   1234567 * time.Microsecond,
+		Generated:  10,
+		Excluded:   2,
+		Dropped:    3,
+		DropCauses: []SummaryCause{{Cause: "unhandled type", Count: 3}},
+		Outputs:    map[string]int{"bindings.txt": 100, "bindings/generated.go": 2048},
+		Packages: []SummaryPackage{
+			{Pkg: "example.com/m", Size: 2000, Exclude: []SummaryBuiltin{{Name: "big-fn", Size: 1500}}},
+		},
+		SharedSize:  48,
+		Suggestions: []string{"# comment\nkey = 1"},
+	}
+	assert.Equal(t, strings.Join([]string{
+		"Ryegen: Wrote 10 builtins to bindings/generated.go in 1.235s",
+		"  2 excluded in bindings.txt (or by source directives)",
+		"  3 dropped due to errors, most commonly:",
+		"    * 3x unhandled type",
+		"  Output files:",
+		"    bindings.txt (100 B)",
+		"    bindings/generated.go (2.0 KiB)",
+		"  Generated Go source by package (not binary size):",
+		"    example.com/m (2.0 KiB)",
+		"    shared by all packages (48 B)",
+		"  example.com/m exceeds max-size; disable in bindings.txt to save 1.5 KiB:",
+		"    * big-fn (1.5 KiB)",
+		"  Suggested config.toml changes:",
+		"    # comment",
+		"    key = 1",
+		"",
+	}, "\n"), s.String())
+}
+
+func TestSizeRecordingSink(t *testing.T) {
+	assert := assert.New(t)
+
+	var mem MemorySink
+	sink := newSizeRecordingSink(&mem)
+	assert.NoError(sink.WriteFile("a.go", []byte("package a")))
+	assert.NoError(sink.WriteFile("b.txt", []byte("b")))
+	assert.NoError(sink.WriteFile("b.txt", []byte("bb")))
+	assert.NoError(sink.RemoveFile("a.go"))
+	assert.Equal(map[string]int{"b.txt": 2}, sink.Sizes())
+	assert.Equal(map[string][]byte{"b.txt": []byte("bb")}, mem.Files())
+}
@@ -0,0 +1,217 @@
+package ryegen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/refaktor/ryegen/parser"
+)
+
+// targetReportPath is the file the target report (see "target-report"
+// in config.toml) is written to, relative to the working directory.
+const targetReportPath = "targets.txt"
+
+// targetVariant is the declaration of an exported symbol in one file of
+// a package.
+type targetVariant struct {
+	constraint string // build constraint of the file ("" if unconstrained)
+	signature  string // e.g. "func(int, string) error" or "type int"
+}
+
+// targetVariants returns the exported funcs, methods, types, consts and
+// vars of the package in dir which are declared in more than one file
+// (e.g. in foo_linux.go and foo_windows.go), by name ("Func", "Type" or
+// "Type.Method"), with one variant per declaration sorted by
+// constraint.
+func targetVariants(dir, pkgPath string) (map[string][]targetVariant, error) {
+	pkg, err := parser.ParseDirAllTargets(token.NewFileSet(), dir, pkgPath)
+	if err != nil {
+		return nil, err
+	}
+	all := make(map[string][]targetVariant)
+	for path, f := range pkg.Files {
+		add := func(name, signature string) {
+			all[name] = append(all[name], targetVariant{
+				constraint: pkg.Constraints[path],
+				signature:  signature,
+			})
+		}
+		for _, decl := range f.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				if !decl.Name.IsExported() {
+					continue
+				}
+				name := decl.Name.Name
+				if decl.Recv != nil && len(decl.Recv.List) == 1 {
+					recv := decl.Recv.List[0].Type
+					if se, ok := recv.(*ast.StarExpr); ok {
+						recv = se.X
+					}
+					if ie, ok := recv.(*ast.IndexExpr); ok {
+						recv = ie.X
+					}
+					id, ok := recv.(*ast.Ident)
+					if !ok || !id.IsExported() {
+						continue
+					}
+					name = id.Name + "." + name
+				}
+				add(name, funcSignature(decl.Type))
+			case *ast.GenDecl:
+				// Implicitly repeated by consts without values.
+				var prevType ast.Expr
+				var prevValues []ast.Expr
+				for _, spec := range decl.Specs {
+					switch spec := spec.(type) {
+					case *ast.TypeSpec:
+						if !spec.Name.IsExported() {
+							continue
+						}
+						if spec.Assign.IsValid() {
+							// Aliases are interchangeable with their
+							// target, unlike defined types.
+							add(spec.Name.Name, "type = "+types.ExprString(spec.Type))
+						} else {
+							add(spec.Name.Name, "type "+types.ExprString(spec.Type))
+						}
+					case *ast.ValueSpec:
+						typ, values := spec.Type, spec.Values
+						if decl.Tok == token.CONST {
+							if len(values) == 0 {
+								typ, values = prevType, prevValues
+							}
+							prevType, prevValues = typ, values
+						}
+						for i, name := range spec.Names {
+							if !name.IsExported() {
+								continue
+							}
+							var value ast.Expr
+							if i < len(values) {
+								value = values[i]
+							}
+							add(name.Name, valueSignature(decl.Tok, typ, value))
+						}
+					}
+				}
+			}
+		}
+	}
+	res := make(map[string][]targetVariant)
+	for name, variants := range all {
+		if len(variants) < 2 {
+			continue
+		}
+		slices.SortFunc(variants, func(a, b targetVariant) int {
+			return strings.Compare(a.constraint, b.constraint)
+		})
+		res[name] = variants
+	}
+	return res, nil
+}
+
+// funcSignature returns the signature of a function type without
+// parameter names, so variants only differing in names are equal.
+func funcSignature(typ *ast.FuncType) string {
+	fieldTypes := func(fl *ast.FieldList) []string {
+		if fl == nil {
+			return nil
+		}
+		var res []string
+		for _, f := range fl.List {
+			n := max(len(f.Names), 1)
+			for range n {
+				res = append(res, types.ExprString(f.Type))
+			}
+		}
+		return res
+	}
+	sig := "func(" + strings.Join(fieldTypes(typ.Params), ", ") + ")"
+	switch results := fieldTypes(typ.Results); len(results) {
+	case 0:
+	case 1:
+		sig += " " + results[0]
+	default:
+		sig += " (" + strings.Join(results, ", ") + ")"
+	}
+	return sig
+}
+
+// valueSignature returns the signature of a const or var (tok) of the
+// declared type typ (nil if none) and value (nil if none), e.g.
+// "const int", "var *os.File" or "const untyped string" for untyped
+// constants of literals. The value is only part of the signature of
+// vars and consts of other types, whose type depends on it.
+func valueSignature(tok token.Token, typ, value ast.Expr) string {
+	if typ != nil {
+		return tok.String() + " " + types.ExprString(typ)
+	}
+	if lit, ok := value.(*ast.BasicLit); ok && tok == token.CONST {
+		kinds := map[token.Token]string{
+			token.INT:    "int",
+			token.FLOAT:  "float",
+			token.IMAG:   "complex",
+			token.CHAR:   "rune",
+			token.STRING: "string",
+		}
+		return "const untyped " + kinds[lit.Kind]
+	}
+	if value == nil {
+		return tok.String()
+	}
+	return tok.String() + " = " + types.ExprString(value)
+}
+
+// targetSignaturesDiffer reports whether the variants of a symbol
+// have different signatures.
+func targetSignaturesDiffer(variants []targetVariant) bool {
+	for _, v := range variants[1:] {
+		if v.signature != variants[0].signature {
+			return true
+		}
+	}
+	return false
+}
+
+// formatTargetVariants returns the variants as "<constraint>: <signature>"
+// lines, with "(unconstrained)" for files without constraint.
+func formatTargetVariants(variants []targetVariant) []string {
+	res := make([]string, len(variants))
+	for i, v := range variants {
+		constraint := v.constraint
+		if constraint == "" {
+			constraint = "(unconstrained)"
+		}
+		res[i] = constraint + ": " + v.signature
+	}
+	return res
+}
+
+// writeTargetReport writes the per-target variants of exported symbols
+// (package path to [targetVariants]) to path in sink.
+func writeTargetReport(sink OutputSink, path string, variants map[string]map[string][]targetVariant) error {
+	var b strings.Builder
+	b.WriteString("# Exported symbols of the bound packages declared separately per build\n")
+	b.WriteString("# target, with their signature by build constraint. Written by ryegen\n")
+	b.WriteString("# (see \"target-report\" in config.toml).\n")
+	for _, pkg := range slices.Sorted(maps.Keys(variants)) {
+		for _, name := range slices.Sorted(maps.Keys(variants[pkg])) {
+			vs := variants[pkg][name]
+			fmt.Fprintf(&b, "%v.%v", pkg, name)
+			if targetSignaturesDiffer(vs) {
+				b.WriteString(" (signatures differ)")
+			}
+			b.WriteString("\n")
+			for _, line := range formatTargetVariants(vs) {
+				fmt.Fprintf(&b, "  %v\n", line)
+			}
+		}
+	}
+	return sink.WriteFile(path, []byte(b.String()))
+}
@@ -0,0 +1,99 @@
+package ryegen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTargetVariants(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"p.go": "package p\n\nfunc Common() {}\n",
+		"p_linux.go": `package p
+
+type Handle int
+type Fd = int
+
+const Sep = '/'
+const (
+	ModeA Mode = iota
+	ModeB
+)
+
+var Stdin *File
+var Default = newDefault()
+
+func Open(name string) (Handle, error) { return 0, nil }
+func (h Handle) Close() error { return nil }
+func unexported() {}
+`,
+		"p_windows.go": `package p
+
+type Handle = uintptr
+type Fd int
+
+const Sep = "\\"
+const (
+	ModeA Mode = iota
+	ModeB
+)
+
+var Stdin *File
+var Default = newWindowsDefault()
+
+func Open(path string) (Handle, error) { return 0, nil }
+func (h Handle) Close() error { return nil }
+func unexported(x int) {}
+`,
+	})
+
+	variants, err := targetVariants(dir, "example.com/p")
+	if !assert.NoError(err) {
+		return
+	}
+	signatures := make(map[string]string)
+	for name, vs := range variants {
+		signatures[name] = strings.Join(formatTargetVariants(vs), "; ")
+		assert.Equal(name != "Open" && name != "Handle.Close" && name != "ModeA" && name != "ModeB" && name != "Stdin", targetSignaturesDiffer(vs), name)
+	}
+	assert.Equal(map[string]string{
+		"Handle":       "linux: type int; windows: type = uintptr",
+		"Fd":           "linux: type = int; windows: type int",
+		"Sep":          "linux: const untyped rune; windows: const untyped string",
+		"ModeA":        "linux: const Mode; windows: const Mode",
+		"ModeB":        "linux: const Mode; windows: const Mode",
+		"Stdin":        "linux: var *File; windows: var *File",
+		"Default":      "linux: var = newDefault(); windows: var = newWindowsDefault()",
+		"Open":         "linux: func(string) (Handle, error); windows: func(string) (Handle, error)",
+		"Handle.Close": "linux: func() error; windows: func() error",
+	}, signatures)
+}
+
+func TestWriteTargetReport(t *testing.T) {
+	var sink MemorySink
+	err := writeTargetReport(&sink, "targets.txt", map[string]map[string][]targetVariant{
+		"example.com/p": {
+			"Sep": {{constraint: "", signature: "const untyped rune"}, {constraint: "windows", signature: "const untyped string"}},
+			"Fd":  {{constraint: "linux", signature: "type int"}, {constraint: "windows", signature: "type int"}},
+		},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, strings.Join([]string{
+		"# Exported symbols of the bound packages declared separately per build",
+		"# target, with their signature by build constraint. Written by ryegen",
+		"# (see \"target-report\" in config.toml).",
+		"example.com/p.Fd",
+		"  linux: type int",
+		"  windows: type int",
+		"example.com/p.Sep (signatures differ)",
+		"  (unconstrained): const untyped rune",
+		"  windows: const untyped string",
+		"",
+	}, "\n"), string(sink.Files()["targets.txt"]))
+}
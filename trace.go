@@ -0,0 +1,74 @@
+package ryegen
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// ruleTracer records which rules (bindings.txt entries, source directives,
+// naming options) were evaluated for each binding. Evaluations of bindings
+// whose unique name matches re are logged, and rule hits are counted for
+// all bindings.
+type ruleTracer struct {
+	// If nil, nothing is logged.
+	re     *regexp.Regexp
+	onInfo func(msg string)
+	// Rule name to number of bindings it matched.
+	hits map[string]int
+}
+
+// newRuleTracer creates a tracer logging bindings matching the regular
+// expression pattern (no logging if pattern is empty).
+func newRuleTracer(pattern string, onInfo func(msg string)) (*ruleTracer, error) {
+	t := &ruleTracer{
+		onInfo: onInfo,
+		hits:   make(map[string]int),
+	}
+	if pattern != "" {
+		var err error
+		t.re, err = regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("RYEGEN_TRACE_RULES: %w", err)
+		}
+	}
+	return t, nil
+}
+
+// Trace records the evaluation of rule for binding. result describes
+// the resulting property change (only used if matched).
+func (t *ruleTracer) Trace(binding, rule string, matched bool, result string) {
+	if matched {
+		t.hits[rule]++
+	}
+	if t.re == nil || !t.re.MatchString(binding) {
+		return
+	}
+	if matched {
+		t.onInfo(fmt.Sprintf("trace: %v: %v: matched: %v", binding, rule, result))
+	} else {
+		t.onInfo(fmt.Sprintf("trace: %v: %v: no match", binding, rule))
+	}
+}
+
+// Log logs msg if binding matches.
+func (t *ruleTracer) Log(binding, msg string) {
+	if t.re == nil || !t.re.MatchString(binding) {
+		return
+	}
+	t.onInfo(fmt.Sprintf("trace: %v: %v", binding, msg))
+}
+
+// Stats returns the rule hit counts in human readable form.
+func (t *ruleTracer) Stats() string {
+	var b strings.Builder
+	if len(t.hits) == 0 {
+		b.WriteString("No rules matched.\n")
+	}
+	for _, rule := range slices.Sorted(maps.Keys(t.hits)) {
+		fmt.Fprintf(&b, "  * %v: %v bindings\n", rule, t.hits[rule])
+	}
+	return b.String()
+}
@@ -0,0 +1,70 @@
+package ryegen
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuleTracer(t *testing.T) {
+	assert := assert.New(t)
+
+	var msgs []string
+	tr, err := newRuleTracer("^greet-hello$", func(msg string) { msgs = append(msgs, msg) })
+	if !assert.NoError(err) {
+		return
+	}
+	tr.Trace("greet-hello", "disable", false, "disabled")
+	tr.Trace("greet-hello", "export", true, "exported")
+	tr.Log("greet-hello", "resolved to greet-hello")
+	// Counted, but not logged.
+	tr.Trace("greet-bye", "disable", true, "disabled")
+	tr.Log("greet-bye", "resolved to greet-bye")
+
+	assert.Equal([]string{
+		"trace: greet-hello: disable: no match",
+		"trace: greet-hello: export: matched: exported",
+		"trace: greet-hello: resolved to greet-hello",
+	}, msgs)
+	assert.Equal("  * disable: 1 bindings\n  * export: 1 bindings\n", tr.Stats())
+
+	_, err = newRuleTracer("(", nil)
+	assert.ErrorContains(err, "RYEGEN_TRACE_RULES: ")
+}
+
+func TestTraceRules(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Setenv("RYEGEN_TRACE_RULES", "^greet-(hello|bye)$")
+	dir := t.TempDir()
+	writeSrcRepos(t, dir, greetSrc+"\nfunc Bye() {}\n")
+	if err := os.WriteFile(filepath.Join(dir, "config.toml"), []byte("out-dir = \"out\"\npackage = \"example.com/greet\"\nversion = \"v1.0.0\"\nproxy = \"off\"\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "bindings.txt"), []byte("[enabled]\ngreet-hello => hi\n\n[disabled]\ngreet-bye\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	var traces []string
+	onInfo := func(msg string) {
+		if strings.HasPrefix(msg, "trace: ") {
+			traces = append(traces, msg)
+		}
+	}
+	if _, err := TryRunWithOptions(RunOptions{Dir: dir, Sink: &MemorySink{}, OnInfo: onInfo}); !assert.NoError(err) {
+		return
+	}
+	// The accepted binding is renamed, the dropped one disabled.
+	assert.Contains(traces, "trace: greet-hello: disable: no match")
+	assert.Contains(traces, "trace: greet-hello: rename: matched: rename candidate hi")
+	assert.Contains(traces, "trace: greet-hello: name candidates hi, greet-hello, resolved to hi")
+	assert.Contains(traces, "trace: greet-bye: disable: matched: disabled")
+	assert.Contains(traces, "trace: greet-bye: rename: no match")
+	// Other bindings aren't logged.
+	for _, msg := range traces {
+		assert.Regexp(`^trace: greet-(hello|bye): `, msg)
+	}
+}
@@ -0,0 +1,186 @@
+package ryegen
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/refaktor/ryegen/binder/binderio"
+)
+
+// Files holding the usage hook API and its tag-dependent implementation
+// of recordUsage, relative to the output directory.
+const (
+	usageFileName    = "usage.go"
+	usageOnFileName  = "usage_on.go"
+	usageOffFileName = "usage_off.go"
+)
+
+// usageRecordCall returns the statement generated at the top of each
+// builtin if usage recording is enabled.
+func usageRecordCall(modulePath, bindingName string) string {
+	return fmt.Sprintf(`recordUsage(%q, %q)`, modulePath, bindingName)
+}
+
+// writeUsageFiles writes the usage hook files into outDir, or removes
+// them if usageTag is empty (see [OutputSink]).
+//
+// The generated builtins call recordUsage, which only calls the hook if
+// the bindings are built with usageTag. Otherwise it is an empty function,
+// which the compiler inlines away.
+func writeUsageFiles(sink OutputSink, outDir, pkgName, usageTag, dontBuildFlag string) error {
+	paths := []string{
+		filepath.Join(outDir, usageFileName),
+		filepath.Join(outDir, usageOnFileName),
+		filepath.Join(outDir, usageOffFileName),
+	}
+	if usageTag == "" {
+		for _, path := range paths {
+			if err := removeOutput(sink, path); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	constraint := func(tag string) string {
+		if dontBuildFlag == "" {
+			return tag
+		}
+		return tag + " && !" + dontBuildFlag
+	}
+
+	header := func(cb *binderio.CodeBuilder) {
+		cb.Linef(`// Code generated by ryegen. DO NOT EDIT.`)
+		cb.Linef(``)
+	}
+
+	{
+		var cb binderio.CodeBuilder
+		header(&cb)
+		cb.Linef(`package %v`, pkgName)
+		cb.Linef(``)
+		cb.Linef(`import (`)
+		cb.Indent++
+		cb.Linef(`"fmt"`)
+		cb.Linef(`"os"`)
+		cb.Linef(`"sort"`)
+		cb.Linef(`"sync"`)
+		cb.Indent--
+		cb.Linef(`)`)
+		cb.Linef(``)
+		cb.Linef(`// UsageHook is called by each builtin with the package path and the`)
+		cb.Linef(`// name of its binding (as in bindings.txt) when it's invoked.`)
+		cb.Linef(`//`)
+		cb.Linef(`// Hooks are only called if the bindings are built with the %q tag.`, usageTag)
+		cb.Linef(`type UsageHook interface {`)
+		cb.Indent++
+		cb.Linef(`BindingUsed(pkg, name string)`)
+		cb.Indent--
+		cb.Linef(`}`)
+		cb.Linef(``)
+		cb.Linef(`var usageHook UsageHook`)
+		cb.Linef(``)
+		cb.Linef(`// SetUsageHook sets the hook called by builtins (nil to disable).`)
+		cb.Linef(`// Call it before running any Rye code.`)
+		cb.Linef(`func SetUsageHook(h UsageHook) {`)
+		cb.Indent++
+		cb.Linef(`usageHook = h`)
+		cb.Indent--
+		cb.Linef(`}`)
+		cb.Linef(``)
+		cb.Linef(`// UsageRecorder is a UsageHook counting calls per binding.`)
+		cb.Linef(`type UsageRecorder struct {`)
+		cb.Indent++
+		cb.Linef(`mu     sync.Mutex`)
+		cb.Linef(`counts map[[2]string]int`)
+		cb.Indent--
+		cb.Linef(`}`)
+		cb.Linef(``)
+		cb.Linef(`func (r *UsageRecorder) BindingUsed(pkg, name string) {`)
+		cb.Indent++
+		cb.Linef(`r.mu.Lock()`)
+		cb.Linef(`defer r.mu.Unlock()`)
+		cb.Linef(`if r.counts == nil {`)
+		cb.Indent++
+		cb.Linef(`r.counts = make(map[[2]string]int)`)
+		cb.Indent--
+		cb.Linef(`}`)
+		cb.Linef(`r.counts[[2]string{pkg, name}]++`)
+		cb.Indent--
+		cb.Linef(`}`)
+		cb.Linef(``)
+		cb.Linef(`// WriteFile writes the recorded calls to a local file, one binding`)
+		cb.Linef(`// per line as "<count>\t<package path>\t<name>", sorted by name.`)
+		cb.Linef(`func (r *UsageRecorder) WriteFile(path string) error {`)
+		cb.Indent++
+		cb.Linef(`r.mu.Lock()`)
+		cb.Linef(`defer r.mu.Unlock()`)
+		cb.Linef(`keys := make([][2]string, 0, len(r.counts))`)
+		cb.Linef(`for k := range r.counts {`)
+		cb.Indent++
+		cb.Linef(`keys = append(keys, k)`)
+		cb.Indent--
+		cb.Linef(`}`)
+		cb.Linef(`sort.Slice(keys, func(i, j int) bool {`)
+		cb.Indent++
+		cb.Linef(`if keys[i][1] != keys[j][1] {`)
+		cb.Indent++
+		cb.Linef(`return keys[i][1] < keys[j][1]`)
+		cb.Indent--
+		cb.Linef(`}`)
+		cb.Linef(`return keys[i][0] < keys[j][0]`)
+		cb.Indent--
+		cb.Linef(`})`)
+		cb.Linef(`var b []byte`)
+		cb.Linef(`for _, k := range keys {`)
+		cb.Indent++
+		cb.Linef(`b = fmt.Appendf(b, "%%v\t%%v\t%%v\n", r.counts[k], k[0], k[1])`)
+		cb.Indent--
+		cb.Linef(`}`)
+		cb.Linef(`return os.WriteFile(path, b, 0666)`)
+		cb.Indent--
+		cb.Linef(`}`)
+
+		if fmtErr, err := saveCode(sink, &cb, paths[0]); err != nil || fmtErr != nil {
+			return fmt.Errorf("save %v: general=%w, fmt=%v", usageFileName, err, fmtErr)
+		}
+	}
+
+	{
+		var cb binderio.CodeBuilder
+		header(&cb)
+		cb.Linef(`//go:build %v`, constraint(usageTag))
+		cb.Linef(``)
+		cb.Linef(`package %v`, pkgName)
+		cb.Linef(``)
+		cb.Linef(`func recordUsage(pkg, name string) {`)
+		cb.Indent++
+		cb.Linef(`if h := usageHook; h != nil {`)
+		cb.Indent++
+		cb.Linef(`h.BindingUsed(pkg, name)`)
+		cb.Indent--
+		cb.Linef(`}`)
+		cb.Indent--
+		cb.Linef(`}`)
+
+		if fmtErr, err := saveCode(sink, &cb, paths[1]); err != nil || fmtErr != nil {
+			return fmt.Errorf("save %v: general=%w, fmt=%v", usageOnFileName, err, fmtErr)
+		}
+	}
+
+	{
+		var cb binderio.CodeBuilder
+		header(&cb)
+		cb.Linef(`//go:build %v`, constraint("!"+usageTag))
+		cb.Linef(``)
+		cb.Linef(`package %v`, pkgName)
+		cb.Linef(``)
+		cb.Linef(`func recordUsage(pkg, name string) {}`)
+
+		if fmtErr, err := saveCode(sink, &cb, paths[2]); err != nil || fmtErr != nil {
+			return fmt.Errorf("save %v: general=%w, fmt=%v", usageOffFileName, err, fmtErr)
+		}
+	}
+
+	return nil
+}
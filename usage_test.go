@@ -0,0 +1,113 @@
+package ryegen
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const usageOnTestSrc = `//go:build ryegen_usage
+
+package check
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestUsage(t *testing.T) {
+	var r UsageRecorder
+	SetUsageHook(&r)
+	defer SetUsageHook(nil)
+	recordUsage("example.com/greet", "greet-hello")
+	recordUsage("example.com/greet", "greet-hello")
+	recordUsage("example.com/greet", "greet-bye")
+
+	path := filepath.Join(t.TempDir(), "usage.txt")
+	if err := r.WriteFile(path); err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "1\texample.com/greet\tgreet-bye\n2\texample.com/greet\tgreet-hello\n"
+	if string(got) != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+`
+
+const usageOffTestSrc = `//go:build !ryegen_usage
+
+package check
+
+import "testing"
+
+type hookFunc func(pkg, name string)
+
+func (f hookFunc) BindingUsed(pkg, name string) { f(pkg, name) }
+
+func TestUsage(t *testing.T) {
+	SetUsageHook(hookFunc(func(pkg, name string) {
+		t.Errorf("hook called for %v %v", pkg, name)
+	}))
+	defer SetUsageHook(nil)
+	recordUsage("example.com/greet", "greet-hello")
+}
+`
+
+func TestWriteUsageFiles(t *testing.T) {
+	assert := assert.New(t)
+
+	var sink MemorySink
+	if !assert.NoError(writeUsageFiles(&sink, "out", "check", "ryegen_usage", "ryegen_nobuild")) {
+		return
+	}
+	files := sink.Files()
+	assert.Len(files, 3)
+	assert.NotContains(string(files["out/usage.go"]), "//go:build")
+	assert.Contains(string(files["out/usage_on.go"]), "//go:build ryegen_usage && !ryegen_nobuild\n")
+	assert.Contains(string(files["out/usage_off.go"]), "//go:build !ryegen_usage && !ryegen_nobuild\n")
+
+	// Disabling usage recording removes the files.
+	assert.NoError(writeUsageFiles(&sink, "out", "check", "", "ryegen_nobuild"))
+	assert.Empty(sink.Files())
+}
+
+func TestUsageBuild(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go test")
+	}
+
+	mod := t.TempDir()
+	write := func(name string, data []byte) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(mod, name), data, 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var sink MemorySink
+	if err := writeUsageFiles(&sink, "", "check", "ryegen_usage", ""); err != nil {
+		t.Fatal(err)
+	}
+	for name, data := range sink.Files() {
+		write(name, data)
+	}
+	write("go.mod", []byte("module example.com/check\n\ngo 1.22\n"))
+	write("check_on_test.go", []byte(usageOnTestSrc))
+	write("check_off_test.go", []byte(usageOffTestSrc))
+
+	for _, tags := range []string{"", "ryegen_usage"} {
+		cmd := exec.Command("go", "test", "-tags="+tags, ".")
+		cmd.Dir = mod
+		cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOPROXY=off", "GOWORK=off")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("go test -tags=%v: %v\n%s", tags, err, out)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package ryegen
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+var verifyDiagRe = regexp.MustCompile(`(?m)^(?:vet: )?(\S*?)([^\s/\\]+\.go):(\d+):(?:\d+:)? (.*)$`)
+
+// verifyOutput runs "go vet" (which type-checks) on the generated
+// package in outDir. Diagnostics in the generated file are attributed to
+// the binding (or top-level declaration, e.g. a converter helper) they
+// occur in. flags are passed to "go vet".
+func verifyOutput(outDir, outFile string, flags ...string) error {
+	cmd := exec.Command("go", append(append([]string{"vet"}, flags...), ".")...)
+	cmd.Dir = outDir
+	out, err := cmd.CombinedOutput()
+	if err == nil {
+		return nil
+	}
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return fmt.Errorf("verify: go vet: %w", err)
+	}
+
+	src, err := os.ReadFile(outFile)
+	if err != nil {
+		return fmt.Errorf("verify: %w", err)
+	}
+	srcLines := strings.Split(string(src), "\n")
+
+	var resErr error
+	for _, m := range verifyDiagRe.FindAllSubmatch(out, -1) {
+		file, lineStr, msg := string(m[2]), string(m[3]), string(m[4])
+		line, _ := strconv.Atoi(lineStr)
+		if file == filepath.Base(outFile) {
+			resErr = multierror.Append(resErr, fmt.Errorf("%v:%v: %v: %v", file, line, declAtLine(srcLines, line), msg))
+		} else {
+			resErr = multierror.Append(resErr, fmt.Errorf("%v:%v: %v", file, line, msg))
+		}
+	}
+	if resErr == nil {
+		resErr = errors.New(string(bytes.TrimSpace(out)))
+	}
+	return fmt.Errorf("verify: generated code doesn't compile: %w", resErr)
+}
+
+// verifyStaged verifies the generated package in outDir (see
+// [verifyOutput]) as it will be once the files staged in staged are
+// committed, so a package which doesn't compile is never written. The
+// package is copied to a temporary directory next to outDir (in the same
+// module), with the staged files applied. Packages in subdirectories of
+// outDir (e.g. the binding data) are imported by their own path, so they
+// are overlaid with their staged copies instead.
+//
+// Returns false without verifying if the outputs aren't written to
+// files, since the files in outDir aren't the ones to be checked then.
+func verifyStaged(staged *stagingSink, outDir, outFile string) (bool, error) {
+	switch staged.sink.(type) {
+	case FileSink, *FileSink:
+	default:
+		return false, nil
+	}
+
+	tmp, err := os.MkdirTemp(filepath.Dir(outDir), "_ryegen-verify-")
+	if err != nil {
+		return true, fmt.Errorf("verify: %w", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	files, err := staged.filesIn(outDir)
+	if err != nil {
+		return true, fmt.Errorf("verify: %w", err)
+	}
+	overlay := make(map[string]string)
+	for rel, data := range files {
+		path := filepath.Join(tmp, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0777); err != nil {
+			return true, fmt.Errorf("verify: %w", err)
+		}
+		if err := os.WriteFile(path, data, 0666); err != nil {
+			return true, fmt.Errorf("verify: %w", err)
+		}
+		if filepath.Dir(rel) != "." {
+			// Relative paths would be resolved in tmp.
+			orig, err := filepath.Abs(filepath.Join(outDir, rel))
+			if err != nil {
+				return true, fmt.Errorf("verify: %w", err)
+			}
+			copied, err := filepath.Abs(path)
+			if err != nil {
+				return true, fmt.Errorf("verify: %w", err)
+			}
+			overlay[orig] = copied
+		}
+	}
+	var flags []string
+	if len(overlay) > 0 {
+		data, err := json.Marshal(map[string]any{"Replace": overlay})
+		if err != nil {
+			return true, fmt.Errorf("verify: %w", err)
+		}
+		path := filepath.Join(tmp, "_overlay.json")
+		if err := os.WriteFile(path, data, 0666); err != nil {
+			return true, fmt.Errorf("verify: %w", err)
+		}
+		flags = append(flags, "-overlay="+path)
+	}
+
+	rel, err := filepath.Rel(outDir, outFile)
+	if err != nil {
+		return true, fmt.Errorf("verify: %w", err)
+	}
+	return true, verifyOutput(tmp, filepath.Join(tmp, rel), flags...)
+}
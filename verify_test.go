@@ -0,0 +1,70 @@
+package ryegen
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyStaged(t *testing.T) {
+	assert := assert.New(t)
+
+	mod := t.TempDir()
+	writeFile := func(name, content string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(name), 0777); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(name, []byte(content), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile(filepath.Join(mod, "go.mod"), "module example.com/m\n\ngo 1.22\n")
+	outDir := filepath.Join(mod, "out")
+	outFile := filepath.Join(outDir, "generated.go")
+	writeFile(filepath.Join(outDir, "custom.go"), "package out\n\nfunc Custom() int { return 1 }\n")
+	// Broken output of a previous run, which is replaced.
+	writeFile(outFile, "package out\n\nvar x = missing\n")
+
+	staged := newStagingSink(FileSink{})
+	staged.WriteFile(outFile, []byte("package out\n\nvar X = Custom()\n"))
+	verified, err := verifyStaged(staged, outDir, outFile)
+	assert.True(verified)
+	assert.NoError(err)
+
+	staged.WriteFile(outFile, []byte("package out\n\nvar X = Custom() + missing\n"))
+	verified, err = verifyStaged(staged, outDir, outFile)
+	assert.True(verified)
+	if assert.Error(err) {
+		assert.Contains(err.Error(), "generated.go:3: X: undefined: missing")
+	}
+	// Nothing is written before the staged files are committed.
+	data, _ := os.ReadFile(outFile)
+	assert.Equal("package out\n\nvar x = missing\n", string(data))
+	ents, _ := os.ReadDir(mod)
+	assert.Len(ents, 2, "temporary directory is removed")
+
+	// Removing a file the package needs is noticed as well.
+	staged = newStagingSink(FileSink{})
+	staged.WriteFile(outFile, []byte("package out\n\nvar X = Custom()\n"))
+	staged.RemoveFile(filepath.Join(outDir, "custom.go"))
+	_, err = verifyStaged(staged, outDir, outFile)
+	assert.Error(err)
+
+	// Staged packages in subdirectories are used instead of the
+	// written ones.
+	writeFile(filepath.Join(outDir, "data", "data.go"), "package data\n")
+	staged = newStagingSink(FileSink{})
+	staged.WriteFile(outFile, []byte("package out\n\nimport \"example.com/m/out/data\"\n\nvar X = data.X\n"))
+	staged.WriteFile(filepath.Join(outDir, "data", "data.go"), []byte("package data\n\nvar X = 1\n"))
+	verified, err = verifyStaged(staged, outDir, outFile)
+	assert.True(verified)
+	assert.NoError(err)
+
+	// The files in outDir aren't the outputs with other sinks.
+	verified, err = verifyStaged(newStagingSink(&MemorySink{}), outDir, outFile)
+	assert.False(verified)
+	assert.NoError(err)
+}
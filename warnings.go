@@ -0,0 +1,176 @@
+package ryegen
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"go/token"
+	"slices"
+	"strings"
+
+	"github.com/refaktor/ryegen/ir"
+)
+
+// Values for the RYEGEN_ERRORS environment variable.
+const (
+	// Print every warning on its own line.
+	warningsFull = "full"
+	// Group warnings by root cause and origin package (default).
+	warningsGrouped = "grouped"
+	// Only print the number of warnings and the most common causes.
+	warningsSummary = "summary"
+)
+
+// bindingError is a non-fatal error that prevented a single
+// binding from being generated.
+type bindingError struct {
+	// Module path of the package the binding originates from.
+	Pkg string
+	// Go name of the binding (e.g. "(*widget.Button).SetText").
+	Binding string
+	// Source position of the Go declaration the binding is generated
+	// from (zero if unknown), so editors can jump to it.
+	Pos token.Position
+	Err error
+}
+
+func newBindingError(pkg, binding string, err error) *bindingError {
+	return &bindingError{
+		Pkg:     pkg,
+		Binding: binding,
+		Err:     err,
+	}
+}
+
+// at sets the position of e to that of the declaration id.
+func (e *bindingError) at(id ir.Ident) *bindingError {
+	e.Pos = id.Position()
+	return e
+}
+
+func (e *bindingError) Error() string {
+	if e.Pos.IsValid() {
+		return e.Pos.String() + ": " + e.Binding + ": " + e.Err.Error()
+	}
+	return e.Binding + ": " + e.Err.Error()
+}
+
+func (e *bindingError) Unwrap() error {
+	return e.Err
+}
+
+// rootCause returns the innermost wrapped error.
+func rootCause(err error) error {
+	for {
+		next := errors.Unwrap(err)
+		if next == nil {
+			return err
+		}
+		err = next
+	}
+}
+
+type warningGroup struct {
+	cause string
+	pkg   string
+	// Names of the affected bindings.
+	examples []string
+	// Position of the first affected binding with a known position.
+	pos   token.Position
+	count int
+}
+
+// groupWarnings groups errs by root cause and origin package,
+// most frequent first.
+func groupWarnings(errs []error, byPkg bool) []*warningGroup {
+	type key struct{ cause, pkg string }
+	groups := make(map[key]*warningGroup)
+	for _, err := range errs {
+		var k key
+		var example string
+		var pos token.Position
+		if bErr, ok := err.(*bindingError); ok {
+			k.cause = rootCause(bErr.Err).Error()
+			if byPkg {
+				k.pkg = bErr.Pkg
+			}
+			example = bErr.Binding
+			pos = bErr.Pos
+		} else {
+			k.cause = rootCause(err).Error()
+		}
+		g, ok := groups[k]
+		if !ok {
+			g = &warningGroup{cause: k.cause, pkg: k.pkg}
+			groups[k] = g
+		}
+		g.count++
+		if !g.pos.IsValid() {
+			g.pos = pos
+		}
+		if example != "" {
+			g.examples = append(g.examples, example)
+		}
+	}
+	res := make([]*warningGroup, 0, len(groups))
+	for _, g := range groups {
+		res = append(res, g)
+	}
+	slices.SortFunc(res, func(a, b *warningGroup) int {
+		return cmp.Or(
+			-cmp.Compare(a.count, b.count),
+			strings.Compare(a.pkg, b.pkg),
+			strings.Compare(a.cause, b.cause),
+		)
+	})
+	return res
+}
+
+// formatWarnings formats errs for console output. mode is one of
+// warningsFull, warningsGrouped or warningsSummary.
+func formatWarnings(errs []error, mode string) string {
+	const maxExamples = 3
+	const maxSummaryCauses = 5
+
+	var b strings.Builder
+	switch mode {
+	case warningsFull:
+		fmt.Fprintln(&b, "Ryegen:", len(errs), "warnings:")
+		for _, e := range errs {
+			fmt.Fprintln(&b, "  *", e)
+		}
+	case warningsSummary:
+		groups := groupWarnings(errs, false)
+		fmt.Fprintf(&b, "Ryegen: %v warnings (%v distinct causes), most common:\n", len(errs), len(groups))
+		for _, g := range groups[:min(len(groups), maxSummaryCauses)] {
+			fmt.Fprintf(&b, "  * %vx %v\n", g.count, g.cause)
+		}
+		fmt.Fprintf(&b, "Ryegen: set RYEGEN_ERRORS=%v or RYEGEN_ERRORS=%v for details\n", warningsGrouped, warningsFull)
+	default:
+		if mode != warningsGrouped {
+			fmt.Fprintf(&b, "Ryegen: unknown RYEGEN_ERRORS value %q (expected %v, %v or %v), using %v\n",
+				mode, warningsFull, warningsGrouped, warningsSummary, warningsGrouped)
+		}
+		groups := groupWarnings(errs, true)
+		fmt.Fprintf(&b, "Ryegen: %v warnings (%v groups):\n", len(errs), len(groups))
+		for _, g := range groups {
+			b.WriteString("  * ")
+			if g.pkg != "" {
+				fmt.Fprintf(&b, "[%v] ", g.pkg)
+			}
+			fmt.Fprintf(&b, "%vx %v", g.count, g.cause)
+			if len(g.examples) > 0 {
+				fmt.Fprintf(&b, " (e.g. %v", strings.Join(g.examples[:min(len(g.examples), maxExamples)], ", "))
+				if len(g.examples) > maxExamples {
+					fmt.Fprintf(&b, ", +%v more", len(g.examples)-maxExamples)
+				}
+				b.WriteString(")")
+			}
+			if g.pos.IsValid() {
+				fmt.Fprintf(&b, " at %v", g.pos)
+			}
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
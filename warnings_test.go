@@ -0,0 +1,86 @@
+package ryegen
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var errUnsupported = errors.New("unsupported type chan int")
+
+func testWarnings() []error {
+	wrap := func(pkg, binding string) error {
+		return newBindingError(pkg, binding, fmt.Errorf("arg 1: %w", errUnsupported))
+	}
+	posErr := wrap("example.com/b", "b.F")
+	posErr.(*bindingError).Pos = token.Position{Filename: "b.go", Line: 3, Column: 1}
+	return []error{
+		wrap("example.com/a", "a.F"),
+		wrap("example.com/a", "a.G"),
+		wrap("example.com/a", "a.H"),
+		wrap("example.com/a", "(*a.T).I"),
+		posErr,
+		errors.New("bindings.txt: unknown binding x"),
+	}
+}
+
+func TestBindingError(t *testing.T) {
+	assert := assert.New(t)
+
+	err := newBindingError("example.com/a", "a.F", fmt.Errorf("arg 1: %w", errUnsupported))
+	assert.Equal("a.F: arg 1: unsupported type chan int", err.Error())
+	assert.ErrorIs(err, errUnsupported)
+	assert.Equal(errUnsupported, rootCause(err))
+
+	err.Pos = token.Position{Filename: "a.go", Line: 2, Column: 6}
+	assert.Equal("a.go:2:6: a.F: arg 1: unsupported type chan int", err.Error())
+}
+
+func TestGroupWarnings(t *testing.T) {
+	assert := assert.New(t)
+
+	groups := groupWarnings(testWarnings(), true)
+	if !assert.Len(groups, 3) {
+		return
+	}
+	assert.Equal(&warningGroup{cause: errUnsupported.Error(), pkg: "example.com/a", examples: []string{"a.F", "a.G", "a.H", "(*a.T).I"}, count: 4}, groups[0])
+	assert.Equal(&warningGroup{cause: "bindings.txt: unknown binding x", count: 1}, groups[1])
+	assert.Equal(&warningGroup{cause: errUnsupported.Error(), pkg: "example.com/b", examples: []string{"b.F"}, pos: token.Position{Filename: "b.go", Line: 3, Column: 1}, count: 1}, groups[2])
+
+	groups = groupWarnings(testWarnings(), false)
+	if !assert.Len(groups, 2) {
+		return
+	}
+	assert.Equal(5, groups[0].count)
+	assert.Equal("b.go:3:1", groups[0].pos.String())
+}
+
+func TestFormatWarnings(t *testing.T) {
+	assert := assert.New(t)
+
+	grouped := "Ryegen: 6 warnings (3 groups):\n" +
+		"  * [example.com/a] 4x unsupported type chan int (e.g. a.F, a.G, a.H, +1 more)\n" +
+		"  * 1x bindings.txt: unknown binding x\n" +
+		"  * [example.com/b] 1x unsupported type chan int (e.g. b.F) at b.go:3:1\n"
+	assert.Equal(grouped, formatWarnings(testWarnings(), warningsGrouped))
+	assert.Equal("Ryegen: unknown RYEGEN_ERRORS value \"all\" (expected full, grouped or summary), using grouped\n"+grouped,
+		formatWarnings(testWarnings(), "all"))
+
+	assert.Equal("Ryegen: 6 warnings:\n"+
+		"  * a.F: arg 1: unsupported type chan int\n"+
+		"  * a.G: arg 1: unsupported type chan int\n"+
+		"  * a.H: arg 1: unsupported type chan int\n"+
+		"  * (*a.T).I: arg 1: unsupported type chan int\n"+
+		"  * b.go:3:1: b.F: arg 1: unsupported type chan int\n"+
+		"  * bindings.txt: unknown binding x\n",
+		formatWarnings(testWarnings(), warningsFull))
+
+	assert.Equal("Ryegen: 6 warnings (2 distinct causes), most common:\n"+
+		"  * 5x unsupported type chan int\n"+
+		"  * 1x bindings.txt: unknown binding x\n"+
+		"Ryegen: set RYEGEN_ERRORS=grouped or RYEGEN_ERRORS=full for details\n",
+		formatWarnings(testWarnings(), warningsSummary))
+}
@@ -0,0 +1,217 @@
+package ryegen
+
+import (
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/refaktor/ryegen/config"
+)
+
+// How often watched files are checked for changes.
+const watchInterval = 500 * time.Millisecond
+
+// Maximum number of binding names listed per kind of change.
+const watchMaxListed = 10
+
+// watchedFiles returns the files whose changes trigger regeneration in
+// watch mode, relative to the working directory: the inputs, including
+// the prelude of cfg (if not nil), and the Go files and go.mod in srcDir
+// (the source of the bound package in the module cache, if not "").
+func watchedFiles(cfg *config.Config, srcDir string) (inputs, src []string) {
+	inputs = []string{"config.toml", "bindings.txt", "go.mod", "go.sum"}
+	if cfg != nil && cfg.Prelude != "" {
+		inputs = append(inputs, cfg.Prelude)
+	}
+	if srcDir != "" {
+		filepath.WalkDir(srcDir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				// Skip unreadable directories, the files found so
+				// far are still watched.
+				return nil
+			}
+			if !d.IsDir() && (strings.HasSuffix(path, ".go") || d.Name() == "go.mod") {
+				src = append(src, path)
+			}
+			return nil
+		})
+	}
+	return inputs, src
+}
+
+// watchConfig returns the config in config.toml, or nil if it doesn't
+// exist (so no default config is created) or is invalid.
+func watchConfig() *config.Config {
+	if _, err := os.Stat("config.toml"); err != nil {
+		return nil
+	}
+	cfg, _, err := config.ReadConfigFromFileOrCreateDefault("config.toml")
+	if err != nil {
+		return nil
+	}
+	return cfg
+}
+
+// fileStamps returns the size and modification time of each file, or
+// zero values for missing files.
+func fileStamps(files []string) map[string][2]int64 {
+	res := make(map[string][2]int64, len(files))
+	for _, f := range files {
+		if info, err := os.Stat(f); err == nil {
+			res[f] = [2]int64{info.Size(), info.ModTime().UnixNano()}
+		} else {
+			res[f] = [2]int64{}
+		}
+	}
+	return res
+}
+
+// restampWritten updates the stamps of the files among written (see
+// [Summary.Outputs]), which the generator wrote itself, so they don't
+// trigger another run.
+func restampWritten(stamps map[string][2]int64, written map[string]int) {
+	for name := range written {
+		if _, ok := stamps[name]; ok {
+			maps.Copy(stamps, fileStamps([]string{name}))
+		}
+	}
+}
+
+// bindingBodies returns the code of each builtin in a generated file by
+// binding name, or nil if the file can't be read.
+func bindingBodies(path string) map[string]string {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	res := make(map[string]string)
+	var name string
+	var body strings.Builder
+	flush := func() {
+		if name != "" {
+			res[name] = body.String()
+		}
+		body.Reset()
+	}
+	for _, line := range strings.Split(string(src), "\n") {
+		if m := builtinEntryRe.FindStringSubmatch(line); m != nil {
+			flush()
+			name = m[1]
+		} else if topLevelDeclRe.MatchString(line) {
+			flush()
+			name = ""
+		}
+		if name != "" && !strings.HasPrefix(strings.TrimSpace(line), "// ") {
+			// Doc comments are left out, since they belong to the
+			// next builtin.
+			body.WriteString(line)
+			body.WriteByte('\n')
+		}
+	}
+	flush()
+	return res
+}
+
+// diffBindings describes the builtins added, removed and changed
+// between two results of [bindingBodies].
+func diffBindings(before, after map[string]string) string {
+	var added, removed, changed []string
+	for name, body := range after {
+		if oldBody, ok := before[name]; !ok {
+			added = append(added, name)
+		} else if oldBody != body {
+			changed = append(changed, name)
+		}
+	}
+	for name := range before {
+		if _, ok := after[name]; !ok {
+			removed = append(removed, name)
+		}
+	}
+	if len(added)+len(removed)+len(changed) == 0 {
+		return "no bindings changed\n"
+	}
+	var b strings.Builder
+	list := func(sign string, names []string) {
+		slices.Sort(names)
+		for i, name := range names {
+			if i == watchMaxListed {
+				fmt.Fprintf(&b, "  %v ... (%v more)\n", sign, len(names)-i)
+				break
+			}
+			fmt.Fprintf(&b, "  %v %v\n", sign, name)
+		}
+	}
+	fmt.Fprintf(&b, "%v added, %v removed, %v changed\n", len(added), len(removed), len(changed))
+	list("+", added)
+	list("-", removed)
+	list("~", changed)
+	return b.String()
+}
+
+// Watch generates bindings like [Run], then regenerates them whenever
+// config.toml, bindings.txt, the prelude, go.mod or the source of the
+// bound package in the module cache change, printing which bindings
+// changed. It runs until the process is interrupted.
+//
+// Run calls Watch if the first command line argument is "watch", e.g.
+// "go run ./gen.go watch".
+func Watch() {
+	var bodies map[string]string
+	var srcDir string
+	for {
+		// Take stamps before generating, so changes made while
+		// generating trigger another run.
+		inputs, src := watchedFiles(watchConfig(), srcDir)
+		stamps := fileStamps(append(inputs, src...))
+
+		res, err := TryRunWithOptions(RunOptions{
+			OnInfo: func(msg string) {
+				fmt.Println("Ryegen:", msg)
+			},
+			Docs:       isEnvEnabled(docsEnv),
+			UpdateLock: isEnvEnabled(lockUpdateEnv),
+		})
+		if err != nil {
+			fmt.Println("Ryegen: error:", err)
+		} else {
+			printResult(res.Stats, res.Warn)
+			newBodies := bindingBodies(res.OutFile)
+			if bodies != nil {
+				fmt.Print("Ryegen: ", diffBindings(bodies, newBodies))
+			}
+			bodies = newBodies
+			if res.Summary != nil {
+				fmt.Print(res.Summary)
+				restampWritten(stamps, res.Summary.Outputs)
+			}
+			if res.SrcDir != srcDir {
+				// On the first run or after a version change, the
+				// source is only known after generating.
+				srcDir = res.SrcDir
+				_, src = watchedFiles(nil, srcDir)
+				maps.Copy(stamps, fileStamps(src))
+			}
+		}
+
+		if srcDir == "" {
+			fmt.Println("Ryegen: watching", strings.Join(inputs, ", "), "for changes")
+		} else {
+			fmt.Printf("Ryegen: watching %v and %v source files in %v for changes\n", strings.Join(inputs, ", "), len(src), srcDir)
+		}
+		for {
+			time.Sleep(watchInterval)
+			// config.toml is only re-read (e.g. for a new prelude)
+			// once it changed.
+			if !maps.Equal(stamps, fileStamps(slices.Collect(maps.Keys(stamps)))) {
+				break
+			}
+		}
+		fmt.Println()
+	}
+}
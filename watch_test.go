@@ -0,0 +1,108 @@
+package ryegen
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/refaktor/ryegen/config"
+)
+
+func TestWatchedFiles(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"go.mod":        "module example.com/m\n",
+		"m.go":          "package m\n",
+		"README.md":     "# m\n",
+		"sub/sub.go":    "package sub\n",
+		"sub/data.json": "{}",
+	})
+
+	inputs, src := watchedFiles(nil, "")
+	assert.Equal([]string{"config.toml", "bindings.txt", "go.mod", "go.sum"}, inputs)
+	assert.Empty(src)
+
+	inputs, src = watchedFiles(&config.Config{Prelude: "prelude.rye"}, dir)
+	assert.Equal([]string{"config.toml", "bindings.txt", "go.mod", "go.sum", "prelude.rye"}, inputs)
+	assert.Equal([]string{
+		filepath.Join(dir, "go.mod"),
+		filepath.Join(dir, "m.go"),
+		filepath.Join(dir, "sub", "sub.go"),
+	}, src)
+}
+
+func TestFileStamps(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	a, missing := filepath.Join(dir, "a"), filepath.Join(dir, "missing")
+	writeFiles(t, dir, map[string]string{"a": "a"})
+
+	stamps := fileStamps([]string{a, missing})
+	assert.Equal(int64(1), stamps[a][0])
+	assert.Equal([2]int64{}, stamps[missing])
+
+	if err := os.WriteFile(a, []byte("aa"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	assert.NotEqual(stamps, fileStamps([]string{a, missing}))
+
+	// Written by the generator, so it's taken as is. Files that aren't
+	// watched aren't added.
+	restampWritten(stamps, map[string]int{a: 2, filepath.Join(dir, "out.go"): 10})
+	assert.Equal(fileStamps([]string{a, missing}), stamps)
+}
+
+func TestBindingBodies(t *testing.T) {
+	assert := assert.New(t)
+
+	path := filepath.Join(t.TempDir(), "generated.go")
+	writeFiles(t, filepath.Dir(path), map[string]string{"generated.go": `package m
+
+func init() {
+	m := builtinsGenerated
+	// Hello greets.
+	m["m-hello"] = &env.Builtin{
+		Fn: hello,
+	}
+	m["m-bye"] = &env.Builtin{
+		Fn: bye,
+	}
+}
+
+func helper() {}
+`})
+	assert.Equal(map[string]string{
+		"m-hello": "\tm[\"m-hello\"] = &env.Builtin{\n\t\tFn: hello,\n\t}\n",
+		"m-bye":   "\tm[\"m-bye\"] = &env.Builtin{\n\t\tFn: bye,\n\t}\n}\n\n",
+	}, bindingBodies(path))
+	assert.Nil(bindingBodies(filepath.Join(t.TempDir(), "missing.go")))
+}
+
+func TestDiffBindings(t *testing.T) {
+	assert := assert.New(t)
+
+	before := map[string]string{"a": "1", "b": "2", "c": "3"}
+	assert.Equal("no bindings changed\n", diffBindings(before, before))
+	assert.Equal(strings.Join([]string{
+		"1 added, 1 removed, 1 changed",
+		"  + d",
+		"  - a",
+		"  ~ b",
+		"",
+	}, "\n"), diffBindings(before, map[string]string{"b": "x", "c": "3", "d": "4"}))
+
+	after := make(map[string]string)
+	for i := range watchMaxListed + 2 {
+		after[fmt.Sprintf("n%02d", i)] = ""
+	}
+	diff := diffBindings(nil, after)
+	assert.Contains(diff, fmt.Sprintf("  + n%02d\n  + ... (2 more)\n", watchMaxListed-1))
+	assert.NotContains(diff, fmt.Sprintf("n%02d", watchMaxListed))
+}